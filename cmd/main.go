@@ -16,7 +16,12 @@ import (
 	"github.com/joshsoftware/peerly-backend/internal/api"
 	"github.com/joshsoftware/peerly-backend/internal/app"
 	"github.com/joshsoftware/peerly-backend/internal/app/cronjob"
+	"github.com/joshsoftware/peerly-backend/internal/app/email"
+	"github.com/joshsoftware/peerly-backend/internal/app/identity"
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
 	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
 	"github.com/joshsoftware/peerly-backend/internal/repository"
 	script "github.com/joshsoftware/peerly-backend/scripts"
@@ -78,10 +83,31 @@ func main() {
 			},
 		},
 		{
-			Name:  "loadUsers",
-			Usage: "load peerly users from intranet",
-			Action: func(c *cli.Context) error {
-				return script.LoadUserScript()
+			Name:  "users",
+			Usage: "manage peerly users",
+			Subcommands: []cli.Command{
+				{
+					Name:  "import",
+					Usage: "import users from the intranet, or from a CSV or JSON file",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "file",
+							Usage: "read the users from a .csv or .json file instead of the intranet",
+						},
+						cli.BoolFlag{
+							Name:  "dry-run",
+							Usage: "report what would change and roll it back",
+						},
+						cli.IntFlag{
+							Name:  "batch-size",
+							Value: constants.DefaultUserImportBatchSize,
+							Usage: "users written per statement",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return importUsers(c)
+					},
+				},
 			},
 		},
 	}
@@ -91,6 +117,32 @@ func main() {
 	}
 }
 
+func importUsers(c *cli.Context) error {
+
+	ctx := context.Background()
+	log.Logger = logger.StandardLogger()
+
+	dbInstance, err := repository.InitializeDatabase()
+	if err != nil {
+		return err
+	}
+	defer dbInstance.Close()
+
+	intranetClient := intranet.NewClient(intranet.OptionsFromConfig())
+	identityProvider, err := identity.NewIdentityProvider(config.IdentityProvider(), intranetClient)
+	if err != nil {
+		return err
+	}
+
+	// the import never sends pushes, so it doesn't need the firebase key the server is configured with
+	services := app.NewService(dbInstance, identityProvider, notification.NewLogService(), intranetClient)
+	return script.ImportUsers(ctx, services.UserService, script.ImportUsersOptions{
+		File:      c.String("file"),
+		DryRun:    c.Bool("dry-run"),
+		BatchSize: c.Int("batch-size"),
+	}, os.Stdout)
+}
+
 func startApp() (err error) {
 
 	// Context for main function
@@ -101,6 +153,30 @@ func startApp() (err error) {
 		return err
 	}
 	log.Info(ctx, "Starting Peerly Application...")
+
+	//initialize email transport
+	emailTransport, err := email.NewTransport(config.EmailTransport())
+	if err != nil {
+		log.Errorf(ctx, "email transport init failed, err: %v", err)
+		return err
+	}
+	email.SetTransport(emailTransport)
+
+	//initialize identity provider, it shares the intranet client with the services
+	intranetClient := intranet.NewClient(intranet.OptionsFromConfig())
+	identityProvider, err := identity.NewIdentityProvider(config.IdentityProvider(), intranetClient)
+	if err != nil {
+		log.Errorf(ctx, "identity provider init failed, err: %v", err)
+		return err
+	}
+
+	//initialize the push notification provider, it is shared by every service
+	notificationService, err := notification.NewService(ctx, config.NotificationProvider(), config.FirebaseAccountKey())
+	if err != nil {
+		log.Errorf(ctx, "notification provider init failed, err: %v", err)
+		return err
+	}
+
 	defer log.Info(ctx, "Shutting Down Peerly Application...")
 	//initialize database
 	dbInstance, err := repository.InitializeDatabase()
@@ -118,7 +194,7 @@ func startApp() (err error) {
 	})
 
 	//initialize service dependencies
-	services := app.NewService(dbInstance)
+	services := app.NewService(dbInstance, identityProvider, notificationService, intranetClient)
 
 	// Initializing Cron Job
 	scheduler, err := gocron.NewScheduler()
@@ -127,7 +203,7 @@ func startApp() (err error) {
 		return err
 	}
 
-	err = cronjob.InitializeJobs(services.AppreciationService, services.UserService, services.OrganizationConfigService, scheduler)
+	err = cronjob.InitializeJobs(services.AppreciationService, services.UserService, services.OrganizationConfigService, services.OutboxService, services.DigestService, services.IntegrationService, services.SessionService, scheduler)
 	if err != nil {
 		logger.WithField("err", err.Error()).Error("CronJob Initialize failed")
 		return
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/joshsoftware/peerly-backend/internal/app/appreciation"
@@ -76,8 +77,38 @@ func listAppreciationsHandler(appreciationSvc appreciation.Service) http.Handler
 		var filter dto.AppreciationFilter
 		ctx := req.Context()
 		filter.Name = req.URL.Query().Get("name")
+		filter.Search = strings.TrimSpace(req.URL.Query().Get("search"))
 		filter.SortOrder = req.URL.Query().Get("sort_order")
 
+		// optional numeric filters, a value that is present but not a number is rejected
+		for param, field := range map[string]*int64{
+			"core_value_id": &filter.CoreValueID,
+			"sender_id":     &filter.SenderID,
+			"receiver_id":   &filter.ReceiverID,
+			"from":          &filter.From,
+			"to":            &filter.To,
+			"department_id": &filter.DepartmentID,
+			"team_id":       &filter.TeamID,
+		} {
+			value := req.URL.Query().Get(param)
+			if value == "" {
+				continue
+			}
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || parsed < 0 {
+				log.Errorf(ctx, "listAppreciationsHandler: invalid %s: %s", param, value)
+				dto.ErrorRepsonse(rw, apperrors.BadRequest)
+				return
+			}
+			*field = parsed
+		}
+
+		if filter.From > 0 && filter.To > 0 && filter.From > filter.To {
+			log.Errorf(ctx, "listAppreciationsHandler: from %d is after to %d", filter.From, filter.To)
+			dto.ErrorRepsonse(rw, apperrors.BadRequest)
+			return
+		}
+
 		// Get pagination parameters
 		page, limit := utils.GetPaginationParams(req)
 
@@ -85,6 +116,19 @@ func listAppreciationsHandler(appreciationSvc appreciation.Service) http.Handler
 		filter.Page = page
 		filter.Self = utils.GetSelfParam(req)
 
+		// sending cursor, even empty for the first page, switches to the keyset paginated feed
+		if req.URL.Query().Has("cursor") {
+			filter.UseCursor = true
+			filter.Cursor = req.URL.Query().Get("cursor")
+		}
+
+		// the feed walks (created_at, id) while a search is ranked, a cursor can't resume a ranked list
+		if filter.UseCursor && filter.Search != "" {
+			log.Errorf(ctx, "listAppreciationsHandler: search %q sent with a cursor", filter.Search)
+			dto.ErrorRepsonse(rw, apperrors.SearchWithCursor)
+			return
+		}
+
 		quarterStr := req.URL.Query().Get("quarter")
 		yearStr := req.URL.Query().Get("year")
 		if quarterStr != "" {
@@ -134,3 +178,67 @@ func deleteAppreciationHandler(appreciationSvc appreciation.Service) http.Handle
 		dto.SuccessRepsonse(rw, http.StatusOK, "Appreciation invalidate successfully", nil)
 	})
 }
+
+func editAppreciationHandler(appreciationSvc appreciation.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		vars := mux.Vars(req)
+		ctx := req.Context()
+		apprId, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding request param data : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.BadRequest)
+			return
+		}
+
+		var edit dto.EditAppreciation
+		err = json.NewDecoder(req.Body).Decode(&edit)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding request data : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+		edit.ID = apprId
+
+		err = edit.ValidateEditAppreciation()
+		if err != nil {
+			log.Errorf(ctx, "Error while validating request data : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		log.Debug(ctx, "editAppreciationHandler: request: ", req)
+		resp, err := appreciationSvc.EditAppreciation(ctx, edit)
+		if err != nil {
+			log.Errorf(ctx, "editAppreciationHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Debug(ctx, "editAppreciationHandler: response: ", resp)
+		log.Info(ctx, "Appreciation updated successfully")
+		dto.SuccessRepsonse(rw, http.StatusOK, "Appreciation updated successfully", resp)
+	})
+}
+
+func listAppreciationEditsHandler(appreciationSvc appreciation.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		vars := mux.Vars(req)
+		ctx := req.Context()
+		apprId, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding request param data : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.BadRequest)
+			return
+		}
+
+		log.Debug(ctx, "listAppreciationEditsHandler: request: ", req)
+		resp, err := appreciationSvc.ListAppreciationEdits(ctx, apprId)
+		if err != nil {
+			log.Errorf(ctx, "listAppreciationEditsHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Debug(ctx, "listAppreciationEditsHandler: response: ", resp)
+		log.Info(ctx, "Appreciation edit history fetched successfully")
+		dto.SuccessRepsonse(rw, http.StatusOK, "Appreciation edit history fetched successfully", resp)
+	})
+}
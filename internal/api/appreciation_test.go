@@ -243,6 +243,58 @@ func TestListAppreciationsHandler(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "search with filters",
+			queryParams: map[string]string{
+				"search":        " release ",
+				"core_value_id": "2",
+				"sender_id":     "3",
+				"receiver_id":   "4",
+				"from":          "1721631405219",
+				"to":            "1721731405219",
+				"page":          "1",
+				"page_size":     "10",
+			},
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("ListAppreciations", mock.Anything, dto.AppreciationFilter{
+					Search:      "release",
+					CoreValueID: 2,
+					SenderID:    3,
+					ReceiverID:  4,
+					From:        1721631405219,
+					To:          1721731405219,
+					Page:        1,
+					Limit:       10,
+				}).Return(dto.ListAppreciationsResponse{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "invalid sender id",
+			queryParams: map[string]string{
+				"sender_id": "abc",
+			},
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "from after to",
+			queryParams: map[string]string{
+				"from": "1721731405219",
+				"to":   "1721631405219",
+			},
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "search with a cursor",
+			queryParams: map[string]string{
+				"search": "release",
+				"cursor": "",
+			},
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -319,3 +371,62 @@ func TestValidateAppreciationHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestEditAppreciationHandler(t *testing.T) {
+	appreciationSvc := new(mocks.Service)
+	handler := editAppreciationHandler(appreciationSvc)
+
+	validDescription := "This is a valid description that is long enough to pass the minimum length validation of one hundred and fifty characters required for an appreciation."
+
+	tests := []struct {
+		name               string
+		id                 string
+		input              dto.EditAppreciation
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name:  "successful edit",
+			id:    "1",
+			input: dto.EditAppreciation{CoreValueID: 2, Description: validDescription},
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("EditAppreciation", mock.Anything, dto.EditAppreciation{ID: 1, CoreValueID: 2, Description: validDescription}).Return(dto.AppreciationResponse{ID: 1}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "short description",
+			id:                 "1",
+			input:              dto.EditAppreciation{CoreValueID: 2, Description: "Too short"},
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "edit window passed",
+			id:    "2",
+			input: dto.EditAppreciation{CoreValueID: 2, Description: validDescription},
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("EditAppreciation", mock.Anything, dto.EditAppreciation{ID: 2, CoreValueID: 2, Description: validDescription}).Return(dto.AppreciationResponse{}, apperrors.AppreciationEditWindowExpired).Once()
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(appreciationSvc)
+
+			reqBody, _ := json.Marshal(tt.input)
+			req := httptest.NewRequest(http.MethodPut, "/appreciations/"+tt.id, bytes.NewReader(reqBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			appreciationSvc.AssertExpectations(t)
+		})
+	}
+}
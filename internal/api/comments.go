@@ -0,0 +1,227 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/comments"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/utils"
+)
+
+func createCommentHandler(commentSvc comments.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		apprID, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding appreciation id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		var comment dto.Comment
+		err = json.NewDecoder(req.Body).Decode(&comment)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding request data : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+		comment.AppreciationID = apprID
+
+		err = comment.ValidateComment()
+		if err != nil {
+			log.Errorf(ctx, "Error while validating request data : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := commentSvc.CreateComment(ctx, comment)
+		if err != nil {
+			log.Errorf(ctx, "createCommentHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Info(ctx, "Comment created successfully")
+		dto.SuccessRepsonse(rw, http.StatusCreated, "Comment created successfully", resp)
+	})
+}
+
+func listCommentsHandler(commentSvc comments.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		apprID, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding appreciation id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		var filter dto.CommentFilter
+		filter.AppreciationID = apprID
+		filter.Page, filter.Limit = utils.GetPaginationParams(req)
+
+		resp, err := commentSvc.ListComments(ctx, filter)
+		if err != nil {
+			log.Errorf(ctx, "listCommentsHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Info(ctx, "Comments fetched successfully")
+		dto.SuccessRepsonse(rw, http.StatusOK, "Comments fetched successfully", resp)
+	})
+}
+
+func updateCommentHandler(commentSvc comments.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		commentID, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding comment id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		var comment dto.Comment
+		err = json.NewDecoder(req.Body).Decode(&comment)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding request data : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+		comment.ID = commentID
+
+		err = comment.ValidateComment()
+		if err != nil {
+			log.Errorf(ctx, "Error while validating request data : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := commentSvc.UpdateComment(ctx, comment)
+		if err != nil {
+			log.Errorf(ctx, "updateCommentHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Info(ctx, "Comment updated successfully")
+		dto.SuccessRepsonse(rw, http.StatusOK, "Comment updated successfully", resp)
+	})
+}
+
+func deleteCommentHandler(commentSvc comments.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		commentID, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding comment id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = commentSvc.DeleteComment(ctx, commentID)
+		if err != nil {
+			log.Errorf(ctx, "deleteCommentHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Info(ctx, "Comment deleted successfully")
+		dto.SuccessRepsonse(rw, http.StatusOK, "Comment deleted successfully", nil)
+	})
+}
+
+func reportCommentHandler(commentSvc comments.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		commentID, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding comment id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		var reqData dto.ReportCommentReq
+		err = json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Errorf(ctx, "err while decoding request data, err: %v", err)
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+		reqData.CommentID = commentID
+
+		resp, err := commentSvc.ReportComment(ctx, reqData)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusCreated, "Comment reported successfully", resp)
+	})
+}
+
+func listReportedCommentsHandler(commentSvc comments.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		resp, err := commentSvc.ListReportedComments(req.Context())
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Reported comments listed successfully", resp)
+	})
+}
+
+func moderateCommentHandler(commentSvc comments.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		reqData, err := decodeCommentModerationReq(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		err = commentSvc.DeleteReportedComment(req.Context(), reqData)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Comment deleted successfully", nil)
+	})
+}
+
+func resolveCommentHandler(commentSvc comments.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		reqData, err := decodeCommentModerationReq(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		err = commentSvc.ResolveReportedComment(req.Context(), reqData)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Comment resolved successfully", nil)
+	})
+}
+
+func decodeCommentModerationReq(req *http.Request) (reqData dto.CommentModerationReq, err error) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	resolutionID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		log.Errorf(ctx, "error while parsing id, err: %v", err)
+		return reqData, apperrors.InvalidId
+	}
+	err = json.NewDecoder(req.Body).Decode(&reqData)
+	if err != nil {
+		log.Errorf(ctx, "error while decoding request data, err: %v", err)
+		return reqData, apperrors.JSONParsingErrorReq
+	}
+	reqData.ResolutionID = resolutionID
+	return reqData, nil
+}
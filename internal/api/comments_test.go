@@ -0,0 +1,129 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/comments/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateCommentHandler(t *testing.T) {
+	commentSvc := new(mocks.Service)
+	handler := createCommentHandler(commentSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		input              dto.Comment
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name:  "success",
+			id:    "1",
+			input: dto.Comment{Comment: "Well deserved"},
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("CreateComment", mock.Anything, dto.Comment{AppreciationID: 1, Comment: "Well deserved"}).Return(dto.Comment{ID: 1, AppreciationID: 1, Comment: "Well deserved"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Blank comment",
+			id:    "1",
+			input: dto.Comment{Comment: "   "},
+			mockSetup: func(mockSvc *mocks.Service) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Comment too long",
+			id:    "1",
+			input: dto.Comment{Comment: strings.Repeat("a", 501)},
+			mockSetup: func(mockSvc *mocks.Service) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Appreciation not found",
+			id:    "2",
+			input: dto.Comment{Comment: "Well deserved"},
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("CreateComment", mock.Anything, dto.Comment{AppreciationID: 2, Comment: "Well deserved"}).Return(dto.Comment{}, apperrors.AppreciationNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(commentSvc)
+
+			reqBody, _ := json.Marshal(tt.input)
+			req := httptest.NewRequest(http.MethodPost, "/appreciations/"+tt.id+"/comments", bytes.NewReader(reqBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			commentSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteCommentHandler(t *testing.T) {
+	commentSvc := new(mocks.Service)
+	handler := deleteCommentHandler(commentSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "1",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("DeleteComment", mock.Anything, int64(1)).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Not the author",
+			id:   "2",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("DeleteComment", mock.Anything, int64(2)).Return(apperrors.CommentActionNotAllowed).Once()
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(commentSvc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/comments/"+tt.id, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			commentSvc.AssertExpectations(t)
+		})
+	}
+}
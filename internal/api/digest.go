@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/digest"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+func getDigestSubscriptionHandler(digestSvc digest.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		resp, err := digestSvc.GetDigestSubscription(ctx)
+		if err != nil {
+			log.Errorf(ctx, "getDigestSubscriptionHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Weekly digest subscription fetched successfully", resp)
+	})
+}
+
+// updateDigestSubscriptionHandler opts the user in or out of the weekly digest
+func updateDigestSubscriptionHandler(digestSvc digest.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var reqData dto.DigestSubscription
+		err := json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		resp, err := digestSvc.UpdateDigestSubscription(ctx, reqData)
+		if err != nil {
+			log.Errorf(ctx, "updateDigestSubscriptionHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Weekly digest subscription updated successfully", resp)
+	})
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/digest/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateDigestSubscriptionHandler(t *testing.T) {
+	digestSvc := new(mocks.Service)
+	handler := updateDigestSubscriptionHandler(digestSvc)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"subscribed":true}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("UpdateDigestSubscription", mock.Anything, dto.DigestSubscription{Subscribed: true}).Return(dto.DigestSubscription{Subscribed: true}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Invalid body",
+			body:               `{"subscribed":"yes"}`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(digestSvc)
+
+			req := httptest.NewRequest(http.MethodPut, "/user_profile/weekly_digest", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			digestSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/feed"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// an idle stream sends a comment this often so proxies don't close it
+const feedHeartbeatInterval = 25 * time.Second
+
+// streamFeedHandler keeps the connection open and writes every feed event as a server-sent event
+func streamFeedHandler(broker feed.Broker) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		flusher, ok := rw.(http.Flusher)
+		if !ok {
+			log.Error(ctx, "streamFeedHandler: response writer does not support streaming")
+			dto.ErrorRepsonse(rw, apperrors.InternalServer)
+			return
+		}
+
+		events, unsubscribe := broker.Subscribe()
+		defer unsubscribe()
+
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.Header().Set("Cache-Control", "no-cache")
+		rw.Header().Set("Connection", "keep-alive")
+		rw.Header().Set("X-Accel-Buffering", "no")
+		rw.WriteHeader(http.StatusOK)
+
+		// the opening comment lets the client know it is subscribed before the first event arrives
+		_, err := fmt.Fprint(rw, ": connected\n\n")
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(feedHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(event.Data)
+				if err != nil {
+					log.Errorf(ctx, "streamFeedHandler: error in marshalling %s event: %v", event.Event, err)
+					continue
+				}
+				_, err = fmt.Fprintf(rw, "event: %s\ndata: %s\n\n", event.Event, data)
+				if err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				_, err = fmt.Fprint(rw, ": heartbeat\n\n")
+				if err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/feed"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamFeedHandler(t *testing.T) {
+	broker := feed.NewBroker()
+	server := httptest.NewServer(streamFeedHandler(broker))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readLine := func() string {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		return line
+	}
+
+	// the handler is subscribed once the opening comment arrives
+	assert.Equal(t, ": connected\n", readLine())
+	assert.Equal(t, "\n", readLine())
+
+	broker.Publish(context.Background(), dto.FeedEvent{
+		Event: constants.RewardGivenEvent,
+		Data:  dto.FeedRewardEventData{AppreciationID: 7, TotalRewards: 2},
+	})
+
+	assert.Equal(t, "event: reward.given\n", readLine())
+	assert.Equal(t, "data: {\"appreciation_id\":7,\"total_rewards\":2}\n", readLine())
+	assert.Equal(t, "\n", readLine())
+}
+
+func TestStreamFeedHandlerWithoutFlusher(t *testing.T) {
+	rr := struct{ http.ResponseWriter }{httptest.NewRecorder()}
+	req := httptest.NewRequest(http.MethodGet, "/appreciations/stream", nil)
+
+	streamFeedHandler(feed.NewBroker()).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.ResponseWriter.(*httptest.ResponseRecorder).Code)
+}
@@ -1 +1,28 @@
 package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+)
+
+// orgFilterParams reads the optional department_id and team_id query parameters lists and reports are narrowed by
+func orgFilterParams(req *http.Request) (filter dto.OrgFilter, err error) {
+	for param, field := range map[string]*int64{
+		"department_id": &filter.DepartmentID,
+		"team_id":       &filter.TeamID,
+	} {
+		value := req.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			return dto.OrgFilter{}, apperrors.BadRequest
+		}
+		*field = parsed
+	}
+	return filter, nil
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/inbox"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/utils"
+)
+
+// listNotificationsHandler lists the inbox of the logged in user along with the unread count
+func listNotificationsHandler(inboxSvc inbox.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var filter dto.NotificationFilter
+		filter.UnreadOnly = req.URL.Query().Get("unread") == "true"
+		filter.Page, filter.Limit = utils.GetPaginationParams(req)
+
+		resp, err := inboxSvc.ListNotifications(ctx, filter)
+		if err != nil {
+			log.Errorf(ctx, "listNotificationsHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Notifications fetched successfully", resp)
+	})
+}
+
+func markNotificationReadHandler(inboxSvc inbox.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding notification id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = inboxSvc.MarkRead(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "markNotificationReadHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Notification marked as read", nil)
+	})
+}
+
+func markAllNotificationsReadHandler(inboxSvc inbox.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		err := inboxSvc.MarkAllRead(ctx)
+		if err != nil {
+			log.Errorf(ctx, "markAllNotificationsReadHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "All notifications marked as read", nil)
+	})
+}
+
+func deleteNotificationHandler(inboxSvc inbox.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding notification id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = inboxSvc.DeleteNotification(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "deleteNotificationHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Notification deleted successfully", nil)
+	})
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/inbox/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListNotificationsHandler(t *testing.T) {
+	inboxSvc := new(mocks.Service)
+	handler := listNotificationsHandler(inboxSvc)
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name:  "All notifications",
+			query: "",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("ListNotifications", mock.Anything, mock.MatchedBy(func(filter dto.NotificationFilter) bool {
+					return !filter.UnreadOnly
+				})).Return(dto.ListNotificationsResponse{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Unread notifications",
+			query: "?unread=true",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("ListNotifications", mock.Anything, mock.MatchedBy(func(filter dto.NotificationFilter) bool {
+					return filter.UnreadOnly
+				})).Return(dto.ListNotificationsResponse{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(inboxSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/notifications"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			inboxSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarkNotificationReadHandler(t *testing.T) {
+	inboxSvc := new(mocks.Service)
+	handler := markNotificationReadHandler(inboxSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "1",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("MarkRead", mock.Anything, int64(1)).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Notification not found",
+			id:   "2",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("MarkRead", mock.Anything, int64(2)).Return(apperrors.NotificationNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(inboxSvc)
+
+			req := httptest.NewRequest(http.MethodPatch, "/notifications/"+tt.id+"/read", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			inboxSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteNotificationHandler(t *testing.T) {
+	inboxSvc := new(mocks.Service)
+	handler := deleteNotificationHandler(inboxSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "1",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("DeleteNotification", mock.Anything, int64(1)).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Notification not found",
+			id:   "2",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("DeleteNotification", mock.Anything, int64(2)).Return(apperrors.NotificationNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(inboxSvc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/notifications/"+tt.id, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			inboxSvc.AssertExpectations(t)
+		})
+	}
+}
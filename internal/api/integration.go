@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/integrations"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+func createIntegrationHandler(integrationSvc integrations.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var reqData dto.CreateIntegrationReq
+		err := json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		err = reqData.Validate()
+		if err != nil {
+			log.Errorf(ctx, "Error in validating request : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := integrationSvc.CreateIntegration(ctx, reqData)
+		if err != nil {
+			log.Errorf(ctx, "createIntegrationHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusCreated, "Integration created successfully", resp)
+	})
+}
+
+func listIntegrationsHandler(integrationSvc integrations.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		resp, err := integrationSvc.ListIntegrations(ctx)
+		if err != nil {
+			log.Errorf(ctx, "listIntegrationsHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Integrations fetched successfully", resp)
+	})
+}
+
+func deleteIntegrationHandler(integrationSvc integrations.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding integration id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = integrationSvc.DeleteIntegration(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "deleteIntegrationHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Integration deleted successfully", nil)
+	})
+}
+
+// testIntegrationHandler posts a test message to the webhook and reports whether it was accepted
+func testIntegrationHandler(integrationSvc integrations.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding integration id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = integrationSvc.TestIntegration(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "testIntegrationHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Test message posted successfully", nil)
+	})
+}
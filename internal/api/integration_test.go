@@ -0,0 +1,61 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/integrations/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateIntegrationHandler(t *testing.T) {
+	integrationSvc := new(mocks.Service)
+	handler := createIntegrationHandler(integrationSvc)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"kind":"slack","name":"#kudos","webhook_url":"https://hooks.slack.com/services/T/B/X","core_value_ids":[1]}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("CreateIntegration", mock.Anything, mock.Anything).Return(dto.Integration{ID: 1}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Unknown kind",
+			body:               `{"kind":"discord","name":"kudos","webhook_url":"https://discord.com/api/webhooks/x"}`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid webhook url",
+			body:               `{"kind":"teams","name":"kudos","webhook_url":"not a url"}`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(integrationSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/integrations", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			integrationSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/org"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+func listDepartmentsHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		resp, err := orgSvc.ListDepartments(ctx)
+		if err != nil {
+			log.Errorf(ctx, "listDepartmentsHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Departments listed", resp)
+	})
+}
+
+func createDepartmentHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var reqData dto.DepartmentReq
+		err := json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		resp, err := orgSvc.CreateDepartment(ctx, reqData)
+		if err != nil {
+			log.Errorf(ctx, "createDepartmentHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusCreated, "Department created successfully", resp)
+	})
+}
+
+func updateDepartmentHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding department id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		var reqData dto.DepartmentReq
+		err = json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		resp, err := orgSvc.UpdateDepartment(ctx, id, reqData)
+		if err != nil {
+			log.Errorf(ctx, "updateDepartmentHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Department updated successfully", resp)
+	})
+}
+
+func deleteDepartmentHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding department id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = orgSvc.DeleteDepartment(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "deleteDepartmentHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Department deleted successfully", nil)
+	})
+}
+
+// listTeamsHandler lists the teams of the department_id query parameter, or every team without it
+func listTeamsHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		filter, err := orgFilterParams(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := orgSvc.ListTeams(ctx, filter.DepartmentID)
+		if err != nil {
+			log.Errorf(ctx, "listTeamsHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Teams listed", resp)
+	})
+}
+
+func createTeamHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var reqData dto.TeamReq
+		err := json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		resp, err := orgSvc.CreateTeam(ctx, reqData)
+		if err != nil {
+			log.Errorf(ctx, "createTeamHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusCreated, "Team created successfully", resp)
+	})
+}
+
+func updateTeamHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding team id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		var reqData dto.TeamReq
+		err = json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		resp, err := orgSvc.UpdateTeam(ctx, id, reqData)
+		if err != nil {
+			log.Errorf(ctx, "updateTeamHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Team updated successfully", resp)
+	})
+}
+
+func deleteTeamHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding team id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = orgSvc.DeleteTeam(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "deleteTeamHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Team deleted successfully", nil)
+	})
+}
+
+// updateUserHierarchyHandler places a user in a department, a team and under a manager
+func updateUserHierarchyHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding user id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		var reqData dto.UserHierarchyReq
+		err = json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		err = orgSvc.UpdateUserHierarchy(ctx, id, reqData)
+		if err != nil {
+			log.Errorf(ctx, "updateUserHierarchyHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "User hierarchy updated successfully", nil)
+	})
+}
+
+// listMyTeamHandler lists the users reporting to the logged in user
+func listMyTeamHandler(orgSvc org.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		resp, err := orgSvc.ListMyTeam(ctx)
+		if err != nil {
+			log.Errorf(ctx, "listMyTeamHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Team members listed", resp)
+	})
+}
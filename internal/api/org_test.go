@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/org/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListTeamsHandler(t *testing.T) {
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name:  "Teams of a department",
+			query: "?department_id=3",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("ListTeams", mock.Anything, int64(3)).Return([]dto.Team{{ID: 5, DepartmentID: 3, Name: "Platform"}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Every team",
+			query: "",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("ListTeams", mock.Anything, int64(0)).Return([]dto.Team{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Invalid department",
+			query:              "?department_id=abc",
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgSvc := mocks.NewService(t)
+			tt.mockSetup(orgSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/teams"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			listTeamsHandler(orgSvc).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
+
+func TestUpdateUserHierarchyHandler(t *testing.T) {
+	tests := []struct {
+		name               string
+		id                 string
+		body               string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "7",
+			body: `{"team_id":5,"manager_id":8}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("UpdateUserHierarchy", mock.Anything, int64(7), dto.UserHierarchyReq{TeamID: 5, ManagerID: 8}).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Manager reports to the user",
+			id:   "7",
+			body: `{"manager_id":8}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("UpdateUserHierarchy", mock.Anything, int64(7), dto.UserHierarchyReq{ManagerID: 8}).Return(apperrors.InvalidManager).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown team",
+			id:   "7",
+			body: `{"team_id":5}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("UpdateUserHierarchy", mock.Anything, int64(7), dto.UserHierarchyReq{TeamID: 5}).Return(apperrors.TeamNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Invalid body",
+			id:                 "7",
+			body:               `{"team_id":`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgSvc := mocks.NewService(t)
+			tt.mockSetup(orgSvc)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/users/"+tt.id+"/hierarchy", bytes.NewBufferString(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+			rr := httptest.NewRecorder()
+
+			updateUserHierarchyHandler(orgSvc).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+		})
+	}
+}
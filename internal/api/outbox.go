@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/outbox"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/utils"
+)
+
+// listOutboxMessagesHandler lists the failed deliveries, or the ones in the requested status
+func listOutboxMessagesHandler(outboxSvc outbox.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var filter dto.OutboxFilter
+		filter.Status = req.URL.Query().Get("status")
+		if filter.Status == "" {
+			filter.Status = constants.OutboxDead
+		}
+		err := filter.Validate()
+		if err != nil {
+			log.Errorf(ctx, "listOutboxMessagesHandler: invalid status: %s", filter.Status)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		filter.Page, filter.Limit = utils.GetPaginationParams(req)
+
+		resp, err := outboxSvc.ListDeliveries(ctx, filter)
+		if err != nil {
+			log.Errorf(ctx, "listOutboxMessagesHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Deliveries fetched successfully", resp)
+	})
+}
+
+func retryOutboxMessageHandler(outboxSvc outbox.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding outbox message id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		resp, err := outboxSvc.RetryDelivery(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "retryOutboxMessageHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Infof(ctx, "Delivery %d queued for retry", id)
+		dto.SuccessRepsonse(rw, http.StatusOK, "Delivery queued for retry", resp)
+	})
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/outbox/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListOutboxMessagesHandler(t *testing.T) {
+	outboxSvc := new(mocks.Service)
+	handler := listOutboxMessagesHandler(outboxSvc)
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name:  "Failed deliveries by default",
+			query: "",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("ListDeliveries", mock.Anything, mock.MatchedBy(func(filter dto.OutboxFilter) bool {
+					return filter.Status == constants.OutboxDead
+				})).Return(dto.ListOutboxMessagesResponse{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Pending deliveries",
+			query: "?status=pending",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("ListDeliveries", mock.Anything, mock.MatchedBy(func(filter dto.OutboxFilter) bool {
+					return filter.Status == constants.OutboxPending
+				})).Return(dto.ListOutboxMessagesResponse{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Invalid status",
+			query:              "?status=lost",
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(outboxSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/outbox"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			outboxSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRetryOutboxMessageHandler(t *testing.T) {
+	outboxSvc := new(mocks.Service)
+	handler := retryOutboxMessageHandler(outboxSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "1",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("RetryDelivery", mock.Anything, int64(1)).Return(dto.OutboxMessage{ID: 1, Status: constants.OutboxPending}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Not a failed delivery",
+			id:   "2",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("RetryDelivery", mock.Anything, int64(2)).Return(dto.OutboxMessage{}, apperrors.OutboxMessageNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(outboxSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/outbox/"+tt.id+"/retry", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			outboxSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/preferences"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+func listNotificationPreferencesHandler(prefSvc preferences.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		resp, err := prefSvc.ListNotificationPreferences(ctx)
+		if err != nil {
+			log.Errorf(ctx, "listNotificationPreferencesHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Notification preferences fetched successfully", resp)
+	})
+}
+
+func updateNotificationPreferencesHandler(prefSvc preferences.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var reqData dto.UpdateNotificationPreferencesReq
+		err := json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		err = reqData.Validate()
+		if err != nil {
+			log.Errorf(ctx, "Error in validating request : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := prefSvc.UpdateNotificationPreferences(ctx, reqData)
+		if err != nil {
+			log.Errorf(ctx, "updateNotificationPreferencesHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Notification preferences updated successfully", resp)
+	})
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/preferences/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateNotificationPreferencesHandler(t *testing.T) {
+	prefSvc := new(mocks.Service)
+	handler := updateNotificationPreferencesHandler(prefSvc)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"preferences":[{"event":"appreciation","channels":["in_app"]},{"event":"broadcast","channels":[]}]}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("UpdateNotificationPreferences", mock.Anything, mock.Anything).Return([]dto.NotificationPreference{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Unknown event",
+			body:               `{"preferences":[{"event":"birthday","channels":["push"]}]}`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown channel",
+			body:               `{"preferences":[{"event":"reward","channels":["sms"]}]}`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(prefSvc)
+
+			req := httptest.NewRequest(http.MethodPut, "/user_profile/notification_preferences", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			prefSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/reactions"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+func addReactionHandler(reactionSvc reactions.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		apprID, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding appreciation id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		var reaction dto.Reaction
+		err = json.NewDecoder(req.Body).Decode(&reaction)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding request data : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+		reaction.AppreciationID = apprID
+
+		err = reaction.ValidateReaction()
+		if err != nil {
+			log.Errorf(ctx, "Error while validating request data : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := reactionSvc.AddReaction(ctx, reaction)
+		if err != nil {
+			log.Errorf(ctx, "addReactionHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Info(ctx, "Reaction added successfully")
+		dto.SuccessRepsonse(rw, http.StatusCreated, "Reaction added successfully", resp)
+	})
+}
+
+func removeReactionHandler(reactionSvc reactions.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		apprID, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding appreciation id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		reaction := dto.Reaction{
+			AppreciationID: apprID,
+			Reaction:       vars["reaction"],
+		}
+		err = reaction.ValidateReaction()
+		if err != nil {
+			log.Errorf(ctx, "Error while validating request data : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := reactionSvc.RemoveReaction(ctx, reaction)
+		if err != nil {
+			log.Errorf(ctx, "removeReactionHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Info(ctx, "Reaction removed successfully")
+		dto.SuccessRepsonse(rw, http.StatusOK, "Reaction removed successfully", resp)
+	})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/reactions/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddReactionHandler(t *testing.T) {
+	reactionSvc := new(mocks.Service)
+	handler := addReactionHandler(reactionSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		input              dto.Reaction
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name:  "success",
+			id:    "1",
+			input: dto.Reaction{Reaction: "Clap"},
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("AddReaction", mock.Anything, dto.Reaction{AppreciationID: 1, Reaction: "clap"}).Return(dto.ReactionSummary{AppreciationID: 1}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:  "Invalid reaction",
+			id:    "1",
+			input: dto.Reaction{Reaction: "unicorn"},
+			mockSetup: func(mockSvc *mocks.Service) {
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Appreciation not found",
+			id:    "2",
+			input: dto.Reaction{Reaction: "heart"},
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("AddReaction", mock.Anything, dto.Reaction{AppreciationID: 2, Reaction: "heart"}).Return(dto.ReactionSummary{}, apperrors.AppreciationNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(reactionSvc)
+
+			reqBody, _ := json.Marshal(tt.input)
+			req := httptest.NewRequest(http.MethodPost, "/appreciations/"+tt.id+"/reactions", bytes.NewReader(reqBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			reactionSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -69,7 +69,13 @@ func listReportedAppreciations(reportAppreciationSvc reportappreciations.Service
 			}
 		}
 
-		resp, err := reportAppreciationSvc.ListReportedAppreciations(req.Context(), quarter, year)
+		orgFilter, err := orgFilterParams(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := reportAppreciationSvc.ListReportedAppreciations(req.Context(), quarter, year, orgFilter)
 		if err != nil {
 			dto.ErrorRepsonse(rw, err)
 			return
@@ -32,7 +32,8 @@ func giveRewardHandler(rewardSvc reward.Service) http.HandlerFunc {
 			return
 		}
 
-		if reward.Point < 1 || reward.Point > 5 {
+		// allowed levels are validated against the reward level mapping by the service
+		if reward.Point < 1 {
 			log.Error(ctx, "Invalid reward point")
 			dto.ErrorRepsonse(rw, apperrors.InvalidRewardPoint)
 			return
@@ -50,3 +51,45 @@ func giveRewardHandler(rewardSvc reward.Service) http.HandlerFunc {
 		dto.SuccessRepsonse(rw, http.StatusCreated, "Reward given successfully", resp)
 	})
 }
+
+func listRewardLevelsHandler(rewardSvc reward.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		resp, err := rewardSvc.ListRewardLevels(ctx)
+		if err != nil {
+			log.Error(ctx, "resp err: ", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Reward levels fetched successfully", resp)
+	})
+}
+
+func updateRewardLevelsHandler(rewardSvc reward.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var reqData dto.UpdateRewardLevelsReq
+		err := json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		err = reqData.ValidateRewardLevels()
+		if err != nil {
+			log.Errorf(ctx, "Error in validating request : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := rewardSvc.UpdateRewardLevels(ctx, reqData)
+		if err != nil {
+			log.Error(ctx, "resp err: ", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Reward levels updated successfully", resp)
+	})
+}
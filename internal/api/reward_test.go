@@ -11,10 +11,16 @@ import (
 	"github.com/joshsoftware/peerly-backend/internal/app/reward/mocks"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	l "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+func init() {
+	log.Logger = l.New()
+}
+
 func TestGiveRewardHandlerHandler(t *testing.T) {
 	rewardSvc := new(mocks.Service)
 	handler := giveRewardHandler(rewardSvc)
@@ -45,7 +51,7 @@ func TestGiveRewardHandlerHandler(t *testing.T) {
 		{
 			name:  "Invalid reward point",
 			id:    "1",
-			input: dto.Reward{Point: 10},
+			input: dto.Reward{Point: 0},
 			mockSetup: func(mockSvc *mocks.Service) {
 			},
 			expectedStatusCode: http.StatusBadRequest,
@@ -79,3 +85,67 @@ func TestGiveRewardHandlerHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateRewardLevelsHandler(t *testing.T) {
+	rewardSvc := new(mocks.Service)
+	handler := updateRewardLevelsHandler(rewardSvc)
+
+	tests := []struct {
+		name               string
+		input              interface{}
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name:  "success",
+			input: dto.UpdateRewardLevelsReq{Levels: []dto.RewardLevelReq{{Point: 1, Value: 100}, {Point: 3, Value: 150}}},
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("UpdateRewardLevels", mock.Anything, mock.Anything).Return([]dto.RewardLevel{{Id: 4, Version: 2, Point: 1, Value: 100}, {Id: 5, Version: 2, Point: 3, Value: 150}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error decoding request data",
+			input:              "levels",
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Empty reward levels",
+			input:              dto.UpdateRewardLevelsReq{},
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Duplicate reward level point",
+			input:              dto.UpdateRewardLevelsReq{Levels: []dto.RewardLevelReq{{Point: 1, Value: 100}, {Point: 1, Value: 150}}},
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "update reward levels failure",
+			input: dto.UpdateRewardLevelsReq{Levels: []dto.RewardLevelReq{{Point: 1, Value: 100}}},
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("UpdateRewardLevels", mock.Anything, mock.Anything).Return(nil, apperrors.InternalServer).Once()
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(rewardSvc)
+
+			reqBody, _ := json.Marshal(tt.input)
+			req := httptest.NewRequest(http.MethodPut, "/reward_levels", bytes.NewReader(reqBody))
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			rewardSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/roles/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPromoteUserHandler(t *testing.T) {
+	roleSvc := new(mocks.Service)
+	handler := promoteUserHandler(roleSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "7",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("PromoteUser", mock.Anything, int64(7)).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Already an admin",
+			id:   "8",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("PromoteUser", mock.Anything, int64(8)).Return(apperrors.InvalidRoleChange).Once()
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:               "Invalid id",
+			id:                 "abc",
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(roleSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/"+tt.id+"/promote", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			roleSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDemoteUserHandler(t *testing.T) {
+	roleSvc := new(mocks.Service)
+	handler := demoteUserHandler(roleSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "7",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("DemoteUser", mock.Anything, int64(7)).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "User not found",
+			id:   "8",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("DemoteUser", mock.Anything, int64(8)).Return(apperrors.UserNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(roleSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/"+tt.id+"/demote", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			roleSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -25,6 +25,11 @@ func NewRouter(deps app.Dependencies) *mux.Router {
 	// Add the RequestIDMiddleware to the subrouter
 	peerlySubrouter.Use(middleware.RequestIDMiddleware)
 
+	// revoked tokens are rejected by JwtAuthMiddleware
+	middleware.UseTokenRevocationChecker(deps.SessionService)
+	middleware.UsePermissionChecker(deps.RoleService)
+	middleware.UseUserStatusChecker(deps.UserService)
+
 	peerlySubrouter.HandleFunc("/ping", pingHandler).Methods(http.MethodGet)
 
 	peerlySubrouter.HandleFunc("/set_logger_level", loggerHandler).Methods(http.MethodPatch)
@@ -37,9 +42,9 @@ func NewRouter(deps app.Dependencies) *mux.Router {
 
 	peerlySubrouter.Handle("/core_values", middleware.JwtAuthMiddleware(listCoreValuesHandler(deps.CoreValueService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/core_values", middleware.JwtAuthMiddleware(createCoreValueHandler(deps.CoreValueService), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/core_values", middleware.JwtAuthMiddleware(middleware.RequirePermission(createCoreValueHandler(deps.CoreValueService), constants.PermissionConfigUpdate), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/core_values/{id:[0-9]+}", middleware.JwtAuthMiddleware(updateCoreValueHandler(deps.CoreValueService), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/core_values/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(updateCoreValueHandler(deps.CoreValueService), constants.PermissionConfigUpdate), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
 
 	//users
 
@@ -49,23 +54,57 @@ func NewRouter(deps app.Dependencies) *mux.Router {
 
 	peerlySubrouter.Handle("/admin/login", loginAdmin(deps.UserService)).Methods(http.MethodPost).Headers(versionHeader, v1)
 
+	peerlySubrouter.Handle("/token/refresh", refreshTokenHandler(deps.SessionService)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/user/logout", middleware.JwtAuthMiddleware(logoutHandler(deps.SessionService), constants.User)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/users/{id:[0-9]+}/promote", middleware.JwtAuthMiddleware(middleware.RequirePermission(promoteUserHandler(deps.RoleService), constants.PermissionUserRoleAssign), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/users/{id:[0-9]+}/demote", middleware.JwtAuthMiddleware(middleware.RequirePermission(demoteUserHandler(deps.RoleService), constants.PermissionUserRoleAssign), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/users", middleware.JwtAuthMiddleware(middleware.RequirePermission(adminListUsersHandler(deps.UserService), constants.PermissionUserManage), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/users/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(adminGetUserHandler(deps.UserService), constants.PermissionUserManage), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/users/{id:[0-9]+}/deactivate", middleware.JwtAuthMiddleware(middleware.RequirePermission(deactivateUserHandler(deps.UserService), constants.PermissionUserManage), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/users/{id:[0-9]+}/reactivate", middleware.JwtAuthMiddleware(middleware.RequirePermission(reactivateUserHandler(deps.UserService), constants.PermissionUserManage), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/users/{id:[0-9]+}/password", middleware.JwtAuthMiddleware(middleware.RequirePermission(setAdminPasswordHandler(deps.UserService), constants.PermissionAdminPasswordSet), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/users/{id:[0-9]+}/hierarchy", middleware.JwtAuthMiddleware(middleware.RequirePermission(updateUserHierarchyHandler(deps.OrgService), constants.PermissionOrgManage), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/user_sync_reports", middleware.JwtAuthMiddleware(middleware.RequirePermission(listUserSyncReportsHandler(deps.UserService), constants.PermissionUserManage), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/users/{id:[0-9]+}/revoke_sessions", middleware.JwtAuthMiddleware(middleware.RequirePermission(revokeUserSessionsHandler(deps.SessionService), constants.PermissionSessionRevoke), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
 	peerlySubrouter.Handle("/intranet/users", listIntranetUsersHandler(deps.UserService)).Methods(http.MethodGet)
 
 	peerlySubrouter.Handle("/users", middleware.JwtAuthMiddleware(listUsersHandler(deps.UserService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
 
 	peerlySubrouter.Handle("/user_profile", middleware.JwtAuthMiddleware(getUserByIdHandler(deps.UserService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
 
+	peerlySubrouter.Handle("/user_profile/notification_preferences", middleware.JwtAuthMiddleware(listNotificationPreferencesHandler(deps.PreferenceService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/user_profile/notification_preferences", middleware.JwtAuthMiddleware(updateNotificationPreferencesHandler(deps.PreferenceService), constants.User)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/user_profile/weekly_digest", middleware.JwtAuthMiddleware(getDigestSubscriptionHandler(deps.DigestService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/user_profile/weekly_digest", middleware.JwtAuthMiddleware(updateDigestSubscriptionHandler(deps.DigestService), constants.User)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
 	peerlySubrouter.Handle("/users/active", middleware.JwtAuthMiddleware(getActiveUserListHandler(deps.UserService), constants.User)).Methods(http.MethodGet)
 
 	peerlySubrouter.Handle("/users/top10", middleware.JwtAuthMiddleware(getTop10UserHandler(deps.UserService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/admin/notification", middleware.JwtAuthMiddleware(adminNotificationHandler(deps.UserService), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/users/me/team", middleware.JwtAuthMiddleware(listMyTeamHandler(deps.OrgService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/admin/notification", middleware.JwtAuthMiddleware(middleware.RequirePermission(adminNotificationHandler(deps.UserService), constants.PermissionNotificationBroadcast), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/admin/appreciation_report", middleware.JwtAuthMiddleware(appreciationReportHandler(deps.UserService, deps.AppreciationService), constants.Admin)).Methods(http.MethodGet)
+	peerlySubrouter.Handle("/admin/appreciation_report", middleware.JwtAuthMiddleware(middleware.RequirePermission(appreciationReportHandler(deps.UserService, deps.AppreciationService), constants.PermissionReportExport), constants.Admin)).Methods(http.MethodGet)
 
-	peerlySubrouter.Handle("/admin/reported_appreciation_report", middleware.JwtAuthMiddleware(reportedAppreciationReportHandler(deps.UserService, deps.ReportAppreciationService), constants.Admin)).Methods(http.MethodGet)
+	peerlySubrouter.Handle("/admin/reported_appreciation_report", middleware.JwtAuthMiddleware(middleware.RequirePermission(reportedAppreciationReportHandler(deps.UserService, deps.ReportAppreciationService), constants.PermissionReportExport), constants.Admin)).Methods(http.MethodGet)
 
-	peerlySubrouter.Handle("/admin/dynamic_engagers_report", middleware.JwtAuthMiddleware(dynamicEngagersReportHandler(deps.UserService), constants.Admin)).Methods(http.MethodGet)
+	peerlySubrouter.Handle("/admin/dynamic_engagers_report", middleware.JwtAuthMiddleware(middleware.RequirePermission(dynamicEngagersReportHandler(deps.UserService), constants.PermissionReportExport), constants.Admin)).Methods(http.MethodGet)
 
 
 	//appreciations
@@ -74,40 +113,123 @@ func NewRouter(deps app.Dependencies) *mux.Router {
 
 	peerlySubrouter.Handle("/appreciations", middleware.JwtAuthMiddleware(listAppreciationsHandler(deps.AppreciationService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/appreciations/{id:[0-9]+}", middleware.JwtAuthMiddleware(deleteAppreciationHandler(deps.AppreciationService), constants.Admin)).Methods(http.MethodDelete).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/appreciations/stream", middleware.JwtAuthMiddleware(streamFeedHandler(deps.FeedBroker), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/appreciations/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(deleteAppreciationHandler(deps.AppreciationService), constants.PermissionAppreciationDelete), constants.Admin)).Methods(http.MethodDelete).Headers(versionHeader, v1)
 
 	peerlySubrouter.Handle("/appreciations", middleware.JwtAuthMiddleware(createAppreciationHandler(deps.AppreciationService), constants.User)).Methods(http.MethodPost).Headers(versionHeader, v1)
 
+	peerlySubrouter.Handle("/appreciations/{id:[0-9]+}", middleware.JwtAuthMiddleware(editAppreciationHandler(deps.AppreciationService), constants.User)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/appreciations/{id:[0-9]+}/edits", middleware.JwtAuthMiddleware(middleware.RequirePermission(listAppreciationEditsHandler(deps.AppreciationService), constants.PermissionAppreciationEdits), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
 	//report appreciation
 	peerlySubrouter.Handle("/report_appreciation/{id:[0-9]+}", middleware.JwtAuthMiddleware(reportAppreciationHandler(deps.ReportAppreciationService), constants.User)).Methods(http.MethodPost).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/report_appreciations", middleware.JwtAuthMiddleware(listReportedAppreciations(deps.ReportAppreciationService), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/report_appreciations", middleware.JwtAuthMiddleware(middleware.RequirePermission(listReportedAppreciations(deps.ReportAppreciationService), constants.PermissionReportModerate), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/moderate_appreciation/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(moderateAppreciation(deps.ReportAppreciationService), constants.PermissionReportModerate), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/resolve_appreciation/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(resolveAppreciation(deps.ReportAppreciationService), constants.PermissionReportModerate), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	//comments
+	peerlySubrouter.Handle("/appreciations/{id:[0-9]+}/comments", middleware.JwtAuthMiddleware(listCommentsHandler(deps.CommentService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/appreciations/{id:[0-9]+}/comments", middleware.JwtAuthMiddleware(createCommentHandler(deps.CommentService), constants.User)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/comments/{id:[0-9]+}", middleware.JwtAuthMiddleware(updateCommentHandler(deps.CommentService), constants.User)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/comments/{id:[0-9]+}", middleware.JwtAuthMiddleware(deleteCommentHandler(deps.CommentService), constants.User)).Methods(http.MethodDelete).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/report_comment/{id:[0-9]+}", middleware.JwtAuthMiddleware(reportCommentHandler(deps.CommentService), constants.User)).Methods(http.MethodPost).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/moderate_appreciation/{id:[0-9]+}", middleware.JwtAuthMiddleware(moderateAppreciation(deps.ReportAppreciationService), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/report_comments", middleware.JwtAuthMiddleware(middleware.RequirePermission(listReportedCommentsHandler(deps.CommentService), constants.PermissionReportModerate), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/resolve_appreciation/{id:[0-9]+}", middleware.JwtAuthMiddleware(resolveAppreciation(deps.ReportAppreciationService), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/moderate_comment/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(moderateCommentHandler(deps.CommentService), constants.PermissionReportModerate), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/resolve_comment/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(resolveCommentHandler(deps.CommentService), constants.PermissionReportModerate), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	//reactions
+	peerlySubrouter.Handle("/appreciations/{id:[0-9]+}/reactions", middleware.JwtAuthMiddleware(addReactionHandler(deps.ReactionService), constants.User)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/appreciations/{id:[0-9]+}/reactions/{reaction}", middleware.JwtAuthMiddleware(removeReactionHandler(deps.ReactionService), constants.User)).Methods(http.MethodDelete).Headers(versionHeader, v1)
 
 	//grades
 	peerlySubrouter.Handle("/grades", middleware.JwtAuthMiddleware(listGradesHandler(deps.GradeService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/grades/{id:[0-9]+}", middleware.JwtAuthMiddleware(editGradesHandler(deps.GradeService), constants.Admin)).Methods(http.MethodPatch).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/grades/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(editGradesHandler(deps.GradeService), constants.PermissionGradeEdit), constants.Admin)).Methods(http.MethodPatch).Headers(versionHeader, v1)
+
+	// departments and teams
+	peerlySubrouter.Handle("/departments", middleware.JwtAuthMiddleware(listDepartmentsHandler(deps.OrgService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/departments", middleware.JwtAuthMiddleware(middleware.RequirePermission(createDepartmentHandler(deps.OrgService), constants.PermissionOrgManage), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/departments/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(updateDepartmentHandler(deps.OrgService), constants.PermissionOrgManage), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/departments/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(deleteDepartmentHandler(deps.OrgService), constants.PermissionOrgManage), constants.Admin)).Methods(http.MethodDelete).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/teams", middleware.JwtAuthMiddleware(listTeamsHandler(deps.OrgService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/teams", middleware.JwtAuthMiddleware(middleware.RequirePermission(createTeamHandler(deps.OrgService), constants.PermissionOrgManage), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/teams/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(updateTeamHandler(deps.OrgService), constants.PermissionOrgManage), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/teams/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(deleteTeamHandler(deps.OrgService), constants.PermissionOrgManage), constants.Admin)).Methods(http.MethodDelete).Headers(versionHeader, v1)
 
 	// reward appreciation
 	peerlySubrouter.Handle("/reward/{id:[0-9]+}", middleware.JwtAuthMiddleware(giveRewardHandler(deps.RewardService), constants.User)).Methods(http.MethodPost).Headers(versionHeader, v1)
 
+	peerlySubrouter.Handle("/reward_levels", middleware.JwtAuthMiddleware(listRewardLevelsHandler(deps.RewardService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/reward_levels", middleware.JwtAuthMiddleware(middleware.RequirePermission(updateRewardLevelsHandler(deps.RewardService), constants.PermissionConfigUpdate), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+
 	// organization config
 	peerlySubrouter.Handle("/organizationconfig", middleware.JwtAuthMiddleware(getOrganizationConfigHandler(deps.OrganizationConfigService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
 
 	//organization config data inserted by seed file
 	// peerlySubrouter.Handle("/organizationconfig", middleware.JwtAuthMiddleware(createOrganizationConfigHandler(deps.OrganizationConfigService),[]string{constants.UserRole})).Methods(http.MethodPost).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/organizationconfig", middleware.JwtAuthMiddleware(updateOrganizationConfigHandler(deps.OrganizationConfigService), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/organizationconfig", middleware.JwtAuthMiddleware(middleware.RequirePermission(updateOrganizationConfigHandler(deps.OrganizationConfigService), constants.PermissionConfigUpdate), constants.Admin)).Methods(http.MethodPut).Headers(versionHeader, v1)
 
 	//badges
 
 	peerlySubrouter.Handle("/badges", middleware.JwtAuthMiddleware(listBadgesHandler(deps.BadgeService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
 
-	peerlySubrouter.Handle("/badges/{id:[0-9]+}", middleware.JwtAuthMiddleware(editBadgesHandler(deps.BadgeService), constants.Admin)).Methods(http.MethodPatch).Headers(versionHeader, v1)
+	peerlySubrouter.Handle("/badges/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(editBadgesHandler(deps.BadgeService), constants.PermissionConfigUpdate), constants.Admin)).Methods(http.MethodPatch).Headers(versionHeader, v1)
+
+	// outbox deliveries
+	peerlySubrouter.Handle("/outbox", middleware.JwtAuthMiddleware(middleware.RequirePermission(listOutboxMessagesHandler(deps.OutboxService), constants.PermissionOutboxManage), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/outbox/{id:[0-9]+}/retry", middleware.JwtAuthMiddleware(middleware.RequirePermission(retryOutboxMessageHandler(deps.OutboxService), constants.PermissionOutboxManage), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	// chat integrations
+	peerlySubrouter.Handle("/integrations", middleware.JwtAuthMiddleware(middleware.RequirePermission(createIntegrationHandler(deps.IntegrationService), constants.PermissionIntegrationManage), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/integrations", middleware.JwtAuthMiddleware(middleware.RequirePermission(listIntegrationsHandler(deps.IntegrationService), constants.PermissionIntegrationManage), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/integrations/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(deleteIntegrationHandler(deps.IntegrationService), constants.PermissionIntegrationManage), constants.Admin)).Methods(http.MethodDelete).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/integrations/{id:[0-9]+}/test", middleware.JwtAuthMiddleware(middleware.RequirePermission(testIntegrationHandler(deps.IntegrationService), constants.PermissionIntegrationManage), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	// webhook subscriptions of third-party consumers
+	peerlySubrouter.Handle("/webhooks", middleware.JwtAuthMiddleware(middleware.RequirePermission(createWebhookSubscriptionHandler(deps.WebhookService), constants.PermissionWebhookManage), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/webhooks", middleware.JwtAuthMiddleware(middleware.RequirePermission(listWebhookSubscriptionsHandler(deps.WebhookService), constants.PermissionWebhookManage), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/webhooks/{id:[0-9]+}", middleware.JwtAuthMiddleware(middleware.RequirePermission(deleteWebhookSubscriptionHandler(deps.WebhookService), constants.PermissionWebhookManage), constants.Admin)).Methods(http.MethodDelete).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/webhooks/{id:[0-9]+}/deliveries", middleware.JwtAuthMiddleware(middleware.RequirePermission(listWebhookDeliveriesHandler(deps.WebhookService), constants.PermissionWebhookManage), constants.Admin)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/webhooks/deliveries/{id:[0-9]+}/redeliver", middleware.JwtAuthMiddleware(middleware.RequirePermission(redeliverWebhookHandler(deps.WebhookService), constants.PermissionWebhookManage), constants.Admin)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	// in-app notifications
+	peerlySubrouter.Handle("/notifications", middleware.JwtAuthMiddleware(listNotificationsHandler(deps.InboxService), constants.User)).Methods(http.MethodGet).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/notifications/read", middleware.JwtAuthMiddleware(markAllNotificationsReadHandler(deps.InboxService), constants.User)).Methods(http.MethodPatch).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/notifications/{id:[0-9]+}/read", middleware.JwtAuthMiddleware(markNotificationReadHandler(deps.InboxService), constants.User)).Methods(http.MethodPatch).Headers(versionHeader, v1)
+
+	peerlySubrouter.Handle("/notifications/{id:[0-9]+}", middleware.JwtAuthMiddleware(deleteNotificationHandler(deps.InboxService), constants.User)).Methods(http.MethodDelete).Headers(versionHeader, v1)
 
 	// No version requirement for /ping
 	peerlySubrouter.HandleFunc("/ping", pingHandler).Methods(http.MethodGet)
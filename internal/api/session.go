@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/sessions"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// refreshTokenHandler swaps a refresh token for a new access and refresh token, the access token may have expired already
+func refreshTokenHandler(sessionSvc sessions.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var reqData dto.RefreshTokenReq
+		err := json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		resp, err := sessionSvc.RefreshTokens(ctx, reqData.RefreshToken)
+		if err != nil {
+			log.Errorf(ctx, "refreshTokenHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Tokens refreshed successfully", resp)
+	})
+}
+
+// logoutHandler revokes the token of the request, the body naming the device token is optional
+func logoutHandler(sessionSvc sessions.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var reqData dto.LogoutReq
+		err := json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil && !errors.Is(err, io.EOF) {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		err = sessionSvc.Logout(ctx, reqData)
+		if err != nil {
+			log.Errorf(ctx, "logoutHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Logged out successfully", nil)
+	})
+}
+
+func revokeUserSessionsHandler(sessionSvc sessions.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding user id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = sessionSvc.RevokeUserSessions(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "revokeUserSessionsHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "User sessions revoked successfully", nil)
+	})
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/sessions/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRefreshTokenHandler(t *testing.T) {
+	sessionSvc := new(mocks.Service)
+	handler := refreshTokenHandler(sessionSvc)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"refresh_token":"valid"}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("RefreshTokens", mock.Anything, "valid").Return(dto.AuthTokens{AuthToken: "access", RefreshToken: "next"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Used refresh token",
+			body: `{"refresh_token":"used"}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("RefreshTokens", mock.Anything, "used").Return(dto.AuthTokens{}, apperrors.InvalidRefreshToken).Once()
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Invalid json",
+			body:               `{"refresh_token":`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(sessionSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/token/refresh", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			sessionSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogoutHandler(t *testing.T) {
+	sessionSvc := new(mocks.Service)
+	handler := logoutHandler(sessionSvc)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"notification_token":"phone"}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("Logout", mock.Anything, dto.LogoutReq{NotificationToken: "phone"}).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Without a body",
+			body: "",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("Logout", mock.Anything, dto.LogoutReq{}).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Invalid json",
+			body:               `{"notification_token":`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(sessionSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/user/logout", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			sessionSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRevokeUserSessionsHandler(t *testing.T) {
+	sessionSvc := new(mocks.Service)
+	handler := revokeUserSessionsHandler(sessionSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "7",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("RevokeUserSessions", mock.Anything, int64(7)).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "User not found",
+			id:   "8",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("RevokeUserSessions", mock.Anything, int64(8)).Return(apperrors.UserNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Invalid id",
+			id:                 "abc",
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(sessionSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/"+tt.id+"/revoke_sessions", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			sessionSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -30,18 +30,7 @@ func loginUser(userSvc user.Service) http.HandlerFunc {
 
 		ctx := req.Context()
 
-		validateResp, err := userSvc.ValidatePeerly(ctx, authToken)
-		if err != nil {
-			dto.ErrorRepsonse(rw, err)
-			return
-		}
-
-		reqData := dto.GetIntranetUserDataReq{
-			Token:  validateResp.Data.JwtToken,
-			UserId: validateResp.Data.UserId,
-		}
-
-		user, err := userSvc.GetIntranetUserData(ctx, reqData)
+		user, err := userSvc.AuthenticateUser(ctx, authToken)
 		if err != nil {
 			dto.ErrorRepsonse(rw, err)
 			return
@@ -210,11 +199,17 @@ func listUsersHandler(userSvc user.Service) http.HandlerFunc {
 		if self == "true" {
 			selfBool = true
 		}
+		orgFilter, err := orgFilterParams(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
 		userListReq := dto.ListUsersReq{
-			Self:     selfBool,
-			Name:     names,
-			Page:     pageInt,
-			PageSize: perPageInt,
+			Self:      selfBool,
+			Name:      names,
+			Page:      pageInt,
+			PageSize:  perPageInt,
+			OrgFilter: orgFilter,
 		}
 
 		resp, err := userSvc.ListUsers(req.Context(), userListReq)
@@ -244,8 +239,14 @@ func getActiveUserListHandler(userSvc user.Service) http.HandlerFunc {
 			return
 		}
 
+		orgFilter, err := orgFilterParams(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
 		log.Info(ctx, "getActiveUserListHandler: req: ", req)
-		resp, err := userSvc.GetActiveUserList(ctx, quarter, year)
+		resp, err := userSvc.GetActiveUserList(ctx, quarter, year, orgFilter)
 		if err != nil {
 			dto.ErrorRepsonse(rw, err)
 			return
@@ -277,7 +278,12 @@ func getTop10UserHandler(userSvc user.Service) http.HandlerFunc {
 	return func(rw http.ResponseWriter, req *http.Request) {
 		ctx := req.Context()
 		log.Info(ctx, "getTop10UserHandler: request: ", req)
-		resp, err := userSvc.GetTop10Users(req.Context())
+		orgFilter, err := orgFilterParams(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		resp, err := userSvc.GetTop10Users(req.Context(), orgFilter)
 		if err != nil {
 			dto.ErrorRepsonse(rw, err)
 			return
@@ -330,12 +336,19 @@ func appreciationReportHandler(userSvc user.Service, appreciationSvc appreciatio
 			}
 		}
 
+		orgFilter, err := orgFilterParams(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
 		filter := dto.AppreciationFilter{
-			Self:    false,
-			Limit:   constants.DefaultPageSize,
-			Page:    1,
-			Quarter: quarter,
-			Year:    year,
+			Self:      false,
+			Limit:     constants.DefaultPageSize,
+			Page:      1,
+			Quarter:   quarter,
+			Year:      year,
+			OrgFilter: orgFilter,
 		}
 
 		appreciationResp, err := appreciationSvc.ListAppreciations(req.Context(), filter)
@@ -377,7 +390,13 @@ func reportedAppreciationReportHandler(userSvc user.Service, reportAppreciationS
 			}
 		}
 
-		reportedAppreciationResp, err := reportAppreciationSvc.ListReportedAppreciations(req.Context(), quarter, year)
+		orgFilter, err := orgFilterParams(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		reportedAppreciationResp, err := reportAppreciationSvc.ListReportedAppreciations(req.Context(), quarter, year, orgFilter)
 		if err != nil {
 			dto.ErrorRepsonse(rw, err)
 			return
@@ -413,7 +432,13 @@ func dynamicEngagersReportHandler(userSvc user.Service) http.HandlerFunc {
 			return
 		}
 
-		tempFileName, err := userSvc.DynamicEngagersReport(ctx, quarter, year)
+		orgFilter, err := orgFilterParams(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		tempFileName, err := userSvc.DynamicEngagersReport(ctx, quarter, year, orgFilter)
 		if err != nil {
 			logger.Errorf(ctx, "dynamicEngagersReportHandler: err: %v", err)
 			dto.ErrorRepsonse(rw, err)
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	user "github.com/joshsoftware/peerly-backend/internal/app/users"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// adminListUsersHandler lists every user including the deactivated ones, filtered by status, grade, role, name,
+// department and team
+func adminListUsersHandler(userSvc user.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		query := req.URL.Query()
+
+		listReq := dto.AdminListUsersReq{
+			Status:   query.Get("status"),
+			Page:     1,
+			PageSize: constants.DefaultPageSize,
+		}
+
+		var err error
+		if page := query.Get("page"); page != "" {
+			listReq.Page, err = strconv.ParseInt(page, 10, 64)
+			if err != nil || listReq.Page <= 0 {
+				dto.ErrorRepsonse(rw, apperrors.InvalidPage)
+				return
+			}
+		}
+		if pageSize := query.Get("page_size"); pageSize != "" {
+			listReq.PageSize, err = strconv.ParseInt(pageSize, 10, 64)
+			if err != nil || listReq.PageSize <= 0 {
+				dto.ErrorRepsonse(rw, apperrors.InvalidPageSize)
+				return
+			}
+		}
+		if gradeID := query.Get("grade_id"); gradeID != "" {
+			listReq.GradeId, err = strconv.ParseInt(gradeID, 10, 64)
+			if err != nil {
+				dto.ErrorRepsonse(rw, apperrors.InvalidId)
+				return
+			}
+		}
+		if roleID := query.Get("role_id"); roleID != "" {
+			listReq.RoleId, err = strconv.ParseInt(roleID, 10, 64)
+			if err != nil {
+				dto.ErrorRepsonse(rw, apperrors.InvalidId)
+				return
+			}
+		}
+		if name := strings.TrimSpace(query.Get("name")); name != "" {
+			listReq.Name = strings.Fields(name)
+		}
+		listReq.OrgFilter, err = orgFilterParams(req)
+		if err != nil {
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := userSvc.AdminListUsers(ctx, listReq)
+		if err != nil {
+			log.Errorf(ctx, "adminListUsersHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Users listed", resp)
+	})
+}
+
+func adminGetUserHandler(userSvc user.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding user id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		resp, err := userSvc.AdminGetUser(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "adminGetUserHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "User fetched successfully", resp)
+	})
+}
+
+func deactivateUserHandler(userSvc user.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding user id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = userSvc.DeactivateUser(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "deactivateUserHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "User deactivated successfully", nil)
+	})
+}
+
+func reactivateUserHandler(userSvc user.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding user id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = userSvc.ReactivateUser(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "reactivateUserHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "User reactivated successfully", nil)
+	})
+}
+
+// setAdminPasswordHandler sets the password of an admin, without a body a random password is generated and returned once
+func setAdminPasswordHandler(userSvc user.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding user id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		var reqData dto.SetAdminPasswordReq
+		err = json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil && !errors.Is(err, io.EOF) {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		resp, err := userSvc.SetAdminPassword(ctx, id, reqData)
+		if err != nil {
+			log.Errorf(ctx, "setAdminPasswordHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Admin password set successfully", resp)
+	})
+}
+
+// listUserSyncReportsHandler lists the reports of the latest intranet sync runs
+func listUserSyncReportsHandler(userSvc user.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		limit := int64(constants.DefaultUserSyncReportsLimit)
+		if limitParam := req.URL.Query().Get("limit"); limitParam != "" {
+			var err error
+			limit, err = strconv.ParseInt(limitParam, 10, 64)
+			if err != nil {
+				dto.ErrorRepsonse(rw, apperrors.InvalidPageSize)
+				return
+			}
+		}
+
+		resp, err := userSvc.ListUserSyncReports(ctx, limit)
+		if err != nil {
+			log.Errorf(ctx, "listUserSyncReportsHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "User sync reports listed", resp)
+	})
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/users/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdminListUsersHandler(t *testing.T) {
+	userSvc := new(mocks.Service)
+	handler := adminListUsersHandler(userSvc)
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name:  "success",
+			query: "?status=deactivated&grade_id=2&role_id=3&name=sam%20lee&page=2&page_size=5",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("AdminListUsers", mock.Anything, dto.AdminListUsersReq{
+					Status:   constants.DeactivatedUser,
+					GradeId:  2,
+					RoleId:   3,
+					Name:     []string{"sam", "lee"},
+					Page:     2,
+					PageSize: 5,
+				}).Return(dto.AdminListUsersResp{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Defaults to the first page",
+			query: "",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("AdminListUsers", mock.Anything, dto.AdminListUsersReq{Page: 1, PageSize: constants.DefaultPageSize}).Return(dto.AdminListUsersResp{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Unknown status",
+			query: "?status=archived",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("AdminListUsers", mock.Anything, dto.AdminListUsersReq{Status: "archived", Page: 1, PageSize: constants.DefaultPageSize}).Return(dto.AdminListUsersResp{}, apperrors.InvalidUserStatus).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid page",
+			query:              "?page=0",
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(userSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/users"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			userSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeactivateUserHandler(t *testing.T) {
+	userSvc := new(mocks.Service)
+	handler := deactivateUserHandler(userSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "4",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("DeactivateUser", mock.Anything, int64(4)).Return(nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Super admin",
+			id:   "2",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("DeactivateUser", mock.Anything, int64(2)).Return(apperrors.CannotDeactivateUser).Once()
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Invalid id",
+			id:                 "abc",
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(userSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/"+tt.id+"/deactivate", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			userSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetAdminPasswordHandler(t *testing.T) {
+	userSvc := new(mocks.Service)
+	handler := setAdminPasswordHandler(userSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		body               string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "2",
+			body: `{"password":"correct horse"}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("SetAdminPassword", mock.Anything, int64(2), dto.SetAdminPasswordReq{Password: "correct horse"}).Return(dto.SetAdminPasswordResp{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Without a body a password is generated",
+			id:   "2",
+			body: "",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("SetAdminPassword", mock.Anything, int64(2), dto.SetAdminPasswordReq{}).Return(dto.SetAdminPasswordResp{Password: "generated"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Password too short",
+			id:   "3",
+			body: `{"password":"short"}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("SetAdminPassword", mock.Anything, int64(3), dto.SetAdminPasswordReq{Password: "short"}).Return(dto.SetAdminPasswordResp{}, apperrors.PasswordTooShort).Once()
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid json",
+			id:                 "2",
+			body:               `{"password":`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(userSvc)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/users/"+tt.id+"/password", bytes.NewBufferString(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			userSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListUserSyncReportsHandler(t *testing.T) {
+	userSvc := new(mocks.Service)
+	handler := listUserSyncReportsHandler(userSvc)
+
+	tests := []struct {
+		name               string
+		query              string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name:  "success",
+			query: "?limit=5",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("ListUserSyncReports", mock.Anything, int64(5)).Return([]dto.UserSyncReport{{Id: 1}}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Default limit",
+			query: "",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("ListUserSyncReports", mock.Anything, int64(constants.DefaultUserSyncReportsLimit)).Return([]dto.UserSyncReport{}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Invalid limit",
+			query:              "?limit=all",
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(userSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/user_sync_reports"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			userSvc.AssertExpectations(t)
+		})
+	}
+}
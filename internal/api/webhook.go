@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/webhooks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/utils"
+)
+
+// createWebhookSubscriptionHandler responds with the signing secret, it is not shown again
+func createWebhookSubscriptionHandler(webhookSvc webhooks.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		var reqData dto.CreateWebhookSubscriptionReq
+		err := json.NewDecoder(req.Body).Decode(&reqData)
+		if err != nil {
+			log.Error(ctx, "Error decoding request data:", err.Error())
+			dto.ErrorRepsonse(rw, apperrors.JSONParsingErrorReq)
+			return
+		}
+
+		err = reqData.Validate()
+		if err != nil {
+			log.Errorf(ctx, "Error in validating request : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+
+		resp, err := webhookSvc.CreateSubscription(ctx, reqData)
+		if err != nil {
+			log.Errorf(ctx, "createWebhookSubscriptionHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusCreated, "Webhook subscription created successfully", resp)
+	})
+}
+
+func listWebhookSubscriptionsHandler(webhookSvc webhooks.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		resp, err := webhookSvc.ListSubscriptions(ctx)
+		if err != nil {
+			log.Errorf(ctx, "listWebhookSubscriptionsHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Webhook subscriptions fetched successfully", resp)
+	})
+}
+
+func deleteWebhookSubscriptionHandler(webhookSvc webhooks.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding webhook subscription id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		err = webhookSvc.DeleteSubscription(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "deleteWebhookSubscriptionHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Webhook subscription deleted successfully", nil)
+	})
+}
+
+// listWebhookDeliveriesHandler lists the delivery log of a subscription, latest first
+func listWebhookDeliveriesHandler(webhookSvc webhooks.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding webhook subscription id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		filter := dto.WebhookDeliveryFilter{SubscriptionID: id}
+		filter.Page, filter.Limit = utils.GetPaginationParams(req)
+
+		resp, err := webhookSvc.ListDeliveries(ctx, filter)
+		if err != nil {
+			log.Errorf(ctx, "listWebhookDeliveriesHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		dto.SuccessRepsonse(rw, http.StatusOK, "Webhook deliveries fetched successfully", resp)
+	})
+}
+
+func redeliverWebhookHandler(webhookSvc webhooks.Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		vars := mux.Vars(req)
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			log.Errorf(ctx, "Error while decoding webhook delivery id : %v", err)
+			dto.ErrorRepsonse(rw, apperrors.InvalidId)
+			return
+		}
+
+		resp, err := webhookSvc.Redeliver(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "redeliverWebhookHandler: err : %v", err)
+			dto.ErrorRepsonse(rw, err)
+			return
+		}
+		log.Infof(ctx, "Webhook delivery %d queued again", id)
+		dto.SuccessRepsonse(rw, http.StatusOK, "Webhook delivery queued again", resp)
+	})
+}
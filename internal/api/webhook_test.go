@@ -0,0 +1,120 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/joshsoftware/peerly-backend/internal/app/webhooks/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateWebhookSubscriptionHandler(t *testing.T) {
+	webhookSvc := new(mocks.Service)
+	handler := createWebhookSubscriptionHandler(webhookSvc)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"url":"https://example.com/peerly","events":["appreciation.created","reward.given"]}`,
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("CreateSubscription", mock.Anything, mock.Anything).Return(dto.WebhookSubscription{ID: 1, Secret: "whsec_x"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Unknown event",
+			body:               `{"url":"https://example.com/peerly","events":["comment.created"]}`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "No events",
+			body:               `{"url":"https://example.com/peerly","events":[]}`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid url",
+			body:               `{"url":"example.com","events":["reward.given"]}`,
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(webhookSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			webhookSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRedeliverWebhookHandler(t *testing.T) {
+	webhookSvc := new(mocks.Service)
+	handler := redeliverWebhookHandler(webhookSvc)
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(mockSvc *mocks.Service)
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			id:   "3",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("Redeliver", mock.Anything, int64(3)).Return(dto.WebhookDelivery{ID: 4, EventID: "evt"}, nil).Once()
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Delivery not found",
+			id:   "5",
+			mockSetup: func(mockSvc *mocks.Service) {
+				mockSvc.On("Redeliver", mock.Anything, int64(5)).Return(dto.WebhookDelivery{}, apperrors.WebhookDeliveryNotFound).Once()
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Invalid id",
+			id:                 "abc",
+			mockSetup:          func(mockSvc *mocks.Service) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup(webhookSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/deliveries/"+tt.id+"/redeliver", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+
+			webhookSvc.AssertExpectations(t)
+		})
+	}
+}
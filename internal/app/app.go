@@ -3,9 +3,24 @@ package app
 import (
 	"github.com/jmoiron/sqlx"
 	"github.com/joshsoftware/peerly-backend/internal/app/badges"
+	"github.com/joshsoftware/peerly-backend/internal/app/comments"
 	corevalues "github.com/joshsoftware/peerly-backend/internal/app/coreValues"
+	"github.com/joshsoftware/peerly-backend/internal/app/digest"
+	"github.com/joshsoftware/peerly-backend/internal/app/feed"
 	"github.com/joshsoftware/peerly-backend/internal/app/grades"
+	"github.com/joshsoftware/peerly-backend/internal/app/identity"
+	"github.com/joshsoftware/peerly-backend/internal/app/inbox"
+	"github.com/joshsoftware/peerly-backend/internal/app/integrations"
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
+	"github.com/joshsoftware/peerly-backend/internal/app/org"
+	"github.com/joshsoftware/peerly-backend/internal/app/outbox"
+	"github.com/joshsoftware/peerly-backend/internal/app/preferences"
+	"github.com/joshsoftware/peerly-backend/internal/app/reactions"
 	reportappreciations "github.com/joshsoftware/peerly-backend/internal/app/reportAppreciations"
+	"github.com/joshsoftware/peerly-backend/internal/app/roles"
+	"github.com/joshsoftware/peerly-backend/internal/app/sessions"
+	"github.com/joshsoftware/peerly-backend/internal/app/webhooks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
 
 	organizationConfig "github.com/joshsoftware/peerly-backend/internal/app/organizationConfig"
 	reward "github.com/joshsoftware/peerly-backend/internal/app/reward"
@@ -27,10 +42,25 @@ type Dependencies struct {
 	GradeService              grades.Service
 	OrganizationConfigService organizationConfig.Service
 	BadgeService              badges.Service
+	CommentService            comments.Service
+	ReactionService           reactions.Service
+	NotificationService       notification.NotificationService
+	OutboxService             outbox.Service
+	InboxService              inbox.Service
+	PreferenceService         preferences.Service
+	DigestService             digest.Service
+	IntegrationService        integrations.Service
+	WebhookService            webhooks.Service
+	FeedBroker                feed.Broker
+	SessionService            sessions.Service
+	RoleService               roles.Service
+	OrgService                org.Service
 }
 
-// NewService initializes and returns a Dependencies instance with the given database connection.
-func NewService(db *sqlx.DB) Dependencies {
+// NewService initializes and returns a Dependencies instance with the given database connection, the identity
+// provider users log in with, the push notification provider shared by every service and the client every call to
+// the intranet goes through.
+func NewService(db *sqlx.DB, identityProvider identity.IdentityProvider, notificationService notification.NotificationService, intranetClient *intranet.Client) Dependencies {
 	// Initialize repository dependencies using the provided database connection.
 
 	coreValueRepo := repository.NewCoreValueRepo(db)
@@ -41,15 +71,42 @@ func NewService(db *sqlx.DB) Dependencies {
 	gradeRepo := repository.NewGradesRepo(db)
 	orgConfigRepo := repository.NewOrganizationConfigRepo(db)
 	badgeRepo := repository.NewBadgeRepo(db)
+	rewardLevelRepo := repository.NewRewardLevelRepo(db)
+	commentRepo := repository.NewCommentRepo(db)
+	reactionRepo := repository.NewReactionRepo(db)
+	outboxRepo := repository.NewOutboxRepo(db)
+	notificationRepo := repository.NewNotificationRepo(db)
+	preferenceRepo := repository.NewNotificationPreferenceRepo(db)
+	digestRepo := repository.NewDigestRepo(db)
+	integrationRepo := repository.NewIntegrationRepo(db)
+	webhookRepo := repository.NewWebhookRepo(db)
+	sessionRepo := repository.NewSessionRepo(db)
+	roleRepo := repository.NewRoleRepo(db)
+	userSyncRepo := repository.NewUserSyncRepo(db)
+	orgRepo := repository.NewOrgRepo(db)
+
+	// the live feed is fanned out within this server, the services publish to it and the stream handler subscribes
+	feedBroker := feed.NewBroker()
 
 	coreValueService := corevalues.NewService(coreValueRepo)
-	appreciationService := appreciation.NewService(appreciationRepo, coreValueRepo, userRepo)
-	userService := user.NewService(userRepo)
-	reportAppreciationService := reportappreciations.NewService(reportAppreciationRepo, userRepo, appreciationRepo)
-	rewardService := reward.NewService(rewardRepo, appreciationRepo, userRepo, reportAppreciationRepo)
+	appreciationService := appreciation.NewService(appreciationRepo, coreValueRepo, userRepo, outboxRepo, notificationRepo, integrationRepo, feedBroker)
+	sessionService := sessions.NewService(sessionRepo, userRepo, roleRepo)
+	userService := user.NewService(userRepo, notificationService, notificationRepo, preferenceRepo, outboxRepo, userSyncRepo, orgRepo, sessionService, identityProvider, intranetClient)
+	reportAppreciationService := reportappreciations.NewService(reportAppreciationRepo, userRepo, appreciationRepo, outboxRepo, notificationRepo, feedBroker)
+	rewardService := reward.NewService(rewardRepo, appreciationRepo, userRepo, reportAppreciationRepo, rewardLevelRepo, outboxRepo, notificationRepo, feedBroker)
 	gradeService := grades.NewService(gradeRepo, userRepo)
 	orgConfigService := organizationConfig.NewService(orgConfigRepo)
 	badgeService := badges.NewService(badgeRepo, userRepo)
+	commentService := comments.NewService(commentRepo, appreciationRepo, userRepo, outboxRepo)
+	reactionService := reactions.NewService(reactionRepo, appreciationRepo)
+	outboxService := outbox.NewService(outboxRepo, userRepo, preferenceRepo, digestRepo, integrationRepo, webhookRepo, notificationService)
+	inboxService := inbox.NewService(notificationRepo)
+	preferenceService := preferences.NewService(preferenceRepo)
+	digestService := digest.NewService(digestRepo, userRepo, outboxRepo)
+	integrationService := integrations.NewService(integrationRepo, coreValueRepo, outboxRepo)
+	webhookService := webhooks.NewService(webhookRepo, outboxRepo)
+	roleService := roles.NewService(roleRepo, sessionService)
+	orgService := org.NewService(orgRepo, userRepo)
 
 	return Dependencies{
 		CoreValueService:          coreValueService,
@@ -60,6 +117,19 @@ func NewService(db *sqlx.DB) Dependencies {
 		GradeService:              gradeService,
 		OrganizationConfigService: orgConfigService,
 		BadgeService:              badgeService,
+		CommentService:            commentService,
+		ReactionService:           reactionService,
+		NotificationService:       notificationService,
+		OutboxService:             outboxService,
+		InboxService:              inboxService,
+		PreferenceService:         preferenceService,
+		DigestService:             digestService,
+		IntegrationService:        integrationService,
+		WebhookService:            webhookService,
+		FeedBroker:                feedBroker,
+		SessionService:            sessionService,
+		RoleService:               roleService,
+		OrgService:                orgService,
 	}
 
 }
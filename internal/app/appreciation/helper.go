@@ -1,6 +1,8 @@
 package appreciation
 
 import (
+	"fmt"
+
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
 	"github.com/joshsoftware/peerly-backend/internal/repository"
 )
@@ -15,6 +17,7 @@ func mapAppreciationDBToDTO(dbAppreciation repository.Appreciation) dto.Apprecia
 		Quarter:           dbAppreciation.Quarter,
 		Sender:            dbAppreciation.Sender,
 		Receiver:          dbAppreciation.Receiver,
+		GroupName:         dbAppreciation.GroupName.String,
 		CreatedAt:         dbAppreciation.CreatedAt,
 		UpdatedAt:         dbAppreciation.UpdatedAt,
 	}
@@ -27,6 +30,7 @@ func mapRepoGetAppreciationInfoToDTOGetAppreciationInfo(info repository.Apprecia
 
 	dtoApprResp := dto.AppreciationResponse{
 		ID:                  info.ID,
+		CoreValueID:         info.CoreValueID,
 		CoreValueName:       info.CoreValueName,
 		CoreValueDesc:       info.CoreValueDesc,
 		Description:         info.Description,
@@ -47,10 +51,22 @@ func mapRepoGetAppreciationInfoToDTOGetAppreciationInfo(info repository.Apprecia
 		TotalRewards:        info.TotalRewards,
 		GivenRewardPoint:    info.GivenRewardPoint,
 		ReportedFlag:        info.ReportedFlag,
+		Receivers:           mapRepoReceiversToDTOReceivers(info.Receivers),
+		GroupName:           info.GroupName,
+		ReactionCounts:      map[string]int64(info.ReactionCounts),
+		MyReactions:         []string(info.MyReactions),
 		CreatedAt:           info.CreatedAt,
 		UpdatedAt:           info.UpdatedAt,
 	}
 
+	// clients expect empty collections rather than null when nobody reacted
+	if dtoApprResp.ReactionCounts == nil {
+		dtoApprResp.ReactionCounts = map[string]int64{}
+	}
+	if dtoApprResp.MyReactions == nil {
+		dtoApprResp.MyReactions = []string{}
+	}
+
 	if info.SenderGradeID != 0 && info.SenderGradeID <= 4 {
 		dtoApprResp.ByManagement = true
 	}
@@ -58,6 +74,54 @@ func mapRepoGetAppreciationInfoToDTOGetAppreciationInfo(info repository.Apprecia
 	return dtoApprResp
 }
 
+func mapRepoReceiversToDTOReceivers(receivers repository.AppreciationReceivers) []dto.AppreciationReceiver {
+	dtoReceivers := make([]dto.AppreciationReceiver, 0, len(receivers))
+	for _, receiver := range receivers {
+		dtoReceivers = append(dtoReceivers, dto.AppreciationReceiver{
+			ID:           receiver.ID,
+			EmployeeID:   receiver.EmployeeID,
+			FirstName:    receiver.FirstName,
+			LastName:     receiver.LastName,
+			ImageURL:     receiver.ImageURL,
+			Designation:  receiver.Designation,
+			RewardPoints: receiver.RewardPoints,
+		})
+	}
+	return dtoReceivers
+}
+
+// receiversDisplayName names the receivers of an appreciation the way it is shown in emails and notifications
+func receiversDisplayName(appr repository.AppreciationResponse) string {
+	if appr.GroupName != "" {
+		return appr.GroupName
+	}
+	if len(appr.Receivers) > 1 {
+		return fmt.Sprintf("%s %s and %d others", appr.ReceiverFirstName, appr.ReceiverLastName, len(appr.Receivers)-1)
+	}
+	return fmt.Sprint(appr.ReceiverFirstName, " ", appr.ReceiverLastName)
+}
+
+func mapAppreciationEventData(appr repository.AppreciationResponse) dto.AppreciationEventData {
+	return dto.AppreciationEventData{
+		ID:            appr.ID,
+		CoreValueID:   appr.CoreValueID,
+		CoreValueName: appr.CoreValueName,
+		Description:   appr.Description,
+		SenderID:      appr.SenderID,
+		ReceiverIDs:   appr.ReceiverIDs(),
+		CreatedAt:     appr.CreatedAt,
+	}
+}
+
+func mapBadgeEventData(userBadgeDetail repository.UserBadgeDetails) dto.BadgeEventData {
+	return dto.BadgeEventData{
+		UserID:      userBadgeDetail.ID,
+		BadgeID:     int64(userBadgeDetail.BadgeID),
+		BadgeName:   userBadgeDetail.BadgeName.String,
+		BadgePoints: int64(userBadgeDetail.BadgePoints),
+	}
+}
+
 // DtoPagination returns modified response pagination struct
 func dtoPagination(pagination repository.Pagination) dto.Pagination {
 	return dto.Pagination{
@@ -67,3 +131,20 @@ func dtoPagination(pagination repository.Pagination) dto.Pagination {
 		TotalRecords: pagination.TotalRecords,
 	}
 }
+
+func mapAppreciationEditDBToDTO(edit repository.AppreciationEditResponse) dto.AppreciationEdit {
+	return dto.AppreciationEdit{
+		ID:                edit.ID,
+		AppreciationID:    edit.AppreciationID,
+		OldCoreValueID:    edit.OldCoreValueID,
+		OldCoreValueName:  edit.OldCoreValueName,
+		NewCoreValueID:    edit.NewCoreValueID,
+		NewCoreValueName:  edit.NewCoreValueName,
+		OldDescription:    edit.OldDescription,
+		NewDescription:    edit.NewDescription,
+		EditedBy:          edit.EditedBy,
+		EditedByFirstName: edit.EditedByFirstName,
+		EditedByLastName:  edit.EditedByLastName,
+		EditedAt:          edit.EditedAt,
+	}
+}
@@ -3,11 +3,15 @@ package appreciation
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
-	"github.com/joshsoftware/peerly-backend/internal/app/email"
+	"github.com/joshsoftware/peerly-backend/internal/app/feed"
+	"github.com/joshsoftware/peerly-backend/internal/app/integrations"
 	"github.com/joshsoftware/peerly-backend/internal/app/notification"
 	user "github.com/joshsoftware/peerly-backend/internal/app/users"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/utils"
@@ -20,6 +24,10 @@ type service struct {
 	appreciationRepo repository.AppreciationStorer
 	corevaluesRespo  repository.CoreValueStorer
 	userRepo         repository.UserStorer
+	outboxRepo       repository.OutboxStorer
+	notificationRepo repository.NotificationStorer
+	integrationRepo  repository.IntegrationStorer
+	feedBroker       feed.Broker
 }
 
 // Service contains all
@@ -29,13 +37,19 @@ type Service interface {
 	ListAppreciations(ctx context.Context, filter dto.AppreciationFilter) (dto.ListAppreciationsResponse, error)
 	DeleteAppreciation(ctx context.Context, apprId int32) error
 	UpdateAppreciation(ctx context.Context, orgTimezone string) (bool, error)
+	EditAppreciation(ctx context.Context, edit dto.EditAppreciation) (dto.AppreciationResponse, error)
+	ListAppreciationEdits(ctx context.Context, apprId int64) ([]dto.AppreciationEdit, error)
 }
 
-func NewService(appreciationRepo repository.AppreciationStorer, coreValuesRepo repository.CoreValueStorer, userRepo repository.UserStorer) Service {
+func NewService(appreciationRepo repository.AppreciationStorer, coreValuesRepo repository.CoreValueStorer, userRepo repository.UserStorer, outboxRepo repository.OutboxStorer, notificationRepo repository.NotificationStorer, integrationRepo repository.IntegrationStorer, feedBroker feed.Broker) Service {
 	return &service{
 		appreciationRepo: appreciationRepo,
 		corevaluesRespo:  coreValuesRepo,
 		userRepo:         userRepo,
+		outboxRepo:       outboxRepo,
+		notificationRepo: notificationRepo,
+		integrationRepo:  integrationRepo,
+		feedBroker:       feedBroker,
 	}
 }
 
@@ -53,15 +67,30 @@ func (apprSvc *service) CreateAppreciation(ctx context.Context, appreciation dto
 		return dto.Appreciation{}, apperrors.InternalServer
 	}
 
-	//check is receiver present in database
-	chk, err := apprSvc.appreciationRepo.IsUserPresent(ctx, nil, appreciation.Receiver)
-	if err != nil {
-		logger.Errorf(ctx, "err: %v", err)
-		return dto.Appreciation{}, err
+	if len(appreciation.Receivers) == 0 {
+		appreciation.Receivers = []int64{appreciation.Receiver}
 	}
-	if !chk {
-		logger.Errorf(ctx, "appreciationService User not found (user_id): %v", appreciation.Receiver)
-		return dto.Appreciation{}, apperrors.UserNotFound
+
+	//check are receivers present in database
+	for _, receiver := range appreciation.Receivers {
+		chk, err := apprSvc.appreciationRepo.IsUserPresent(ctx, nil, receiver)
+		if err != nil {
+			logger.Errorf(ctx, "err: %v", err)
+			return dto.Appreciation{}, err
+		}
+		if !chk {
+			logger.Errorf(ctx, "appreciationService User not found (user_id): %v", receiver)
+			return dto.Appreciation{}, apperrors.UserNotFound
+		}
+		active, err := apprSvc.appreciationRepo.IsUserActive(ctx, nil, receiver)
+		if err != nil {
+			logger.Errorf(ctx, "err: %v", err)
+			return dto.Appreciation{}, err
+		}
+		if !active {
+			logger.Errorf(ctx, "appreciationService receiver is deactivated (user_id): %v", receiver)
+			return dto.Appreciation{}, apperrors.ReceiverDeactivated
+		}
 	}
 	appreciation.Sender = sender
 
@@ -74,6 +103,8 @@ func (apprSvc *service) CreateAppreciation(ctx context.Context, appreciation dto
 		return dto.Appreciation{}, err
 	}
 
+	// set once everything is written, the live feed only hears about committed appreciations
+	var feedEvent *dto.FeedEvent
 	defer func() {
 		rvr := recover()
 		defer func() {
@@ -89,6 +120,9 @@ func (apprSvc *service) CreateAppreciation(ctx context.Context, appreciation dto
 			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
 			return
 		}
+		if feedEvent != nil {
+			apprSvc.feedBroker.Publish(ctx, *feedEvent)
+		}
 	}()
 
 	//check is corevalue present in database
@@ -99,8 +133,8 @@ func (apprSvc *service) CreateAppreciation(ctx context.Context, appreciation dto
 	}
 
 	// check self appreciation
-	if appreciation.Receiver == sender {
-		logger.Errorf(ctx, "appreciationService Self Appreciation Error: %v \n Userid: %d", apperrors.SelfAppreciationError, appreciation.Receiver)
+	if slices.Contains(appreciation.Receivers, sender) {
+		logger.Errorf(ctx, "appreciationService Self Appreciation Error: %v \n Userid: %d", apperrors.SelfAppreciationError, sender)
 		return dto.Appreciation{}, apperrors.SelfAppreciationError
 	}
 
@@ -111,10 +145,11 @@ func (apprSvc *service) CreateAppreciation(ctx context.Context, appreciation dto
 	}
 
 	res := mapAppreciationDBToDTO(appr)
+	res.Receivers = appreciation.Receivers
 	apprInfo, err := apprSvc.appreciationRepo.GetAppreciationById(ctx, tx, int32(res.ID))
 	if err != nil {
 		logger.Errorf(ctx, "appreciationService err: %v", err)
-		return res, nil
+		return dto.Appreciation{}, err
 	}
 
 	logger.Debug(ctx, "appreciationService createAppreciation result: ", res)
@@ -129,14 +164,50 @@ func (apprSvc *service) CreateAppreciation(ctx context.Context, appreciation dto
 		logger.Info(ctx, "appreciationService error in getting create appreciation sender info")
 	}
 
-	reqGetUserById.UserId = appreciation.Receiver
-	receiverInfo, err := apprSvc.userRepo.GetUserById(ctx, reqGetUserById)
+	receiverInfos := make([]dto.GetUserByIdResp, 0, len(appreciation.Receivers))
+	for _, receiver := range appreciation.Receivers {
+		reqGetUserById.UserId = receiver
+		receiverInfo, err := apprSvc.userRepo.GetUserById(ctx, reqGetUserById)
+		if err != nil {
+			logger.Info(ctx, "appreciationService error in getting create appreciation receiver info")
+			continue
+		}
+		receiverInfos = append(receiverInfos, receiverInfo)
+	}
+
+	// emails and notifications are delivered by the outbox worker once the appreciation is committed
+	err = apprSvc.enqueueAppreciationEmails(ctx, tx, apprInfo, senderInfo, receiverInfos)
 	if err != nil {
-		logger.Info(ctx, "appreciationService error in getting create appreciation sender info")
+		return dto.Appreciation{}, err
+	}
+	for _, receiver := range appreciation.Receivers {
+		err = apprSvc.enqueueAppreciationNotificationToReceiver(ctx, tx, receiver, apprInfo)
+		if err != nil {
+			return dto.Appreciation{}, err
+		}
+	}
+	err = apprSvc.enqueueAppreciationNotificationToAll(ctx, tx, apprInfo)
+	if err != nil {
+		return dto.Appreciation{}, err
+	}
+	err = apprSvc.addAppreciationToInbox(ctx, tx, appreciation.Receivers, apprInfo)
+	if err != nil {
+		return dto.Appreciation{}, err
+	}
+	err = apprSvc.postAppreciationToIntegrations(ctx, tx, apprInfo)
+	if err != nil {
+		return dto.Appreciation{}, err
+	}
+	err = apprSvc.outboxRepo.EnqueueWebhookEvent(ctx, tx, constants.AppreciationCreatedEvent, mapAppreciationEventData(apprInfo))
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService: EnqueueWebhookEvent: err: %v", err)
+		return dto.Appreciation{}, err
+	}
+
+	feedEvent = &dto.FeedEvent{
+		Event: constants.AppreciationCreatedEvent,
+		Data:  mapRepoGetAppreciationInfoToDTOGetAppreciationInfo(apprInfo),
 	}
-	err = sendAppreciationEmail(apprInfo, senderInfo.Email, receiverInfo.Email)
-	apprSvc.sendAppreciationNotificationToReceiver(ctx, apprInfo)
-	apprSvc.sendAppreciationNotificationToAll(ctx, apprInfo)
 	return res, nil
 }
 
@@ -155,6 +226,10 @@ func (apprSvc *service) GetAppreciationById(ctx context.Context, appreciationId
 func (apprSvc *service) ListAppreciations(ctx context.Context, filter dto.AppreciationFilter) (dto.ListAppreciationsResponse, error) {
 
 	logger.Debug(ctx, "appreciationService filter: ", filter)
+	if filter.UseCursor {
+		return apprSvc.listAppreciationsByCursor(ctx, filter)
+	}
+
 	infos, pagination, err := apprSvc.appreciationRepo.ListAppreciations(ctx, nil, filter)
 	if err != nil {
 		logger.Errorf(ctx, "err: %v", err)
@@ -171,9 +246,84 @@ func (apprSvc *service) ListAppreciations(ctx context.Context, filter dto.Apprec
 	return dto.ListAppreciationsResponse{Appreciations: responses, MetaData: paginationResp}, nil
 }
 
-func (apprSvc *service) DeleteAppreciation(ctx context.Context, apprId int32) error {
+// listAppreciationsByCursor serves the feed one keyset page at a time, an empty cursor starts from the newest appreciation
+func (apprSvc *service) listAppreciationsByCursor(ctx context.Context, filter dto.AppreciationFilter) (dto.ListAppreciationsResponse, error) {
+
+	var after *dto.AppreciationCursor
+	if filter.Cursor != "" {
+		cursor, err := dto.DecodeAppreciationCursor(filter.Cursor)
+		if err != nil {
+			logger.Errorf(ctx, "appreciationService invalid cursor: %s", filter.Cursor)
+			return dto.ListAppreciationsResponse{}, err
+		}
+		after = &cursor
+	}
+
+	infos, hasMore, err := apprSvc.appreciationRepo.ListAppreciationsByCursor(ctx, nil, filter, after)
+	if err != nil {
+		logger.Errorf(ctx, "err: %v", err)
+		return dto.ListAppreciationsResponse{}, err
+	}
+
+	responses := make([]dto.AppreciationResponse, 0, len(infos))
+	for _, info := range infos {
+		responses = append(responses, mapRepoGetAppreciationInfoToDTOGetAppreciationInfo(info))
+	}
+
+	res := dto.ListAppreciationsResponse{
+		Appreciations: responses,
+		MetaData:      dto.Pagination{PageSize: filter.Limit},
+	}
+	if hasMore && len(infos) > 0 {
+		last := infos[len(infos)-1]
+		res.NextCursor = dto.AppreciationCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	return res, nil
+}
+
+func (apprSvc *service) DeleteAppreciation(ctx context.Context, apprId int32) (err error) {
 	logger.Debug(ctx, "appreciationService apprId: ", apprId)
-	return apprSvc.appreciationRepo.DeleteAppreciation(ctx, nil, apprId)
+
+	tx, err := apprSvc.appreciationRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService error in begin transaction: %v", err)
+		return err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := apprSvc.appreciationRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "appreciationService error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+		if err == nil && rvr == nil {
+			apprSvc.feedBroker.Publish(ctx, dto.FeedEvent{
+				Event: constants.AppreciationDeletedEvent,
+				Data:  dto.AppreciationDeletedEventData{ID: int64(apprId)},
+			})
+		}
+	}()
+
+	err = apprSvc.appreciationRepo.DeleteAppreciation(ctx, tx, apprId)
+	if err != nil {
+		return err
+	}
+
+	err = apprSvc.outboxRepo.EnqueueWebhookEvent(ctx, tx, constants.AppreciationDeletedEvent, dto.AppreciationDeletedEventData{ID: int64(apprId)})
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService: EnqueueWebhookEvent: err: %v", err)
+		return err
+	}
+	return nil
 }
 
 func (apprSvc *service) UpdateAppreciation(ctx context.Context, orgTimezone string) (bool, error) {
@@ -217,11 +367,143 @@ func (apprSvc *service) UpdateAppreciation(ctx context.Context, orgTimezone stri
 		return false, err
 	}
 	logger.Debug(ctx, "appreciationService UpdateAppreciation: ", userBadgeDetails)
-	apprSvc.sendEmailForBadgeAllocation(userBadgeDetails)
+	err = apprSvc.addBadgesToInbox(ctx, tx, userBadgeDetails)
+	if err != nil {
+		return false, err
+	}
+	err = apprSvc.postBadgesToIntegrations(ctx, tx, userBadgeDetails)
+	if err != nil {
+		return false, err
+	}
+	err = apprSvc.enqueueBadgeEmails(ctx, tx, userBadgeDetails)
+	if err != nil {
+		return false, err
+	}
+	for _, userBadgeDetail := range userBadgeDetails {
+		err = apprSvc.outboxRepo.EnqueueWebhookEvent(ctx, tx, constants.BadgeAwardedEvent, mapBadgeEventData(userBadgeDetail))
+		if err != nil {
+			logger.Errorf(ctx, "appreciationService: EnqueueWebhookEvent: err: %v", err)
+			return false, err
+		}
+	}
 	return true, nil
 }
 
-func sendAppreciationEmail(emailData repository.AppreciationResponse, senderEmail string, receiverEmail string) error {
+func (apprSvc *service) EditAppreciation(ctx context.Context, edit dto.EditAppreciation) (resp dto.AppreciationResponse, err error) {
+
+	logger.Debug(ctx, "appreciationService EditAppreciation: edit: ", edit)
+	userId, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "appreciationService err in parsing userid from token")
+		return dto.AppreciationResponse{}, apperrors.InternalServer
+	}
+	edit.EditedBy = userId
+
+	existing, err := apprSvc.appreciationRepo.GetAppreciationById(ctx, nil, int32(edit.ID))
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService GetAppreciationById: err: %v", err)
+		return dto.AppreciationResponse{}, err
+	}
+
+	if existing.SenderID != userId {
+		logger.Errorf(ctx, "appreciationService user %d cannot edit appreciation %d", userId, edit.ID)
+		return dto.AppreciationResponse{}, apperrors.AppreciationEditNotAllowed
+	}
+
+	edit.EditWindow = int64(config.AppreciationEditWindow())
+	editWindow := time.Duration(edit.EditWindow) * time.Minute
+	if time.Since(time.UnixMilli(existing.CreatedAt)) > editWindow {
+		logger.Errorf(ctx, "appreciationService edit window of appreciation %d has passed", edit.ID)
+		return dto.AppreciationResponse{}, apperrors.AppreciationEditWindowExpired
+	}
+
+	if existing.TotalRewards > 0 {
+		logger.Errorf(ctx, "appreciationService appreciation %d is already rewarded", edit.ID)
+		return dto.AppreciationResponse{}, apperrors.AppreciationNotEditable
+	}
+
+	if existing.CoreValueID == edit.CoreValueID && existing.Description == edit.Description {
+		return mapRepoGetAppreciationInfoToDTOGetAppreciationInfo(existing), nil
+	}
+
+	_, err = apprSvc.corevaluesRespo.GetCoreValue(ctx, edit.CoreValueID)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService err: %v", err)
+		return dto.AppreciationResponse{}, err
+	}
+
+	tx, err := apprSvc.appreciationRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService error in begin transaction: %v", err)
+		return dto.AppreciationResponse{}, err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := apprSvc.appreciationRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	// the update itself refuses appreciations whose window closed or that got rewarded or reported in the meantime
+	_, err = apprSvc.appreciationRepo.EditAppreciation(ctx, tx, edit)
+	if err == apperrors.AppreciationNotEditable && time.Since(time.UnixMilli(existing.CreatedAt)) > editWindow {
+		err = apperrors.AppreciationEditWindowExpired
+	}
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService EditAppreciation: err: %v", err)
+		return dto.AppreciationResponse{}, err
+	}
+
+	err = apprSvc.appreciationRepo.CreateAppreciationEdit(ctx, tx, repository.AppreciationEdit{
+		AppreciationID: edit.ID,
+		OldCoreValueID: existing.CoreValueID,
+		NewCoreValueID: edit.CoreValueID,
+		OldDescription: existing.Description,
+		NewDescription: edit.Description,
+		EditedBy:       userId,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService CreateAppreciationEdit: err: %v", err)
+		return dto.AppreciationResponse{}, err
+	}
+
+	updated, err := apprSvc.appreciationRepo.GetAppreciationById(ctx, tx, int32(edit.ID))
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService GetAppreciationById: err: %v", err)
+		return dto.AppreciationResponse{}, err
+	}
+
+	return mapRepoGetAppreciationInfoToDTOGetAppreciationInfo(updated), nil
+}
+
+func (apprSvc *service) ListAppreciationEdits(ctx context.Context, apprId int64) ([]dto.AppreciationEdit, error) {
+
+	logger.Debug(ctx, "appreciationService ListAppreciationEdits: apprId: ", apprId)
+	edits, err := apprSvc.appreciationRepo.ListAppreciationEdits(ctx, nil, apprId)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService ListAppreciationEdits: err: %v", err)
+		return nil, err
+	}
+
+	res := make([]dto.AppreciationEdit, 0, len(edits))
+	for _, edit := range edits {
+		res = append(res, mapAppreciationEditDBToDTO(edit))
+	}
+	return res, nil
+}
+
+func (apprSvc *service) enqueueAppreciationEmails(ctx context.Context, tx repository.Transaction, emailData repository.AppreciationResponse, senderInfo dto.GetUserByIdResp, receiverInfos []dto.GetUserByIdResp) error {
 
 	templateData := struct {
 		SenderName               string
@@ -231,74 +513,169 @@ func sendAppreciationEmail(emailData repository.AppreciationResponse, senderEmai
 		CoreValueBackgroundColor string
 	}{
 		SenderName:               fmt.Sprint(emailData.SenderFirstName, " ", emailData.SenderLastName),
-		ReceiverName:             fmt.Sprint(emailData.ReceiverFirstName, " ", emailData.ReceiverLastName),
+		ReceiverName:             receiversDisplayName(emailData),
 		Description:              emailData.Description,
 		CoreValueName:            emailData.CoreValueName,
 		CoreValueBackgroundColor: utils.GetCoreValueBackgroundColor(emailData.CoreValueName),
 	}
 
-	logger.Infof(context.Background(), "appreciation sender email: %v :receiver email: %v  ", senderEmail, receiverEmail)
-	mailReq := email.NewMail([]string{receiverEmail}, []string{}, []string{}, fmt.Sprintf("Kudos! You've Been Praised by %s %s! 🎉 ", emailData.SenderFirstName, emailData.SenderLastName))
-	err := mailReq.ParseTemplate("./internal/app/email/templates/receiverAppreciation.html", templateData)
+	logger.Infof(ctx, "appreciation sender email: %v :receivers: %v  ", senderInfo.Email, receiverInfos)
+	for _, receiverInfo := range receiverInfos {
+		err := apprSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxEmail, dto.OutboxEmail{
+			To:       []string{receiverInfo.Email},
+			Subject:  fmt.Sprintf("Kudos! You've Been Praised by %s %s! 🎉 ", emailData.SenderFirstName, emailData.SenderLastName),
+			Template: "./internal/app/email/templates/receiverAppreciation.html",
+			Data:     templateData,
+			UserID:   receiverInfo.UserId,
+			Event:    constants.AppreciationNotification,
+		})
+		if err != nil {
+			logger.Errorf(ctx, "appreciationService err: %v", err)
+			return err
+		}
+	}
+
+	err := apprSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxEmail, dto.OutboxEmail{
+		To:       []string{senderInfo.Email},
+		Subject:  fmt.Sprintf("Your appreciation to %s has been sent! 🙌", templateData.ReceiverName),
+		Template: "./internal/app/email/templates/senderAppreciation.html",
+		Data:     templateData,
+		UserID:   senderInfo.UserId,
+		Event:    constants.AppreciationNotification,
+	})
 	if err != nil {
-		logger.Errorf(context.Background(), "err in creating html file : %v", err)
+		logger.Errorf(ctx, "appreciationService err: %v", err)
 		return err
 	}
-	err = mailReq.Send()
+	return nil
+}
+
+func (apprSvc *service) enqueueAppreciationNotificationToReceiver(ctx context.Context, tx repository.Transaction, receiverId int64, appr repository.AppreciationResponse) error {
+
+	logger.Debug(ctx, "appreciationService apprResponse: ", appr)
+	msg := dto.OutboxPush{
+		UserID: receiverId,
+		Title:  "Appreciation incoming!",
+		Body:   fmt.Sprintf("You've been appreciated by %s %s! Well done and keep up the JOSH!", appr.SenderFirstName, appr.SenderLastName),
+		Event:  constants.AppreciationNotification,
+	}
+
+	logger.Infof(ctx, "appreciationService message: %v", msg)
+	err := apprSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxPush, msg)
 	if err != nil {
-		logger.Errorf(context.Background(), "appreciationService err: %v", err)
+		logger.Errorf(ctx, "appreciationService err: %v", err)
 		return err
 	}
-	mailReq = email.NewMail([]string{senderEmail}, []string{}, []string{}, fmt.Sprintf("Your appreciation to %s %s has been sent! 🙌", emailData.ReceiverFirstName, emailData.ReceiverLastName))
-	err = mailReq.ParseTemplate("./internal/app/email/templates/senderAppreciation.html", templateData)
+	return nil
+}
+
+func (apprSvc *service) enqueueAppreciationNotificationToAll(ctx context.Context, tx repository.Transaction, appr repository.AppreciationResponse) error {
+
+	logger.Debug(ctx, " appreciationService appr: ", appr)
+	msg := dto.OutboxPush{
+		Topic: notification.AllUsersTopic,
+		Title: "Appreciation",
+		Body:  fmt.Sprintf(" %s received an appreciation", receiversDisplayName(appr)),
+		Event: constants.BroadcastNotification,
+	}
+	logger.Infof(ctx, "appreciationService message: %v", msg)
+	err := apprSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxPush, msg)
 	if err != nil {
-		logger.Errorf(context.Background(), "appreciationService err: %v", err)
+		logger.Errorf(ctx, "appreciationService err: %v", err)
 		return err
 	}
-	err = mailReq.Send()
+	return nil
+}
+
+// postAppreciationToIntegrations queues the appreciation for the chat integrations that follow its core value
+func (apprSvc *service) postAppreciationToIntegrations(ctx context.Context, tx repository.Transaction, appr repository.AppreciationResponse) error {
+
+	chatIntegrations, err := apprSvc.integrationRepo.ListIntegrationsForCoreValue(ctx, tx, appr.CoreValueID)
 	if err != nil {
-		logger.Errorf(context.Background(), "appreciationService err: %v", err)
+		logger.Errorf(ctx, "appreciationService err: %v", err)
 		return err
 	}
-	return nil
+
+	return integrations.EnqueuePost(ctx, tx, apprSvc.outboxRepo, chatIntegrations, dto.FeedPost{
+		Event: constants.AppreciationNotification,
+		Title: "New appreciation 🎉",
+		Text:  fmt.Sprintf("%s %s appreciated %s", appr.SenderFirstName, appr.SenderLastName, receiversDisplayName(appr)),
+		Fields: []dto.FeedPostField{
+			{Title: "Core value", Value: appr.CoreValueName},
+			{Title: "Appreciation", Value: appr.Description},
+		},
+	})
 }
 
-func (apprSvc *service) sendAppreciationNotificationToReceiver(ctx context.Context, appr repository.AppreciationResponse) {
+func (apprSvc *service) postBadgesToIntegrations(ctx context.Context, tx repository.Transaction, userBadgeDetails []repository.UserBadgeDetails) error {
 
-	logger.Debug(ctx, "appreciationService apprResponse: ", appr)
-	notificationTokens, err := apprSvc.userRepo.ListDeviceTokensByUserID(ctx, appr.ReceiverID)
+	if len(userBadgeDetails) == 0 {
+		return nil
+	}
+
+	chatIntegrations, err := apprSvc.integrationRepo.ListIntegrations(ctx, tx)
 	if err != nil {
-		logger.Errorf(ctx, "appreciationService err in getting device tokens: %v", err)
-		return
+		logger.Errorf(ctx, "appreciationService err: %v", err)
+		return err
 	}
 
-	logger.Debug(ctx, "appreciationService notificationTokens: ", notificationTokens)
-	msg := notification.Message{
-		Title: "Appreciation incoming!",
-		Body:  fmt.Sprintf("You've been appreciated by %s %s! Well done and keep up the JOSH!", appr.SenderFirstName, appr.SenderLastName),
+	for _, userBadgeDetail := range userBadgeDetails {
+		err = integrations.EnqueuePost(ctx, tx, apprSvc.outboxRepo, chatIntegrations, dto.FeedPost{
+			Event: constants.BadgeNotification,
+			Title: "Badge unlocked 🏅",
+			Text:  fmt.Sprintf("%s %s earned the %s badge for %d points", userBadgeDetail.FirstName, userBadgeDetail.LastName, userBadgeDetail.BadgeName.String, userBadgeDetail.BadgePoints),
+		})
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	logger.Infof(ctx, "appreciationService message: %v", msg)
-	for _, notificationToken := range notificationTokens {
-		msg.SendNotificationToNotificationToken(notificationToken)
+func (apprSvc *service) addAppreciationToInbox(ctx context.Context, tx repository.Transaction, receivers []int64, appr repository.AppreciationResponse) error {
+
+	notifications := make([]dto.Notification, 0, len(receivers))
+	for _, receiver := range receivers {
+		notifications = append(notifications, dto.Notification{
+			UserID:         receiver,
+			Type:           constants.AppreciationNotification,
+			Title:          "Appreciation incoming!",
+			Body:           fmt.Sprintf("You've been appreciated by %s %s for %s", appr.SenderFirstName, appr.SenderLastName, appr.CoreValueName),
+			AppreciationID: appr.ID,
+		})
 	}
 
+	err := apprSvc.notificationRepo.CreateNotifications(ctx, tx, notifications)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService err: %v", err)
+		return err
+	}
+	return nil
 }
 
-func (apprSvc *service) sendAppreciationNotificationToAll(ctx context.Context, appr repository.AppreciationResponse) {
+func (apprSvc *service) addBadgesToInbox(ctx context.Context, tx repository.Transaction, userBadgeDetails []repository.UserBadgeDetails) error {
 
-	logger.Debug(ctx, " appreciationService appr: ", appr)
-	msg := notification.Message{
-		Title: "Appreciation",
-		Body:  fmt.Sprintf(" %s %s has received an appreciation", appr.ReceiverFirstName, appr.ReceiverLastName),
+	notifications := make([]dto.Notification, 0, len(userBadgeDetails))
+	for _, userBadgeDetail := range userBadgeDetails {
+		notifications = append(notifications, dto.Notification{
+			UserID: userBadgeDetail.ID,
+			Type:   constants.BadgeNotification,
+			Title:  "New badge unlocked!",
+			Body:   fmt.Sprintf("You've bagged the %s badge for crushing %d points", userBadgeDetail.BadgeName.String, userBadgeDetail.BadgePoints),
+		})
 	}
-	logger.Infof(ctx, "appreciationService message: %v", msg)
-	msg.SendNotificationToTopic("peerly")
+
+	err := apprSvc.notificationRepo.CreateNotifications(ctx, tx, notifications)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationService err: %v", err)
+		return err
+	}
+	return nil
 }
 
-func (apprSvc *service) sendEmailForBadgeAllocation(userBadgeDetails []repository.UserBadgeDetails) {
+func (apprSvc *service) enqueueBadgeEmails(ctx context.Context, tx repository.Transaction, userBadgeDetails []repository.UserBadgeDetails) error {
 
-	logger.Debug(context.Background(), "appreciationService user Badge Details: ", userBadgeDetails)
+	logger.Debug(ctx, "appreciationService user Badge Details: ", userBadgeDetails)
 	for _, userBadgeDetail := range userBadgeDetails {
 
 		// Determine the BadgeImageUrl based on the BadgeName
@@ -326,18 +703,19 @@ func (apprSvc *service) sendEmailForBadgeAllocation(userBadgeDetails []repositor
 			BadgeImageName:     badgeImageUrl,
 			AppreciationPoints: userBadgeDetail.BadgePoints,
 		}
-		logger.Info(context.Background(), "appreciationService badge data: ", templateData)
-		mailReq := email.NewMail([]string{userBadgeDetail.Email}, []string{}, []string{}, fmt.Sprintf("You've Bagged the %s for Crushing %d Points! 🏆", userBadgeDetail.BadgeName.String, userBadgeDetail.BadgePoints))
-		err := mailReq.ParseTemplate("./internal/app/email/templates/badge.html", templateData)
+		logger.Info(ctx, "appreciationService badge data: ", templateData)
+		err := apprSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxEmail, dto.OutboxEmail{
+			To:       []string{userBadgeDetail.Email},
+			Subject:  fmt.Sprintf("You've Bagged the %s for Crushing %d Points! 🏆", userBadgeDetail.BadgeName.String, userBadgeDetail.BadgePoints),
+			Template: "./internal/app/email/templates/badge.html",
+			Data:     templateData,
+			UserID:   userBadgeDetail.ID,
+			Event:    constants.BadgeNotification,
+		})
 		if err != nil {
-			logger.Errorf(context.Background(), "appreciationService err in creating html file : %v", err)
-			return
+			logger.Errorf(ctx, "appreciationService err: %v", err)
+			return err
 		}
-		err = mailReq.Send()
-		if err != nil {
-			logger.Errorf(context.Background(), "appreciationService err: %v", err)
-			return
-		}
-		logger.Infof(context.Background(), "appreciationService mail request: %v", mailReq)
 	}
+	return nil
 }
@@ -5,27 +5,43 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/joshsoftware/peerly-backend/internal/app/feed"
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
 	"github.com/joshsoftware/peerly-backend/internal/repository"
 	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	"github.com/lib/pq"
+	l "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+func init() {
+	log.Logger = l.New()
+}
+
 func TestCreateAppreciation(t *testing.T) {
 	appreciationRepo := mocks.NewAppreciationStorer(t)
 	corevalueRepo := mocks.NewCoreValueStorer(t)
 	userRepo := mocks.NewUserStorer(t)
-	service := NewService(appreciationRepo, corevalueRepo, userRepo)
+	outboxRepo := mocks.NewOutboxStorer(t)
+	notificationRepo := mocks.NewNotificationStorer(t)
+	integrationRepo := mocks.NewIntegrationStorer(t)
+	feedBroker := feed.NewBroker()
+	service := NewService(appreciationRepo, corevalueRepo, userRepo, outboxRepo, notificationRepo, integrationRepo, feedBroker)
+	feedEvents, unsubscribe := feedBroker.Subscribe()
+	defer unsubscribe()
 
 	tests := []struct {
 		name            string
 		context         context.Context
 		appreciation    dto.Appreciation
-		setup           func(apprMock *mocks.AppreciationStorer, coreValueRepo *mocks.CoreValueStorer)
+		setup           func(apprMock *mocks.AppreciationStorer, coreValueRepo *mocks.CoreValueStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer)
 		isErrorExpected bool
 		expectedResult  dto.Appreciation
 		expectedError   error
@@ -37,9 +53,10 @@ func TestCreateAppreciation(t *testing.T) {
 				CoreValueID: 1,
 				Receiver:    2,
 			},
-			setup: func(apprMock *mocks.AppreciationStorer, coreValueRepo *mocks.CoreValueStorer) {
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueRepo *mocks.CoreValueStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer) {
 				tx := &sql.Tx{}
 				apprMock.On("IsUserPresent", mock.Anything, nil, int64(2)).Return(true, nil).Once()
+				apprMock.On("IsUserActive", mock.Anything, nil, int64(2)).Return(true, nil).Once()
 				apprMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
 				coreValueRepo.On("GetCoreValue", mock.Anything, int64(1)).Return(repository.CoreValue{
 					ID:                1,
@@ -48,10 +65,27 @@ func TestCreateAppreciation(t *testing.T) {
 					ParentCoreValueID: sql.NullInt64{Int64: int64(0), Valid: true},
 				}, nil).Once()
 				apprMock.On("CreateAppreciation", mock.Anything, tx, mock.Anything).Return(repository.Appreciation{ID: 1}, nil).Once()
+				apprMock.On("GetAppreciationById", mock.Anything, tx, int32(1)).Return(repository.AppreciationResponse{ID: 1, CoreValueID: 1, SenderFirstName: "Jane", SenderLastName: "Doe", ReceiverFirstName: "John", ReceiverLastName: "Smith"}, nil).Once()
+				userMock.On("GetUserById", mock.Anything, mock.MatchedBy(func(req dto.GetUserByIdReq) bool { return req.UserId == 1 })).Return(dto.GetUserByIdResp{UserId: 1, Email: "jane@example.com"}, nil).Once()
+				userMock.On("GetUserById", mock.Anything, mock.MatchedBy(func(req dto.GetUserByIdReq) bool { return req.UserId == 2 })).Return(dto.GetUserByIdResp{UserId: 2, Email: "john@example.com"}, nil).Once()
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxEmail, mock.MatchedBy(func(mail dto.OutboxEmail) bool { return mail.To[0] == "john@example.com" })).Return(nil).Once()
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxEmail, mock.MatchedBy(func(mail dto.OutboxEmail) bool { return mail.To[0] == "jane@example.com" })).Return(nil).Once()
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxPush, mock.MatchedBy(func(push dto.OutboxPush) bool { return push.UserID == 2 })).Return(nil).Once()
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxPush, mock.MatchedBy(func(push dto.OutboxPush) bool { return push.Topic == notification.AllUsersTopic })).Return(nil).Once()
+				notificationRepo.On("CreateNotifications", mock.Anything, tx, mock.MatchedBy(func(notifications []dto.Notification) bool {
+					return len(notifications) == 1 && notifications[0].UserID == 2 && notifications[0].Type == constants.AppreciationNotification && notifications[0].AppreciationID == 1
+				})).Return(nil).Once()
+				integrationRepo.On("ListIntegrationsForCoreValue", mock.Anything, tx, int64(1)).Return([]repository.Integration{{ID: 4, Kind: constants.SlackIntegration}}, nil).Once()
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxWebhook, mock.MatchedBy(func(webhook dto.OutboxWebhook) bool {
+					return webhook.IntegrationID == 4 && webhook.Post.Text == "Jane Doe appreciated John Smith"
+				})).Return(nil).Once()
+				outboxMock.On("EnqueueWebhookEvent", mock.Anything, tx, constants.AppreciationCreatedEvent, mock.MatchedBy(func(data dto.AppreciationEventData) bool {
+					return data.ID == 1 && data.CoreValueID == 1
+				})).Return(nil).Once()
 				apprMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
 			},
 			isErrorExpected: false,
-			expectedResult:  dto.Appreciation{ID: 1},
+			expectedResult:  dto.Appreciation{ID: 1, Receivers: []int64{2}},
 			expectedError:   nil,
 		},
 		{
@@ -61,9 +95,10 @@ func TestCreateAppreciation(t *testing.T) {
 				CoreValueID: 1,
 				Receiver:    2,
 			},
-			setup: func(apprMock *mocks.AppreciationStorer, coreValueRepo *mocks.CoreValueStorer) {
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueRepo *mocks.CoreValueStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer) {
 				tx := &sql.Tx{}
 				apprMock.On("IsUserPresent", mock.Anything, nil, int64(2)).Return(true, nil).Once()
+				apprMock.On("IsUserActive", mock.Anything, nil, int64(2)).Return(true, nil).Once()
 				apprMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
 				coreValueRepo.On("GetCoreValue", mock.Anything, int64(1)).Return(repository.CoreValue{}, apperrors.InvalidCoreValueData).Once()
 				apprMock.On("HandleTransaction", mock.Anything, tx, false).Return(apperrors.InvalidCoreValueData).Once()
@@ -80,31 +115,54 @@ func TestCreateAppreciation(t *testing.T) {
 				Description: "Great teamwork!",
 				Receiver:    2,
 			},
-			setup: func(apprMock *mocks.AppreciationStorer, coreValueRepo *mocks.CoreValueStorer) {
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueRepo *mocks.CoreValueStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer) {
 				apprMock.On("IsUserPresent", mock.Anything, nil, int64(2)).Return(false, apperrors.UserNotFound).Once() // Ensure correct transaction context
 			},
 			isErrorExpected: true,
 			expectedResult:  dto.Appreciation{},
 			expectedError:   apperrors.UserNotFound,
 		},
+		{
+			name:    "receiver deactivated",
+			context: context.WithValue(context.Background(), constants.UserId, int64(1)),
+			appreciation: dto.Appreciation{
+				CoreValueID: 1,
+				Description: "Great teamwork!",
+				Receiver:    2,
+			},
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueRepo *mocks.CoreValueStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer) {
+				apprMock.On("IsUserPresent", mock.Anything, nil, int64(2)).Return(true, nil).Once()
+				apprMock.On("IsUserActive", mock.Anything, nil, int64(2)).Return(false, nil).Once()
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.Appreciation{},
+			expectedError:   apperrors.ReceiverDeactivated,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setup(appreciationRepo, corevalueRepo)
+			tt.setup(appreciationRepo, corevalueRepo, userRepo, outboxRepo)
 
 			result, err := service.CreateAppreciation(tt.context, tt.appreciation)
 
 			if tt.isErrorExpected {
 				assert.Error(t, err)
 				assert.Equal(t, tt.expectedError, err)
+				assert.Empty(t, feedEvents)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedResult, result)
+				feedEvent := <-feedEvents
+				assert.Equal(t, constants.AppreciationCreatedEvent, feedEvent.Event)
+				assert.Equal(t, result.ID, feedEvent.Data.(dto.AppreciationResponse).ID)
 			}
 
 			appreciationRepo.AssertExpectations(t)
 			corevalueRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+			notificationRepo.AssertExpectations(t)
+			integrationRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -113,7 +171,7 @@ func TestGetAppreciationById(t *testing.T) {
 	appreciationRepo := mocks.NewAppreciationStorer(t)
 	coreVaueRepo := mocks.NewCoreValueStorer(t)
 	userRepo := mocks.NewUserStorer(t)
-	service := NewService(appreciationRepo, coreVaueRepo, userRepo)
+	service := NewService(appreciationRepo, coreVaueRepo, userRepo, mocks.NewOutboxStorer(t), mocks.NewNotificationStorer(t), mocks.NewIntegrationStorer(t), feed.NewBroker())
 
 	tests := []struct {
 		name            string
@@ -144,6 +202,10 @@ func TestGetAppreciationById(t *testing.T) {
 					ReceiverLastName:    "Smith",
 					ReceiverImageURL:    sql.NullString{String: "image_url", Valid: true},
 					ReceiverDesignation: "Developer",
+					Receivers:           repository.AppreciationReceivers{{ID: 2, FirstName: "Jane", LastName: "Smith", RewardPoints: 100}},
+					GroupName:           "Platform squad",
+					ReactionCounts:      repository.ReactionCounts{"clap": 2},
+					MyReactions:         pq.StringArray{"clap"},
 					CreatedAt:           1620000000,
 					UpdatedAt:           1620000000,
 				}, nil).Once()
@@ -163,6 +225,10 @@ func TestGetAppreciationById(t *testing.T) {
 				ReceiverLastName:    "Smith",
 				ReceiverImageURL:    "image_url",
 				ReceiverDesignation: "Developer",
+				Receivers:           []dto.AppreciationReceiver{{ID: 2, FirstName: "Jane", LastName: "Smith", RewardPoints: 100}},
+				GroupName:           "Platform squad",
+				ReactionCounts:      map[string]int64{"clap": 2},
+				MyReactions:         []string{"clap"},
 				CreatedAt:           1620000000,
 				UpdatedAt:           1620000000,
 			},
@@ -215,14 +281,18 @@ func TestValidateAppreciation(t *testing.T) {
 	appreciationRepo := mocks.NewAppreciationStorer(t)
 	coreVaueRepo := mocks.NewCoreValueStorer(t)
 	userRepo := mocks.NewUserStorer(t)
-	service := NewService(appreciationRepo, coreVaueRepo, userRepo)
+	outboxRepo := mocks.NewOutboxStorer(t)
+	feedBroker := feed.NewBroker()
+	service := NewService(appreciationRepo, coreVaueRepo, userRepo, outboxRepo, mocks.NewNotificationStorer(t), mocks.NewIntegrationStorer(t), feedBroker)
+	feedEvents, unsubscribe := feedBroker.Subscribe()
+	defer unsubscribe()
 
 	tests := []struct {
 		name            string
 		context         context.Context
 		isValid         bool
 		apprId          int32
-		setup           func(apprMock *mocks.AppreciationStorer)
+		setup           func(apprMock *mocks.AppreciationStorer, outboxMock *mocks.OutboxStorer)
 		isErrorExpected bool
 		expectedResult  bool
 		expectedError   error
@@ -232,8 +302,12 @@ func TestValidateAppreciation(t *testing.T) {
 			context: context.Background(),
 			isValid: true,
 			apprId:  1,
-			setup: func(apprMock *mocks.AppreciationStorer) {
-				apprMock.On("DeleteAppreciation", mock.Anything, nil, int32(1)).Return(nil).Once()
+			setup: func(apprMock *mocks.AppreciationStorer, outboxMock *mocks.OutboxStorer) {
+				tx := &mocks.Transaction{}
+				apprMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				apprMock.On("DeleteAppreciation", mock.Anything, tx, int32(1)).Return(nil).Once()
+				outboxMock.On("EnqueueWebhookEvent", mock.Anything, tx, constants.AppreciationDeletedEvent, dto.AppreciationDeletedEventData{ID: 1}).Return(nil).Once()
+				apprMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
 			},
 			isErrorExpected: false,
 			expectedResult:  true,
@@ -244,8 +318,11 @@ func TestValidateAppreciation(t *testing.T) {
 			context: context.Background(),
 			isValid: false,
 			apprId:  1,
-			setup: func(apprMock *mocks.AppreciationStorer) {
-				apprMock.On("DeleteAppreciation", mock.Anything, nil, int32(1)).Return(apperrors.InternalServer).Once()
+			setup: func(apprMock *mocks.AppreciationStorer, outboxMock *mocks.OutboxStorer) {
+				tx := &mocks.Transaction{}
+				apprMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				apprMock.On("DeleteAppreciation", mock.Anything, tx, int32(1)).Return(apperrors.InternalServer).Once()
+				apprMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
 			},
 			isErrorExpected: true,
 			expectedResult:  false,
@@ -255,13 +332,223 @@ func TestValidateAppreciation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setup(appreciationRepo)
+			tt.setup(appreciationRepo, outboxRepo)
 
 			err := service.DeleteAppreciation(tt.context, tt.apprId)
 
 			if tt.isErrorExpected {
 				assert.Error(t, err)
 				assert.Equal(t, tt.expectedError, err)
+				assert.Empty(t, feedEvents)
+			} else {
+				assert.Equal(t, dto.FeedEvent{Event: constants.AppreciationDeletedEvent, Data: dto.AppreciationDeletedEventData{ID: int64(tt.apprId)}}, <-feedEvents)
+			}
+
+			appreciationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEditAppreciation(t *testing.T) {
+	appreciationRepo := mocks.NewAppreciationStorer(t)
+	coreValueRepo := mocks.NewCoreValueStorer(t)
+	userRepo := mocks.NewUserStorer(t)
+	service := NewService(appreciationRepo, coreValueRepo, userRepo, mocks.NewOutboxStorer(t), mocks.NewNotificationStorer(t), mocks.NewIntegrationStorer(t), feed.NewBroker())
+
+	now := time.Now().UnixMilli()
+	edit := dto.EditAppreciation{ID: 1, CoreValueID: 2, Description: "Updated description"}
+
+	tests := []struct {
+		name            string
+		context         context.Context
+		edit            dto.EditAppreciation
+		setup           func(apprMock *mocks.AppreciationStorer, coreValueMock *mocks.CoreValueStorer)
+		isErrorExpected bool
+		expectedResult  dto.AppreciationResponse
+		expectedError   error
+	}{
+		{
+			name:    "successful edit",
+			context: context.WithValue(context.Background(), constants.UserId, int64(1)),
+			edit:    edit,
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueMock *mocks.CoreValueStorer) {
+				tx := &sql.Tx{}
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 1, CoreValueID: 1, Description: "Old description", CreatedAt: now}, nil).Once()
+				coreValueMock.On("GetCoreValue", mock.Anything, int64(2)).Return(repository.CoreValue{ID: 2}, nil).Once()
+				apprMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				apprMock.On("EditAppreciation", mock.Anything, tx, dto.EditAppreciation{ID: 1, CoreValueID: 2, Description: "Updated description", EditedBy: 1, EditWindow: constants.DefaultAppreciationEditWindow}).Return(repository.Appreciation{ID: 1}, nil).Once()
+				apprMock.On("CreateAppreciationEdit", mock.Anything, tx, repository.AppreciationEdit{
+					AppreciationID: 1,
+					OldCoreValueID: 1,
+					NewCoreValueID: 2,
+					OldDescription: "Old description",
+					NewDescription: "Updated description",
+					EditedBy:       1,
+				}).Return(nil).Once()
+				apprMock.On("GetAppreciationById", mock.Anything, tx, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 1, CoreValueID: 2, Description: "Updated description", CreatedAt: now}, nil).Once()
+				apprMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+			expectedResult: dto.AppreciationResponse{
+				ID:             1,
+				SenderID:       1,
+				CoreValueID:    2,
+				Description:    "Updated description",
+				Receivers:      []dto.AppreciationReceiver{},
+				ReactionCounts: map[string]int64{},
+				MyReactions:    []string{},
+				CreatedAt:      now,
+			},
+		},
+		{
+			name:    "not the sender",
+			context: context.WithValue(context.Background(), constants.UserId, int64(3)),
+			edit:    edit,
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueMock *mocks.CoreValueStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 1, CreatedAt: now}, nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.AppreciationEditNotAllowed,
+		},
+		{
+			name:    "edit window passed",
+			context: context.WithValue(context.Background(), constants.UserId, int64(1)),
+			edit:    edit,
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueMock *mocks.CoreValueStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 1, CreatedAt: now - time.Hour.Milliseconds()}, nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.AppreciationEditWindowExpired,
+		},
+		{
+			name:    "already rewarded",
+			context: context.WithValue(context.Background(), constants.UserId, int64(1)),
+			edit:    edit,
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueMock *mocks.CoreValueStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 1, TotalRewards: 1, CreatedAt: now}, nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.AppreciationNotEditable,
+		},
+		{
+			name:    "reported before the update",
+			context: context.WithValue(context.Background(), constants.UserId, int64(1)),
+			edit:    edit,
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueMock *mocks.CoreValueStorer) {
+				tx := &sql.Tx{}
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 1, CoreValueID: 1, CreatedAt: now}, nil).Once()
+				coreValueMock.On("GetCoreValue", mock.Anything, int64(2)).Return(repository.CoreValue{ID: 2}, nil).Once()
+				apprMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				apprMock.On("EditAppreciation", mock.Anything, tx, mock.Anything).Return(repository.Appreciation{}, apperrors.AppreciationNotEditable).Once()
+				apprMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.AppreciationNotEditable,
+		},
+		{
+			name:    "edit window closed before the update",
+			context: context.WithValue(context.Background(), constants.UserId, int64(1)),
+			edit:    edit,
+			setup: func(apprMock *mocks.AppreciationStorer, coreValueMock *mocks.CoreValueStorer) {
+				tx := &sql.Tx{}
+				// still within the window when it was read, the window closes before the update runs
+				createdAt := time.Now().Add(-time.Duration(constants.DefaultAppreciationEditWindow)*time.Minute + 100*time.Millisecond).UnixMilli()
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 1, CoreValueID: 1, CreatedAt: createdAt}, nil).Once()
+				coreValueMock.On("GetCoreValue", mock.Anything, int64(2)).Return(repository.CoreValue{ID: 2}, nil).Once()
+				apprMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				apprMock.On("EditAppreciation", mock.Anything, tx, mock.Anything).Run(func(args mock.Arguments) {
+					time.Sleep(200 * time.Millisecond)
+				}).Return(repository.Appreciation{}, apperrors.AppreciationNotEditable).Once()
+				apprMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.AppreciationEditWindowExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(appreciationRepo, coreValueRepo)
+
+			result, err := service.EditAppreciation(tt.context, tt.edit)
+
+			if tt.isErrorExpected {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			appreciationRepo.AssertExpectations(t)
+			coreValueRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAppreciationsByCursor(t *testing.T) {
+	appreciationRepo := mocks.NewAppreciationStorer(t)
+	coreValueRepo := mocks.NewCoreValueStorer(t)
+	userRepo := mocks.NewUserStorer(t)
+	service := NewService(appreciationRepo, coreValueRepo, userRepo, mocks.NewOutboxStorer(t), mocks.NewNotificationStorer(t), mocks.NewIntegrationStorer(t), feed.NewBroker())
+
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+	cursor := dto.AppreciationCursor{CreatedAt: 1620000000, ID: 7}
+
+	tests := []struct {
+		name               string
+		filter             dto.AppreciationFilter
+		setup              func(apprMock *mocks.AppreciationStorer)
+		isErrorExpected    bool
+		expectedCount      int
+		expectedNextCursor string
+		expectedError      error
+	}{
+		{
+			name:   "first page with more to come",
+			filter: dto.AppreciationFilter{UseCursor: true, Limit: 2},
+			setup: func(apprMock *mocks.AppreciationStorer) {
+				apprMock.On("ListAppreciationsByCursor", mock.Anything, nil, dto.AppreciationFilter{UseCursor: true, Limit: 2}, (*dto.AppreciationCursor)(nil)).Return([]repository.AppreciationResponse{
+					{ID: 9, CreatedAt: 1630000000},
+					{ID: 7, CreatedAt: 1620000000},
+				}, true, nil).Once()
+			},
+			expectedCount:      2,
+			expectedNextCursor: cursor.Encode(),
+		},
+		{
+			name:   "last page",
+			filter: dto.AppreciationFilter{UseCursor: true, Cursor: cursor.Encode(), Limit: 2},
+			setup: func(apprMock *mocks.AppreciationStorer) {
+				apprMock.On("ListAppreciationsByCursor", mock.Anything, nil, mock.Anything, &cursor).Return([]repository.AppreciationResponse{
+					{ID: 3, CreatedAt: 1610000000},
+				}, false, nil).Once()
+			},
+			expectedCount:      1,
+			expectedNextCursor: "",
+		},
+		{
+			name:            "invalid cursor",
+			filter:          dto.AppreciationFilter{UseCursor: true, Cursor: "not a cursor", Limit: 2},
+			setup:           func(apprMock *mocks.AppreciationStorer) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(appreciationRepo)
+
+			result, err := service.ListAppreciations(ctx, tt.filter)
+
+			if tt.isErrorExpected {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Appreciations, tt.expectedCount)
+				assert.Equal(t, tt.expectedNextCursor, result.NextCursor)
 			}
 
 			appreciationRepo.AssertExpectations(t)
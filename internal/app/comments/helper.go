@@ -0,0 +1,54 @@
+package comments
+
+import (
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+func mapCommentDbToSvc(dbComment repository.Comment) dto.Comment {
+	return dto.Comment{
+		ID:             dbComment.ID,
+		AppreciationID: dbComment.AppreciationID,
+		Comment:        dbComment.Comment,
+		CommentedBy:    dbComment.CommentedBy,
+		CreatedAt:      dbComment.CreatedAt,
+		UpdatedAt:      dbComment.UpdatedAt,
+	}
+}
+
+func mapCommentResponseDbToSvc(dbComment repository.CommentResponse) dto.CommentResponse {
+	return dto.CommentResponse{
+		ID:                   dbComment.ID,
+		AppreciationID:       dbComment.AppreciationID,
+		Comment:              dbComment.Comment,
+		CommentedBy:          dbComment.CommentedBy,
+		CommenterEmployeeID:  dbComment.CommenterEmployeeID,
+		CommenterFirstName:   dbComment.CommenterFirstName,
+		CommenterLastName:    dbComment.CommenterLastName,
+		CommenterImageURL:    dbComment.CommenterImageURL.String,
+		CommenterDesignation: dbComment.CommenterDesignation,
+		CreatedAt:            dbComment.CreatedAt,
+		UpdatedAt:            dbComment.UpdatedAt,
+	}
+}
+
+func mapReportedCommentDbToSvc(dbResolution repository.ReportedComment) dto.ReportedComment {
+	return dto.ReportedComment{
+		ID:                   dbResolution.ID,
+		CommentID:            dbResolution.CommentID,
+		AppreciationID:       dbResolution.AppreciationID,
+		Comment:              dbResolution.Comment,
+		IsValid:              dbResolution.IsValid,
+		CommenterFirstName:   dbResolution.CommenterFirstName,
+		CommenterLastName:    dbResolution.CommenterLastName,
+		ReportingComment:     dbResolution.ReportingComment,
+		ReportedByFirstName:  dbResolution.ReportedByFirstName,
+		ReportedByLastName:   dbResolution.ReportedByLastName,
+		ReportedAt:           dbResolution.ReportedAt,
+		ModeratorComment:     dbResolution.ModeratorComment.String,
+		ModeratedByFirstName: dbResolution.ModeratedByFirstName.String,
+		ModeratedByLastName:  dbResolution.ModeratedByLastName.String,
+		ModeratedAt:          dbResolution.ModeratedAt.Int64,
+		Status:               dbResolution.Status,
+	}
+}
@@ -0,0 +1,415 @@
+package comments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/utils"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type service struct {
+	commentRepo      repository.CommentStorer
+	appreciationRepo repository.AppreciationStorer
+	userRepo         repository.UserStorer
+	outboxRepo       repository.OutboxStorer
+}
+
+// Service contains all comment related operations
+type Service interface {
+	CreateComment(ctx context.Context, comment dto.Comment) (dto.Comment, error)
+	ListComments(ctx context.Context, filter dto.CommentFilter) (dto.ListCommentsResponse, error)
+	UpdateComment(ctx context.Context, comment dto.Comment) (dto.Comment, error)
+	DeleteComment(ctx context.Context, commentId int64) error
+	ReportComment(ctx context.Context, reqData dto.ReportCommentReq) (dto.ReportCommentResp, error)
+	ListReportedComments(ctx context.Context) (dto.ListReportedCommentsResponse, error)
+	DeleteReportedComment(ctx context.Context, reqData dto.CommentModerationReq) error
+	ResolveReportedComment(ctx context.Context, reqData dto.CommentModerationReq) error
+}
+
+func NewService(commentRepo repository.CommentStorer, appreciationRepo repository.AppreciationStorer, userRepo repository.UserStorer, outboxRepo repository.OutboxStorer) Service {
+	return &service{
+		commentRepo:      commentRepo,
+		appreciationRepo: appreciationRepo,
+		userRepo:         userRepo,
+		outboxRepo:       outboxRepo,
+	}
+}
+
+func (cmtSvc *service) CreateComment(ctx context.Context, comment dto.Comment) (res dto.Comment, err error) {
+
+	logger.Debug(ctx, "commentService: CreateComment: ", comment)
+	userId, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "commentService: err in parsing userid from token")
+		return dto.Comment{}, apperrors.InternalServer
+	}
+	comment.CommentedBy = userId
+
+	appr, err := cmtSvc.appreciationRepo.GetAppreciationById(ctx, nil, int32(comment.AppreciationID))
+	if err != nil {
+		logger.Errorf(ctx, "commentService: GetAppreciationById: err: %v", err)
+		return dto.Comment{}, err
+	}
+
+	tx, err := cmtSvc.commentRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: error in BeginTx: %v", err)
+		return dto.Comment{}, err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := cmtSvc.commentRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	dbComment, err := cmtSvc.commentRepo.CreateComment(ctx, tx, comment)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: CreateComment: err: %v", err)
+		return dto.Comment{}, err
+	}
+
+	commenter, err := cmtSvc.userRepo.GetUserById(ctx, dto.GetUserByIdReq{
+		UserId:          userId,
+		QuaterTimeStamp: utils.GetQuarterStartUnixTime(),
+	})
+	if err != nil {
+		logger.Errorf(ctx, "commentService: err in getting commenter info: %v", err)
+		return dto.Comment{}, err
+	}
+
+	// the author and the receiver of the appreciation are notified, except the one who commented, the outbox worker
+	// delivers it once the comment is committed
+	for _, participantId := range append([]int64{appr.SenderID}, appr.ReceiverIDs()...) {
+		if participantId == userId {
+			continue
+		}
+		err = cmtSvc.enqueueCommentNotification(ctx, tx, participantId, commenter)
+		if err != nil {
+			return dto.Comment{}, err
+		}
+		err = cmtSvc.enqueueCommentEmail(ctx, tx, participantId, commenter, appr, dbComment.Comment)
+		if err != nil {
+			return dto.Comment{}, err
+		}
+	}
+
+	return mapCommentDbToSvc(dbComment), nil
+}
+
+func (cmtSvc *service) ListComments(ctx context.Context, filter dto.CommentFilter) (dto.ListCommentsResponse, error) {
+
+	logger.Debug(ctx, "commentService: ListComments: filter: ", filter)
+	_, err := cmtSvc.appreciationRepo.GetAppreciationById(ctx, nil, int32(filter.AppreciationID))
+	if err != nil {
+		logger.Errorf(ctx, "commentService: GetAppreciationById: err: %v", err)
+		return dto.ListCommentsResponse{}, err
+	}
+
+	dbComments, pagination, err := cmtSvc.commentRepo.ListComments(ctx, nil, filter)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: ListComments: err: %v", err)
+		return dto.ListCommentsResponse{}, err
+	}
+
+	comments := make([]dto.CommentResponse, 0, len(dbComments))
+	for _, dbComment := range dbComments {
+		comments = append(comments, mapCommentResponseDbToSvc(dbComment))
+	}
+
+	return dto.ListCommentsResponse{
+		Comments: comments,
+		MetaData: dto.Pagination{
+			CurrentPage:  pagination.CurrentPage,
+			TotalPage:    pagination.TotalPage,
+			PageSize:     pagination.RecordPerPage,
+			TotalRecords: pagination.TotalRecords,
+		},
+	}, nil
+}
+
+func (cmtSvc *service) UpdateComment(ctx context.Context, comment dto.Comment) (dto.Comment, error) {
+
+	logger.Debug(ctx, "commentService: UpdateComment: ", comment)
+	userId, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "commentService: err in parsing userid from token")
+		return dto.Comment{}, apperrors.InternalServer
+	}
+
+	existing, err := cmtSvc.commentRepo.GetCommentById(ctx, nil, comment.ID)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: GetCommentById: err: %v", err)
+		return dto.Comment{}, err
+	}
+
+	if existing.CommentedBy != userId {
+		logger.Errorf(ctx, "commentService: user %d cannot edit comment %d", userId, comment.ID)
+		return dto.Comment{}, apperrors.CommentActionNotAllowed
+	}
+
+	dbComment, err := cmtSvc.commentRepo.UpdateComment(ctx, nil, comment.ID, comment.Comment)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: UpdateComment: err: %v", err)
+		return dto.Comment{}, err
+	}
+
+	return mapCommentDbToSvc(dbComment), nil
+}
+
+// DeleteComment lets the author remove their own comment, admins can remove any comment
+func (cmtSvc *service) DeleteComment(ctx context.Context, commentId int64) error {
+
+	logger.Debug(ctx, "commentService: DeleteComment: ", commentId)
+	userId, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "commentService: err in parsing userid from token")
+		return apperrors.InternalServer
+	}
+	role, _ := ctx.Value(constants.Role).(int)
+
+	existing, err := cmtSvc.commentRepo.GetCommentById(ctx, nil, commentId)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: GetCommentById: err: %v", err)
+		return err
+	}
+
+	if existing.CommentedBy != userId && role != constants.Admin {
+		logger.Errorf(ctx, "commentService: user %d cannot delete comment %d", userId, commentId)
+		return apperrors.CommentActionNotAllowed
+	}
+
+	return cmtSvc.commentRepo.DeleteComment(ctx, nil, commentId)
+}
+
+func (cmtSvc *service) ReportComment(ctx context.Context, reqData dto.ReportCommentReq) (dto.ReportCommentResp, error) {
+
+	logger.Debug(ctx, "commentService: ReportComment: ", reqData)
+	userId, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "commentService: err in parsing userid from token")
+		return dto.ReportCommentResp{}, apperrors.InternalServer
+	}
+	reqData.ReportedBy = userId
+
+	comment, err := cmtSvc.commentRepo.GetCommentById(ctx, nil, reqData.CommentID)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: GetCommentById: err: %v", err)
+		return dto.ReportCommentResp{}, err
+	}
+
+	if comment.CommentedBy == userId {
+		return dto.ReportCommentResp{}, apperrors.CannotReportOwnComment
+	}
+
+	isDuplicate, err := cmtSvc.commentRepo.CheckDuplicateCommentReport(ctx, nil, reqData.CommentID, userId)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: CheckDuplicateCommentReport: err: %v", err)
+		return dto.ReportCommentResp{}, err
+	}
+	if isDuplicate {
+		return dto.ReportCommentResp{}, apperrors.RepeatedReport
+	}
+
+	resolution, err := cmtSvc.commentRepo.ReportComment(ctx, nil, reqData)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: ReportComment: err: %v", err)
+		return dto.ReportCommentResp{}, err
+	}
+
+	return dto.ReportCommentResp{
+		ID:               resolution.ID,
+		CommentID:        resolution.CommentID,
+		ReportingComment: resolution.ReportingComment,
+		ReportedBy:       resolution.ReportedBy,
+		ReportedAt:       resolution.ReportedAt,
+	}, nil
+}
+
+func (cmtSvc *service) ListReportedComments(ctx context.Context) (dto.ListReportedCommentsResponse, error) {
+
+	dbResolutions, err := cmtSvc.commentRepo.ListReportedComments(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: ListReportedComments: err: %v", err)
+		return dto.ListReportedCommentsResponse{}, err
+	}
+
+	comments := make([]dto.ReportedComment, 0, len(dbResolutions))
+	for _, dbResolution := range dbResolutions {
+		comments = append(comments, mapReportedCommentDbToSvc(dbResolution))
+	}
+
+	return dto.ListReportedCommentsResponse{Comments: comments}, nil
+}
+
+// DeleteReportedComment marks the report as deleted and hides the reported comment
+func (cmtSvc *service) DeleteReportedComment(ctx context.Context, reqData dto.CommentModerationReq) (err error) {
+	return cmtSvc.moderateComment(ctx, reqData, constants.DeletedStatus)
+}
+
+// ResolveReportedComment marks the report as resolved and keeps the comment as it is
+func (cmtSvc *service) ResolveReportedComment(ctx context.Context, reqData dto.CommentModerationReq) (err error) {
+	return cmtSvc.moderateComment(ctx, reqData, constants.ResolvedStatus)
+}
+
+func (cmtSvc *service) moderateComment(ctx context.Context, reqData dto.CommentModerationReq, status string) (err error) {
+
+	logger.Debug(ctx, "commentService: moderateComment: ", reqData, " status: ", status)
+	moderatorId, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "commentService: err in parsing userid from token")
+		return apperrors.InternalServer
+	}
+	reqData.ModeratedBy = moderatorId
+
+	resolution, err := cmtSvc.commentRepo.GetCommentResolution(ctx, nil, reqData.ResolutionID)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: GetCommentResolution: err: %v", err)
+		return err
+	}
+	reqData.CommentID = resolution.CommentID
+
+	tx, err := cmtSvc.commentRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: error in BeginTx: %v", err)
+		return err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := cmtSvc.commentRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	err = cmtSvc.commentRepo.UpdateCommentResolution(ctx, tx, reqData, status)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: UpdateCommentResolution: err: %v", err)
+		return err
+	}
+
+	if status == constants.DeletedStatus && resolution.IsValid {
+		err = cmtSvc.commentRepo.DeleteComment(ctx, tx, resolution.CommentID)
+		if err != nil {
+			logger.Errorf(ctx, "commentService: DeleteComment: err: %v", err)
+			return err
+		}
+	}
+
+	reporter, userErr := cmtSvc.userRepo.GetUserById(ctx, dto.GetUserByIdReq{
+		UserId:          resolution.ReportedBy,
+		QuaterTimeStamp: utils.GetQuarterStartUnixTime(),
+	})
+	if userErr != nil {
+		logger.Errorf(ctx, "commentService: err in getting reporter info: %v", userErr)
+		return
+	}
+
+	templateData := dto.ReportedCommentMail{
+		Status:           status,
+		CommentBy:        fmt.Sprint(resolution.CommenterFirstName, " ", resolution.CommenterLastName),
+		Comment:          resolution.Comment,
+		ReportingComment: resolution.ReportingComment,
+		ModeratorComment: reqData.ModeratorComment,
+		Icon:             config.PeerlyBaseUrl() + constants.CheckIconLogo,
+	}
+	err = enqueueReportedCommentEmail(ctx, tx, cmtSvc.outboxRepo, reporter, templateData)
+	return
+}
+
+func (cmtSvc *service) enqueueCommentNotification(ctx context.Context, tx repository.Transaction, userId int64, commenter dto.GetUserByIdResp) error {
+
+	msg := dto.OutboxPush{
+		UserID: userId,
+		Title:  "New comment",
+		Body:   fmt.Sprintf("%s %s commented on an appreciation you are part of", commenter.FirstName, commenter.LastName),
+		Event:  constants.CommentNotification,
+	}
+
+	logger.Debug(ctx, "commentService: msg: ", msg)
+	err := cmtSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxPush, msg)
+	if err != nil {
+		logger.Errorf(ctx, "commentService: EnqueueOutboxMessage: err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (cmtSvc *service) enqueueCommentEmail(ctx context.Context, tx repository.Transaction, userId int64, commenter dto.GetUserByIdResp, appr repository.AppreciationResponse, comment string) error {
+
+	receiver, err := cmtSvc.userRepo.GetUserById(ctx, dto.GetUserByIdReq{
+		UserId:          userId,
+		QuaterTimeStamp: utils.GetQuarterStartUnixTime(),
+	})
+	if err != nil {
+		logger.Errorf(ctx, "commentService: err in getting user info: %v", err)
+		return nil
+	}
+
+	templateData := dto.CommentMail{
+		CommenterName:    fmt.Sprint(commenter.FirstName, " ", commenter.LastName),
+		AppreciationBy:   fmt.Sprint(appr.SenderFirstName, " ", appr.SenderLastName),
+		AppreciationTo:   fmt.Sprint(appr.ReceiverFirstName, " ", appr.ReceiverLastName),
+		AppreciationDesc: appr.Description,
+		Comment:          comment,
+		Icon:             config.PeerlyBaseUrl() + constants.CheckIconLogo,
+	}
+
+	err = cmtSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxEmail, dto.OutboxEmail{
+		To:       []string{receiver.Email},
+		Subject:  fmt.Sprintf("%s commented on an appreciation 💬", templateData.CommenterName),
+		Template: "./internal/app/email/templates/comment.html",
+		Data:     templateData,
+		UserID:   receiver.UserId,
+		Event:    constants.CommentNotification,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "commentService: EnqueueOutboxMessage: err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func enqueueReportedCommentEmail(ctx context.Context, tx repository.Transaction, outboxRepo repository.OutboxStorer, reporter dto.GetUserByIdResp, templateData dto.ReportedCommentMail) error {
+
+	err := outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxEmail, dto.OutboxEmail{
+		To:       []string{reporter.Email},
+		Subject:  "Results of reported comment",
+		Template: "./internal/app/email/templates/reportedComment.html",
+		Data:     templateData,
+		UserID:   reporter.UserId,
+		Event:    constants.ReportOutcomeNotification,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "commentService: EnqueueOutboxMessage: err: %v", err)
+		return err
+	}
+	return nil
+}
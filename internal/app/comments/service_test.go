@@ -0,0 +1,422 @@
+package comments
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestListComments(t *testing.T) {
+	tests := []struct {
+		name            string
+		filter          dto.CommentFilter
+		setup           func(cmtMock *mocks.CommentStorer, apprMock *mocks.AppreciationStorer)
+		isErrorExpected bool
+		expectedResult  dto.ListCommentsResponse
+		expectedError   error
+	}{
+		{
+			name:   "Success",
+			filter: dto.CommentFilter{AppreciationID: 1, Page: 1, Limit: 10},
+			setup: func(cmtMock *mocks.CommentStorer, apprMock *mocks.AppreciationStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1}, nil)
+				cmtMock.On("ListComments", mock.Anything, nil, dto.CommentFilter{AppreciationID: 1, Page: 1, Limit: 10}).Return(
+					[]repository.CommentResponse{{ID: 1, AppreciationID: 1, Comment: "Well deserved", CommentedBy: 2}},
+					repository.Pagination{RecordPerPage: 10, CurrentPage: 1, TotalPage: 1, TotalRecords: 1}, nil)
+			},
+			isErrorExpected: false,
+			expectedResult: dto.ListCommentsResponse{
+				Comments: []dto.CommentResponse{{ID: 1, AppreciationID: 1, Comment: "Well deserved", CommentedBy: 2}},
+				MetaData: dto.Pagination{CurrentPage: 1, TotalPage: 1, PageSize: 10, TotalRecords: 1},
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "Appreciation not found",
+			filter: dto.CommentFilter{AppreciationID: 1, Page: 1, Limit: 10},
+			setup: func(cmtMock *mocks.CommentStorer, apprMock *mocks.AppreciationStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{}, apperrors.AppreciationNotFound)
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.ListCommentsResponse{},
+			expectedError:   apperrors.AppreciationNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmtMock := mocks.NewCommentStorer(t)
+			apprMock := mocks.NewAppreciationStorer(t)
+			userMock := mocks.NewUserStorer(t)
+			service := NewService(cmtMock, apprMock, userMock, mocks.NewOutboxStorer(t))
+
+			test.setup(cmtMock, apprMock)
+
+			result, err := service.ListComments(context.Background(), test.filter)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		comment         dto.Comment
+		setup           func(cmtMock *mocks.CommentStorer)
+		isErrorExpected bool
+		expectedResult  dto.Comment
+		expectedError   error
+	}{
+		{
+			name:    "Success",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(2)),
+			comment: dto.Comment{ID: 1, Comment: "Edited"},
+			setup: func(cmtMock *mocks.CommentStorer) {
+				cmtMock.On("GetCommentById", mock.Anything, nil, int64(1)).Return(repository.CommentResponse{ID: 1, CommentedBy: 2}, nil)
+				cmtMock.On("UpdateComment", mock.Anything, nil, int64(1), "Edited").Return(repository.Comment{ID: 1, AppreciationID: 1, Comment: "Edited", CommentedBy: 2}, nil)
+			},
+			isErrorExpected: false,
+			expectedResult:  dto.Comment{ID: 1, AppreciationID: 1, Comment: "Edited", CommentedBy: 2},
+			expectedError:   nil,
+		},
+		{
+			name:    "Error in parsing userid from token",
+			ctx:     context.Background(),
+			comment: dto.Comment{ID: 1, Comment: "Edited"},
+			setup: func(cmtMock *mocks.CommentStorer) {
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.Comment{},
+			expectedError:   apperrors.InternalServer,
+		},
+		{
+			name:    "Comment not found",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(2)),
+			comment: dto.Comment{ID: 1, Comment: "Edited"},
+			setup: func(cmtMock *mocks.CommentStorer) {
+				cmtMock.On("GetCommentById", mock.Anything, nil, int64(1)).Return(repository.CommentResponse{}, apperrors.CommentNotFound)
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.Comment{},
+			expectedError:   apperrors.CommentNotFound,
+		},
+		{
+			name:    "Only the author can edit",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(3)),
+			comment: dto.Comment{ID: 1, Comment: "Edited"},
+			setup: func(cmtMock *mocks.CommentStorer) {
+				cmtMock.On("GetCommentById", mock.Anything, nil, int64(1)).Return(repository.CommentResponse{ID: 1, CommentedBy: 2}, nil)
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.Comment{},
+			expectedError:   apperrors.CommentActionNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmtMock := mocks.NewCommentStorer(t)
+			apprMock := mocks.NewAppreciationStorer(t)
+			userMock := mocks.NewUserStorer(t)
+			service := NewService(cmtMock, apprMock, userMock, mocks.NewOutboxStorer(t))
+
+			test.setup(cmtMock)
+
+			result, err := service.UpdateComment(test.ctx, test.comment)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	userCtx := context.WithValue(context.Background(), constants.UserId, int64(3))
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		commentId       int64
+		setup           func(cmtMock *mocks.CommentStorer)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name:      "Author deletes own comment",
+			ctx:       context.WithValue(userCtx, constants.Role, constants.User),
+			commentId: 1,
+			setup: func(cmtMock *mocks.CommentStorer) {
+				cmtMock.On("GetCommentById", mock.Anything, nil, int64(1)).Return(repository.CommentResponse{ID: 1, CommentedBy: 3}, nil)
+				cmtMock.On("DeleteComment", mock.Anything, nil, int64(1)).Return(nil)
+			},
+			isErrorExpected: false,
+			expectedError:   nil,
+		},
+		{
+			name:      "Admin deletes any comment",
+			ctx:       context.WithValue(userCtx, constants.Role, constants.Admin),
+			commentId: 1,
+			setup: func(cmtMock *mocks.CommentStorer) {
+				cmtMock.On("GetCommentById", mock.Anything, nil, int64(1)).Return(repository.CommentResponse{ID: 1, CommentedBy: 2}, nil)
+				cmtMock.On("DeleteComment", mock.Anything, nil, int64(1)).Return(nil)
+			},
+			isErrorExpected: false,
+			expectedError:   nil,
+		},
+		{
+			name:      "User cannot delete others comment",
+			ctx:       context.WithValue(userCtx, constants.Role, constants.User),
+			commentId: 1,
+			setup: func(cmtMock *mocks.CommentStorer) {
+				cmtMock.On("GetCommentById", mock.Anything, nil, int64(1)).Return(repository.CommentResponse{ID: 1, CommentedBy: 2}, nil)
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.CommentActionNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmtMock := mocks.NewCommentStorer(t)
+			apprMock := mocks.NewAppreciationStorer(t)
+			userMock := mocks.NewUserStorer(t)
+			service := NewService(cmtMock, apprMock, userMock, mocks.NewOutboxStorer(t))
+
+			test.setup(cmtMock)
+
+			err := service.DeleteComment(test.ctx, test.commentId)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateComment(t *testing.T) {
+	// the comment email links to assets served by peerly
+	viper.Set(constants.PeerlyBaseUrl, "http://localhost:33001")
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		comment         dto.Comment
+		setup           func(cmtMock *mocks.CommentStorer, apprMock *mocks.AppreciationStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer)
+		isErrorExpected bool
+		expectedResult  dto.Comment
+		expectedError   error
+	}{
+		{
+			name:    "Success",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(2)),
+			comment: dto.Comment{AppreciationID: 1, Comment: "Well deserved"},
+			setup: func(cmtMock *mocks.CommentStorer, apprMock *mocks.AppreciationStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer) {
+				tx := &sql.Tx{}
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 3, ReceiverID: 4}, nil)
+				cmtMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				cmtMock.On("CreateComment", mock.Anything, tx, dto.Comment{AppreciationID: 1, Comment: "Well deserved", CommentedBy: 2}).Return(repository.Comment{ID: 1, AppreciationID: 1, Comment: "Well deserved", CommentedBy: 2}, nil)
+				userMock.On("GetUserById", mock.Anything, mock.MatchedBy(func(req dto.GetUserByIdReq) bool { return req.UserId == 2 })).Return(dto.GetUserByIdResp{UserId: 2, FirstName: "John"}, nil)
+				userMock.On("GetUserById", mock.Anything, mock.MatchedBy(func(req dto.GetUserByIdReq) bool { return req.UserId == 3 })).Return(dto.GetUserByIdResp{UserId: 3, Email: "jane@example.com"}, nil)
+				userMock.On("GetUserById", mock.Anything, mock.MatchedBy(func(req dto.GetUserByIdReq) bool { return req.UserId == 4 })).Return(dto.GetUserByIdResp{UserId: 4, Email: "jim@example.com"}, nil)
+				for _, participant := range []struct {
+					id    int64
+					email string
+				}{{3, "jane@example.com"}, {4, "jim@example.com"}} {
+					outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxPush, mock.MatchedBy(func(push dto.OutboxPush) bool {
+						return push.UserID == participant.id && push.Event == constants.CommentNotification
+					})).Return(nil).Once()
+					outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxEmail, mock.MatchedBy(func(mail dto.OutboxEmail) bool {
+						return mail.To[0] == participant.email && mail.UserID == participant.id && mail.Event == constants.CommentNotification
+					})).Return(nil).Once()
+				}
+				cmtMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+			expectedResult:  dto.Comment{ID: 1, AppreciationID: 1, Comment: "Well deserved", CommentedBy: 2},
+		},
+		{
+			name:    "The comment is rolled back when its notification can't be queued",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(3)),
+			comment: dto.Comment{AppreciationID: 1, Comment: "Well deserved"},
+			setup: func(cmtMock *mocks.CommentStorer, apprMock *mocks.AppreciationStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer) {
+				tx := &sql.Tx{}
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 3, ReceiverID: 4}, nil)
+				cmtMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				cmtMock.On("CreateComment", mock.Anything, tx, mock.Anything).Return(repository.Comment{ID: 1}, nil)
+				userMock.On("GetUserById", mock.Anything, mock.Anything).Return(dto.GetUserByIdResp{UserId: 3}, nil)
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxPush, mock.Anything).Return(apperrors.InternalServer).Once()
+				cmtMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+		{
+			name:    "The comment is rolled back when the commenter can't be loaded",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(2)),
+			comment: dto.Comment{AppreciationID: 1, Comment: "Well deserved"},
+			setup: func(cmtMock *mocks.CommentStorer, apprMock *mocks.AppreciationStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer) {
+				tx := &sql.Tx{}
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 3, ReceiverID: 4}, nil)
+				cmtMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				cmtMock.On("CreateComment", mock.Anything, tx, mock.Anything).Return(repository.Comment{ID: 1}, nil)
+				userMock.On("GetUserById", mock.Anything, mock.Anything).Return(dto.GetUserByIdResp{}, apperrors.InternalServer)
+				cmtMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+		{
+			name:    "Appreciation not found",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(2)),
+			comment: dto.Comment{AppreciationID: 1, Comment: "Well deserved"},
+			setup: func(cmtMock *mocks.CommentStorer, apprMock *mocks.AppreciationStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{}, apperrors.AppreciationNotFound)
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.AppreciationNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmtMock := mocks.NewCommentStorer(t)
+			apprMock := mocks.NewAppreciationStorer(t)
+			userMock := mocks.NewUserStorer(t)
+			outboxMock := mocks.NewOutboxStorer(t)
+			service := NewService(cmtMock, apprMock, userMock, outboxMock)
+
+			test.setup(cmtMock, apprMock, userMock, outboxMock)
+
+			result, err := service.CreateComment(test.ctx, test.comment)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestDeleteReportedComment(t *testing.T) {
+	viper.Set(constants.PeerlyBaseUrl, "http://localhost:33001")
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+	reqData := dto.CommentModerationReq{ResolutionID: 5, ModeratorComment: "Removed"}
+	tx := &sql.Tx{}
+	cmtMock := mocks.NewCommentStorer(t)
+	userMock := mocks.NewUserStorer(t)
+	outboxMock := mocks.NewOutboxStorer(t)
+	service := NewService(cmtMock, mocks.NewAppreciationStorer(t), userMock, outboxMock)
+
+	cmtMock.On("GetCommentResolution", mock.Anything, nil, int64(5)).Return(repository.ReportedComment{ID: 5, CommentID: 9, IsValid: true, ReportedBy: 3}, nil)
+	cmtMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	cmtMock.On("UpdateCommentResolution", mock.Anything, tx, dto.CommentModerationReq{ResolutionID: 5, CommentID: 9, ModeratorComment: "Removed", ModeratedBy: 1}, constants.DeletedStatus).Return(nil)
+	cmtMock.On("DeleteComment", mock.Anything, tx, int64(9)).Return(nil)
+	userMock.On("GetUserById", mock.Anything, mock.Anything).Return(dto.GetUserByIdResp{UserId: 3, Email: "jane@example.com"}, nil)
+	// the email is queued with the moderation and only goes out once it is committed
+	outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxEmail, mock.MatchedBy(func(mail dto.OutboxEmail) bool {
+		return mail.To[0] == "jane@example.com" && mail.UserID == 3 && mail.Event == constants.ReportOutcomeNotification
+	})).Return(nil).Once()
+	cmtMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+
+	err := service.DeleteReportedComment(ctx, reqData)
+
+	assert.NoError(t, err)
+}
+
+func TestReportComment(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		reqData         dto.ReportCommentReq
+		setup           func(cmtMock *mocks.CommentStorer)
+		isErrorExpected bool
+		expectedResult  dto.ReportCommentResp
+		expectedError   error
+	}{
+		{
+			name:    "Success",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(3)),
+			reqData: dto.ReportCommentReq{CommentID: 1, ReportingComment: "Inappropriate"},
+			setup: func(cmtMock *mocks.CommentStorer) {
+				cmtMock.On("GetCommentById", mock.Anything, nil, int64(1)).Return(repository.CommentResponse{ID: 1, CommentedBy: 2}, nil)
+				cmtMock.On("CheckDuplicateCommentReport", mock.Anything, nil, int64(1), int64(3)).Return(false, nil)
+				cmtMock.On("ReportComment", mock.Anything, nil, dto.ReportCommentReq{CommentID: 1, ReportingComment: "Inappropriate", ReportedBy: 3}).Return(repository.CommentResolution{ID: 1, CommentID: 1, ReportingComment: "Inappropriate", ReportedBy: 3, ReportedAt: 100}, nil)
+			},
+			isErrorExpected: false,
+			expectedResult:  dto.ReportCommentResp{ID: 1, CommentID: 1, ReportingComment: "Inappropriate", ReportedBy: 3, ReportedAt: 100},
+			expectedError:   nil,
+		},
+		{
+			name:    "Cannot report own comment",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(2)),
+			reqData: dto.ReportCommentReq{CommentID: 1, ReportingComment: "Inappropriate"},
+			setup: func(cmtMock *mocks.CommentStorer) {
+				cmtMock.On("GetCommentById", mock.Anything, nil, int64(1)).Return(repository.CommentResponse{ID: 1, CommentedBy: 2}, nil)
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.ReportCommentResp{},
+			expectedError:   apperrors.CannotReportOwnComment,
+		},
+		{
+			name:    "Repeated report",
+			ctx:     context.WithValue(context.Background(), constants.UserId, int64(3)),
+			reqData: dto.ReportCommentReq{CommentID: 1, ReportingComment: "Inappropriate"},
+			setup: func(cmtMock *mocks.CommentStorer) {
+				cmtMock.On("GetCommentById", mock.Anything, nil, int64(1)).Return(repository.CommentResponse{ID: 1, CommentedBy: 2}, nil)
+				cmtMock.On("CheckDuplicateCommentReport", mock.Anything, nil, int64(1), int64(3)).Return(true, nil)
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.ReportCommentResp{},
+			expectedError:   apperrors.RepeatedReport,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmtMock := mocks.NewCommentStorer(t)
+			apprMock := mocks.NewAppreciationStorer(t)
+			userMock := mocks.NewUserStorer(t)
+			service := NewService(cmtMock, apprMock, userMock, mocks.NewOutboxStorer(t))
+
+			test.setup(cmtMock)
+
+			result, err := service.ReportComment(test.ctx, test.reqData)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedResult, result)
+			}
+		})
+	}
+}
@@ -3,11 +3,15 @@ package cronjob
 import (
 	"github.com/go-co-op/gocron/v2"
 	"github.com/joshsoftware/peerly-backend/internal/app/appreciation"
+	"github.com/joshsoftware/peerly-backend/internal/app/digest"
+	"github.com/joshsoftware/peerly-backend/internal/app/integrations"
 	orgSvc "github.com/joshsoftware/peerly-backend/internal/app/organizationConfig"
+	"github.com/joshsoftware/peerly-backend/internal/app/outbox"
+	"github.com/joshsoftware/peerly-backend/internal/app/sessions"
 	"github.com/joshsoftware/peerly-backend/internal/app/users"
 )
 
-func InitializeJobs(appreciationSvc appreciation.Service, userSvc user.Service, organizationConfigService orgSvc.Service, scheduler gocron.Scheduler) error {
+func InitializeJobs(appreciationSvc appreciation.Service, userSvc user.Service, organizationConfigService orgSvc.Service, outboxSvc outbox.Service, digestSvc digest.Service, integrationSvc integrations.Service, sessionSvc sessions.Service, scheduler gocron.Scheduler) error {
 
 	DailyJob := NewDailyJob(appreciationSvc, organizationConfigService, scheduler)
 	err := DailyJob.Schedule()
@@ -19,5 +23,30 @@ func InitializeJobs(appreciationSvc appreciation.Service, userSvc user.Service,
 	if err != nil {
 		return err
 	}
+	OutboxJob := NewOutboxJob(outboxSvc, scheduler)
+	err = OutboxJob.Schedule()
+	if err != nil {
+		return err
+	}
+	WeeklyDigestJob := NewWeeklyDigestJob(digestSvc, organizationConfigService, scheduler)
+	err = WeeklyDigestJob.Schedule()
+	if err != nil {
+		return err
+	}
+	QuarterWinnersJob := NewQuarterWinnersJob(integrationSvc, organizationConfigService, scheduler)
+	err = QuarterWinnersJob.Schedule()
+	if err != nil {
+		return err
+	}
+	TokenCleanupJob := NewTokenCleanupJob(sessionSvc, scheduler)
+	err = TokenCleanupJob.Schedule()
+	if err != nil {
+		return err
+	}
+	IntranetSyncJob := NewIntranetSyncJob(userSvc, scheduler)
+	err = IntranetSyncJob.Schedule()
+	if err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,70 @@
+package cronjob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-co-op/gocron/v2"
+	user "github.com/joshsoftware/peerly-backend/internal/app/users"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+const INTRANET_SYNC_JOB = "INTRANET_SYNC_JOB"
+const INTRANET_SYNC_CRON_JOB_INTERVAL_DAYS = 1
+
+// runs before the working day so leavers lose access overnight
+var IntranetSyncJobTiming = JobTime{
+	hours:   2,
+	minutes: 0,
+	seconds: 0,
+}
+
+// IntranetSyncJob registers joiners, updates changed profiles and deactivates leavers
+type IntranetSyncJob struct {
+	CronJob
+	userService user.Service
+}
+
+func NewIntranetSyncJob(userService user.Service, scheduler gocron.Scheduler) Job {
+	return &IntranetSyncJob{
+		userService: userService,
+		CronJob: CronJob{
+			name:      INTRANET_SYNC_JOB,
+			scheduler: scheduler,
+		},
+	}
+}
+
+func (cron *IntranetSyncJob) Schedule() error {
+	var err error
+	cron.job, err = cron.scheduler.NewJob(
+		gocron.DailyJob(
+			INTRANET_SYNC_CRON_JOB_INTERVAL_DAYS,
+			gocron.NewAtTimes(
+				gocron.NewAtTime(
+					IntranetSyncJobTiming.hours,
+					IntranetSyncJobTiming.minutes,
+					IntranetSyncJobTiming.seconds,
+				),
+			),
+		),
+		gocron.NewTask(cron.Execute, cron.Task),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	cron.scheduler.Start()
+
+	if err != nil {
+		logger.Warn(context.TODO(), fmt.Sprintf("error occurred while scheduling %s, message %+v", cron.name, err.Error()))
+	}
+	return nil
+}
+
+func (cron *IntranetSyncJob) Task(ctx context.Context) {
+	report, err := cron.userService.SyncIntranetUsers(ctx)
+	if err != nil {
+		logger.Info(ctx, fmt.Sprintf("intranet sync cron job err: %v ", err))
+		return
+	}
+	logger.Infof(ctx, "intranet sync cron job report %d: %d created, %d updated, %d deactivated, %d failed", report.Id,
+		len(report.Changes.Created), len(report.Changes.Updated), len(report.Changes.Deactivated), len(report.Changes.Failed))
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/go-co-op/gocron/v2"
-	"github.com/joshsoftware/peerly-backend/internal/app/notification"
 	orgSvc "github.com/joshsoftware/peerly-backend/internal/app/organizationConfig"
 	user "github.com/joshsoftware/peerly-backend/internal/app/users"
 	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
@@ -71,9 +70,9 @@ func (cron *MonthlyJob) Task(ctx context.Context) {
 	var err error
 	for i := 0; i < 3; i++ {
 		logger.Info(ctx, "cron job attempt:", i+1)
+		// the refill push is queued in the outbox along with the new quota
 		err = cron.userService.UpdateRewardQuota(ctx)
 		if err == nil {
-			sendRewardQuotaRefilledNotificationToAll()
 			return
 		}
 		log.Info(ctx, fmt.Sprintf("cronjob fail error: %v", err.Error()))
@@ -82,16 +81,6 @@ func (cron *MonthlyJob) Task(ctx context.Context) {
 	return
 }
 
-func sendRewardQuotaRefilledNotificationToAll() {
-	msg := notification.Message{
-		Title: "Reward Quota is Refilled",
-		Body:  "Your reward quota is reset! You now recognize your colleagues.",
-	}
-
-	logger.Debug(context.Background(), "msg:", msg)
-	msg.SendNotificationToTopic("peerly")
-}
-
 func (cron *MonthlyJob) setMonthlyInterval() error {
 	orgInfo, err := cron.organizationConfigService.GetOrganizationConfig(context.Background())
 	if err != nil {
@@ -0,0 +1,56 @@
+package cronjob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/joshsoftware/peerly-backend/internal/app/outbox"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+const OUTBOX_JOB = "OUTBOX_JOB"
+const OUTBOX_CRON_JOB_INTERVAL = 30 * time.Second
+
+// OutboxJob delivers the emails and push notifications written to the outbox
+type OutboxJob struct {
+	CronJob
+	outboxService outbox.Service
+}
+
+func NewOutboxJob(outboxService outbox.Service, scheduler gocron.Scheduler) Job {
+	return &OutboxJob{
+		outboxService: outboxService,
+		CronJob: CronJob{
+			name:      OUTBOX_JOB,
+			scheduler: scheduler,
+		},
+	}
+}
+
+func (cron *OutboxJob) Schedule() error {
+	var err error
+	cron.job, err = cron.scheduler.NewJob(
+		gocron.DurationJob(OUTBOX_CRON_JOB_INTERVAL),
+		gocron.NewTask(cron.Execute, cron.Task),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	cron.scheduler.Start()
+
+	if err != nil {
+		logger.Warn(context.TODO(), fmt.Sprintf("error occurred while scheduling %s, message %+v", cron.name, err.Error()))
+	}
+	return nil
+}
+
+func (cron *OutboxJob) Task(ctx context.Context) {
+	sent, err := cron.outboxService.DeliverDue(ctx)
+	if err != nil {
+		logger.Info(ctx, fmt.Sprintf("outbox cron job err: %v ", err))
+		return
+	}
+	if sent > 0 {
+		logger.Infof(ctx, "outbox cron job delivered %d messages", sent)
+	}
+}
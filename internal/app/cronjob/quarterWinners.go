@@ -0,0 +1,68 @@
+package cronjob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/joshsoftware/peerly-backend/internal/app/integrations"
+	orgSvc "github.com/joshsoftware/peerly-backend/internal/app/organizationConfig"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+const QUARTER_WINNERS_JOB = "QUARTER_WINNERS_JOB"
+
+// quarters start in March, June, September and December, the winners are posted on the first morning of the next quarter
+const QUARTER_WINNERS_CRONTAB = "CRON_TZ=%s 0 9 1 3,6,9,12 *"
+
+// QuarterWinnersJob posts the winners of the quarter that just ended to the chat integrations
+type QuarterWinnersJob struct {
+	CronJob
+	integrationService        integrations.Service
+	organizationConfigService orgSvc.Service
+}
+
+func NewQuarterWinnersJob(integrationService integrations.Service, organizationConfigService orgSvc.Service, scheduler gocron.Scheduler) Job {
+	return &QuarterWinnersJob{
+		integrationService:        integrationService,
+		organizationConfigService: organizationConfigService,
+		CronJob: CronJob{
+			name:      QUARTER_WINNERS_JOB,
+			scheduler: scheduler,
+		},
+	}
+}
+
+func (cron *QuarterWinnersJob) Schedule() error {
+	orgInfo, err := cron.organizationConfigService.GetOrganizationConfig(context.Background())
+	if err != nil {
+		return err
+	}
+	_, err = time.LoadLocation(orgInfo.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid organization timezone %q: %w", orgInfo.Timezone, err)
+	}
+
+	cron.job, err = cron.scheduler.NewJob(
+		gocron.CronJob(fmt.Sprintf(QUARTER_WINNERS_CRONTAB, orgInfo.Timezone), false),
+		gocron.NewTask(cron.Execute, cron.Task),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	cron.scheduler.Start()
+	if err != nil {
+		logger.Warn(context.TODO(), fmt.Sprintf("error occurred while scheduling %s, message %+v", cron.name, err.Error()))
+		return err
+	}
+	return nil
+}
+
+func (cron *QuarterWinnersJob) Task(ctx context.Context) {
+	logger.Info(ctx, "in quarter winners job task")
+	queued, err := cron.integrationService.PostQuarterWinners(ctx)
+	if err != nil {
+		logger.Info(ctx, fmt.Sprintf("quarter winners cron job err: %v ", err))
+		return
+	}
+	logger.Infof(ctx, "quarter winners cron job queued %d posts", queued)
+}
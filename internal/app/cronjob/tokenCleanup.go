@@ -0,0 +1,54 @@
+package cronjob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/joshsoftware/peerly-backend/internal/app/sessions"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+const TOKEN_CLEANUP_JOB = "TOKEN_CLEANUP_JOB"
+const TOKEN_CLEANUP_CRON_JOB_INTERVAL = 6 * time.Hour
+
+// TokenCleanupJob purges the blacklisted tokens that have expired anyway
+type TokenCleanupJob struct {
+	CronJob
+	sessionService sessions.Service
+}
+
+func NewTokenCleanupJob(sessionService sessions.Service, scheduler gocron.Scheduler) Job {
+	return &TokenCleanupJob{
+		sessionService: sessionService,
+		CronJob: CronJob{
+			name:      TOKEN_CLEANUP_JOB,
+			scheduler: scheduler,
+		},
+	}
+}
+
+func (cron *TokenCleanupJob) Schedule() error {
+	var err error
+	cron.job, err = cron.scheduler.NewJob(
+		gocron.DurationJob(TOKEN_CLEANUP_CRON_JOB_INTERVAL),
+		gocron.NewTask(cron.Execute, cron.Task),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	cron.scheduler.Start()
+
+	if err != nil {
+		logger.Warn(context.TODO(), fmt.Sprintf("error occurred while scheduling %s, message %+v", cron.name, err.Error()))
+	}
+	return nil
+}
+
+func (cron *TokenCleanupJob) Task(ctx context.Context) {
+	deleted, err := cron.sessionService.PurgeExpiredTokens(ctx)
+	if err != nil {
+		logger.Info(ctx, fmt.Sprintf("token cleanup cron job err: %v ", err))
+		return
+	}
+	logger.Infof(ctx, "token cleanup cron job purged %d blacklisted tokens", deleted)
+}
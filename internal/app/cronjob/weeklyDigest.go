@@ -0,0 +1,96 @@
+package cronjob
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/joshsoftware/peerly-backend/internal/app/digest"
+	orgSvc "github.com/joshsoftware/peerly-backend/internal/app/organizationConfig"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+const WEEKLY_DIGEST_JOB = "WEEKLY_DIGEST_JOB"
+
+// WeeklyDigestJob sends the weekly digest at the configured weekday and time in the organization timezone
+type WeeklyDigestJob struct {
+	CronJob
+	digestService             digest.Service
+	organizationConfigService orgSvc.Service
+}
+
+func NewWeeklyDigestJob(digestService digest.Service, organizationConfigService orgSvc.Service, scheduler gocron.Scheduler) Job {
+	return &WeeklyDigestJob{
+		digestService:             digestService,
+		organizationConfigService: organizationConfigService,
+		CronJob: CronJob{
+			name:      WEEKLY_DIGEST_JOB,
+			scheduler: scheduler,
+		},
+	}
+}
+
+func (cron *WeeklyDigestJob) Schedule() error {
+	crontab, err := cron.crontab()
+	if err != nil {
+		return err
+	}
+	logger.Info(context.Background(), fmt.Sprintf("%s crontab = %s", cron.name, crontab))
+
+	cron.job, err = cron.scheduler.NewJob(
+		gocron.CronJob(crontab, false),
+		gocron.NewTask(cron.Execute, cron.Task),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	cron.scheduler.Start()
+	if err != nil {
+		logger.Warn(context.TODO(), fmt.Sprintf("error occurred while scheduling %s, message %+v", cron.name, err.Error()))
+		return err
+	}
+	return nil
+}
+
+func (cron *WeeklyDigestJob) Task(ctx context.Context) {
+	logger.Info(ctx, "in weekly digest job task")
+	queued, err := cron.digestService.SendWeeklyDigest(ctx)
+	if err != nil {
+		logger.Info(ctx, fmt.Sprintf("weekly digest cron job err: %v ", err))
+		return
+	}
+	logger.Infof(ctx, "weekly digest cron job queued %d digests", queued)
+}
+
+// crontab builds the schedule from DIGEST_WEEKDAY and DIGEST_TIME in the organization timezone
+func (cron *WeeklyDigestJob) crontab() (string, error) {
+	orgInfo, err := cron.organizationConfigService.GetOrganizationConfig(context.Background())
+	if err != nil {
+		return "", err
+	}
+	_, err = time.LoadLocation(orgInfo.Timezone)
+	if err != nil {
+		return "", fmt.Errorf("invalid organization timezone %q: %w", orgInfo.Timezone, err)
+	}
+
+	weekday, err := parseWeekday(config.DigestWeekday())
+	if err != nil {
+		return "", err
+	}
+	at, err := time.Parse("15:04", config.DigestTime())
+	if err != nil {
+		return "", fmt.Errorf("invalid digest time %q, expected HH:MM: %w", config.DigestTime(), err)
+	}
+
+	return fmt.Sprintf("CRON_TZ=%s %d %d * * %d", orgInfo.Timezone, at.Minute(), at.Hour(), weekday), nil
+}
+
+func parseWeekday(day string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), strings.TrimSpace(day)) {
+			return weekday, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid digest weekday %q", day)
+}
@@ -0,0 +1,74 @@
+package digest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+const digestDateFormat = "02 Jan 2006"
+
+// digestTemplateData is rendered by weeklyDigest.html
+type digestTemplateData struct {
+	EmployeeName          string
+	PeriodStart           string
+	PeriodEnd             string
+	AppreciationsReceived int64
+	AppreciationsGiven    int64
+	RewardsReceived       int64
+	RecentAppreciations   []digestAppreciation
+	QuarterPoints         int64
+	NextBadgeName         string
+	PointsToNextBadge     int64
+	TopUsers              []digestTopUser
+}
+
+type digestAppreciation struct {
+	SenderName    string
+	CoreValueName string
+	Description   string
+}
+
+type digestTopUser struct {
+	Rank               int
+	Name               string
+	AppreciationPoints int
+}
+
+func mapDigestTemplateData(subscriber repository.DigestSubscriber, activity repository.DigestActivity, topUsers []repository.Top10Users, periodStart time.Time, periodEnd time.Time) digestTemplateData {
+	data := digestTemplateData{
+		EmployeeName:          fmt.Sprint(subscriber.FirstName, " ", subscriber.LastName),
+		PeriodStart:           periodStart.Format(digestDateFormat),
+		PeriodEnd:             periodEnd.Format(digestDateFormat),
+		AppreciationsReceived: activity.AppreciationsReceived,
+		AppreciationsGiven:    activity.AppreciationsGiven,
+		RewardsReceived:       activity.RewardsReceived,
+		QuarterPoints:         activity.QuarterPoints,
+		RecentAppreciations:   make([]digestAppreciation, 0, len(activity.RecentAppreciations)),
+		TopUsers:              make([]digestTopUser, 0, len(topUsers)),
+	}
+
+	// no next badge once the user holds the highest one
+	if activity.NextBadgeName.Valid {
+		data.NextBadgeName = activity.NextBadgeName.String
+		data.PointsToNextBadge = activity.NextBadgePoints.Int64 - activity.QuarterPoints
+	}
+
+	for _, appreciation := range activity.RecentAppreciations {
+		data.RecentAppreciations = append(data.RecentAppreciations, digestAppreciation{
+			SenderName:    fmt.Sprint(appreciation.SenderFirstName, " ", appreciation.SenderLastName),
+			CoreValueName: appreciation.CoreValueName,
+			Description:   appreciation.Description,
+		})
+	}
+
+	for i, topUser := range topUsers {
+		data.TopUsers = append(data.TopUsers, digestTopUser{
+			Rank:               i + 1,
+			Name:               fmt.Sprint(topUser.FirstName, " ", topUser.LastName),
+			AppreciationPoints: topUser.AppreciationPoints,
+		})
+	}
+	return data
+}
@@ -0,0 +1,134 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	user "github.com/joshsoftware/peerly-backend/internal/app/users"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// period summarized by the weekly digest
+const digestPeriod = 7 * 24 * time.Hour
+
+type service struct {
+	digestRepo repository.DigestStorer
+	userRepo   repository.UserStorer
+	outboxRepo repository.OutboxStorer
+}
+
+// Service manages the weekly digest subscription of the logged in user and sends the digest
+type Service interface {
+	GetDigestSubscription(ctx context.Context) (dto.DigestSubscription, error)
+	UpdateDigestSubscription(ctx context.Context, req dto.DigestSubscription) (dto.DigestSubscription, error)
+	SendWeeklyDigest(ctx context.Context) (int, error)
+}
+
+func NewService(digestRepo repository.DigestStorer, userRepo repository.UserStorer, outboxRepo repository.OutboxStorer) Service {
+	return &service{
+		digestRepo: digestRepo,
+		userRepo:   userRepo,
+		outboxRepo: outboxRepo,
+	}
+}
+
+func (dgSvc *service) GetDigestSubscription(ctx context.Context) (dto.DigestSubscription, error) {
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return dto.DigestSubscription{}, err
+	}
+
+	subscribed, err := dgSvc.digestRepo.IsSubscribedToDigest(ctx, nil, userID)
+	if err != nil {
+		logger.Errorf(ctx, "digestService: IsSubscribedToDigest: err: %v", err)
+		return dto.DigestSubscription{}, err
+	}
+
+	return dto.DigestSubscription{Subscribed: subscribed}, nil
+}
+
+// UpdateDigestSubscription opts the user in or out of the weekly digest
+func (dgSvc *service) UpdateDigestSubscription(ctx context.Context, req dto.DigestSubscription) (dto.DigestSubscription, error) {
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return dto.DigestSubscription{}, err
+	}
+
+	logger.Debug(ctx, "digestService: UpdateDigestSubscription: userID: ", userID, " req: ", req)
+	if req.Subscribed {
+		err = dgSvc.digestRepo.SubscribeToDigest(ctx, nil, userID)
+	} else {
+		err = dgSvc.digestRepo.UnsubscribeFromDigest(ctx, nil, userID)
+	}
+	if err != nil {
+		logger.Errorf(ctx, "digestService: UpdateDigestSubscription: err: %v", err)
+		return dto.DigestSubscription{}, err
+	}
+
+	return dto.DigestSubscription{Subscribed: req.Subscribed}, nil
+}
+
+// SendWeeklyDigest queues the digest of the last week for every subscriber and returns how many were queued.
+// A subscriber whose digest can't be built is skipped so the others still get theirs.
+func (dgSvc *service) SendWeeklyDigest(ctx context.Context) (int, error) {
+
+	subscribers, err := dgSvc.digestRepo.ListDigestSubscribers(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "digestService: ListDigestSubscribers: err: %v", err)
+		return 0, err
+	}
+	if len(subscribers) == 0 {
+		return 0, nil
+	}
+
+	quarterStart := user.GetQuarterStartUnixTime()
+	topUsers, err := dgSvc.userRepo.GetTop10Users(ctx, quarterStart, dto.OrgFilter{})
+	if err != nil {
+		logger.Errorf(ctx, "digestService: GetTop10Users: err: %v", err)
+		return 0, apperrors.InternalServerError
+	}
+
+	periodEnd := time.Now()
+	periodStart := periodEnd.Add(-digestPeriod)
+
+	queued := 0
+	for _, subscriber := range subscribers {
+		activity, err := dgSvc.digestRepo.GetDigestActivity(ctx, nil, subscriber.ID, periodStart.UnixMilli(), quarterStart)
+		if err != nil {
+			logger.Errorf(ctx, "digestService: GetDigestActivity: user: %d, err: %v", subscriber.ID, err)
+			continue
+		}
+
+		err = dgSvc.outboxRepo.EnqueueOutboxMessage(ctx, nil, constants.OutboxEmail, dto.OutboxEmail{
+			To:       []string{subscriber.Email},
+			Subject:  fmt.Sprintf("Your Peerly week: %s - %s", periodStart.Format(digestDateFormat), periodEnd.Format(digestDateFormat)),
+			Template: "./internal/app/email/templates/weeklyDigest.html",
+			Data:     mapDigestTemplateData(subscriber, activity, topUsers, periodStart, periodEnd),
+		})
+		if err != nil {
+			logger.Errorf(ctx, "digestService: EnqueueOutboxMessage: user: %d, err: %v", subscriber.ID, err)
+			continue
+		}
+		queued++
+	}
+
+	logger.Infof(ctx, "digestService: weekly digest queued for %d of %d subscribers", queued, len(subscribers))
+	return queued, nil
+}
+
+func currentUserID(ctx context.Context) (int64, error) {
+	data := ctx.Value(constants.UserId)
+	userID, ok := data.(int64)
+	if !ok {
+		logger.Error(ctx, "digestService: err in parsing userid from token")
+		return 0, apperrors.InternalServer
+	}
+	return userID, nil
+}
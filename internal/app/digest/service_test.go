@@ -0,0 +1,119 @@
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestUpdateDigestSubscription(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             dto.DigestSubscription
+		setup           func(digestMock *mocks.DigestStorer)
+		isErrorExpected bool
+	}{
+		{
+			name: "User opts in to the digest",
+			req:  dto.DigestSubscription{Subscribed: true},
+			setup: func(digestMock *mocks.DigestStorer) {
+				digestMock.On("SubscribeToDigest", mock.Anything, nil, int64(7)).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "User opts out of the digest",
+			req:  dto.DigestSubscription{Subscribed: false},
+			setup: func(digestMock *mocks.DigestStorer) {
+				digestMock.On("UnsubscribeFromDigest", mock.Anything, nil, int64(7)).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Error in saving the subscription",
+			req:  dto.DigestSubscription{Subscribed: true},
+			setup: func(digestMock *mocks.DigestStorer) {
+				digestMock.On("SubscribeToDigest", mock.Anything, nil, int64(7)).Return(apperrors.InternalServer).Once()
+			},
+			isErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			digestMock := mocks.NewDigestStorer(t)
+			test.setup(digestMock)
+			service := NewService(digestMock, mocks.NewUserStorer(t), mocks.NewOutboxStorer(t))
+
+			ctx := context.WithValue(context.Background(), constants.UserId, int64(7))
+			result, err := service.UpdateDigestSubscription(ctx, test.req)
+
+			if test.isErrorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.req, result)
+		})
+	}
+}
+
+func TestSendWeeklyDigest(t *testing.T) {
+	digestMock := mocks.NewDigestStorer(t)
+	userMock := mocks.NewUserStorer(t)
+	outboxMock := mocks.NewOutboxStorer(t)
+
+	digestMock.On("ListDigestSubscribers", mock.Anything, nil).Return([]repository.DigestSubscriber{
+		{ID: 2, FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"},
+		{ID: 3, FirstName: "John", LastName: "Roe", Email: "john@example.com"},
+	}, nil).Once()
+	userMock.On("GetTop10Users", mock.Anything, mock.Anything, mock.Anything).Return([]repository.Top10Users{
+		{ID: 3, FirstName: "John", LastName: "Roe", AppreciationPoints: 120},
+	}, nil).Once()
+	digestMock.On("GetDigestActivity", mock.Anything, nil, int64(2), mock.Anything, mock.Anything).Return(repository.DigestActivity{
+		AppreciationsReceived: 2,
+		AppreciationsGiven:    1,
+		RewardsReceived:       1,
+		QuarterPoints:         80,
+		NextBadgeName:         sql.NullString{String: "Silver", Valid: true},
+		NextBadgePoints:       sql.NullInt64{Int64: 100, Valid: true},
+		RecentAppreciations: []repository.DigestAppreciation{
+			{SenderFirstName: "John", SenderLastName: "Roe", CoreValueName: "Teamwork", Description: "Thanks for the help"},
+		},
+	}, nil).Once()
+	digestMock.On("GetDigestActivity", mock.Anything, nil, int64(3), mock.Anything, mock.Anything).Return(repository.DigestActivity{}, apperrors.InternalServer).Once()
+
+	var queuedEmail dto.OutboxEmail
+	outboxMock.On("EnqueueOutboxMessage", mock.Anything, nil, constants.OutboxEmail, mock.Anything).Run(func(args mock.Arguments) {
+		queuedEmail = args.Get(3).(dto.OutboxEmail)
+	}).Return(nil).Once()
+
+	service := NewService(digestMock, userMock, outboxMock)
+	queued, err := service.SendWeeklyDigest(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, queued)
+	assert.Equal(t, []string{"jane@example.com"}, queuedEmail.To)
+	// the digest itself is opt-in, so it isn't tagged with an event the email preferences apply to
+	assert.Empty(t, queuedEmail.Event)
+
+	data := queuedEmail.Data.(digestTemplateData)
+	assert.Equal(t, "Jane Doe", data.EmployeeName)
+	assert.Equal(t, int64(20), data.PointsToNextBadge)
+	assert.Equal(t, []digestAppreciation{{SenderName: "John Roe", CoreValueName: "Teamwork", Description: "Thanks for the help"}}, data.RecentAppreciations)
+	assert.Equal(t, []digestTopUser{{Rank: 1, Name: "John Roe", AppreciationPoints: 120}}, data.TopUsers)
+}
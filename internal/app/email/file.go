@@ -0,0 +1,50 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// fileTransport writes every mail as an .eml file into a directory instead of sending it, for local development and tests
+type fileTransport struct {
+	dir  string
+	from string
+	seq  atomic.Uint64
+}
+
+func NewFileTransport(dir string, from string) (Transport, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("email outbox directory not configured")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileTransport{dir: dir, from: from}, nil
+}
+
+func (ft *fileTransport) Deliver(ctx context.Context, msg Message) error {
+
+	msg.From = ft.from
+	body, err := buildMIME(msg, true)
+	if err != nil {
+		logger.Errorf(ctx, "email: error in building mail: %v", err)
+		return err
+	}
+
+	// the sequence keeps mails written within the same nanosecond apart and in order
+	fileName := fmt.Sprintf("%d-%06d.eml", time.Now().UnixNano(), ft.seq.Add(1))
+	path := filepath.Join(ft.dir, fileName)
+	if err = os.WriteFile(path, body, 0o644); err != nil {
+		logger.Errorf(ctx, "email: error in writing %s: %v", path, err)
+		return err
+	}
+
+	logDelivery(ctx, FileTransport, msg)
+	return nil
+}
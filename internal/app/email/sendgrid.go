@@ -0,0 +1,60 @@
+package email
+
+import (
+	"context"
+	"fmt"
+
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+type sendGridTransport struct {
+	client *sendgrid.Client
+	from   string
+}
+
+func NewSendGridTransport(apiKey string, from string) (Transport, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("sendgrid API key not configured")
+	}
+	if from == "" {
+		return nil, fmt.Errorf("sender email not configured")
+	}
+	return &sendGridTransport{client: sendgrid.NewSendClient(apiKey), from: from}, nil
+}
+
+func (sg *sendGridTransport) Deliver(ctx context.Context, msg Message) error {
+
+	m := mail.NewV3Mail()
+	m.SetFrom(mail.NewEmail(msg.FromName, sg.from))
+	m.AddContent(mail.NewContent("text/html", msg.HTMLBody))
+
+	personalization := mail.NewPersonalization()
+	for _, email := range msg.To {
+		personalization.AddTos(mail.NewEmail("to", email))
+	}
+	for _, email := range msg.CC {
+		personalization.AddCCs(mail.NewEmail("cc", email))
+	}
+	for _, email := range msg.BCC {
+		personalization.AddBCCs(mail.NewEmail("bcc", email))
+	}
+	personalization.Subject = msg.Subject
+	m.AddPersonalizations(personalization)
+
+	response, err := sg.client.SendWithContext(ctx, m)
+	if err != nil {
+		logger.Errorf(ctx, "unable to send mail, err: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Response status code: %v", response.StatusCode)
+	logger.Debug(ctx, "Response body: ", response.Body)
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("sendgrid responded with status %d", response.StatusCode)
+	}
+
+	logDelivery(ctx, SendGridTransport, msg)
+	return nil
+}
@@ -6,10 +6,7 @@ import (
 	"fmt"
 	"html/template"
 
-	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
 	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
-	"github.com/sendgrid/sendgrid-go"
-	"github.com/sendgrid/sendgrid-go/helpers/mail"
 )
 
 // MailService represents the interface for our mail service.
@@ -20,7 +17,6 @@ type MailService interface {
 
 // Mail represents a email request
 type Mail struct {
-	from    string
 	to      []string
 	subject string
 	body    string
@@ -28,64 +24,27 @@ type Mail struct {
 	BCC     []string
 }
 
+// Send delivers the mail through the transport configured at startup
 func (ms *Mail) Send() error {
 
-	logger.Info(context.Background(), " Mail: ", ms)
-	senderEmail := config.ReadEnvString("SENDER_EMAIL")
-	if senderEmail == "" {
-		logger.Error(context.Background(), "SENDER_EMAIL environment variable is not set")
-		return fmt.Errorf("sender email not configured")
+	ctx := context.Background()
+	logger.Info(ctx, " Mail: ", ms.subject, " to: ", ms.to)
+	transport := currentTransport()
+	if transport == nil {
+		logger.Error(ctx, "email transport is not configured")
+		return fmt.Errorf("email transport not configured")
 	}
 
-	sendGridAPIKey := config.ReadEnvString("SENDGRID_API_KEY")
-	if sendGridAPIKey == "" {
-		logger.Error(context.Background(), "SENDGRID_API_KEY environment variable is not set")
-		return fmt.Errorf("sendgrid API key not configured")
-	}
-	logger.Info(context.Background(), "from_------------->, ", senderEmail)
-
-	fromEmail := mail.NewEmail("Peerly", senderEmail)
-	content := mail.NewContent("text/html", ms.body)
-
-	// create new *SGMailV3
-	m := mail.NewV3Mail()
-	m.SetFrom(fromEmail)
-	m.AddContent(content)
-
-	personalization := mail.NewPersonalization()
-
-	for _, email := range ms.to {
-		toEmail := mail.NewEmail("to", email)
-		personalization.AddTos(toEmail)
-	}
-
-	for _, email := range ms.CC {
-		ccEmail := mail.NewEmail("cc", email)
-		personalization.AddCCs(ccEmail)
-	}
-
-	for _, email := range ms.BCC {
-		bccEmail := mail.NewEmail("bcc", email)
-		personalization.AddBCCs(bccEmail)
-	}
-
-	personalization.Subject = ms.subject
-	m.AddPersonalizations(personalization)
-
-	client := sendgrid.NewSendClient(sendGridAPIKey)
-
-	response, err := client.Send(m)
-	if err != nil {
-		logger.Error(context.Background(), "unable to send mail", "error", err)
-		return err
-	}
-
-	logger.Info(context.Background(), "Email response: ")
-	logger.Infof(context.Background(), "Response status code: %v", response.StatusCode)
-	logger.Infof(context.Background(), "Response body: %v", response.Body)
-	logger.Infof(context.Background(), "Response headers: %v", response.Headers)
-	return nil
+	return transport.Deliver(ctx, Message{
+		FromName: senderName,
+		To:       ms.to,
+		CC:       ms.CC,
+		BCC:      ms.BCC,
+		Subject:  ms.subject,
+		HTMLBody: ms.body,
+	})
 }
+
 func (r *Mail) ParseTemplate(templateFileName string, data interface{}) error {
 	t, err := template.ParseFiles(templateFileName)
 	if err != nil {
@@ -105,7 +64,6 @@ func (r *Mail) ParseTemplate(templateFileName string, data interface{}) error {
 // NewMail returns a new mail request.
 func NewMail(to []string, cc []string, bcc []string, subject string) MailService {
 	return &Mail{
-		from:    config.ReadEnvString("SENDER_EMAIL"),
 		to:      to,
 		CC:      cc,
 		BCC:     bcc,
@@ -0,0 +1,104 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// StartTLS upgrades the connection before authenticating, the server must support it
+	StartTLS bool
+}
+
+type smtpTransport struct {
+	cfg  SMTPConfig
+	from string
+}
+
+func NewSMTPTransport(cfg SMTPConfig, from string) (Transport, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("smtp host not configured")
+	}
+	if from == "" {
+		return nil, fmt.Errorf("sender email not configured")
+	}
+	return &smtpTransport{cfg: cfg, from: from}, nil
+}
+
+func (st *smtpTransport) Deliver(ctx context.Context, msg Message) (err error) {
+
+	msg.From = st.from
+	body, err := buildMIME(msg, false)
+	if err != nil {
+		logger.Errorf(ctx, "email: error in building mail: %v", err)
+		return err
+	}
+
+	addr := net.JoinHostPort(st.cfg.Host, strconv.Itoa(st.cfg.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		logger.Errorf(ctx, "email: error in connecting to %s: %v", addr, err)
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, st.cfg.Host)
+	if err != nil {
+		conn.Close()
+		logger.Errorf(ctx, "email: error in smtp handshake: %v", err)
+		return err
+	}
+	defer client.Close()
+
+	if st.cfg.StartTLS {
+		if err = client.StartTLS(&tls.Config{ServerName: st.cfg.Host}); err != nil {
+			logger.Errorf(ctx, "email: error in starttls: %v", err)
+			return err
+		}
+	}
+
+	// PlainAuth refuses to send credentials over an unencrypted connection to a remote host
+	if st.cfg.Username != "" {
+		if err = client.Auth(smtp.PlainAuth("", st.cfg.Username, st.cfg.Password, st.cfg.Host)); err != nil {
+			logger.Errorf(ctx, "email: error in smtp auth: %v", err)
+			return err
+		}
+	}
+
+	if err = client.Mail(st.from); err != nil {
+		return err
+	}
+	for _, recipients := range [][]string{msg.To, msg.CC, msg.BCC} {
+		for _, recipient := range recipients {
+			if err = client.Rcpt(recipient); err != nil {
+				logger.Errorf(ctx, "email: recipient %s refused: %v", recipient, err)
+				return err
+			}
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(body); err != nil {
+		writer.Close()
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	logDelivery(ctx, SMTPTransport, msg)
+	return client.Quit()
+}
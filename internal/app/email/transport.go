@@ -0,0 +1,116 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// Email transports selectable through the EMAIL_TRANSPORT config
+const (
+	SendGridTransport = "sendgrid"
+	SMTPTransport     = "smtp"
+	FileTransport     = "file"
+)
+
+const senderName = "Peerly"
+
+// Transport delivers a rendered mail
+type Transport interface {
+	Deliver(ctx context.Context, msg Message) error
+}
+
+// Message is a rendered mail ready to be handed over to a transport
+type Message struct {
+	From     string
+	FromName string
+	To       []string
+	CC       []string
+	BCC      []string
+	Subject  string
+	HTMLBody string
+}
+
+var (
+	transportMu sync.RWMutex
+	transport   Transport
+)
+
+// SetTransport sets the transport every mail is delivered through, it is called once at startup
+func SetTransport(t Transport) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	transport = t
+}
+
+func currentTransport() Transport {
+	transportMu.RLock()
+	defer transportMu.RUnlock()
+	return transport
+}
+
+// NewTransport builds the transport of the given kind from the email config
+func NewTransport(kind string) (Transport, error) {
+	switch kind {
+	case SendGridTransport:
+		return NewSendGridTransport(config.ReadEnvString("SENDGRID_API_KEY"), config.SenderEmail())
+	case SMTPTransport:
+		return NewSMTPTransport(SMTPConfig{
+			Host:     config.SMTPHost(),
+			Port:     config.SMTPPort(),
+			Username: config.SMTPUsername(),
+			Password: config.SMTPPassword(),
+			StartTLS: config.SMTPStartTLS(),
+		}, config.SenderEmail())
+	case FileTransport:
+		return NewFileTransport(config.EmailOutboxDir(), config.SenderEmail())
+	}
+	return nil, fmt.Errorf("unknown email transport: %s", kind)
+}
+
+// buildMIME renders the message as an RFC 5322 mail with a quoted-printable html body.
+// Bcc recipients are only written when withBCC is set, transports delivering over the wire must leave them out.
+func buildMIME(msg Message, withBCC bool) ([]byte, error) {
+
+	var buf bytes.Buffer
+	writeHeader := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+
+	writeHeader("From", (&mail.Address{Name: msg.FromName, Address: msg.From}).String())
+	writeHeader("To", strings.Join(msg.To, ", "))
+	if len(msg.CC) > 0 {
+		writeHeader("Cc", strings.Join(msg.CC, ", "))
+	}
+	if withBCC && len(msg.BCC) > 0 {
+		writeHeader("Bcc", strings.Join(msg.BCC, ", "))
+	}
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	writeHeader("Date", time.Now().Format(time.RFC1123Z))
+	writeHeader("MIME-Version", "1.0")
+	writeHeader("Content-Type", `text/html; charset="utf-8"`)
+	writeHeader("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(msg.HTMLBody)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func logDelivery(ctx context.Context, kind string, msg Message) {
+	logger.Infof(ctx, "email: delivered through %s, subject: %s, to: %v", kind, msg.Subject, msg.To)
+}
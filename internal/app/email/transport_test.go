@@ -0,0 +1,137 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestFileTransport(t *testing.T) {
+	dir := t.TempDir()
+	transport, err := NewFileTransport(dir, "peerly@example.com")
+	require.NoError(t, err)
+	SetTransport(transport)
+	defer SetTransport(nil)
+
+	mail := &Mail{
+		to:      []string{"jane@example.com"},
+		CC:      []string{"support@example.com"},
+		BCC:     []string{"audit@example.com"},
+		subject: "Kudos! 🎉",
+		body:    "<p>Well done</p>",
+	}
+	require.NoError(t, mail.Send())
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0].Name(), ".eml"))
+
+	eml, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	content := string(eml)
+	assert.Contains(t, content, "From: \"Peerly\" <peerly@example.com>\r\n")
+	assert.Contains(t, content, "To: jane@example.com\r\n")
+	assert.Contains(t, content, "Cc: support@example.com\r\n")
+	assert.Contains(t, content, "Bcc: audit@example.com\r\n")
+	assert.Contains(t, content, "Subject: =?utf-8?q?Kudos!_=F0=9F=8E=89?=\r\n")
+	assert.Contains(t, content, "<p>Well done</p>")
+}
+
+func TestSendWithoutTransport(t *testing.T) {
+	SetTransport(nil)
+	mail := NewMail([]string{"jane@example.com"}, nil, nil, "Hello")
+	assert.Error(t, mail.Send())
+}
+
+func TestSMTPTransport(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go serveFakeSMTP(listener, received)
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	transport, err := NewSMTPTransport(SMTPConfig{Host: "127.0.0.1", Port: port}, "peerly@example.com")
+	require.NoError(t, err)
+
+	err = transport.Deliver(context.Background(), Message{
+		FromName: senderName,
+		To:       []string{"jane@example.com"},
+		BCC:      []string{"audit@example.com"},
+		Subject:  "Hello",
+		HTMLBody: "<p>Hi</p>",
+	})
+	require.NoError(t, err)
+
+	commands := <-received
+	assert.Contains(t, commands, "MAIL FROM:<peerly@example.com> BODY=8BITMIME")
+	assert.Contains(t, commands, "RCPT TO:<jane@example.com>")
+	assert.Contains(t, commands, "RCPT TO:<audit@example.com>")
+	assert.NotContains(t, commands, "Bcc: audit@example.com")
+	assert.Contains(t, commands, "Subject: Hello")
+}
+
+// serveFakeSMTP accepts a single session and reports every line the client sent
+func serveFakeSMTP(listener net.Listener, received chan<- []string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var lines []string
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost ESMTP")
+	inData := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+		line = strings.TrimRight(line, "\r\n")
+		lines = append(lines, line)
+
+		if inData {
+			if line == "." {
+				inData = false
+				reply("250 OK")
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "EHLO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case line == "DATA":
+			inData = true
+			reply("354 go ahead")
+		case line == "QUIT":
+			reply("221 bye")
+			received <- lines
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+	received <- lines
+}
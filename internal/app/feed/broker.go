@@ -0,0 +1,65 @@
+package feed
+
+import (
+	"context"
+	"sync"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// buffered events per subscriber before it is considered too slow to keep up
+const subscriberBuffer = 32
+
+// Broker fans the live feed events out to every connected client.
+// The in-process broker only reaches the clients of its own server, a broker backed by
+// PostgreSQL LISTEN/NOTIFY can implement the same interface once several instances run.
+type Broker interface {
+	Publish(ctx context.Context, event dto.FeedEvent)
+	Subscribe() (events <-chan dto.FeedEvent, unsubscribe func())
+}
+
+type broker struct {
+	mu          sync.RWMutex
+	subscribers map[chan dto.FeedEvent]struct{}
+}
+
+func NewBroker() Broker {
+	return &broker{
+		subscribers: make(map[chan dto.FeedEvent]struct{}),
+	}
+}
+
+// Publish never blocks the caller, a subscriber with a full buffer misses the event
+func (b *broker) Publish(ctx context.Context, event dto.FeedEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			logger.Warn(ctx, "feedBroker: subscriber is too slow, dropped event ", event.Event)
+		}
+	}
+}
+
+// Subscribe returns the events published from now on, unsubscribe closes the channel and is safe to call more than once
+func (b *broker) Subscribe() (<-chan dto.FeedEvent, func()) {
+	subscriber := make(chan dto.FeedEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[subscriber] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, subscriber)
+			close(subscriber)
+			b.mu.Unlock()
+		})
+	}
+	return subscriber, unsubscribe
+}
@@ -0,0 +1,56 @@
+package feed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestPublish(t *testing.T) {
+	broker := NewBroker()
+	first, unsubscribeFirst := broker.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := broker.Subscribe()
+	defer unsubscribeSecond()
+
+	event := dto.FeedEvent{Event: constants.AppreciationDeletedEvent, Data: dto.AppreciationDeletedEventData{ID: 1}}
+	broker.Publish(context.Background(), event)
+
+	assert.Equal(t, event, <-first)
+	assert.Equal(t, event, <-second)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe()
+
+	unsubscribe()
+	unsubscribe()
+	broker.Publish(context.Background(), dto.FeedEvent{Event: constants.AppreciationDeletedEvent})
+
+	_, open := <-events
+	assert.False(t, open)
+}
+
+func TestPublishDoesNotBlockOnSlowSubscriber(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		broker.Publish(context.Background(), dto.FeedEvent{Event: constants.RewardGivenEvent, Data: dto.FeedRewardEventData{AppreciationID: 1, TotalRewards: int32(i + 1)}})
+	}
+
+	assert.Len(t, events, subscriberBuffer)
+	first := <-events
+	assert.Equal(t, int32(1), first.Data.(dto.FeedRewardEventData).TotalRewards)
+}
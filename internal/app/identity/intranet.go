@@ -0,0 +1,53 @@
+package identity
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// intranetProvider logs users in with the token the Josh intranet issued them
+type intranetProvider struct {
+	client *intranet.Client
+}
+
+func NewIntranetProvider(client *intranet.Client) IdentityProvider {
+	return &intranetProvider{client: client}
+}
+
+func (ip *intranetProvider) ValidateCredential(ctx context.Context, credential string) (Identity, error) {
+
+	validateResp, err := ip.client.ValidatePeerly(ctx, credential)
+	if err != nil {
+		logger.Errorf(ctx, "intranetProvider: %v", err)
+		return Identity{}, intranet.AppError(err)
+	}
+
+	return Identity{
+		Subject: strconv.FormatInt(validateResp.Data.UserId, 10),
+		Token:   validateResp.Data.JwtToken,
+	}, nil
+}
+
+func (ip *intranetProvider) GetProfile(ctx context.Context, identity Identity) (dto.IntranetUserData, error) {
+
+	userID, err := strconv.ParseInt(identity.Subject, 10, 64)
+	if err != nil {
+		logger.Errorf(ctx, "intranetProvider: invalid intranet user id %s", identity.Subject)
+		return dto.IntranetUserData{}, apperrors.InvalidAuthToken
+	}
+
+	user, err := ip.client.GetUser(ctx, dto.GetIntranetUserDataReq{
+		Token:  identity.Token,
+		UserId: userID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "intranetProvider: %v", err)
+		return dto.IntranetUserData{}, intranet.AppError(err)
+	}
+	return user, nil
+}
@@ -0,0 +1,293 @@
+package identity
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	// the signing keys of the issuer are reloaded at most this often, an unknown key id doesn't hammer the issuer
+	oidcKeysMinReload = time.Minute
+	oidcTimeout       = 10 * time.Second
+)
+
+// ClaimMapping names the ID token claims a profile is read from, a dotted name reads a nested claim
+type ClaimMapping struct {
+	Email         string
+	FirstName     string
+	LastName      string
+	ProfileImgUrl string
+	EmployeeId    string
+	Grade         string
+	Designation   string
+}
+
+// DefaultClaimMapping reads the standard OIDC claims, the employee details have no standard claim
+func DefaultClaimMapping() ClaimMapping {
+	return ClaimMapping{
+		Email:         "email",
+		FirstName:     "given_name",
+		LastName:      "family_name",
+		ProfileImgUrl: "picture",
+		EmployeeId:    "employee_id",
+		Grade:         "grade",
+		Designation:   "designation",
+	}
+}
+
+// ParseClaimMapping overrides the default mapping with comma separated field=claim pairs,
+// e.g. "employee_id=emp_no,grade=org.grade"
+func ParseClaimMapping(mapping string) (ClaimMapping, error) {
+
+	claims := DefaultClaimMapping()
+	fields := map[string]*string{
+		"email":             &claims.Email,
+		"first_name":        &claims.FirstName,
+		"last_name":         &claims.LastName,
+		"profile_image_url": &claims.ProfileImgUrl,
+		"employee_id":       &claims.EmployeeId,
+		"grade":             &claims.Grade,
+		"designation":       &claims.Designation,
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		field, claim, ok := strings.Cut(pair, "=")
+		field, claim = strings.TrimSpace(field), strings.TrimSpace(claim)
+		target, known := fields[field]
+		if !ok || !known || claim == "" {
+			return ClaimMapping{}, fmt.Errorf("invalid oidc claim mapping: %s", pair)
+		}
+		*target = claim
+	}
+	return claims, nil
+}
+
+// oidcProvider logs users in with an ID token of an OpenID Connect issuer, the token is verified against the signing
+// keys the issuer publishes and the profile is read from its claims
+type oidcProvider struct {
+	issuer   string
+	clientID string
+	claims   ClaimMapping
+	client   *http.Client
+
+	mu           sync.Mutex
+	keys         map[string]*rsa.PublicKey
+	keysLoadedAt time.Time
+}
+
+func NewOIDCProvider(issuer string, clientID string, claims ClaimMapping) (IdentityProvider, error) {
+
+	if issuer == "" || clientID == "" {
+		return nil, fmt.Errorf("oidc identity provider needs an issuer and a client id")
+	}
+
+	return &oidcProvider{
+		issuer:   strings.TrimSuffix(issuer, "/"),
+		clientID: clientID,
+		claims:   claims,
+		client:   &http.Client{Timeout: oidcTimeout},
+	}, nil
+}
+
+func (op *oidcProvider) ValidateCredential(ctx context.Context, credential string) (Identity, error) {
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(credential, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		keyID, _ := token.Header["kid"].(string)
+		return op.signingKey(ctx, keyID)
+	})
+	if err != nil {
+		logger.Errorf(ctx, "oidcProvider: invalid id token, err: %v", err)
+		if validationErr, ok := err.(*jwt.ValidationError); ok && validationErr.Inner == apperrors.InternalServerError {
+			return Identity{}, apperrors.InternalServerError
+		}
+		return Identity{}, apperrors.InvalidAuthToken
+	}
+
+	// the expiry is optional for jwt-go but not for an ID token
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		logger.Errorf(ctx, "oidcProvider: id token without an expiry")
+		return Identity{}, apperrors.InvalidAuthToken
+	}
+	if !claims.VerifyIssuer(op.issuer, true) || !hasAudience(claims["aud"], op.clientID) {
+		logger.Errorf(ctx, "oidcProvider: id token of issuer %v for audience %v", claims["iss"], claims["aud"])
+		return Identity{}, apperrors.InvalidAuthToken
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		logger.Errorf(ctx, "oidcProvider: id token without a subject")
+		return Identity{}, apperrors.InvalidAuthToken
+	}
+
+	return Identity{Subject: subject, Token: credential, Claims: claims}, nil
+}
+
+func (op *oidcProvider) GetProfile(ctx context.Context, identity Identity) (dto.IntranetUserData, error) {
+	return dto.IntranetUserData{
+		Email: claimString(identity.Claims, op.claims.Email),
+		PublicProfile: dto.PublicProfile{
+			ProfileImgUrl: claimString(identity.Claims, op.claims.ProfileImgUrl),
+			FirstName:     claimString(identity.Claims, op.claims.FirstName),
+			LastName:      claimString(identity.Claims, op.claims.LastName),
+		},
+		EmpolyeeDetail: dto.EmployeeDetail{
+			EmployeeId:  claimString(identity.Claims, op.claims.EmployeeId),
+			Designation: dto.Designation{Name: claimString(identity.Claims, op.claims.Designation)},
+			Grade:       claimString(identity.Claims, op.claims.Grade),
+		},
+	}, nil
+}
+
+// signingKey returns the key the issuer signs with under the given id, the keys are reloaded when the issuer rotated them
+func (op *oidcProvider) signingKey(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	key, ok := op.keys[keyID]
+	if ok {
+		return key, nil
+	}
+	if time.Since(op.keysLoadedAt) < oidcKeysMinReload {
+		return nil, fmt.Errorf("unknown signing key %s", keyID)
+	}
+
+	keys, err := op.loadKeys(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "oidcProvider: error in loading the signing keys of %s, err: %v", op.issuer, err)
+		return nil, apperrors.InternalServerError
+	}
+	op.keys = keys
+	op.keysLoadedAt = time.Now()
+
+	key, ok = op.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %s", keyID)
+	}
+	return key, nil
+}
+
+func (op *oidcProvider) loadKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+
+	var discovery struct {
+		JwksURI string `json:"jwks_uri"`
+	}
+	err := op.getJSON(ctx, op.issuer+oidcDiscoveryPath, &discovery)
+	if err != nil {
+		return nil, err
+	}
+	if discovery.JwksURI == "" {
+		return nil, fmt.Errorf("discovery document without a jwks_uri")
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	err = op.getJSON(ctx, discovery.JwksURI, &jwks)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of key %s: %w", jwk.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of key %s: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+func (op *oidcProvider) getJSON(ctx context.Context, url string, v interface{}) error {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := op.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// the aud claim is either a single client id or a list of them
+func hasAudience(aud interface{}, clientID string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func claimString(claims map[string]interface{}, name string) string {
+
+	var value interface{} = claims
+	for _, part := range strings.Split(name, ".") {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = nested[part]
+	}
+
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		// json numbers, employee ids are often numeric
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return ""
+}
@@ -0,0 +1,239 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+// fakeIssuer publishes the discovery document and the signing key of an OIDC issuer
+func fakeIssuer(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case oidcDiscoveryPath:
+			json.NewEncoder(rw).Encode(map[string]string{"issuer": server.URL, "jwks_uri": server.URL + "/keys"})
+		case "/keys":
+			json.NewEncoder(rw).Encode(map[string]interface{}{
+				"keys": []map[string]string{{
+					"kty": "RSA",
+					"kid": "key-1",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				}},
+			})
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func signIDToken(t *testing.T, key *rsa.PrivateKey, keyID string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func TestOIDCProvider(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	issuer := fakeIssuer(t, key)
+
+	mapping, err := ParseClaimMapping("employee_id=emp_no, grade=org.grade")
+	assert.NoError(t, err)
+	provider, err := NewOIDCProvider(issuer.URL, "peerly", mapping)
+	assert.NoError(t, err)
+
+	claims := func(override jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":         issuer.URL,
+			"aud":         []string{"peerly", "other"},
+			"sub":         "user-1",
+			"exp":         time.Now().Add(time.Hour).Unix(),
+			"email":       "jane@example.com",
+			"given_name":  "Jane",
+			"family_name": "Doe",
+			"emp_no":      101,
+			"designation": "Engineer",
+			"org":         map[string]interface{}{"grade": "J1"},
+		}
+		for name, value := range override {
+			if value == nil {
+				delete(c, name)
+				continue
+			}
+			c[name] = value
+		}
+		return c
+	}
+
+	tests := []struct {
+		name            string
+		credential      string
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name:            "Valid id token",
+			credential:      signIDToken(t, key, "key-1", claims(nil)),
+			isErrorExpected: false,
+		},
+		{
+			name:            "Expired id token",
+			credential:      signIDToken(t, key, "key-1", claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})),
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidAuthToken,
+		},
+		{
+			name:            "Id token without an expiry",
+			credential:      signIDToken(t, key, "key-1", claims(jwt.MapClaims{"exp": nil})),
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidAuthToken,
+		},
+		{
+			name:            "Id token of another issuer",
+			credential:      signIDToken(t, key, "key-1", claims(jwt.MapClaims{"iss": "https://issuer.example.com"})),
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidAuthToken,
+		},
+		{
+			name:            "Id token issued to another client",
+			credential:      signIDToken(t, key, "key-1", claims(jwt.MapClaims{"aud": "other"})),
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidAuthToken,
+		},
+		{
+			name:            "Id token signed with another key",
+			credential:      signIDToken(t, otherKey, "key-1", claims(nil)),
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidAuthToken,
+		},
+		{
+			name:            "Id token signed with an unknown key",
+			credential:      signIDToken(t, key, "key-2", claims(nil)),
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidAuthToken,
+		},
+		{
+			name: "Id token signed with a shared secret",
+			credential: func() string {
+				s, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims(nil)).SignedString([]byte("secret"))
+				return s
+			}(),
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidAuthToken,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identity, err := provider.ValidateCredential(context.Background(), test.credential)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "user-1", identity.Subject)
+
+			profile, err := provider.GetProfile(context.Background(), identity)
+			assert.NoError(t, err)
+			assert.Equal(t, dto.IntranetUserData{
+				Email:          "jane@example.com",
+				PublicProfile:  dto.PublicProfile{FirstName: "Jane", LastName: "Doe"},
+				EmpolyeeDetail: dto.EmployeeDetail{EmployeeId: "101", Designation: dto.Designation{Name: "Engineer"}, Grade: "J1"},
+			}, profile)
+		})
+	}
+}
+
+func TestOIDCProviderIssuerDown(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	issuer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer issuer.Close()
+
+	provider, err := NewOIDCProvider(issuer.URL, "peerly", DefaultClaimMapping())
+	assert.NoError(t, err)
+
+	_, err = provider.ValidateCredential(context.Background(), signIDToken(t, key, "key-1", jwt.MapClaims{"sub": "user-1"}))
+
+	assert.Equal(t, apperrors.InternalServerError, err)
+}
+
+func TestParseClaimMapping(t *testing.T) {
+	tests := []struct {
+		name            string
+		mapping         string
+		isErrorExpected bool
+		expected        ClaimMapping
+	}{
+		{
+			name:            "Defaults",
+			mapping:         "",
+			isErrorExpected: false,
+			expected:        DefaultClaimMapping(),
+		},
+		{
+			name:            "Overridden claims",
+			mapping:         "email=upn,designation=job_title",
+			isErrorExpected: false,
+			expected: func() ClaimMapping {
+				m := DefaultClaimMapping()
+				m.Email = "upn"
+				m.Designation = "job_title"
+				return m
+			}(),
+		},
+		{
+			name:            "Unknown field",
+			mapping:         "department=dept",
+			isErrorExpected: true,
+		},
+		{
+			name:            "Missing claim",
+			mapping:         "grade=",
+			isErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapping, err := ParseClaimMapping(test.mapping)
+
+			if test.isErrorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, mapping)
+		})
+	}
+}
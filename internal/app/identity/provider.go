@@ -0,0 +1,53 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
+)
+
+// Identity providers selectable through the IDENTITY_PROVIDER config
+const (
+	IntranetProvider = "intranet"
+	OIDCProvider     = "oidc"
+	StaticProvider   = "static"
+)
+
+// IdentityProvider authenticates the users logging in to Peerly
+type IdentityProvider interface {
+	// ValidateCredential checks the credential a client logged in with and returns whom it belongs to
+	ValidateCredential(ctx context.Context, credential string) (Identity, error)
+	// GetProfile fetches the profile a Peerly user is registered and updated with
+	GetProfile(ctx context.Context, identity Identity) (dto.IntranetUserData, error)
+}
+
+// Identity is a user authenticated by a provider
+type Identity struct {
+	// Subject identifies the user at the provider
+	Subject string
+	// Token is what the apis of the provider are called with on behalf of the user
+	Token string
+	// Claims are what the credential itself asserts about the user, when it carries any
+	Claims map[string]interface{}
+}
+
+// NewIdentityProvider builds the provider of the given kind from the identity config, the intranet provider calls
+// the intranet through the given client
+func NewIdentityProvider(kind string, intranetClient *intranet.Client) (IdentityProvider, error) {
+	switch kind {
+	case IntranetProvider:
+		return NewIntranetProvider(intranetClient), nil
+	case OIDCProvider:
+		mapping, err := ParseClaimMapping(config.OIDCClaimMapping())
+		if err != nil {
+			return nil, err
+		}
+		return NewOIDCProvider(config.OIDCIssuerURL(), config.OIDCClientID(), mapping)
+	case StaticProvider:
+		return LoadStaticProvider(config.StaticIdentityUsersFile())
+	}
+	return nil, fmt.Errorf("unknown identity provider: %s", kind)
+}
@@ -0,0 +1,57 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// staticProvider logs in a fixed set of users, each with its own credential, for local development and tests
+type staticProvider struct {
+	users map[string]dto.IntranetUserData
+}
+
+// NewStaticProvider logs in the given users, keyed by the credential each one logs in with
+func NewStaticProvider(users map[string]dto.IntranetUserData) IdentityProvider {
+	return &staticProvider{users: users}
+}
+
+// LoadStaticProvider reads the users from a JSON object of credentials to profiles in the intranet format
+func LoadStaticProvider(path string) (IdentityProvider, error) {
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error in reading static identity users: %w", err)
+	}
+
+	var users map[string]dto.IntranetUserData
+	err = json.Unmarshal(content, &users)
+	if err != nil {
+		return nil, fmt.Errorf("error in parsing static identity users %s: %w", path, err)
+	}
+
+	return NewStaticProvider(users), nil
+}
+
+func (sp *staticProvider) ValidateCredential(ctx context.Context, credential string) (Identity, error) {
+
+	if _, ok := sp.users[credential]; !ok {
+		logger.Errorf(ctx, "staticProvider: unknown credential")
+		return Identity{}, apperrors.InvalidAuthToken
+	}
+	return Identity{Subject: credential, Token: credential}, nil
+}
+
+func (sp *staticProvider) GetProfile(ctx context.Context, identity Identity) (dto.IntranetUserData, error) {
+
+	user, ok := sp.users[identity.Subject]
+	if !ok {
+		return dto.IntranetUserData{}, apperrors.InvalidAuthToken
+	}
+	return user, nil
+}
@@ -0,0 +1,44 @@
+package identity
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	err := os.WriteFile(path, []byte(`{"jane-token":{"email":"jane@example.com","public_profile":{"first_name":"Jane"},"employee_detail":{"grade":"J1"}}}`), 0o600)
+	assert.NoError(t, err)
+
+	provider, err := LoadStaticProvider(path)
+	assert.NoError(t, err)
+
+	identity, err := provider.ValidateCredential(context.Background(), "jane-token")
+	assert.NoError(t, err)
+	profile, err := provider.GetProfile(context.Background(), identity)
+	assert.NoError(t, err)
+	assert.Equal(t, dto.IntranetUserData{
+		Email:          "jane@example.com",
+		PublicProfile:  dto.PublicProfile{FirstName: "Jane"},
+		EmpolyeeDetail: dto.EmployeeDetail{Grade: "J1"},
+	}, profile)
+
+	_, err = provider.ValidateCredential(context.Background(), "unknown")
+	assert.Equal(t, apperrors.InvalidAuthToken, err)
+}
+
+func TestNewIdentityProvider(t *testing.T) {
+	provider, err := NewIdentityProvider(IntranetProvider, intranet.NewClient(intranet.Options{}))
+	assert.NoError(t, err)
+	assert.NotNil(t, provider)
+
+	_, err = NewIdentityProvider("saml", nil)
+	assert.Error(t, err)
+}
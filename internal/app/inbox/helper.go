@@ -0,0 +1,20 @@
+package inbox
+
+import (
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+func mapNotificationDbToSvc(dbNotification repository.Notification) dto.Notification {
+	return dto.Notification{
+		ID:             dbNotification.ID,
+		UserID:         dbNotification.UserID,
+		Type:           dbNotification.Type,
+		Title:          dbNotification.Title,
+		Body:           dbNotification.Body,
+		AppreciationID: dbNotification.AppreciationID.Int64,
+		IsRead:         dbNotification.ReadAt.Valid,
+		ReadAt:         dbNotification.ReadAt.Int64,
+		CreatedAt:      dbNotification.CreatedAt,
+	}
+}
@@ -0,0 +1,122 @@
+package inbox
+
+import (
+	"context"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type service struct {
+	notificationRepo repository.NotificationStorer
+}
+
+// Service serves the in-app notifications of the logged in user
+type Service interface {
+	ListNotifications(ctx context.Context, filter dto.NotificationFilter) (dto.ListNotificationsResponse, error)
+	MarkRead(ctx context.Context, notificationID int64) error
+	MarkAllRead(ctx context.Context) error
+	DeleteNotification(ctx context.Context, notificationID int64) error
+}
+
+func NewService(notificationRepo repository.NotificationStorer) Service {
+	return &service{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (inboxSvc *service) ListNotifications(ctx context.Context, filter dto.NotificationFilter) (dto.ListNotificationsResponse, error) {
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return dto.ListNotificationsResponse{}, err
+	}
+	filter.UserID = userID
+
+	logger.Debug(ctx, "inboxService: ListNotifications: filter: ", filter)
+	notifications, pagination, err := inboxSvc.notificationRepo.ListNotifications(ctx, nil, filter)
+	if err != nil {
+		logger.Errorf(ctx, "inboxService: ListNotifications: err: %v", err)
+		return dto.ListNotificationsResponse{}, err
+	}
+
+	unreadCount, err := inboxSvc.notificationRepo.CountUnreadNotifications(ctx, nil, userID)
+	if err != nil {
+		logger.Errorf(ctx, "inboxService: CountUnreadNotifications: err: %v", err)
+		return dto.ListNotificationsResponse{}, err
+	}
+
+	res := make([]dto.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		res = append(res, mapNotificationDbToSvc(notification))
+	}
+
+	return dto.ListNotificationsResponse{
+		Notifications: res,
+		UnreadCount:   unreadCount,
+		MetaData: dto.Pagination{
+			CurrentPage:  pagination.CurrentPage,
+			TotalPage:    pagination.TotalPage,
+			PageSize:     pagination.RecordPerPage,
+			TotalRecords: pagination.TotalRecords,
+		},
+	}, nil
+}
+
+func (inboxSvc *service) MarkRead(ctx context.Context, notificationID int64) error {
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = inboxSvc.notificationRepo.MarkNotificationRead(ctx, nil, userID, notificationID)
+	if err != nil {
+		logger.Errorf(ctx, "inboxService: MarkNotificationRead: id: %d, err: %v", notificationID, err)
+		return err
+	}
+	return nil
+}
+
+func (inboxSvc *service) MarkAllRead(ctx context.Context) error {
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = inboxSvc.notificationRepo.MarkAllNotificationsRead(ctx, nil, userID)
+	if err != nil {
+		logger.Errorf(ctx, "inboxService: MarkAllNotificationsRead: err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (inboxSvc *service) DeleteNotification(ctx context.Context, notificationID int64) error {
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = inboxSvc.notificationRepo.DeleteNotification(ctx, nil, userID, notificationID)
+	if err != nil {
+		logger.Errorf(ctx, "inboxService: DeleteNotification: id: %d, err: %v", notificationID, err)
+		return err
+	}
+	return nil
+}
+
+func currentUserID(ctx context.Context) (int64, error) {
+	data := ctx.Value(constants.UserId)
+	userID, ok := data.(int64)
+	if !ok {
+		logger.Error(ctx, "inboxService: err in parsing userid from token")
+		return 0, apperrors.InternalServer
+	}
+	return userID, nil
+}
@@ -0,0 +1,176 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestListNotifications(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		filter          dto.NotificationFilter
+		setup           func(notificationMock *mocks.NotificationStorer)
+		isErrorExpected bool
+		expectedResult  dto.ListNotificationsResponse
+	}{
+		{
+			name:   "Inbox of the logged in user with the unread count",
+			ctx:    context.WithValue(context.Background(), constants.UserId, int64(7)),
+			filter: dto.NotificationFilter{Page: 1, Limit: 10},
+			setup: func(notificationMock *mocks.NotificationStorer) {
+				notificationMock.On("ListNotifications", mock.Anything, nil, dto.NotificationFilter{UserID: 7, Page: 1, Limit: 10}).Return([]repository.Notification{
+					{ID: 2, UserID: 7, Type: constants.RewardNotification, Title: "Reward's incoming!", AppreciationID: sql.NullInt64{Int64: 4, Valid: true}, CreatedAt: 20},
+					{ID: 1, UserID: 7, Type: constants.QuotaRefillNotification, Title: "Reward quota refilled", ReadAt: sql.NullInt64{Int64: 15, Valid: true}, CreatedAt: 10},
+				}, repository.Pagination{CurrentPage: 1, TotalPage: 1, RecordPerPage: 10, TotalRecords: 2}, nil).Once()
+				notificationMock.On("CountUnreadNotifications", mock.Anything, nil, int64(7)).Return(int64(1), nil).Once()
+			},
+			isErrorExpected: false,
+			expectedResult: dto.ListNotificationsResponse{
+				Notifications: []dto.Notification{
+					{ID: 2, UserID: 7, Type: constants.RewardNotification, Title: "Reward's incoming!", AppreciationID: 4, CreatedAt: 20},
+					{ID: 1, UserID: 7, Type: constants.QuotaRefillNotification, Title: "Reward quota refilled", IsRead: true, ReadAt: 15, CreatedAt: 10},
+				},
+				UnreadCount: 1,
+				MetaData:    dto.Pagination{CurrentPage: 1, TotalPage: 1, PageSize: 10, TotalRecords: 2},
+			},
+		},
+		{
+			name:            "User missing from the token",
+			ctx:             context.Background(),
+			filter:          dto.NotificationFilter{Page: 1, Limit: 10},
+			setup:           func(notificationMock *mocks.NotificationStorer) {},
+			isErrorExpected: true,
+		},
+		{
+			name:   "Error in listing notifications",
+			ctx:    context.WithValue(context.Background(), constants.UserId, int64(7)),
+			filter: dto.NotificationFilter{Page: 1, Limit: 10, UnreadOnly: true},
+			setup: func(notificationMock *mocks.NotificationStorer) {
+				notificationMock.On("ListNotifications", mock.Anything, nil, mock.Anything).Return(nil, repository.Pagination{}, apperrors.InternalServerError).Once()
+			},
+			isErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			notificationMock := mocks.NewNotificationStorer(t)
+			test.setup(notificationMock)
+			service := NewService(notificationMock)
+
+			result, err := service.ListNotifications(test.ctx, test.filter)
+
+			if test.isErrorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedResult, result)
+		})
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		setup         func(notificationMock *mocks.NotificationStorer)
+		expectedError error
+	}{
+		{
+			name: "success",
+			id:   3,
+			setup: func(notificationMock *mocks.NotificationStorer) {
+				notificationMock.On("MarkNotificationRead", mock.Anything, nil, int64(7), int64(3)).Return(nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Notification of another user",
+			id:   4,
+			setup: func(notificationMock *mocks.NotificationStorer) {
+				notificationMock.On("MarkNotificationRead", mock.Anything, nil, int64(7), int64(4)).Return(apperrors.NotificationNotFound).Once()
+			},
+			expectedError: apperrors.NotificationNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			notificationMock := mocks.NewNotificationStorer(t)
+			test.setup(notificationMock)
+			service := NewService(notificationMock)
+
+			ctx := context.WithValue(context.Background(), constants.UserId, int64(7))
+			err := service.MarkRead(ctx, test.id)
+
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestMarkAllRead(t *testing.T) {
+	notificationMock := mocks.NewNotificationStorer(t)
+	notificationMock.On("MarkAllNotificationsRead", mock.Anything, nil, int64(7)).Return(nil).Once()
+	service := NewService(notificationMock)
+
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(7))
+	err := service.MarkAllRead(ctx)
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteNotification(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		setup         func(notificationMock *mocks.NotificationStorer)
+		expectedError error
+	}{
+		{
+			name: "success",
+			id:   3,
+			setup: func(notificationMock *mocks.NotificationStorer) {
+				notificationMock.On("DeleteNotification", mock.Anything, nil, int64(7), int64(3)).Return(nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Notification not found",
+			id:   4,
+			setup: func(notificationMock *mocks.NotificationStorer) {
+				notificationMock.On("DeleteNotification", mock.Anything, nil, int64(7), int64(4)).Return(apperrors.NotificationNotFound).Once()
+			},
+			expectedError: apperrors.NotificationNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			notificationMock := mocks.NewNotificationStorer(t)
+			test.setup(notificationMock)
+			service := NewService(notificationMock)
+
+			ctx := context.WithValue(context.Background(), constants.UserId, int64(7))
+			err := service.DeleteNotification(ctx, test.id)
+
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
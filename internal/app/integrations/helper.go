@@ -0,0 +1,67 @@
+package integrations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/lib/pq"
+)
+
+func mapCreateIntegrationReqToDb(userID int64, req dto.CreateIntegrationReq) repository.Integration {
+	return repository.Integration{
+		Kind:         req.Kind,
+		Name:         req.Name,
+		WebhookURL:   req.WebhookURL,
+		CoreValueIDs: pq.Int64Array(req.CoreValueIDs),
+		CreatedBy:    userID,
+	}
+}
+
+func mapIntegrationDbToSvc(dbIntegration repository.Integration) dto.Integration {
+	coreValueIDs := []int64(dbIntegration.CoreValueIDs)
+	if coreValueIDs == nil {
+		coreValueIDs = []int64{}
+	}
+	return dto.Integration{
+		ID:           dbIntegration.ID,
+		Kind:         dbIntegration.Kind,
+		Name:         dbIntegration.Name,
+		WebhookURL:   dbIntegration.WebhookURL,
+		CoreValueIDs: coreValueIDs,
+		CreatedBy:    dbIntegration.CreatedBy,
+		CreatedAt:    dbIntegration.CreatedAt,
+	}
+}
+
+// previousQuarter returns the start and end of the quarter before the one now falls in.
+// Quarters start in March, June, September and December as they do for the leaderboard.
+func previousQuarter(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	month := int(now.Month())
+	startMonth := (month+9)%12/3*3 + 3
+	year := now.Year()
+	if startMonth > month {
+		year--
+	}
+	quarterEnd := time.Date(year, time.Month(startMonth), 1, 0, 0, 0, 0, time.UTC)
+	return quarterEnd.AddDate(0, -3, 0), quarterEnd
+}
+
+func quarterWinnersPost(winners []repository.QuarterWinner, quarterStart time.Time, quarterEnd time.Time) dto.FeedPost {
+	fields := make([]dto.FeedPostField, 0, len(winners))
+	for i, winner := range winners {
+		fields = append(fields, dto.FeedPostField{
+			Title: fmt.Sprintf("%d. %s %s", i+1, winner.FirstName, winner.LastName),
+			Value: fmt.Sprintf("%d points", winner.AppreciationPoints),
+		})
+	}
+	return dto.FeedPost{
+		Event:  constants.QuarterWinnersEvent,
+		Title:  "Quarter winners 🏆",
+		Text:   fmt.Sprintf("The most appreciated peers of %s - %s", quarterStart.Format("Jan 2006"), quarterEnd.AddDate(0, 0, -1).Format("Jan 2006")),
+		Fields: fields,
+	}
+}
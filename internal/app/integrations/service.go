@@ -0,0 +1,150 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type service struct {
+	integrationRepo repository.IntegrationStorer
+	coreValueRepo   repository.CoreValueStorer
+	outboxRepo      repository.OutboxStorer
+}
+
+// Service manages the Slack and Teams webhooks the appreciation feed is posted to
+type Service interface {
+	CreateIntegration(ctx context.Context, req dto.CreateIntegrationReq) (dto.Integration, error)
+	ListIntegrations(ctx context.Context) ([]dto.Integration, error)
+	DeleteIntegration(ctx context.Context, id int64) error
+	TestIntegration(ctx context.Context, id int64) error
+	PostQuarterWinners(ctx context.Context) (int, error)
+}
+
+func NewService(integrationRepo repository.IntegrationStorer, coreValueRepo repository.CoreValueStorer, outboxRepo repository.OutboxStorer) Service {
+	return &service{
+		integrationRepo: integrationRepo,
+		coreValueRepo:   coreValueRepo,
+		outboxRepo:      outboxRepo,
+	}
+}
+
+func (intSvc *service) CreateIntegration(ctx context.Context, req dto.CreateIntegrationReq) (dto.Integration, error) {
+
+	data := ctx.Value(constants.UserId)
+	userID, ok := data.(int64)
+	if !ok {
+		logger.Error(ctx, "integrationService: err in parsing userid from token")
+		return dto.Integration{}, apperrors.InternalServer
+	}
+
+	for _, coreValueID := range req.CoreValueIDs {
+		_, err := intSvc.coreValueRepo.GetCoreValue(ctx, coreValueID)
+		if err != nil {
+			logger.Errorf(ctx, "integrationService: GetCoreValue: id: %d, err: %v", coreValueID, err)
+			return dto.Integration{}, err
+		}
+	}
+
+	integration, err := intSvc.integrationRepo.CreateIntegration(ctx, nil, mapCreateIntegrationReqToDb(userID, req))
+	if err != nil {
+		logger.Errorf(ctx, "integrationService: CreateIntegration: err: %v", err)
+		return dto.Integration{}, err
+	}
+
+	logger.Infof(ctx, "integrationService: %s integration %d created by user %d", integration.Kind, integration.ID, userID)
+	return mapIntegrationDbToSvc(integration), nil
+}
+
+func (intSvc *service) ListIntegrations(ctx context.Context) ([]dto.Integration, error) {
+
+	integrations, err := intSvc.integrationRepo.ListIntegrations(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "integrationService: ListIntegrations: err: %v", err)
+		return nil, err
+	}
+
+	res := make([]dto.Integration, 0, len(integrations))
+	for _, integration := range integrations {
+		res = append(res, mapIntegrationDbToSvc(integration))
+	}
+	return res, nil
+}
+
+func (intSvc *service) DeleteIntegration(ctx context.Context, id int64) error {
+
+	err := intSvc.integrationRepo.DeleteIntegration(ctx, nil, id)
+	if err != nil {
+		logger.Errorf(ctx, "integrationService: DeleteIntegration: id: %d, err: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// TestIntegration posts a test message right away so admins can check the webhook works
+func (intSvc *service) TestIntegration(ctx context.Context, id int64) error {
+
+	integration, err := intSvc.integrationRepo.GetIntegration(ctx, nil, id)
+	if err != nil {
+		logger.Errorf(ctx, "integrationService: GetIntegration: id: %d, err: %v", id, err)
+		return err
+	}
+
+	err = Deliver(ctx, integration.Kind, integration.WebhookURL, dto.FeedPost{
+		Title: "Peerly is connected",
+		Text:  fmt.Sprintf("Appreciations will be posted here through the %s integration.", integration.Name),
+	})
+	if err != nil {
+		logger.Errorf(ctx, "integrationService: test post to integration %d failed: %v", id, err)
+		return apperrors.WebhookDeliveryFailed
+	}
+	return nil
+}
+
+// PostQuarterWinners queues the winners of the quarter that just ended for every integration and returns how many were queued
+func (intSvc *service) PostQuarterWinners(ctx context.Context) (int, error) {
+
+	quarterStart, quarterEnd := previousQuarter(time.Now())
+	winners, err := intSvc.integrationRepo.ListQuarterWinners(ctx, nil, quarterStart.UnixMilli(), quarterEnd.UnixMilli(), constants.QuarterWinnersCount)
+	if err != nil {
+		logger.Errorf(ctx, "integrationService: ListQuarterWinners: err: %v", err)
+		return 0, err
+	}
+	if len(winners) == 0 {
+		logger.Info(ctx, "integrationService: no appreciations last quarter, no winners to post")
+		return 0, nil
+	}
+
+	integrations, err := intSvc.integrationRepo.ListIntegrations(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "integrationService: ListIntegrations: err: %v", err)
+		return 0, err
+	}
+
+	err = EnqueuePost(ctx, nil, intSvc.outboxRepo, integrations, quarterWinnersPost(winners, quarterStart, quarterEnd))
+	if err != nil {
+		return 0, err
+	}
+	return len(integrations), nil
+}
+
+// EnqueuePost queues the post for each of the integrations, the outbox worker delivers and retries them
+func EnqueuePost(ctx context.Context, tx repository.Transaction, outboxRepo repository.OutboxStorer, integrations []repository.Integration, post dto.FeedPost) error {
+	for _, integration := range integrations {
+		err := outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxWebhook, dto.OutboxWebhook{
+			IntegrationID: integration.ID,
+			Post:          post,
+		})
+		if err != nil {
+			logger.Errorf(ctx, "integrationService: EnqueueOutboxMessage: integration: %d, err: %v", integration.ID, err)
+			return err
+		}
+	}
+	return nil
+}
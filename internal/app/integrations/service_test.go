@@ -0,0 +1,143 @@
+package integrations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	"github.com/lib/pq"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestCreateIntegration(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             dto.CreateIntegrationReq
+		setup           func(integrationMock *mocks.IntegrationStorer, coreValueMock *mocks.CoreValueStorer)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name: "Integration following a core value is created",
+			req:  dto.CreateIntegrationReq{Kind: constants.SlackIntegration, Name: "#kudos", WebhookURL: "https://hooks.slack.com/services/T/B/X", CoreValueIDs: []int64{3}},
+			setup: func(integrationMock *mocks.IntegrationStorer, coreValueMock *mocks.CoreValueStorer) {
+				coreValueMock.On("GetCoreValue", mock.Anything, int64(3)).Return(repository.CoreValue{ID: 3}, nil).Once()
+				integrationMock.On("CreateIntegration", mock.Anything, nil, repository.Integration{
+					Kind:         constants.SlackIntegration,
+					Name:         "#kudos",
+					WebhookURL:   "https://hooks.slack.com/services/T/B/X",
+					CoreValueIDs: pq.Int64Array{3},
+					CreatedBy:    1,
+				}).Return(repository.Integration{ID: 1, Kind: constants.SlackIntegration, CoreValueIDs: pq.Int64Array{3}}, nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Unknown core value",
+			req:  dto.CreateIntegrationReq{Kind: constants.TeamsIntegration, Name: "Kudos", WebhookURL: "https://example.webhook.office.com/x", CoreValueIDs: []int64{9}},
+			setup: func(integrationMock *mocks.IntegrationStorer, coreValueMock *mocks.CoreValueStorer) {
+				coreValueMock.On("GetCoreValue", mock.Anything, int64(9)).Return(repository.CoreValue{}, apperrors.InvalidCoreValueData).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidCoreValueData,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			integrationMock := mocks.NewIntegrationStorer(t)
+			coreValueMock := mocks.NewCoreValueStorer(t)
+			test.setup(integrationMock, coreValueMock)
+			service := NewService(integrationMock, coreValueMock, mocks.NewOutboxStorer(t))
+
+			ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+			result, err := service.CreateIntegration(ctx, test.req)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []int64{3}, result.CoreValueIDs)
+		})
+	}
+}
+
+func TestTestIntegration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	integrationMock := mocks.NewIntegrationStorer(t)
+	integrationMock.On("GetIntegration", mock.Anything, nil, int64(1)).Return(repository.Integration{ID: 1, Kind: constants.TeamsIntegration, WebhookURL: server.URL}, nil).Once()
+	service := NewService(integrationMock, mocks.NewCoreValueStorer(t), mocks.NewOutboxStorer(t))
+
+	err := service.TestIntegration(context.Background(), 1)
+
+	assert.Equal(t, apperrors.WebhookDeliveryFailed, err)
+}
+
+func TestPostQuarterWinners(t *testing.T) {
+	integrationMock := mocks.NewIntegrationStorer(t)
+	outboxMock := mocks.NewOutboxStorer(t)
+
+	integrationMock.On("ListQuarterWinners", mock.Anything, nil, mock.Anything, mock.Anything, constants.QuarterWinnersCount).Return([]repository.QuarterWinner{
+		{ID: 2, FirstName: "Jane", LastName: "Doe", AppreciationPoints: 120},
+		{ID: 3, FirstName: "John", LastName: "Roe", AppreciationPoints: 90},
+	}, nil).Once()
+	integrationMock.On("ListIntegrations", mock.Anything, nil).Return([]repository.Integration{{ID: 1}, {ID: 2}}, nil).Once()
+	outboxMock.On("EnqueueOutboxMessage", mock.Anything, nil, constants.OutboxWebhook, mock.MatchedBy(func(webhook dto.OutboxWebhook) bool {
+		return webhook.Post.Event == constants.QuarterWinnersEvent && len(webhook.Post.Fields) == 2 && webhook.Post.Fields[0].Title == "1. Jane Doe"
+	})).Return(nil).Twice()
+
+	service := NewService(integrationMock, mocks.NewCoreValueStorer(t), outboxMock)
+	queued, err := service.PostQuarterWinners(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, queued)
+}
+
+func TestPreviousQuarter(t *testing.T) {
+	tests := []struct {
+		now           time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			now:           time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC),
+			expectedStart: time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			now:           time.Date(2026, time.December, 1, 9, 0, 0, 0, time.UTC),
+			expectedStart: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			now:           time.Date(2027, time.January, 15, 0, 0, 0, 0, time.UTC),
+			expectedStart: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		start, end := previousQuarter(test.now)
+		assert.Equal(t, test.expectedStart, start)
+		assert.Equal(t, test.expectedEnd, end)
+	}
+}
@@ -0,0 +1,120 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+)
+
+// how long a chat tool gets to accept a post before the attempt fails
+const webhookTimeout = 10 * time.Second
+
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+// Deliver renders the post for the integration's chat tool and posts it to the incoming webhook.
+// Any response other than a 2xx is an error so the outbox retries it.
+func Deliver(ctx context.Context, kind string, webhookURL string, post dto.FeedPost) error {
+	var payload interface{}
+	switch kind {
+	case constants.SlackIntegration:
+		payload = slackPayload(post)
+	case constants.TeamsIntegration:
+		payload = teamsPayload(post)
+	default:
+		return fmt.Errorf("unknown integration kind: %s", kind)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error in marshalling %s payload: %w", kind, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error in creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error in posting to the %s webhook: %w", kind, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s webhook responded with %d: %s", kind, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// slackPayload builds a Block Kit message, text is the fallback shown in notifications
+func slackPayload(post dto.FeedPost) map[string]interface{} {
+	blocks := []map[string]interface{}{
+		{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": post.Title},
+		},
+		{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": slackEscaper.Replace(post.Text)},
+		},
+	}
+
+	if len(post.Fields) > 0 {
+		fields := make([]map[string]interface{}, 0, len(post.Fields))
+		for _, field := range post.Fields {
+			fields = append(fields, map[string]interface{}{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*%s*\n%s", slackEscaper.Replace(field.Title), slackEscaper.Replace(field.Value)),
+			})
+		}
+		blocks = append(blocks, map[string]interface{}{"type": "section", "fields": fields})
+	}
+
+	return map[string]interface{}{
+		"text":   post.Title + ": " + post.Text,
+		"blocks": blocks,
+	}
+}
+
+// teamsPayload wraps an Adaptive Card in the message envelope Teams incoming webhooks expect
+func teamsPayload(post dto.FeedPost) map[string]interface{} {
+	body := []map[string]interface{}{
+		{"type": "TextBlock", "text": post.Title, "weight": "Bolder", "size": "Medium", "wrap": true},
+		{"type": "TextBlock", "text": post.Text, "wrap": true},
+	}
+
+	if len(post.Fields) > 0 {
+		facts := make([]map[string]interface{}, 0, len(post.Fields))
+		for _, field := range post.Fields {
+			facts = append(facts, map[string]interface{}{"title": field.Title, "value": field.Value})
+		}
+		body = append(body, map[string]interface{}{"type": "FactSet", "facts": facts})
+	}
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			},
+		},
+	}
+}
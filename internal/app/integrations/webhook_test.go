@@ -0,0 +1,86 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliver(t *testing.T) {
+	post := dto.FeedPost{
+		Title:  "New appreciation",
+		Text:   "Jane Doe appreciated John <Smith>",
+		Fields: []dto.FeedPostField{{Title: "Core value", Value: "Trust"}},
+	}
+
+	tests := []struct {
+		name            string
+		kind            string
+		status          int
+		isErrorExpected bool
+		checkBody       func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			name:   "Slack gets a Block Kit message",
+			kind:   constants.SlackIntegration,
+			status: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				blocks := body["blocks"].([]interface{})
+				assert.Len(t, blocks, 3)
+				section := blocks[1].(map[string]interface{})["text"].(map[string]interface{})
+				assert.Equal(t, "Jane Doe appreciated John &lt;Smith&gt;", section["text"])
+			},
+		},
+		{
+			name:   "Teams gets an Adaptive Card",
+			kind:   constants.TeamsIntegration,
+			status: http.StatusAccepted,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "message", body["type"])
+				attachment := body["attachments"].([]interface{})[0].(map[string]interface{})
+				assert.Equal(t, "application/vnd.microsoft.card.adaptive", attachment["contentType"])
+				card := attachment["content"].(map[string]interface{})
+				assert.Equal(t, "AdaptiveCard", card["type"])
+				assert.Len(t, card["body"], 3)
+			},
+		},
+		{
+			name:            "Webhook rejecting the post is an error",
+			kind:            constants.SlackIntegration,
+			status:          http.StatusNotFound,
+			isErrorExpected: true,
+			checkBody:       func(t *testing.T, body map[string]interface{}) {},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var received map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+				body, err := io.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.NoError(t, json.Unmarshal(body, &received))
+				rw.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			err := Deliver(context.Background(), test.kind, server.URL, post)
+
+			if test.isErrorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			test.checkBody(t, received)
+		})
+	}
+}
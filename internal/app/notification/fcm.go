@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"context"
+
+	firebase "firebase.google.com/go"
+	"firebase.google.com/go/messaging"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"google.golang.org/api/option"
+)
+
+type fcmService struct {
+	client *messaging.Client
+}
+
+// NewFCMService initializes the firebase app and its messaging client from the service account key file
+func NewFCMService(ctx context.Context, serviceAccountKey string) (NotificationService, error) {
+
+	opt := option.WithCredentialsFile(serviceAccountKey)
+	app, err := firebase.NewApp(ctx, nil, opt)
+	if err != nil {
+		logger.Errorf(ctx, "Error initializing app: %v", err)
+		return nil, apperrors.InternalServerError
+	}
+
+	client, err := app.Messaging(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "Error getting Messaging client: %v", err)
+		return nil, apperrors.InternalServerError
+	}
+
+	return &fcmService{client: client}, nil
+}
+
+func (fcmSvc *fcmService) SendNotificationToNotificationToken(ctx context.Context, msg Message, notificationToken string) (err error) {
+
+	logger.Debug(ctx, " notificationSvc: ", msg)
+	message := &messaging.Message{
+		Notification: &messaging.Notification{
+			Title: msg.Title,
+			Body:  msg.Body,
+		},
+		Token: notificationToken,
+	}
+
+	response, err := fcmSvc.client.Send(ctx, message)
+	if err != nil {
+		logger.Errorf(ctx, "Error sending message: %v", err)
+		// the app was uninstalled, sending to the token again can't succeed. An invalid argument may just as well be a bad
+		// message, so it is retried like any other failure instead of dropping the token
+		if messaging.IsRegistrationTokenNotRegistered(err) {
+			return apperrors.UnregisteredNotificationToken
+		}
+		return apperrors.InternalServerError
+	}
+	logger.Infof(ctx, "Successfully sent message: %v", response)
+	return
+}
+
+func (fcmSvc *fcmService) SendNotificationToTopic(ctx context.Context, msg Message, topic string) (err error) {
+
+	logger.Debug(ctx, " notificationSvc: ", msg)
+	message := &messaging.Message{
+		Notification: &messaging.Notification{
+			Title: msg.Title,
+			Body:  msg.Body,
+		},
+		Topic: topic,
+	}
+
+	response, err := fcmSvc.client.Send(ctx, message)
+	if err != nil {
+		logger.Errorf(ctx, "error sending message: %v", err)
+		return apperrors.InternalServerError
+	}
+	logger.Infof(ctx, "Successfully sent message: %v", response)
+	return
+}
@@ -0,0 +1,24 @@
+package notification
+
+import (
+	"context"
+
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// logService only logs the notifications, for environments without firebase
+type logService struct{}
+
+func NewLogService() NotificationService {
+	return &logService{}
+}
+
+func (ls *logService) SendNotificationToNotificationToken(ctx context.Context, msg Message, notificationToken string) (err error) {
+	logger.Infof(ctx, "notification to token %s: %s - %s", notificationToken, msg.Title, msg.Body)
+	return
+}
+
+func (ls *logService) SendNotificationToTopic(ctx context.Context, msg Message, topic string) (err error) {
+	logger.Infof(ctx, "notification to topic %s: %s - %s", topic, msg.Title, msg.Body)
+	return
+}
@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"context"
+	"sync"
+)
+
+// SentNotification is a notification kept by the recording service, either Token or Topic is set
+type SentNotification struct {
+	Message Message
+	Token   string
+	Topic   string
+}
+
+// RecordingService keeps every notification in memory instead of sending it, to run flows offline and assert on them in tests
+type RecordingService struct {
+	mu       sync.Mutex
+	sent     []SentNotification
+	failures map[string]error
+}
+
+func NewRecordingService() *RecordingService {
+	return &RecordingService{}
+}
+
+func (rs *RecordingService) SendNotificationToNotificationToken(ctx context.Context, msg Message, notificationToken string) (err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if err, ok := rs.failures[notificationToken]; ok {
+		return err
+	}
+	rs.sent = append(rs.sent, SentNotification{Message: msg, Token: notificationToken})
+	return
+}
+
+func (rs *RecordingService) SendNotificationToTopic(ctx context.Context, msg Message, topic string) (err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.sent = append(rs.sent, SentNotification{Message: msg, Topic: topic})
+	return
+}
+
+// Sent returns the notifications recorded so far, oldest first
+func (rs *RecordingService) Sent() []SentNotification {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return append([]SentNotification(nil), rs.sent...)
+}
+
+// FailToken makes every notification to the token fail with err instead of being recorded
+func (rs *RecordingService) FailToken(notificationToken string, err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.failures == nil {
+		rs.failures = make(map[string]error)
+	}
+	rs.failures[notificationToken] = err
+}
+
+func (rs *RecordingService) Reset() {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.sent = nil
+}
@@ -2,18 +2,24 @@ package notification
 
 import (
 	"context"
+	"fmt"
 
-	firebase "firebase.google.com/go"
-	"firebase.google.com/go/messaging"
-	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
 	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
-	"google.golang.org/api/option"
 )
 
+// Notification providers selectable through the NOTIFICATION_PROVIDER config
+const (
+	FCMProvider    = "fcm"
+	MemoryProvider = "memory"
+	LogProvider    = "log"
+)
+
+// Topic every user of the application is subscribed to
+const AllUsersTopic = "peerly"
+
 type NotificationService interface {
-	SendNotificationToNotificationToken(notificationToken string) (err error)
-	SendNotificationToTopic(topic string) (err error)
-	// SendNotificationToNotificationTokens(userId int64)
+	SendNotificationToNotificationToken(ctx context.Context, msg Message, notificationToken string) (err error)
+	SendNotificationToTopic(ctx context.Context, msg Message, topic string) (err error)
 }
 
 type Message struct {
@@ -22,129 +28,18 @@ type Message struct {
 	ImageURL string `json:"image,omitempty"`
 }
 
-func (notificationSvc *Message) SendNotificationToNotificationToken(notificationToken string) (err error) {
-
-	// Path to your service account key file
-	serviceAccountKey := "serviceAccountKey.json"
-
-	// Initialize the Firebase app
-	opt := option.WithCredentialsFile(serviceAccountKey)
-	app, err := firebase.NewApp(context.Background(), nil, opt)
-	if err != nil {
-		logger.Errorf(context.Background(), "Error initializing app: %v", err)
-		err = apperrors.InternalServerError
-		return
-	}
-
-	// Obtain a messaging client from the Firebase app
-	client, err := app.Messaging(context.Background())
-	if err != nil {
-		logger.Errorf(context.Background(), "Error getting Messaging client: %v", err)
-		err = apperrors.InternalServerError
-		return
-	}
-
-	logger.Debug(context.Background(), " notificationSvc: ", notificationSvc)
-	// Create a message to send
-	message := &messaging.Message{
-		Notification: &messaging.Notification{
-			Title: notificationSvc.Title,
-			Body:  notificationSvc.Body,
-		},
-		Token: notificationToken,
-	}
-
-	// Send the message
-	response, err := client.Send(context.Background(), message)
-	logger.Debug(context.Background(), " response: ", response)
-	logger.Debug(context.Background(), " err: ", err)
-	if err != nil {
-		logger.Errorf(context.Background(), "Error sending message: %v", err)
-		err = apperrors.InternalServerError
-		return
-	}
-	logger.Infof(context.Background(), "Successfully sent message: %v", response)
-	return
-}
-
-func (notificationSvc *Message) SendNotificationToTopic(topic string) (err error) {
-
-	// Path to your service account key file
-	serviceAccountKey := "serviceAccountKey.json"
-
-	// Initialize the Firebase app
-	opt := option.WithCredentialsFile(serviceAccountKey)
-	app, err := firebase.NewApp(context.Background(), nil, opt)
-	if err != nil {
-		logger.Errorf(context.Background(), "error initializing app: %v", err)
-		err = apperrors.InternalServerError
-		return
-	}
-
-	// Obtain a messaging client from the Firebase app
-	client, err := app.Messaging(context.Background())
-	if err != nil {
-		logger.Errorf(context.Background(), "error getting Messaging client: %v", err)
-		err = apperrors.InternalServerError
-		return
-	}
-
-	logger.Debug(context.Background(), " notificationSvc: ", notificationSvc)
-	// Create a message to send
-	message := &messaging.Message{
-		Notification: &messaging.Notification{
-			Title: notificationSvc.Title,
-			Body:  notificationSvc.Body,
-		},
-		Topic: topic,
-	}
-
-	// Send the message
-	response, err := client.Send(context.Background(), message)
-	if err != nil {
-		logger.Errorf(context.Background(), "error sending message: %v", err)
-		err = apperrors.InternalServerError
-		return
-	}
-
-	logger.Infof(context.Background(), "Successfully sent message: %v", response)
-
-	return
+// NewService builds the notification provider once at startup, an unknown provider or a firebase app that cannot be
+// initialized is an error so a misconfigured server doesn't start without notifications
+func NewService(ctx context.Context, provider string, serviceAccountKey string) (NotificationService, error) {
+	switch provider {
+	case FCMProvider:
+		return NewFCMService(ctx, serviceAccountKey)
+	case MemoryProvider:
+		logger.Info(ctx, "notification: using the in-memory provider")
+		return NewRecordingService(), nil
+	case LogProvider:
+		logger.Info(ctx, "notification: using the log provider")
+		return NewLogService(), nil
+	}
+	return nil, fmt.Errorf("unknown notification provider: %s", provider)
 }
-
-// func (notificationSvc *Message) SendNotificationToNotificationTokens(userId int64) {
-
-// 	// Path to your service account key file
-// 	serviceAccountKey := "serviceAccountKey.json"
-
-// 	// Initialize the Firebase app
-// 	opt := option.WithCredentialsFile(serviceAccountKey)
-// 	app, err := firebase.NewApp(context.Background(), nil, opt)
-// 	if err != nil {
-// 		logger.Errorf("Error initializing app: %v", err)
-// 		return
-// 	}
-
-// 	// Obtain a messaging client from the Firebase app
-// 	client, err := app.Messaging(context.Background())
-// 	if err != nil {
-// 		logger.Errorf("Error getting Messaging client: %v", err)
-// 		return
-// 	}
-// 	// Create a message to send
-// 	message := &messaging.Message{
-// 		Notification: &messaging.Notification{
-// 			Title: notificationSvc.Title,
-// 			Body:  notificationSvc.Body,
-// 		},
-// 		Token: notificationToken,
-// 	}
-
-// 	// Send the message
-// 	response, err := client.Send(context.Background(), message)
-// 	if err != nil {
-// 		logger.Errorf("Error sending message: %v", err)
-// 		return
-// 	}
-// 	logger.Infof("Successfully sent message: %v", response)
-// }
@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestNewService(t *testing.T) {
+	missingKey := filepath.Join(t.TempDir(), "serviceAccountKey.json")
+
+	tests := []struct {
+		name            string
+		provider        string
+		isErrorExpected bool
+	}{
+		{name: "In-memory provider", provider: MemoryProvider},
+		{name: "Log provider", provider: LogProvider},
+		{name: "Firebase without a service account key stops the startup", provider: FCMProvider, isErrorExpected: true},
+		{name: "Unknown provider", provider: "pigeon", isErrorExpected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc, err := NewService(context.Background(), test.provider, missingKey)
+
+			if test.isErrorExpected {
+				assert.Error(t, err)
+				assert.Nil(t, svc)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, svc)
+		})
+	}
+}
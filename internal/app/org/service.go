@@ -0,0 +1,392 @@
+package org
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/utils"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type service struct {
+	orgRepo  repository.OrgStorer
+	userRepo repository.UserStorer
+}
+
+// Service manages the departments and teams of the organization and where the users sit in it
+type Service interface {
+	ListDepartments(ctx context.Context) ([]dto.Department, error)
+	CreateDepartment(ctx context.Context, req dto.DepartmentReq) (dto.Department, error)
+	UpdateDepartment(ctx context.Context, id int64, req dto.DepartmentReq) (dto.Department, error)
+	DeleteDepartment(ctx context.Context, id int64) error
+
+	ListTeams(ctx context.Context, departmentID int64) ([]dto.Team, error)
+	CreateTeam(ctx context.Context, req dto.TeamReq) (dto.Team, error)
+	UpdateTeam(ctx context.Context, id int64, req dto.TeamReq) (dto.Team, error)
+	DeleteTeam(ctx context.Context, id int64) error
+
+	UpdateUserHierarchy(ctx context.Context, userID int64, req dto.UserHierarchyReq) error
+	ListMyTeam(ctx context.Context) ([]dto.TeamMember, error)
+}
+
+func NewService(orgRepo repository.OrgStorer, userRepo repository.UserStorer) Service {
+	return &service{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (orgSvc *service) ListDepartments(ctx context.Context) ([]dto.Department, error) {
+
+	departments, err := orgSvc.orgRepo.ListDepartments(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: ListDepartments: err: %v", err)
+		return nil, err
+	}
+
+	resp := make([]dto.Department, 0, len(departments))
+	for _, department := range departments {
+		resp = append(resp, mapDbDepartmentToDto(department))
+	}
+	return resp, nil
+}
+
+func (orgSvc *service) CreateDepartment(ctx context.Context, req dto.DepartmentReq) (dto.Department, error) {
+
+	err := req.Validate()
+	if err != nil {
+		return dto.Department{}, err
+	}
+	name := strings.TrimSpace(req.Name)
+
+	createdBy, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "orgService: err in parsing userid from token")
+		return dto.Department{}, apperrors.InternalServer
+	}
+
+	_, err = orgSvc.orgRepo.GetDepartmentByName(ctx, nil, name)
+	if err == nil {
+		return dto.Department{}, apperrors.DepartmentAlreadyPresent
+	}
+	if err != apperrors.DepartmentNotFound {
+		logger.Errorf(ctx, "orgService: GetDepartmentByName: err: %v", err)
+		return dto.Department{}, err
+	}
+
+	department, err := orgSvc.orgRepo.CreateDepartment(ctx, nil, repository.Department{
+		Name:      name,
+		CreatedBy: sql.NullInt64{Int64: createdBy, Valid: true},
+	})
+	if err != nil {
+		logger.Errorf(ctx, "orgService: CreateDepartment: err: %v", err)
+		return dto.Department{}, err
+	}
+	return mapDbDepartmentToDto(department), nil
+}
+
+func (orgSvc *service) UpdateDepartment(ctx context.Context, id int64, req dto.DepartmentReq) (dto.Department, error) {
+
+	err := req.Validate()
+	if err != nil {
+		return dto.Department{}, err
+	}
+	name := strings.TrimSpace(req.Name)
+
+	existing, err := orgSvc.orgRepo.GetDepartmentByName(ctx, nil, name)
+	if err == nil && existing.ID != id {
+		return dto.Department{}, apperrors.DepartmentAlreadyPresent
+	}
+	if err != nil && err != apperrors.DepartmentNotFound {
+		logger.Errorf(ctx, "orgService: GetDepartmentByName: err: %v", err)
+		return dto.Department{}, err
+	}
+
+	department, err := orgSvc.orgRepo.UpdateDepartment(ctx, nil, id, name)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: UpdateDepartment: department: %d, err: %v", id, err)
+		return dto.Department{}, err
+	}
+	return mapDbDepartmentToDto(department), nil
+}
+
+// DeleteDepartment only deletes a department without teams or users
+func (orgSvc *service) DeleteDepartment(ctx context.Context, id int64) error {
+
+	err := orgSvc.orgRepo.DeleteDepartment(ctx, nil, id)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: DeleteDepartment: department: %d, err: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+func (orgSvc *service) ListTeams(ctx context.Context, departmentID int64) ([]dto.Team, error) {
+
+	teams, err := orgSvc.orgRepo.ListTeams(ctx, nil, departmentID)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: ListTeams: err: %v", err)
+		return nil, err
+	}
+
+	resp := make([]dto.Team, 0, len(teams))
+	for _, team := range teams {
+		resp = append(resp, mapDbTeamToDto(team))
+	}
+	return resp, nil
+}
+
+func (orgSvc *service) CreateTeam(ctx context.Context, req dto.TeamReq) (dto.Team, error) {
+
+	err := req.Validate()
+	if err != nil {
+		return dto.Team{}, err
+	}
+	name := strings.TrimSpace(req.Name)
+
+	createdBy, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "orgService: err in parsing userid from token")
+		return dto.Team{}, apperrors.InternalServer
+	}
+
+	_, err = orgSvc.orgRepo.GetDepartment(ctx, nil, req.DepartmentID)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: GetDepartment: department: %d, err: %v", req.DepartmentID, err)
+		return dto.Team{}, err
+	}
+
+	_, err = orgSvc.orgRepo.GetTeamByName(ctx, nil, req.DepartmentID, name)
+	if err == nil {
+		return dto.Team{}, apperrors.TeamAlreadyPresent
+	}
+	if err != apperrors.TeamNotFound {
+		logger.Errorf(ctx, "orgService: GetTeamByName: err: %v", err)
+		return dto.Team{}, err
+	}
+
+	team, err := orgSvc.orgRepo.CreateTeam(ctx, nil, repository.Team{
+		DepartmentID: req.DepartmentID,
+		Name:         name,
+		CreatedBy:    sql.NullInt64{Int64: createdBy, Valid: true},
+	})
+	if err != nil {
+		logger.Errorf(ctx, "orgService: CreateTeam: err: %v", err)
+		return dto.Team{}, err
+	}
+	return mapDbTeamToDto(team), nil
+}
+
+// UpdateTeam renames a team, a team doesn't move between departments
+func (orgSvc *service) UpdateTeam(ctx context.Context, id int64, req dto.TeamReq) (dto.Team, error) {
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return dto.Team{}, apperrors.TeamNameBlank
+	}
+
+	team, err := orgSvc.orgRepo.GetTeam(ctx, nil, id)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: GetTeam: team: %d, err: %v", id, err)
+		return dto.Team{}, err
+	}
+	if req.DepartmentID != 0 && req.DepartmentID != team.DepartmentID {
+		return dto.Team{}, apperrors.TeamNotInDepartment
+	}
+
+	existing, err := orgSvc.orgRepo.GetTeamByName(ctx, nil, team.DepartmentID, name)
+	if err == nil && existing.ID != id {
+		return dto.Team{}, apperrors.TeamAlreadyPresent
+	}
+	if err != nil && err != apperrors.TeamNotFound {
+		logger.Errorf(ctx, "orgService: GetTeamByName: err: %v", err)
+		return dto.Team{}, err
+	}
+
+	team, err = orgSvc.orgRepo.UpdateTeam(ctx, nil, id, name)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: UpdateTeam: team: %d, err: %v", id, err)
+		return dto.Team{}, err
+	}
+	return mapDbTeamToDto(team), nil
+}
+
+// DeleteTeam only deletes a team without users
+func (orgSvc *service) DeleteTeam(ctx context.Context, id int64) error {
+
+	err := orgSvc.orgRepo.DeleteTeam(ctx, nil, id)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: DeleteTeam: team: %d, err: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateUserHierarchy places a user in a department, a team and under a manager as an admin sets it. The next intranet
+// sync overrides what the intranet lists the user with.
+func (orgSvc *service) UpdateUserHierarchy(ctx context.Context, userID int64, req dto.UserHierarchyReq) (err error) {
+
+	updatedBy, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "orgService: err in parsing userid from token")
+		return apperrors.InternalServer
+	}
+
+	tx, err := orgSvc.orgRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: BeginTx: err: %v", err)
+		return err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := orgSvc.orgRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	_, err = orgSvc.userRepo.GetUser(ctx, tx, userID)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: GetUser: user: %d, err: %v", userID, err)
+		return err
+	}
+
+	var hierarchy repository.UserHierarchy
+	if req.TeamID != 0 {
+		team, err := orgSvc.orgRepo.GetTeam(ctx, tx, req.TeamID)
+		if err != nil {
+			logger.Errorf(ctx, "orgService: GetTeam: team: %d, err: %v", req.TeamID, err)
+			return err
+		}
+		if req.DepartmentID != 0 && req.DepartmentID != team.DepartmentID {
+			return apperrors.TeamNotInDepartment
+		}
+		req.DepartmentID = team.DepartmentID
+		hierarchy.TeamID = sql.NullInt64{Int64: team.ID, Valid: true}
+	}
+	if req.DepartmentID != 0 {
+		_, err = orgSvc.orgRepo.GetDepartment(ctx, tx, req.DepartmentID)
+		if err != nil {
+			logger.Errorf(ctx, "orgService: GetDepartment: department: %d, err: %v", req.DepartmentID, err)
+			return err
+		}
+		hierarchy.DepartmentID = sql.NullInt64{Int64: req.DepartmentID, Valid: true}
+	}
+	if req.ManagerID != 0 {
+		err = orgSvc.validateManager(ctx, tx, userID, req.ManagerID)
+		if err != nil {
+			return err
+		}
+		hierarchy.ManagerID = sql.NullInt64{Int64: req.ManagerID, Valid: true}
+	}
+
+	err = orgSvc.orgRepo.UpdateUserHierarchy(ctx, tx, userID, hierarchy, updatedBy)
+	if err != nil {
+		logger.Errorf(ctx, "orgService: UpdateUserHierarchy: user: %d, err: %v", userID, err)
+		return err
+	}
+
+	logger.Infof(ctx, "orgService: hierarchy of user %d set to %+v by user %d", userID, req, updatedBy)
+	return nil
+}
+
+// validateManager walks up the reporting line of the manager, the user can't report to themselves or to someone
+// reporting to them
+func (orgSvc *service) validateManager(ctx context.Context, tx repository.Transaction, userID int64, managerID int64) error {
+
+	if managerID == userID {
+		return apperrors.InvalidManager
+	}
+
+	current := managerID
+	for depth := 0; depth < constants.MaxReportingLineDepth; depth++ {
+		manager, err := orgSvc.userRepo.GetUser(ctx, tx, current)
+		if err != nil {
+			logger.Errorf(ctx, "orgService: GetUser: manager: %d, err: %v", current, err)
+			if err == apperrors.UserNotFound {
+				return apperrors.InvalidManager
+			}
+			return err
+		}
+		if !manager.ManagerID.Valid {
+			return nil
+		}
+		if manager.ManagerID.Int64 == userID {
+			return apperrors.InvalidManager
+		}
+		current = manager.ManagerID.Int64
+	}
+
+	logger.Errorf(ctx, "orgService: reporting line of manager %d is deeper than %d", managerID, constants.MaxReportingLineDepth)
+	return apperrors.InvalidManager
+}
+
+// ListMyTeam lists the users reporting to the logged in user with what they received and sent this quarter
+func (orgSvc *service) ListMyTeam(ctx context.Context) ([]dto.TeamMember, error) {
+
+	managerID, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "orgService: err in parsing userid from token")
+		return nil, apperrors.InternalServer
+	}
+
+	members, err := orgSvc.orgRepo.ListTeamMembers(ctx, nil, managerID, utils.GetQuarterStartUnixTime())
+	if err != nil {
+		logger.Errorf(ctx, "orgService: ListTeamMembers: manager: %d, err: %v", managerID, err)
+		return nil, err
+	}
+
+	resp := make([]dto.TeamMember, 0, len(members))
+	for _, member := range members {
+		resp = append(resp, mapDbTeamMemberToDto(member))
+	}
+	return resp, nil
+}
+
+func mapDbDepartmentToDto(department repository.Department) dto.Department {
+	return dto.Department{
+		ID:        department.ID,
+		Name:      department.Name,
+		CreatedAt: department.CreatedAt,
+	}
+}
+
+func mapDbTeamToDto(team repository.Team) dto.Team {
+	return dto.Team{
+		ID:           team.ID,
+		DepartmentID: team.DepartmentID,
+		Name:         team.Name,
+		CreatedAt:    team.CreatedAt,
+	}
+}
+
+func mapDbTeamMemberToDto(member repository.TeamMember) dto.TeamMember {
+	return dto.TeamMember{
+		ID:                 member.ID,
+		EmployeeID:         member.EmployeeID,
+		FirstName:          member.FirstName,
+		LastName:           member.LastName,
+		Email:              member.Email,
+		ProfileImgUrl:      member.ProfileImageURL.String,
+		Designation:        member.Designation,
+		DepartmentID:       member.DepartmentID.Int64,
+		TeamID:             member.TeamID.Int64,
+		AppreciationPoints: member.AppreciationPoints,
+		AppreciationsSent:  member.AppreciationsSent,
+	}
+}
@@ -0,0 +1,275 @@
+package org
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestCreateDepartment(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+
+	tests := []struct {
+		name            string
+		req             dto.DepartmentReq
+		setup           func(orgMock *mocks.OrgStorer)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name: "Department is created",
+			req:  dto.DepartmentReq{Name: " Engineering "},
+			setup: func(orgMock *mocks.OrgStorer) {
+				orgMock.On("GetDepartmentByName", mock.Anything, nil, "Engineering").Return(repository.Department{}, apperrors.DepartmentNotFound).Once()
+				orgMock.On("CreateDepartment", mock.Anything, nil, repository.Department{Name: "Engineering", CreatedBy: sql.NullInt64{Int64: 1, Valid: true}}).
+					Return(repository.Department{ID: 3, Name: "Engineering"}, nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Name is taken",
+			req:  dto.DepartmentReq{Name: "engineering"},
+			setup: func(orgMock *mocks.OrgStorer) {
+				orgMock.On("GetDepartmentByName", mock.Anything, nil, "engineering").Return(repository.Department{ID: 3, Name: "Engineering"}, nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.DepartmentAlreadyPresent,
+		},
+		{
+			name:            "Blank name",
+			req:             dto.DepartmentReq{Name: "  "},
+			setup:           func(orgMock *mocks.OrgStorer) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.DepartmentNameBlank,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orgMock := mocks.NewOrgStorer(t)
+			test.setup(orgMock)
+			svc := NewService(orgMock, mocks.NewUserStorer(t))
+
+			resp, err := svc.CreateDepartment(ctx, test.req)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, dto.Department{ID: 3, Name: "Engineering"}, resp)
+		})
+	}
+}
+
+func TestCreateTeam(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+
+	tests := []struct {
+		name            string
+		req             dto.TeamReq
+		setup           func(orgMock *mocks.OrgStorer)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name: "Team is created",
+			req:  dto.TeamReq{DepartmentID: 3, Name: "Platform"},
+			setup: func(orgMock *mocks.OrgStorer) {
+				orgMock.On("GetDepartment", mock.Anything, nil, int64(3)).Return(repository.Department{ID: 3}, nil).Once()
+				orgMock.On("GetTeamByName", mock.Anything, nil, int64(3), "Platform").Return(repository.Team{}, apperrors.TeamNotFound).Once()
+				orgMock.On("CreateTeam", mock.Anything, nil, repository.Team{DepartmentID: 3, Name: "Platform", CreatedBy: sql.NullInt64{Int64: 1, Valid: true}}).
+					Return(repository.Team{ID: 5, DepartmentID: 3, Name: "Platform"}, nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Unknown department",
+			req:  dto.TeamReq{DepartmentID: 3, Name: "Platform"},
+			setup: func(orgMock *mocks.OrgStorer) {
+				orgMock.On("GetDepartment", mock.Anything, nil, int64(3)).Return(repository.Department{}, apperrors.DepartmentNotFound).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.DepartmentNotFound,
+		},
+		{
+			name: "Name is taken in the department",
+			req:  dto.TeamReq{DepartmentID: 3, Name: "Platform"},
+			setup: func(orgMock *mocks.OrgStorer) {
+				orgMock.On("GetDepartment", mock.Anything, nil, int64(3)).Return(repository.Department{ID: 3}, nil).Once()
+				orgMock.On("GetTeamByName", mock.Anything, nil, int64(3), "Platform").Return(repository.Team{ID: 5}, nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.TeamAlreadyPresent,
+		},
+		{
+			name:            "Department missing",
+			req:             dto.TeamReq{Name: "Platform"},
+			setup:           func(orgMock *mocks.OrgStorer) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.DepartmentNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orgMock := mocks.NewOrgStorer(t)
+			test.setup(orgMock)
+			svc := NewService(orgMock, mocks.NewUserStorer(t))
+
+			resp, err := svc.CreateTeam(ctx, test.req)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, dto.Team{ID: 5, DepartmentID: 3, Name: "Platform"}, resp)
+		})
+	}
+}
+
+func TestUpdateUserHierarchy(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+	valid := func(id int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	tests := []struct {
+		name            string
+		req             dto.UserHierarchyReq
+		setup           func(orgMock *mocks.OrgStorer, userMock *mocks.UserStorer, tx *sql.Tx)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name: "Team sets the department",
+			req:  dto.UserHierarchyReq{TeamID: 5, ManagerID: 8},
+			setup: func(orgMock *mocks.OrgStorer, userMock *mocks.UserStorer, tx *sql.Tx) {
+				userMock.On("GetUser", mock.Anything, tx, int64(7)).Return(repository.User{Id: 7}, nil).Once()
+				orgMock.On("GetTeam", mock.Anything, tx, int64(5)).Return(repository.Team{ID: 5, DepartmentID: 3}, nil).Once()
+				orgMock.On("GetDepartment", mock.Anything, tx, int64(3)).Return(repository.Department{ID: 3}, nil).Once()
+				userMock.On("GetUser", mock.Anything, tx, int64(8)).Return(repository.User{Id: 8, ManagerID: valid(9)}, nil).Once()
+				userMock.On("GetUser", mock.Anything, tx, int64(9)).Return(repository.User{Id: 9}, nil).Once()
+				orgMock.On("UpdateUserHierarchy", mock.Anything, tx, int64(7), repository.UserHierarchy{DepartmentID: valid(3), TeamID: valid(5), ManagerID: valid(8)}, int64(1)).Return(nil).Once()
+				orgMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Zero values clear the hierarchy",
+			req:  dto.UserHierarchyReq{},
+			setup: func(orgMock *mocks.OrgStorer, userMock *mocks.UserStorer, tx *sql.Tx) {
+				userMock.On("GetUser", mock.Anything, tx, int64(7)).Return(repository.User{Id: 7, DepartmentID: valid(3)}, nil).Once()
+				orgMock.On("UpdateUserHierarchy", mock.Anything, tx, int64(7), repository.UserHierarchy{}, int64(1)).Return(nil).Once()
+				orgMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Team of another department",
+			req:  dto.UserHierarchyReq{DepartmentID: 4, TeamID: 5},
+			setup: func(orgMock *mocks.OrgStorer, userMock *mocks.UserStorer, tx *sql.Tx) {
+				userMock.On("GetUser", mock.Anything, tx, int64(7)).Return(repository.User{Id: 7}, nil).Once()
+				orgMock.On("GetTeam", mock.Anything, tx, int64(5)).Return(repository.Team{ID: 5, DepartmentID: 3}, nil).Once()
+				orgMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.TeamNotInDepartment,
+		},
+		{
+			name: "User reports to themselves",
+			req:  dto.UserHierarchyReq{ManagerID: 7},
+			setup: func(orgMock *mocks.OrgStorer, userMock *mocks.UserStorer, tx *sql.Tx) {
+				userMock.On("GetUser", mock.Anything, tx, int64(7)).Return(repository.User{Id: 7}, nil).Once()
+				orgMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidManager,
+		},
+		{
+			name: "Manager reports to the user",
+			req:  dto.UserHierarchyReq{ManagerID: 8},
+			setup: func(orgMock *mocks.OrgStorer, userMock *mocks.UserStorer, tx *sql.Tx) {
+				userMock.On("GetUser", mock.Anything, tx, int64(7)).Return(repository.User{Id: 7}, nil).Once()
+				userMock.On("GetUser", mock.Anything, tx, int64(8)).Return(repository.User{Id: 8, ManagerID: valid(9)}, nil).Once()
+				userMock.On("GetUser", mock.Anything, tx, int64(9)).Return(repository.User{Id: 9, ManagerID: valid(7)}, nil).Once()
+				orgMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidManager,
+		},
+		{
+			name: "Unknown manager",
+			req:  dto.UserHierarchyReq{ManagerID: 8},
+			setup: func(orgMock *mocks.OrgStorer, userMock *mocks.UserStorer, tx *sql.Tx) {
+				userMock.On("GetUser", mock.Anything, tx, int64(7)).Return(repository.User{Id: 7}, nil).Once()
+				userMock.On("GetUser", mock.Anything, tx, int64(8)).Return(repository.User{}, apperrors.UserNotFound).Once()
+				orgMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidManager,
+		},
+		{
+			name: "Unknown user",
+			req:  dto.UserHierarchyReq{DepartmentID: 3},
+			setup: func(orgMock *mocks.OrgStorer, userMock *mocks.UserStorer, tx *sql.Tx) {
+				userMock.On("GetUser", mock.Anything, tx, int64(7)).Return(repository.User{}, apperrors.UserNotFound).Once()
+				orgMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.UserNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orgMock := mocks.NewOrgStorer(t)
+			userMock := mocks.NewUserStorer(t)
+			tx := &sql.Tx{}
+			orgMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+			test.setup(orgMock, userMock, tx)
+			svc := NewService(orgMock, userMock)
+
+			err := svc.UpdateUserHierarchy(ctx, 7, test.req)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestListMyTeam(t *testing.T) {
+	orgMock := mocks.NewOrgStorer(t)
+	orgMock.On("ListTeamMembers", mock.Anything, nil, int64(8), mock.Anything).Return([]repository.TeamMember{
+		{ID: 7, FirstName: "Jane", Email: "jane@example.com", TeamID: sql.NullInt64{Int64: 5, Valid: true}, AppreciationPoints: 40, AppreciationsSent: 2},
+	}, nil).Once()
+	svc := NewService(orgMock, mocks.NewUserStorer(t))
+
+	members, err := svc.ListMyTeam(context.WithValue(context.Background(), constants.UserId, int64(8)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.TeamMember{
+		{ID: 7, FirstName: "Jane", Email: "jane@example.com", TeamID: 5, AppreciationPoints: 40, AppreciationsSent: 2},
+	}, members)
+
+	_, err = svc.ListMyTeam(context.Background())
+	assert.Equal(t, apperrors.InternalServer, err)
+}
@@ -0,0 +1,22 @@
+package outbox
+
+import (
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+func mapOutboxMessageDbToSvc(dbMsg repository.OutboxMessage) dto.OutboxMessage {
+	return dto.OutboxMessage{
+		ID:            dbMsg.ID,
+		Kind:          dbMsg.Kind,
+		Payload:       dbMsg.Payload,
+		Status:        dbMsg.Status,
+		Attempts:      dbMsg.Attempts,
+		MaxAttempts:   dbMsg.MaxAttempts,
+		NextAttemptAt: dbMsg.NextAttemptAt,
+		LastError:     dbMsg.LastError.String,
+		CreatedAt:     dbMsg.CreatedAt,
+		UpdatedAt:     dbMsg.UpdatedAt,
+		SentAt:        dbMsg.SentAt.Int64,
+	}
+}
@@ -0,0 +1,330 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/email"
+	"github.com/joshsoftware/peerly-backend/internal/app/integrations"
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
+	"github.com/joshsoftware/peerly-backend/internal/app/webhooks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+const (
+	// messages picked by the worker in one run
+	deliveryBatchSize = 50
+	// how long a picked message stays hidden from other workers while it is being delivered
+	deliveryLease = 5 * time.Minute
+	// delay before the first retry, doubled after every failed attempt
+	baseBackoff = 30 * time.Second
+	maxBackoff  = time.Hour
+)
+
+type service struct {
+	outboxRepo      repository.OutboxStorer
+	userRepo        repository.UserStorer
+	preferenceRepo  repository.NotificationPreferenceStorer
+	digestRepo      repository.DigestStorer
+	integrationRepo repository.IntegrationStorer
+	webhookRepo     repository.WebhookStorer
+	notificationSvc notification.NotificationService
+}
+
+type Service interface {
+	ListDeliveries(ctx context.Context, filter dto.OutboxFilter) (dto.ListOutboxMessagesResponse, error)
+	RetryDelivery(ctx context.Context, id int64) (dto.OutboxMessage, error)
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+func NewService(outboxRepo repository.OutboxStorer, userRepo repository.UserStorer, preferenceRepo repository.NotificationPreferenceStorer, digestRepo repository.DigestStorer, integrationRepo repository.IntegrationStorer, webhookRepo repository.WebhookStorer, notificationSvc notification.NotificationService) Service {
+	return &service{
+		outboxRepo:      outboxRepo,
+		userRepo:        userRepo,
+		preferenceRepo:  preferenceRepo,
+		digestRepo:      digestRepo,
+		integrationRepo: integrationRepo,
+		webhookRepo:     webhookRepo,
+		notificationSvc: notificationSvc,
+	}
+}
+
+func (obSvc *service) ListDeliveries(ctx context.Context, filter dto.OutboxFilter) (dto.ListOutboxMessagesResponse, error) {
+
+	logger.Debug(ctx, "outboxService: ListDeliveries: filter: ", filter)
+	messages, pagination, err := obSvc.outboxRepo.ListOutboxMessages(ctx, nil, filter)
+	if err != nil {
+		logger.Errorf(ctx, "outboxService: ListOutboxMessages: err: %v", err)
+		return dto.ListOutboxMessagesResponse{}, err
+	}
+
+	res := make([]dto.OutboxMessage, 0, len(messages))
+	for _, msg := range messages {
+		res = append(res, mapOutboxMessageDbToSvc(msg))
+	}
+
+	return dto.ListOutboxMessagesResponse{
+		Messages: res,
+		MetaData: dto.Pagination{
+			CurrentPage:  pagination.CurrentPage,
+			TotalPage:    pagination.TotalPage,
+			PageSize:     pagination.RecordPerPage,
+			TotalRecords: pagination.TotalRecords,
+		},
+	}, nil
+}
+
+func (obSvc *service) RetryDelivery(ctx context.Context, id int64) (dto.OutboxMessage, error) {
+
+	msg, err := obSvc.outboxRepo.RetryOutboxMessage(ctx, nil, id)
+	if err != nil {
+		logger.Errorf(ctx, "outboxService: RetryOutboxMessage: err: %v", err)
+		return dto.OutboxMessage{}, err
+	}
+
+	logger.Infof(ctx, "outboxService: outbox message %d queued for retry", id)
+	return mapOutboxMessageDbToSvc(msg), nil
+}
+
+// DeliverDue delivers the pending messages that are due and returns how many of them were sent.
+// A failed message is retried with exponential backoff and dead lettered once it runs out of attempts.
+func (obSvc *service) DeliverDue(ctx context.Context) (int, error) {
+
+	now := time.Now()
+	messages, err := obSvc.outboxRepo.ClaimDueOutboxMessages(ctx, nil, deliveryBatchSize, now.Add(deliveryLease).UnixMilli())
+	if err != nil {
+		logger.Errorf(ctx, "outboxService: ClaimDueOutboxMessages: err: %v", err)
+		return 0, err
+	}
+
+	sent := 0
+	for _, msg := range messages {
+		deliveryErr := obSvc.deliver(ctx, msg)
+		if deliveryErr == nil {
+			err = obSvc.outboxRepo.MarkOutboxMessageSent(ctx, nil, msg.ID)
+			if err != nil {
+				logger.Errorf(ctx, "outboxService: MarkOutboxMessageSent: id: %d, err: %v", msg.ID, err)
+				continue
+			}
+			sent++
+			continue
+		}
+
+		attempts := msg.Attempts + 1
+		dead := attempts >= msg.MaxAttempts
+		if dead {
+			logger.Errorf(ctx, "outboxService: outbox message %d is dead after %d attempts, err: %v", msg.ID, attempts, deliveryErr)
+		} else {
+			logger.Infof(ctx, "outboxService: outbox message %d failed on attempt %d, err: %v", msg.ID, attempts, deliveryErr)
+		}
+
+		nextAttemptAt := time.Now().Add(backoff(attempts)).UnixMilli()
+		err = obSvc.outboxRepo.MarkOutboxMessageFailed(ctx, nil, msg.ID, deliveryErr.Error(), nextAttemptAt, dead)
+		if err != nil {
+			logger.Errorf(ctx, "outboxService: MarkOutboxMessageFailed: id: %d, err: %v", msg.ID, err)
+		}
+	}
+
+	return sent, nil
+}
+
+func (obSvc *service) deliver(ctx context.Context, msg repository.OutboxMessage) error {
+	switch msg.Kind {
+	case constants.OutboxEmail:
+		var payload dto.OutboxEmail
+		err := json.Unmarshal(msg.Payload, &payload)
+		if err != nil {
+			return fmt.Errorf("invalid email payload: %w", err)
+		}
+		if payload.UserID != 0 && payload.Event != "" {
+			enabled, err := obSvc.preferenceRepo.IsChannelEnabled(ctx, nil, payload.UserID, payload.Event, constants.EmailChannel)
+			if err != nil {
+				return fmt.Errorf("error in getting email preference of user %d: %w", payload.UserID, err)
+			}
+			if !enabled {
+				logger.Infof(ctx, "outboxService: user %d turned off %s emails, dropping outbox message %d", payload.UserID, payload.Event, msg.ID)
+				return nil
+			}
+		}
+		if payload.UserID != 0 && slices.Contains(constants.DigestEvents, payload.Event) {
+			// the weekly digest summarizes these for its subscribers
+			subscribed, err := obSvc.digestRepo.IsSubscribedToDigest(ctx, nil, payload.UserID)
+			if err != nil {
+				return fmt.Errorf("error in getting digest subscription of user %d: %w", payload.UserID, err)
+			}
+			if subscribed {
+				logger.Infof(ctx, "outboxService: user %d gets the weekly digest, dropping outbox message %d", payload.UserID, msg.ID)
+				return nil
+			}
+		}
+		return deliverEmail(payload)
+	case constants.OutboxPush:
+		var payload dto.OutboxPush
+		err := json.Unmarshal(msg.Payload, &payload)
+		if err != nil {
+			return fmt.Errorf("invalid push payload: %w", err)
+		}
+		return obSvc.deliverPush(ctx, payload)
+	case constants.OutboxWebhook:
+		var payload dto.OutboxWebhook
+		err := json.Unmarshal(msg.Payload, &payload)
+		if err != nil {
+			return fmt.Errorf("invalid webhook payload: %w", err)
+		}
+		integration, err := obSvc.integrationRepo.GetIntegration(ctx, nil, payload.IntegrationID)
+		if err != nil {
+			if errors.Is(err, apperrors.IntegrationNotFound) {
+				logger.Infof(ctx, "outboxService: integration %d was removed, dropping outbox message %d", payload.IntegrationID, msg.ID)
+				return nil
+			}
+			return fmt.Errorf("error in getting integration %d: %w", payload.IntegrationID, err)
+		}
+		return integrations.Deliver(ctx, integration.Kind, integration.WebhookURL, payload.Post)
+	case constants.OutboxWebhookEvent:
+		var payload dto.OutboxWebhookEvent
+		err := json.Unmarshal(msg.Payload, &payload)
+		if err != nil {
+			return fmt.Errorf("invalid webhook event payload: %w", err)
+		}
+		return obSvc.deliverWebhookEvent(ctx, msg.ID, payload.DeliveryID)
+	}
+	return fmt.Errorf("unknown outbox message kind: %s", msg.Kind)
+}
+
+func deliverEmail(payload dto.OutboxEmail) error {
+	mailReq := email.NewMail(payload.To, payload.CC, payload.BCC, payload.Subject)
+	err := mailReq.ParseTemplate(payload.Template, payload.Data)
+	if err != nil {
+		return fmt.Errorf("error in rendering %s: %w", payload.Template, err)
+	}
+	return mailReq.Send()
+}
+
+// deliverWebhookEvent posts the event to its subscription and logs the attempt on the delivery.
+// Deliveries of a deleted subscription are removed with it, so a missing delivery is dropped.
+func (obSvc *service) deliverWebhookEvent(ctx context.Context, msgID int64, deliveryID int64) error {
+	delivery, err := obSvc.webhookRepo.GetWebhookDelivery(ctx, nil, deliveryID)
+	if err != nil {
+		if errors.Is(err, apperrors.WebhookDeliveryNotFound) {
+			logger.Infof(ctx, "outboxService: webhook delivery %d was removed, dropping outbox message %d", deliveryID, msgID)
+			return nil
+		}
+		return fmt.Errorf("error in getting webhook delivery %d: %w", deliveryID, err)
+	}
+
+	subscription, err := obSvc.webhookRepo.GetWebhookSubscription(ctx, nil, delivery.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, apperrors.WebhookSubscriptionNotFound) {
+			logger.Infof(ctx, "outboxService: webhook subscription %d was removed, dropping outbox message %d", delivery.SubscriptionID, msgID)
+			return nil
+		}
+		return fmt.Errorf("error in getting webhook subscription %d: %w", delivery.SubscriptionID, err)
+	}
+
+	responseStatus, deliveryErr := webhooks.Deliver(ctx, subscription, delivery)
+	lastError := ""
+	if deliveryErr != nil {
+		lastError = deliveryErr.Error()
+	}
+	err = obSvc.webhookRepo.RecordWebhookAttempt(ctx, nil, deliveryID, responseStatus, lastError)
+	if err != nil {
+		logger.Errorf(ctx, "outboxService: RecordWebhookAttempt: delivery: %d, err: %v", deliveryID, err)
+	}
+	return deliveryErr
+}
+
+// deliverPush sends the notification to the topic, or to every device the user is signed in on.
+// Pushes for an event skip the users who turned them off. An unregistered device token is removed instead of retried.
+func (obSvc *service) deliverPush(ctx context.Context, payload dto.OutboxPush) error {
+	msg := notification.Message{
+		Title:    payload.Title,
+		Body:     payload.Body,
+		ImageURL: payload.ImageURL,
+	}
+
+	var notificationTokens []string
+	var err error
+	switch {
+	case payload.Token != "":
+		notificationTokens = []string{payload.Token}
+	case payload.Topic != "" && payload.Event == "":
+		return obSvc.notificationSvc.SendNotificationToTopic(ctx, msg, payload.Topic)
+	case payload.Topic != "":
+		// a topic can't leave out the users who opted out, so the push goes to everyone else's devices
+		notificationTokens, err = obSvc.preferenceRepo.ListDeviceTokensForEvent(ctx, nil, payload.Event)
+		if err != nil {
+			return fmt.Errorf("error in getting device tokens for %s: %w", payload.Event, err)
+		}
+	default:
+		if payload.Event != "" {
+			enabled, err := obSvc.preferenceRepo.IsChannelEnabled(ctx, nil, payload.UserID, payload.Event, constants.PushChannel)
+			if err != nil {
+				return fmt.Errorf("error in getting push preference of user %d: %w", payload.UserID, err)
+			}
+			if !enabled {
+				logger.Infof(ctx, "outboxService: user %d turned off %s pushes", payload.UserID, payload.Event)
+				return nil
+			}
+		}
+
+		notificationTokens, err = obSvc.userRepo.ListDeviceTokensByUserID(ctx, payload.UserID)
+		if err != nil {
+			return fmt.Errorf("error in getting device tokens of user %d: %w", payload.UserID, err)
+		}
+	}
+
+	var failed []string
+	var sendErr error
+	for _, notificationToken := range notificationTokens {
+		err = obSvc.notificationSvc.SendNotificationToNotificationToken(ctx, msg, notificationToken)
+		switch {
+		case err == nil:
+		case errors.Is(err, apperrors.UnregisteredNotificationToken):
+			logger.Infof(ctx, "outboxService: dropping an unregistered device token")
+			err = obSvc.userRepo.DeleteUnregisteredDeviceToken(ctx, nil, notificationToken)
+			if err != nil {
+				logger.Errorf(ctx, "outboxService: DeleteUnregisteredDeviceToken: err: %v", err)
+			}
+		default:
+			failed = append(failed, notificationToken)
+			sendErr = err
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	if len(notificationTokens) == 1 {
+		return sendErr
+	}
+
+	// the devices that got the push are done, each failed one is retried on its own so the others don't get it twice
+	for _, notificationToken := range failed {
+		err = obSvc.outboxRepo.EnqueueOutboxMessage(ctx, nil, constants.OutboxPush, dto.OutboxPush{
+			Token:    notificationToken,
+			Title:    payload.Title,
+			Body:     payload.Body,
+			ImageURL: payload.ImageURL,
+		})
+		if err != nil {
+			return fmt.Errorf("error in queueing the push for a failed device: %w", err)
+		}
+	}
+	return nil
+}
+
+// backoff returns the delay before the next attempt, doubling from baseBackoff up to maxBackoff
+func backoff(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
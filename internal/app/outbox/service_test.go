@@ -0,0 +1,289 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
+	"github.com/joshsoftware/peerly-backend/internal/app/webhooks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func pushMessage(t *testing.T, id int64, attempts int, push dto.OutboxPush) repository.OutboxMessage {
+	payload, err := json.Marshal(push)
+	assert.NoError(t, err)
+	return repository.OutboxMessage{ID: id, Kind: constants.OutboxPush, Payload: payload, Status: constants.OutboxPending, Attempts: attempts, MaxAttempts: 3}
+}
+
+func TestDeliverDue(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer)
+		failedTokens  map[string]error
+		expectedSent  int
+		expectedTitle []string
+	}{
+		{
+			name: "Push notifications are sent to the user's devices and the topic",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					pushMessage(t, 1, 0, dto.OutboxPush{UserID: 2, Title: "Reward's incoming!"}),
+					pushMessage(t, 2, 0, dto.OutboxPush{Topic: notification.AllUsersTopic, Title: "Appreciation"}),
+				}, nil).Once()
+				userMock.On("ListDeviceTokensByUserID", mock.Anything, int64(2)).Return([]string{"phone", "tablet"}, nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(2)).Return(nil).Once()
+			},
+			expectedSent:  2,
+			expectedTitle: []string{"Reward's incoming!", "Reward's incoming!", "Appreciation"},
+		},
+		{
+			name: "Failed delivery is retried later",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					pushMessage(t, 1, 0, dto.OutboxPush{UserID: 2, Title: "Reward's incoming!"}),
+				}, nil).Once()
+				userMock.On("ListDeviceTokensByUserID", mock.Anything, int64(2)).Return(nil, errors.New("connection refused")).Once()
+				outboxMock.On("MarkOutboxMessageFailed", mock.Anything, nil, int64(1), mock.Anything, mock.Anything, false).Return(nil).Once()
+			},
+			expectedSent:  0,
+			expectedTitle: []string{},
+		},
+		{
+			name: "Delivery out of attempts is dead lettered",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					pushMessage(t, 1, 2, dto.OutboxPush{UserID: 2, Title: "Reward's incoming!"}),
+				}, nil).Once()
+				userMock.On("ListDeviceTokensByUserID", mock.Anything, int64(2)).Return(nil, errors.New("connection refused")).Once()
+				outboxMock.On("MarkOutboxMessageFailed", mock.Anything, nil, int64(1), mock.Anything, mock.Anything, true).Return(nil).Once()
+			},
+			expectedSent:  0,
+			expectedTitle: []string{},
+		},
+		{
+			name: "Unregistered device token is removed and the other devices still get the push",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					pushMessage(t, 1, 0, dto.OutboxPush{UserID: 2, Title: "Reward's incoming!"}),
+				}, nil).Once()
+				userMock.On("ListDeviceTokensByUserID", mock.Anything, int64(2)).Return([]string{"phone", "uninstalled"}, nil).Once()
+				userMock.On("DeleteUnregisteredDeviceToken", mock.Anything, nil, "uninstalled").Return(nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+			},
+			failedTokens:  map[string]error{"uninstalled": apperrors.UnregisteredNotificationToken},
+			expectedSent:  1,
+			expectedTitle: []string{"Reward's incoming!"},
+		},
+		{
+			name: "Device that failed is retried on its own instead of pushing to every device again",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					pushMessage(t, 1, 0, dto.OutboxPush{UserID: 2, Title: "Reward's incoming!", Event: constants.RewardNotification}),
+				}, nil).Once()
+				prefMock.On("IsChannelEnabled", mock.Anything, nil, int64(2), constants.RewardNotification, constants.PushChannel).Return(true, nil).Once()
+				userMock.On("ListDeviceTokensByUserID", mock.Anything, int64(2)).Return([]string{"phone", "tablet"}, nil).Once()
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, nil, constants.OutboxPush, dto.OutboxPush{Token: "tablet", Title: "Reward's incoming!"}).Return(nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+			},
+			failedTokens:  map[string]error{"tablet": apperrors.InternalServerError},
+			expectedSent:  1,
+			expectedTitle: []string{"Reward's incoming!"},
+		},
+		{
+			name: "Push to a single device that failed is retried later",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					pushMessage(t, 1, 0, dto.OutboxPush{Token: "tablet", Title: "Reward's incoming!"}),
+				}, nil).Once()
+				outboxMock.On("MarkOutboxMessageFailed", mock.Anything, nil, int64(1), apperrors.InternalServerError.Error(), mock.Anything, false).Return(nil).Once()
+			},
+			failedTokens:  map[string]error{"tablet": apperrors.InternalServerError},
+			expectedSent:  0,
+			expectedTitle: []string{},
+		},
+		{
+			name: "Pushes for an event skip the users who turned them off",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					pushMessage(t, 1, 0, dto.OutboxPush{UserID: 2, Title: "Reward's incoming!", Event: constants.RewardNotification}),
+					pushMessage(t, 2, 0, dto.OutboxPush{UserID: 3, Title: "Reward's incoming!", Event: constants.RewardNotification}),
+				}, nil).Once()
+				prefMock.On("IsChannelEnabled", mock.Anything, nil, int64(2), constants.RewardNotification, constants.PushChannel).Return(false, nil).Once()
+				prefMock.On("IsChannelEnabled", mock.Anything, nil, int64(3), constants.RewardNotification, constants.PushChannel).Return(true, nil).Once()
+				userMock.On("ListDeviceTokensByUserID", mock.Anything, int64(3)).Return([]string{"phone"}, nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(2)).Return(nil).Once()
+			},
+			expectedSent:  2,
+			expectedTitle: []string{"Reward's incoming!"},
+		},
+		{
+			name: "Broadcast goes to the devices of the users who didn't turn it off",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					pushMessage(t, 1, 0, dto.OutboxPush{Topic: notification.AllUsersTopic, Title: "Appreciation", Event: constants.BroadcastNotification}),
+				}, nil).Once()
+				prefMock.On("ListDeviceTokensForEvent", mock.Anything, nil, constants.BroadcastNotification).Return([]string{"phone", "laptop"}, nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+			},
+			expectedSent:  1,
+			expectedTitle: []string{"Appreciation", "Appreciation"},
+		},
+		{
+			name: "Email is dropped when the user turned off emails for the event",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				payload, err := json.Marshal(dto.OutboxEmail{To: []string{"jane@example.com"}, Template: "missing.html", UserID: 2, Event: constants.AppreciationNotification})
+				assert.NoError(t, err)
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					{ID: 1, Kind: constants.OutboxEmail, Payload: payload, Status: constants.OutboxPending, MaxAttempts: 3},
+				}, nil).Once()
+				prefMock.On("IsChannelEnabled", mock.Anything, nil, int64(2), constants.AppreciationNotification, constants.EmailChannel).Return(false, nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+			},
+			expectedSent:  1,
+			expectedTitle: []string{},
+		},
+		{
+			name: "Email is dropped for a digest subscriber",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				payload, err := json.Marshal(dto.OutboxEmail{To: []string{"jane@example.com"}, Template: "missing.html", UserID: 2, Event: constants.BadgeNotification})
+				assert.NoError(t, err)
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					{ID: 1, Kind: constants.OutboxEmail, Payload: payload, Status: constants.OutboxPending, MaxAttempts: 3},
+				}, nil).Once()
+				prefMock.On("IsChannelEnabled", mock.Anything, nil, int64(2), constants.BadgeNotification, constants.EmailChannel).Return(true, nil).Once()
+				digestMock.On("IsSubscribedToDigest", mock.Anything, nil, int64(2)).Return(true, nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+			},
+			expectedSent:  1,
+			expectedTitle: []string{},
+		},
+		{
+			name: "Webhook post is delivered to the integration and dropped once the integration is removed",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					rw.WriteHeader(http.StatusOK)
+				}))
+				t.Cleanup(server.Close)
+
+				payload, err := json.Marshal(dto.OutboxWebhook{IntegrationID: 4, Post: dto.FeedPost{Title: "New appreciation"}})
+				assert.NoError(t, err)
+				removed, err := json.Marshal(dto.OutboxWebhook{IntegrationID: 5, Post: dto.FeedPost{Title: "New appreciation"}})
+				assert.NoError(t, err)
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					{ID: 1, Kind: constants.OutboxWebhook, Payload: payload, Status: constants.OutboxPending, MaxAttempts: 3},
+					{ID: 2, Kind: constants.OutboxWebhook, Payload: removed, Status: constants.OutboxPending, MaxAttempts: 3},
+				}, nil).Once()
+				integrationMock.On("GetIntegration", mock.Anything, nil, int64(4)).Return(repository.Integration{ID: 4, Kind: constants.SlackIntegration, WebhookURL: server.URL}, nil).Once()
+				integrationMock.On("GetIntegration", mock.Anything, nil, int64(5)).Return(repository.Integration{}, apperrors.IntegrationNotFound).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(2)).Return(nil).Once()
+			},
+			expectedSent:  2,
+			expectedTitle: []string{},
+		},
+		{
+			name: "Webhook event is signed for the subscription and every attempt is logged",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				body := []byte(`{"id":"evt","event":"reward.given","created_at":1,"data":{}}`)
+				server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					timestamp, err := strconv.ParseInt(req.Header.Get(webhooks.TimestampHeader), 10, 64)
+					assert.NoError(t, err)
+					if req.Header.Get(webhooks.SignatureHeader) != webhooks.Sign("secret", timestamp, body) {
+						rw.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					rw.WriteHeader(http.StatusNoContent)
+				}))
+				t.Cleanup(server.Close)
+
+				signed, err := json.Marshal(dto.OutboxWebhookEvent{DeliveryID: 7})
+				assert.NoError(t, err)
+				wrongSecret, err := json.Marshal(dto.OutboxWebhookEvent{DeliveryID: 8})
+				assert.NoError(t, err)
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					{ID: 1, Kind: constants.OutboxWebhookEvent, Payload: signed, Status: constants.OutboxPending, MaxAttempts: 3},
+					{ID: 2, Kind: constants.OutboxWebhookEvent, Payload: wrongSecret, Status: constants.OutboxPending, MaxAttempts: 3},
+				}, nil).Once()
+				webhookMock.On("GetWebhookDelivery", mock.Anything, nil, int64(7)).Return(repository.WebhookDelivery{ID: 7, SubscriptionID: 3, Event: constants.RewardGivenEvent, Payload: body}, nil).Once()
+				webhookMock.On("GetWebhookDelivery", mock.Anything, nil, int64(8)).Return(repository.WebhookDelivery{ID: 8, SubscriptionID: 4, Event: constants.RewardGivenEvent, Payload: body}, nil).Once()
+				webhookMock.On("GetWebhookSubscription", mock.Anything, nil, int64(3)).Return(repository.WebhookSubscription{ID: 3, URL: server.URL, Secret: "secret"}, nil).Once()
+				webhookMock.On("GetWebhookSubscription", mock.Anything, nil, int64(4)).Return(repository.WebhookSubscription{ID: 4, URL: server.URL, Secret: "rotated"}, nil).Once()
+				webhookMock.On("RecordWebhookAttempt", mock.Anything, nil, int64(7), http.StatusNoContent, "").Return(nil).Once()
+				webhookMock.On("RecordWebhookAttempt", mock.Anything, nil, int64(8), http.StatusUnauthorized, mock.Anything).Return(nil).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+				outboxMock.On("MarkOutboxMessageFailed", mock.Anything, nil, int64(2), mock.Anything, mock.Anything, false).Return(nil).Once()
+			},
+			expectedSent:  1,
+			expectedTitle: []string{},
+		},
+		{
+			name: "Webhook event of a removed subscription is dropped",
+			setup: func(outboxMock *mocks.OutboxStorer, userMock *mocks.UserStorer, prefMock *mocks.NotificationPreferenceStorer, digestMock *mocks.DigestStorer, integrationMock *mocks.IntegrationStorer, webhookMock *mocks.WebhookStorer) {
+				payload, err := json.Marshal(dto.OutboxWebhookEvent{DeliveryID: 7})
+				assert.NoError(t, err)
+				outboxMock.On("ClaimDueOutboxMessages", mock.Anything, nil, deliveryBatchSize, mock.Anything).Return([]repository.OutboxMessage{
+					{ID: 1, Kind: constants.OutboxWebhookEvent, Payload: payload, Status: constants.OutboxPending, MaxAttempts: 3},
+				}, nil).Once()
+				webhookMock.On("GetWebhookDelivery", mock.Anything, nil, int64(7)).Return(repository.WebhookDelivery{}, apperrors.WebhookDeliveryNotFound).Once()
+				outboxMock.On("MarkOutboxMessageSent", mock.Anything, nil, int64(1)).Return(nil).Once()
+			},
+			expectedSent:  1,
+			expectedTitle: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outboxMock := mocks.NewOutboxStorer(t)
+			userMock := mocks.NewUserStorer(t)
+			prefMock := mocks.NewNotificationPreferenceStorer(t)
+			digestMock := mocks.NewDigestStorer(t)
+			integrationMock := mocks.NewIntegrationStorer(t)
+			webhookMock := mocks.NewWebhookStorer(t)
+			notificationSvc := notification.NewRecordingService()
+			for token, err := range test.failedTokens {
+				notificationSvc.FailToken(token, err)
+			}
+			test.setup(outboxMock, userMock, prefMock, digestMock, integrationMock, webhookMock)
+
+			service := NewService(outboxMock, userMock, prefMock, digestMock, integrationMock, webhookMock, notificationSvc)
+			sent, err := service.DeliverDue(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSent, sent)
+
+			titles := make([]string, 0)
+			for _, msg := range notificationSvc.Sent() {
+				titles = append(titles, msg.Message.Title)
+			}
+			assert.Equal(t, test.expectedTitle, titles)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, backoff(1))
+	assert.Equal(t, time.Minute, backoff(2))
+	assert.Equal(t, 4*time.Minute, backoff(4))
+	assert.Equal(t, time.Hour, backoff(20))
+}
@@ -0,0 +1,37 @@
+package preferences
+
+import (
+	"slices"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+func mapNotificationPreferenceDbToSvc(dbPreference repository.NotificationPreference) dto.NotificationPreference {
+	channels := make([]string, 0, len(constants.NotificationChannels))
+	if dbPreference.Push {
+		channels = append(channels, constants.PushChannel)
+	}
+	if dbPreference.Email {
+		channels = append(channels, constants.EmailChannel)
+	}
+	if dbPreference.InApp {
+		channels = append(channels, constants.InAppChannel)
+	}
+
+	return dto.NotificationPreference{
+		Event:    dbPreference.Event,
+		Channels: channels,
+	}
+}
+
+func mapNotificationPreferenceSvcToDb(userID int64, preference dto.NotificationPreference) repository.NotificationPreference {
+	return repository.NotificationPreference{
+		UserID: userID,
+		Event:  preference.Event,
+		Push:   slices.Contains(preference.Channels, constants.PushChannel),
+		Email:  slices.Contains(preference.Channels, constants.EmailChannel),
+		InApp:  slices.Contains(preference.Channels, constants.InAppChannel),
+	}
+}
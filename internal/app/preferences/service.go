@@ -0,0 +1,90 @@
+package preferences
+
+import (
+	"context"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type service struct {
+	preferenceRepo repository.NotificationPreferenceStorer
+}
+
+// Service manages the channels the logged in user gets each notification event on
+type Service interface {
+	ListNotificationPreferences(ctx context.Context) ([]dto.NotificationPreference, error)
+	UpdateNotificationPreferences(ctx context.Context, req dto.UpdateNotificationPreferencesReq) ([]dto.NotificationPreference, error)
+}
+
+func NewService(preferenceRepo repository.NotificationPreferenceStorer) Service {
+	return &service{
+		preferenceRepo: preferenceRepo,
+	}
+}
+
+// ListNotificationPreferences returns every event, the ones the user never changed are on for every channel
+func (prefSvc *service) ListNotificationPreferences(ctx context.Context) ([]dto.NotificationPreference, error) {
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPreferences, err := prefSvc.preferenceRepo.ListNotificationPreferences(ctx, nil, userID)
+	if err != nil {
+		logger.Errorf(ctx, "preferenceService: ListNotificationPreferences: err: %v", err)
+		return nil, err
+	}
+
+	saved := make(map[string]repository.NotificationPreference, len(dbPreferences))
+	for _, preference := range dbPreferences {
+		saved[preference.Event] = preference
+	}
+
+	res := make([]dto.NotificationPreference, 0, len(constants.NotificationEvents))
+	for _, event := range constants.NotificationEvents {
+		preference, ok := saved[event]
+		if !ok {
+			preference = repository.NotificationPreference{UserID: userID, Event: event, Push: true, Email: true, InApp: true}
+		}
+		res = append(res, mapNotificationPreferenceDbToSvc(preference))
+	}
+	return res, nil
+}
+
+// UpdateNotificationPreferences replaces the channels of the events in the request, other events are left as they are
+func (prefSvc *service) UpdateNotificationPreferences(ctx context.Context, req dto.UpdateNotificationPreferencesReq) ([]dto.NotificationPreference, error) {
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug(ctx, "preferenceService: UpdateNotificationPreferences: userID: ", userID, " req: ", req)
+	preferences := make([]repository.NotificationPreference, 0, len(req.Preferences))
+	for _, preference := range req.Preferences {
+		preferences = append(preferences, mapNotificationPreferenceSvcToDb(userID, preference))
+	}
+
+	err = prefSvc.preferenceRepo.UpsertNotificationPreferences(ctx, nil, preferences)
+	if err != nil {
+		logger.Errorf(ctx, "preferenceService: UpsertNotificationPreferences: err: %v", err)
+		return nil, err
+	}
+
+	return prefSvc.ListNotificationPreferences(ctx)
+}
+
+func currentUserID(ctx context.Context) (int64, error) {
+	data := ctx.Value(constants.UserId)
+	userID, ok := data.(int64)
+	if !ok {
+		logger.Error(ctx, "preferenceService: err in parsing userid from token")
+		return 0, apperrors.InternalServer
+	}
+	return userID, nil
+}
@@ -0,0 +1,94 @@
+package preferences
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestListNotificationPreferences(t *testing.T) {
+	prefMock := mocks.NewNotificationPreferenceStorer(t)
+	prefMock.On("ListNotificationPreferences", mock.Anything, nil, int64(7)).Return([]repository.NotificationPreference{
+		{UserID: 7, Event: constants.AppreciationNotification, Push: true},
+		{UserID: 7, Event: constants.BroadcastNotification},
+	}, nil).Once()
+	service := NewService(prefMock)
+
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(7))
+	result, err := service.ListNotificationPreferences(ctx)
+
+	assert.NoError(t, err)
+	every := []string{constants.PushChannel, constants.EmailChannel, constants.InAppChannel}
+	assert.Equal(t, []dto.NotificationPreference{
+		{Event: constants.AppreciationNotification, Channels: []string{constants.PushChannel}},
+		{Event: constants.RewardNotification, Channels: every},
+		{Event: constants.BadgeNotification, Channels: every},
+		{Event: constants.ReportOutcomeNotification, Channels: every},
+		{Event: constants.QuotaRefillNotification, Channels: every},
+		{Event: constants.CommentNotification, Channels: every},
+		{Event: constants.BroadcastNotification, Channels: []string{}},
+	}, result)
+}
+
+func TestUpdateNotificationPreferences(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             dto.UpdateNotificationPreferencesReq
+		setup           func(prefMock *mocks.NotificationPreferenceStorer)
+		isErrorExpected bool
+	}{
+		{
+			name: "Channels of the events in the request are replaced",
+			req: dto.UpdateNotificationPreferencesReq{Preferences: []dto.NotificationPreference{
+				{Event: constants.AppreciationNotification, Channels: []string{constants.InAppChannel}},
+				{Event: constants.BroadcastNotification, Channels: []string{}},
+			}},
+			setup: func(prefMock *mocks.NotificationPreferenceStorer) {
+				prefMock.On("UpsertNotificationPreferences", mock.Anything, nil, []repository.NotificationPreference{
+					{UserID: 7, Event: constants.AppreciationNotification, InApp: true},
+					{UserID: 7, Event: constants.BroadcastNotification},
+				}).Return(nil).Once()
+				prefMock.On("ListNotificationPreferences", mock.Anything, nil, int64(7)).Return([]repository.NotificationPreference{}, nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Error in saving the preferences",
+			req: dto.UpdateNotificationPreferencesReq{Preferences: []dto.NotificationPreference{
+				{Event: constants.RewardNotification, Channels: []string{constants.PushChannel}},
+			}},
+			setup: func(prefMock *mocks.NotificationPreferenceStorer) {
+				prefMock.On("UpsertNotificationPreferences", mock.Anything, nil, mock.Anything).Return(apperrors.InternalServer).Once()
+			},
+			isErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prefMock := mocks.NewNotificationPreferenceStorer(t)
+			test.setup(prefMock)
+			service := NewService(prefMock)
+
+			ctx := context.WithValue(context.Background(), constants.UserId, int64(7))
+			_, err := service.UpdateNotificationPreferences(ctx, test.req)
+
+			if (err != nil) != test.isErrorExpected {
+				t.Errorf("Test Failed, expected error to be %v, but got err %v", test.isErrorExpected, err != nil)
+			}
+		})
+	}
+}
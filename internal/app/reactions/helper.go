@@ -0,0 +1,23 @@
+package reactions
+
+import (
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+func mapReactionSummaryDbToSvc(dbSummary repository.ReactionSummary) dto.ReactionSummary {
+	summary := dto.ReactionSummary{
+		AppreciationID: dbSummary.AppreciationID,
+		ReactionCounts: map[string]int64(dbSummary.ReactionCounts),
+		MyReactions:    []string(dbSummary.MyReactions),
+	}
+
+	if summary.ReactionCounts == nil {
+		summary.ReactionCounts = map[string]int64{}
+	}
+	if summary.MyReactions == nil {
+		summary.MyReactions = []string{}
+	}
+
+	return summary
+}
@@ -0,0 +1,85 @@
+package reactions
+
+import (
+	"context"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type service struct {
+	reactionRepo     repository.ReactionStorer
+	appreciationRepo repository.AppreciationStorer
+}
+
+// Service contains all emoji reaction related operations
+type Service interface {
+	AddReaction(ctx context.Context, reaction dto.Reaction) (dto.ReactionSummary, error)
+	RemoveReaction(ctx context.Context, reaction dto.Reaction) (dto.ReactionSummary, error)
+}
+
+func NewService(reactionRepo repository.ReactionStorer, appreciationRepo repository.AppreciationStorer) Service {
+	return &service{
+		reactionRepo:     reactionRepo,
+		appreciationRepo: appreciationRepo,
+	}
+}
+
+// AddReaction adds the reaction of the logged in user, reactions do not spend any reward quota
+func (rctSvc *service) AddReaction(ctx context.Context, reaction dto.Reaction) (dto.ReactionSummary, error) {
+
+	logger.Debug(ctx, "reactionService: AddReaction: ", reaction)
+	userId, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "reactionService: err in parsing userid from token")
+		return dto.ReactionSummary{}, apperrors.InternalServer
+	}
+	reaction.ReactedBy = userId
+
+	_, err := rctSvc.appreciationRepo.GetAppreciationById(ctx, nil, int32(reaction.AppreciationID))
+	if err != nil {
+		logger.Errorf(ctx, "reactionService: GetAppreciationById: err: %v", err)
+		return dto.ReactionSummary{}, err
+	}
+
+	err = rctSvc.reactionRepo.AddReaction(ctx, nil, reaction)
+	if err != nil {
+		logger.Errorf(ctx, "reactionService: AddReaction: err: %v", err)
+		return dto.ReactionSummary{}, err
+	}
+
+	return rctSvc.getReactionSummary(ctx, reaction.AppreciationID, userId)
+}
+
+func (rctSvc *service) RemoveReaction(ctx context.Context, reaction dto.Reaction) (dto.ReactionSummary, error) {
+
+	logger.Debug(ctx, "reactionService: RemoveReaction: ", reaction)
+	userId, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "reactionService: err in parsing userid from token")
+		return dto.ReactionSummary{}, apperrors.InternalServer
+	}
+	reaction.ReactedBy = userId
+
+	err := rctSvc.reactionRepo.RemoveReaction(ctx, nil, reaction)
+	if err != nil {
+		logger.Errorf(ctx, "reactionService: RemoveReaction: err: %v", err)
+		return dto.ReactionSummary{}, err
+	}
+
+	return rctSvc.getReactionSummary(ctx, reaction.AppreciationID, userId)
+}
+
+func (rctSvc *service) getReactionSummary(ctx context.Context, appreciationId int64, userId int64) (dto.ReactionSummary, error) {
+
+	summary, err := rctSvc.reactionRepo.GetReactionSummary(ctx, nil, appreciationId, userId)
+	if err != nil {
+		logger.Errorf(ctx, "reactionService: GetReactionSummary: err: %v", err)
+		return dto.ReactionSummary{}, err
+	}
+
+	return mapReactionSummaryDbToSvc(summary), nil
+}
@@ -0,0 +1,157 @@
+package reactions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	"github.com/lib/pq"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestAddReaction(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		reaction        dto.Reaction
+		setup           func(rctMock *mocks.ReactionStorer, apprMock *mocks.AppreciationStorer)
+		isErrorExpected bool
+		expectedResult  dto.ReactionSummary
+		expectedError   error
+	}{
+		{
+			name:     "Success",
+			ctx:      context.WithValue(context.Background(), constants.UserId, int64(1)),
+			reaction: dto.Reaction{AppreciationID: 1, Reaction: "clap"},
+			setup: func(rctMock *mocks.ReactionStorer, apprMock *mocks.AppreciationStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1}, nil)
+				rctMock.On("AddReaction", mock.Anything, nil, dto.Reaction{AppreciationID: 1, Reaction: "clap", ReactedBy: 1}).Return(nil)
+				rctMock.On("GetReactionSummary", mock.Anything, nil, int64(1), int64(1)).Return(repository.ReactionSummary{
+					AppreciationID: 1,
+					ReactionCounts: repository.ReactionCounts{"clap": 3, "heart": 1},
+					MyReactions:    pq.StringArray{"clap"},
+				}, nil)
+			},
+			isErrorExpected: false,
+			expectedResult: dto.ReactionSummary{
+				AppreciationID: 1,
+				ReactionCounts: map[string]int64{"clap": 3, "heart": 1},
+				MyReactions:    []string{"clap"},
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Error in parsing userid from token",
+			ctx:      context.Background(),
+			reaction: dto.Reaction{AppreciationID: 1, Reaction: "clap"},
+			setup: func(rctMock *mocks.ReactionStorer, apprMock *mocks.AppreciationStorer) {
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.ReactionSummary{},
+			expectedError:   apperrors.InternalServer,
+		},
+		{
+			name:     "Appreciation not found",
+			ctx:      context.WithValue(context.Background(), constants.UserId, int64(1)),
+			reaction: dto.Reaction{AppreciationID: 1, Reaction: "clap"},
+			setup: func(rctMock *mocks.ReactionStorer, apprMock *mocks.AppreciationStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{}, apperrors.AppreciationNotFound)
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.ReactionSummary{},
+			expectedError:   apperrors.AppreciationNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rctMock := mocks.NewReactionStorer(t)
+			apprMock := mocks.NewAppreciationStorer(t)
+			service := NewService(rctMock, apprMock)
+
+			test.setup(rctMock, apprMock)
+
+			result, err := service.AddReaction(test.ctx, test.reaction)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestRemoveReaction(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		reaction        dto.Reaction
+		setup           func(rctMock *mocks.ReactionStorer)
+		isErrorExpected bool
+		expectedResult  dto.ReactionSummary
+		expectedError   error
+	}{
+		{
+			name:     "Success",
+			ctx:      context.WithValue(context.Background(), constants.UserId, int64(1)),
+			reaction: dto.Reaction{AppreciationID: 1, Reaction: "clap"},
+			setup: func(rctMock *mocks.ReactionStorer) {
+				rctMock.On("RemoveReaction", mock.Anything, nil, dto.Reaction{AppreciationID: 1, Reaction: "clap", ReactedBy: 1}).Return(nil)
+				rctMock.On("GetReactionSummary", mock.Anything, nil, int64(1), int64(1)).Return(repository.ReactionSummary{
+					AppreciationID: 1,
+					ReactionCounts: repository.ReactionCounts{},
+				}, nil)
+			},
+			isErrorExpected: false,
+			expectedResult: dto.ReactionSummary{
+				AppreciationID: 1,
+				ReactionCounts: map[string]int64{},
+				MyReactions:    []string{},
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Reaction not found",
+			ctx:      context.WithValue(context.Background(), constants.UserId, int64(1)),
+			reaction: dto.Reaction{AppreciationID: 1, Reaction: "heart"},
+			setup: func(rctMock *mocks.ReactionStorer) {
+				rctMock.On("RemoveReaction", mock.Anything, nil, dto.Reaction{AppreciationID: 1, Reaction: "heart", ReactedBy: 1}).Return(apperrors.ReactionNotFound)
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.ReactionSummary{},
+			expectedError:   apperrors.ReactionNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rctMock := mocks.NewReactionStorer(t)
+			apprMock := mocks.NewAppreciationStorer(t)
+			service := NewService(rctMock, apprMock)
+
+			test.setup(rctMock)
+
+			result, err := service.RemoveReaction(test.ctx, test.reaction)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedResult, result)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/joshsoftware/peerly-backend/internal/app/email"
+	"github.com/joshsoftware/peerly-backend/internal/app/feed"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
@@ -18,21 +19,27 @@ type service struct {
 	reportAppreciationRepo repository.ReportAppreciationStorer
 	userRepo               repository.UserStorer
 	appreciationRepo       repository.AppreciationStorer
+	outboxRepo             repository.OutboxStorer
+	notificationRepo       repository.NotificationStorer
+	feedBroker             feed.Broker
 }
 
 type Service interface {
 	ReportAppreciation(ctx context.Context, reqData dto.ReportAppreciationReq) (resp dto.ReportAppricaitionResp, err error)
-	ListReportedAppreciations(ctx context.Context, quarter int, year int) (dto.ListReportedAppreciationsResponse, error)
+	ListReportedAppreciations(ctx context.Context, quarter int, year int, filter dto.OrgFilter) (dto.ListReportedAppreciationsResponse, error)
 	GetReportedAppreciationByAppreciationID(ctx context.Context, appreciationID int64) (dto.ReportedAppreciation, error)
 	DeleteAppreciation(ctx context.Context, reqData dto.ModerationReq) (err error)
 	ResolveAppreciation(ctx context.Context, reqData dto.ModerationReq) (err error)
 }
 
-func NewService(reportAppreciationRepo repository.ReportAppreciationStorer, userRepo repository.UserStorer, appreciationRepo repository.AppreciationStorer) Service {
+func NewService(reportAppreciationRepo repository.ReportAppreciationStorer, userRepo repository.UserStorer, appreciationRepo repository.AppreciationStorer, outboxRepo repository.OutboxStorer, notificationRepo repository.NotificationStorer, feedBroker feed.Broker) Service {
 	return &service{
 		reportAppreciationRepo: reportAppreciationRepo,
 		userRepo:               userRepo,
 		appreciationRepo:       appreciationRepo,
+		outboxRepo:             outboxRepo,
+		notificationRepo:       notificationRepo,
+		feedBroker:             feedBroker,
 	}
 }
 
@@ -73,14 +80,13 @@ func (rs *service) ReportAppreciation(ctx context.Context, reqData dto.ReportApp
 		err = apperrors.InternalServerError
 		return
 	}
-	if usersData.Sender == reqData.ReportedBy || usersData.Receiver == reqData.ReportedBy {
+	if usersData.Sender == reqData.ReportedBy || usersData.Receiver == reqData.ReportedBy || usersData.IsReceiver {
 		err = apperrors.CannotReportOwnAppreciation
 		return
 	}
 
-	resp, err = rs.reportAppreciationRepo.ReportAppreciation(ctx, reqData)
+	resp, err = rs.createReport(ctx, reqData)
 	if err != nil {
-		err = apperrors.InternalServerError
 		return
 	}
 
@@ -111,13 +117,60 @@ func (rs *service) ReportAppreciation(ctx context.Context, reqData dto.ReportApp
 	return
 }
 
-func (rs *service) ListReportedAppreciations(ctx context.Context, quarter int, year int) (dto.ListReportedAppreciationsResponse, error) {
+// createReport saves the report together with its report.created event, the event is only delivered once the report is
+// committed and the report is only saved when the event is queued
+func (rs *service) createReport(ctx context.Context, reqData dto.ReportAppreciationReq) (resp dto.ReportAppricaitionResp, err error) {
+
+	tx, err := rs.reportAppreciationRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "reportAppreciationService: BeginTx: err: %v", err)
+		return
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := rs.reportAppreciationRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	resp, err = rs.reportAppreciationRepo.ReportAppreciation(ctx, tx, reqData)
+	if err != nil {
+		err = apperrors.InternalServerError
+		return
+	}
+
+	err = rs.outboxRepo.EnqueueWebhookEvent(ctx, tx, constants.ReportCreatedEvent, dto.ReportEventData{
+		ID:               resp.Id,
+		AppreciationID:   resp.AppreciationId,
+		ReportingComment: resp.ReportingComment,
+		ReportedBy:       resp.ReportedBy,
+		ReportedAt:       resp.ReportedAt,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "reportAppreciationService: EnqueueWebhookEvent: err: %v", err)
+		return
+	}
+	return
+}
+
+func (rs *service) ListReportedAppreciations(ctx context.Context, quarter int, year int, filter dto.OrgFilter) (dto.ListReportedAppreciationsResponse, error) {
 
 	var resp dto.ListReportedAppreciationsResponse
 
 	var appreciationList []dto.ReportedAppreciation
 
-	appreciations, err := rs.reportAppreciationRepo.ListReportedAppreciations(ctx, quarter, year)
+	appreciations, err := rs.reportAppreciationRepo.ListReportedAppreciations(ctx, quarter, year, filter)
 	if err != nil {
 		logger.Error(ctx, err.Error())
 		err = apperrors.InternalServerError
@@ -255,12 +308,6 @@ func (rs *service) DeleteAppreciation(ctx context.Context, reqData dto.Moderatio
 	}
 
 	reqData.AppreciationId = appreciation.Appreciation_id
-	err = rs.reportAppreciationRepo.DeleteAppreciation(ctx, reqData)
-	if err != nil {
-		logger.Error(ctx, err.Error())
-		err = apperrors.InternalServerError
-		return
-	}
 
 	senderDataReq := dto.GetUserByIdReq{
 		UserId:          appreciation.Sender,
@@ -292,6 +339,42 @@ func (rs *service) DeleteAppreciation(ctx context.Context, reqData dto.Moderatio
 		return
 	}
 
+	tx, err := rs.reportAppreciationRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "reportAppreciationService: BeginTx: err: %v", err)
+		return
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := rs.reportAppreciationRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+		if err == nil && rvr == nil {
+			rs.feedBroker.Publish(ctx, dto.FeedEvent{
+				Event: constants.AppreciationDeletedEvent,
+				Data:  dto.AppreciationDeletedEventData{ID: reqData.AppreciationId},
+			})
+		}
+	}()
+
+	err = rs.reportAppreciationRepo.DeleteAppreciation(ctx, tx, reqData)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		err = apperrors.InternalServerError
+		return
+	}
+
 	seconds := appreciation.CreatedAt / 1000
 	nanoseconds := (appreciation.CreatedAt % 1000) * 1e6
 
@@ -308,10 +391,34 @@ func (rs *service) DeleteAppreciation(ctx context.Context, reqData dto.Moderatio
 		Icon:             config.PeerlyBaseUrl() + constants.CheckIconLogo,
 	}
 
-	fmt.Println("Reporter mail: ", reporter.Email)
-	err = sendDeleteEmail(reporter.Email, sender.Email, receiver.Email, templateData)
-	// err = sendDeleteEmail("sharayumarwadi11@gmail.com", templateData)
+	// the emails are delivered by the outbox worker once the moderation is committed
+	err = rs.enqueueDeleteEmails(ctx, tx, reporter, sender, receiver, templateData)
+	if err != nil {
+		return
+	}
+
+	err = rs.addReportOutcomeToInbox(ctx, tx, []dto.Notification{
+		{
+			UserID: appreciation.ReportedBy,
+			Title:  "Reported appreciation removed",
+			Body:   fmt.Sprintf("The appreciation you reported from %s to %s has been removed", templateData.AppreciationBy, templateData.AppreciationTo),
+		},
+		{
+			UserID: appreciation.Sender,
+			Title:  "Appreciation removed",
+			Body:   fmt.Sprintf("Your appreciation to %s has been removed after moderation", templateData.AppreciationTo),
+		},
+		{
+			UserID: appreciation.Receiver,
+			Title:  "Appreciation removed",
+			Body:   fmt.Sprintf("The appreciation you received from %s has been removed after moderation", templateData.AppreciationBy),
+		},
+	})
+	if err != nil {
+		return
+	}
 
+	err = rs.enqueueResolvedEvents(ctx, tx, reqData, constants.ReportOutcomeDeleted)
 	return
 }
 
@@ -399,12 +506,6 @@ func (rs *service) ResolveAppreciation(ctx context.Context, reqData dto.Moderati
 	}
 
 	reqData.AppreciationId = appreciation.Appreciation_id
-	err = rs.reportAppreciationRepo.ResolveAppreciation(ctx, reqData)
-	if err != nil {
-		logger.Error(ctx, err.Error())
-		err = apperrors.InternalServerError
-		return
-	}
 
 	senderDataReq := dto.GetUserByIdReq{
 		UserId:          appreciation.Sender,
@@ -436,6 +537,36 @@ func (rs *service) ResolveAppreciation(ctx context.Context, reqData dto.Moderati
 		return
 	}
 
+	tx, err := rs.reportAppreciationRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "reportAppreciationService: BeginTx: err: %v", err)
+		return
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := rs.reportAppreciationRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	err = rs.reportAppreciationRepo.ResolveAppreciation(ctx, tx, reqData)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		err = apperrors.InternalServerError
+		return
+	}
+
 	templateData := dto.ResolveAppreciationMail{
 		ModeratorComment: reqData.ModeratorComment,
 		AppreciationBy:   sender.FirstName + " " + sender.LastName,
@@ -445,51 +576,101 @@ func (rs *service) ResolveAppreciation(ctx context.Context, reqData dto.Moderati
 		Icon:             config.PeerlyBaseUrl() + constants.CheckIconLogo,
 	}
 
-	fmt.Println("Reporter mail: ", reporter.Email)
-	err = sendResolveEmail(reporter.Email, templateData)
+	// the email is delivered by the outbox worker once the moderation is committed
+	err = rs.enqueueResolveEmail(ctx, tx, reporter, templateData)
+	if err != nil {
+		return
+	}
+
+	err = rs.addReportOutcomeToInbox(ctx, tx, []dto.Notification{
+		{
+			UserID:         appreciation.ReportedBy,
+			Title:          "Reported appreciation reviewed",
+			Body:           fmt.Sprintf("The appreciation you reported from %s to %s has been reviewed and kept", templateData.AppreciationBy, templateData.AppreciationTo),
+			AppreciationID: appreciation.Appreciation_id,
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	err = rs.enqueueResolvedEvents(ctx, tx, reqData, constants.ReportOutcomeKept)
 	return
 }
 
-func sendDeleteEmail(reporterEmail string, senderEmail string, receiverEmail string, templateData dto.DeleteAppreciationMail) error {
-
-	ctx := context.Background()
-	logger.Info(ctx, "reporter email: ---------> ", reporterEmail)
-	mailReq := email.NewMail([]string{reporterEmail}, []string{}, []string{}, "Results of reported appreciation")
-	mailReq.ParseTemplate("./internal/app/email/templates/deleteAppreciation.html", templateData)
-	err := mailReq.Send()
+// enqueueResolvedEvents sends report.resolved with the outcome, and appreciation.deleted when the moderator removed it
+func (rs *service) enqueueResolvedEvents(ctx context.Context, tx repository.Transaction, reqData dto.ModerationReq, outcome string) error {
+	err := rs.outboxRepo.EnqueueWebhookEvent(ctx, tx, constants.ReportResolvedEvent, dto.ReportResolvedEventData{
+		ResolutionID:     reqData.ResolutionId,
+		AppreciationID:   reqData.AppreciationId,
+		Outcome:          outcome,
+		ModeratorComment: reqData.ModeratorComment,
+		ModeratedBy:      reqData.ModeratedBy,
+	})
 	if err != nil {
-		logger.Errorf(ctx, "err: %v", err)
+		logger.Errorf(ctx, "reportAppreciationService: EnqueueWebhookEvent: err: %v", err)
 		return err
 	}
+	if outcome != constants.ReportOutcomeDeleted {
+		return nil
+	}
 
-	logger.Info(ctx, "sender email: ---------> ", senderEmail)
-	mailReq = email.NewMail([]string{senderEmail}, []string{}, []string{}, "Results of reported appreciation")
-	mailReq.ParseTemplate("./internal/app/email/templates/senderDeleteEmail.html", templateData)
-	err = mailReq.Send()
+	err = rs.outboxRepo.EnqueueWebhookEvent(ctx, tx, constants.AppreciationDeletedEvent, dto.AppreciationDeletedEventData{ID: reqData.AppreciationId})
 	if err != nil {
-		logger.Errorf(ctx, "err: %v", err)
+		logger.Errorf(ctx, "reportAppreciationService: EnqueueWebhookEvent: err: %v", err)
 		return err
 	}
+	return nil
+}
+
+func (rs *service) enqueueDeleteEmails(ctx context.Context, tx repository.Transaction, reporter dto.GetUserByIdResp, sender dto.GetUserByIdResp, receiver dto.GetUserByIdResp, templateData dto.DeleteAppreciationMail) error {
 
-	logger.Info(ctx, "receiver email: ---------> ", receiverEmail)
-	mailReq = email.NewMail([]string{receiverEmail}, []string{}, []string{}, "Results of reported appreciation")
-	mailReq.ParseTemplate("./internal/app/email/templates/receiverDeleteEmail.html", templateData)
-	err = mailReq.Send()
+	mails := []dto.OutboxEmail{
+		{To: []string{reporter.Email}, UserID: reporter.UserId, Template: "./internal/app/email/templates/deleteAppreciation.html"},
+		{To: []string{sender.Email}, UserID: sender.UserId, Template: "./internal/app/email/templates/senderDeleteEmail.html"},
+		{To: []string{receiver.Email}, UserID: receiver.UserId, Template: "./internal/app/email/templates/receiverDeleteEmail.html"},
+	}
+
+	for _, mail := range mails {
+		logger.Info(ctx, "delete appreciation email: ---------> ", mail.To)
+		mail.Subject = "Results of reported appreciation"
+		mail.Data = templateData
+		mail.Event = constants.ReportOutcomeNotification
+		err := rs.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxEmail, mail)
+		if err != nil {
+			logger.Errorf(ctx, "err: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (rs *service) enqueueResolveEmail(ctx context.Context, tx repository.Transaction, reporter dto.GetUserByIdResp, templateData dto.ResolveAppreciationMail) error {
+
+	logger.Info(ctx, "report sender email: ---------> ", reporter.Email)
+	err := rs.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxEmail, dto.OutboxEmail{
+		To:       []string{reporter.Email},
+		Subject:  "Results of reported appreciation",
+		Template: "./internal/app/email/templates/resolveAppreciation.html",
+		Data:     templateData,
+		UserID:   reporter.UserId,
+		Event:    constants.ReportOutcomeNotification,
+	})
 	if err != nil {
 		logger.Errorf(ctx, "err: %v", err)
 		return err
 	}
-
 	return nil
 }
 
-func sendResolveEmail(senderEmail string, templateData dto.ResolveAppreciationMail) error {
+func (rs *service) addReportOutcomeToInbox(ctx context.Context, tx repository.Transaction, notifications []dto.Notification) error {
 
-	ctx := context.Background()
-	logger.Info(ctx, "report sender email: ---------> ", senderEmail)
-	mailReq := email.NewMail([]string{senderEmail}, []string{}, []string{}, "Results of reported appreciation")
-	mailReq.ParseTemplate("./internal/app/email/templates/resolveAppreciation.html", templateData)
-	err := mailReq.Send()
+	for i := range notifications {
+		notifications[i].Type = constants.ReportOutcomeNotification
+	}
+
+	err := rs.notificationRepo.CreateNotifications(ctx, tx, notifications)
 	if err != nil {
 		logger.Errorf(ctx, "err: %v", err)
 		return err
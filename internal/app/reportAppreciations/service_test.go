@@ -2,27 +2,37 @@ package reportappreciations
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
+	"github.com/joshsoftware/peerly-backend/internal/app/feed"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
 	"github.com/joshsoftware/peerly-backend/internal/repository"
 	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
 )
 
+func init() {
+	log.Logger = l.New()
+}
+
 func TestReportAppreciation(t *testing.T) {
 	reportAppreciationRepo := mocks.NewReportAppreciationStorer(t)
 	userRepo := mocks.NewUserStorer(t)
 	appreciationRepo := mocks.NewAppreciationStorer(t)
-	service := NewService(reportAppreciationRepo, userRepo, appreciationRepo)
+	outboxRepo := mocks.NewOutboxStorer(t)
+	service := NewService(reportAppreciationRepo, userRepo, appreciationRepo, outboxRepo, mocks.NewNotificationStorer(t), feed.NewBroker())
 
 	tests := []struct {
 		name            string
 		userId          int64
 		reqData         dto.ReportAppreciationReq
-		setup           func(reportAppreciationMock *mocks.ReportAppreciationStorer)
+		setup           func(reportAppreciationMock *mocks.ReportAppreciationStorer, outboxMock *mocks.OutboxStorer)
 		isErrorExpected bool
 	}{
 		{
@@ -32,14 +42,20 @@ func TestReportAppreciation(t *testing.T) {
 				ReportingComment: "reporting comment",
 				AppreciationId:   4,
 			},
-			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer) {
+			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer, outboxMock *mocks.OutboxStorer) {
 				reportAppreciationMock.On("CheckAppreciation", mock.Anything, mock.Anything).Return(true, nil).Once()
 				reportAppreciationMock.On("CheckDuplicateReport", mock.Anything, mock.Anything).Return(false, nil).Once()
 				reportAppreciationMock.On("GetSenderAndReceiver", mock.Anything, mock.Anything).Return(dto.GetSenderAndReceiverResp{
 					Sender:   1004,
 					Receiver: 1100,
 				}, nil).Once()
-				reportAppreciationMock.On("ReportAppreciation", mock.Anything, mock.Anything).Return(dto.ReportAppricaitionResp{}, nil).Once()
+				tx := &sql.Tx{}
+				reportAppreciationMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				reportAppreciationMock.On("ReportAppreciation", mock.Anything, tx, mock.Anything).Return(dto.ReportAppricaitionResp{Id: 9, AppreciationId: 4}, nil).Once()
+				outboxMock.On("EnqueueWebhookEvent", mock.Anything, tx, constants.ReportCreatedEvent, mock.MatchedBy(func(data dto.ReportEventData) bool {
+					return data.ID == 9 && data.AppreciationID == 4
+				})).Return(nil).Once()
+				reportAppreciationMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
 			},
 			isErrorExpected: false,
 		},
@@ -50,14 +66,39 @@ func TestReportAppreciation(t *testing.T) {
 				ReportingComment: "reporting comment",
 				AppreciationId:   4,
 			},
-			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer) {
+			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer, outboxMock *mocks.OutboxStorer) {
+				reportAppreciationMock.On("CheckAppreciation", mock.Anything, mock.Anything).Return(true, nil).Once()
+				reportAppreciationMock.On("CheckDuplicateReport", mock.Anything, mock.Anything).Return(false, nil).Once()
+				reportAppreciationMock.On("GetSenderAndReceiver", mock.Anything, mock.Anything).Return(dto.GetSenderAndReceiverResp{
+					Sender:   1004,
+					Receiver: 1100,
+				}, nil).Once()
+				tx := &sql.Tx{}
+				reportAppreciationMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				reportAppreciationMock.On("ReportAppreciation", mock.Anything, tx, mock.Anything).Return(dto.ReportAppricaitionResp{}, apperrors.InternalServerError).Once()
+				reportAppreciationMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+		},
+		{
+			name:   "Report is rolled back when report.created can't be queued",
+			userId: 1334,
+			reqData: dto.ReportAppreciationReq{
+				ReportingComment: "reporting comment",
+				AppreciationId:   4,
+			},
+			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer, outboxMock *mocks.OutboxStorer) {
 				reportAppreciationMock.On("CheckAppreciation", mock.Anything, mock.Anything).Return(true, nil).Once()
 				reportAppreciationMock.On("CheckDuplicateReport", mock.Anything, mock.Anything).Return(false, nil).Once()
 				reportAppreciationMock.On("GetSenderAndReceiver", mock.Anything, mock.Anything).Return(dto.GetSenderAndReceiverResp{
 					Sender:   1004,
 					Receiver: 1100,
 				}, nil).Once()
-				reportAppreciationMock.On("ReportAppreciation", mock.Anything, mock.Anything).Return(dto.ReportAppricaitionResp{}, apperrors.InternalServerError).Once()
+				tx := &sql.Tx{}
+				reportAppreciationMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				reportAppreciationMock.On("ReportAppreciation", mock.Anything, tx, mock.Anything).Return(dto.ReportAppricaitionResp{Id: 9, AppreciationId: 4}, nil).Once()
+				outboxMock.On("EnqueueWebhookEvent", mock.Anything, tx, constants.ReportCreatedEvent, mock.Anything).Return(apperrors.InternalServer).Once()
+				reportAppreciationMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
 			},
 			isErrorExpected: true,
 		},
@@ -68,7 +109,7 @@ func TestReportAppreciation(t *testing.T) {
 				ReportingComment: "reporting comment",
 				AppreciationId:   4,
 			},
-			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer) {
+			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer, outboxMock *mocks.OutboxStorer) {
 				reportAppreciationMock.On("CheckAppreciation", mock.Anything, mock.Anything).Return(true, nil).Once()
 				reportAppreciationMock.On("CheckDuplicateReport", mock.Anything, mock.Anything).Return(false, nil).Once()
 				reportAppreciationMock.On("GetSenderAndReceiver", mock.Anything, mock.Anything).Return(dto.GetSenderAndReceiverResp{
@@ -85,7 +126,7 @@ func TestReportAppreciation(t *testing.T) {
 				ReportingComment: "reporting comment",
 				AppreciationId:   4,
 			},
-			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer) {
+			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer, outboxMock *mocks.OutboxStorer) {
 				reportAppreciationMock.On("CheckAppreciation", mock.Anything, mock.Anything).Return(true, nil).Once()
 				reportAppreciationMock.On("CheckDuplicateReport", mock.Anything, mock.Anything).Return(false, nil).Once()
 				reportAppreciationMock.On("GetSenderAndReceiver", mock.Anything, mock.Anything).Return(dto.GetSenderAndReceiverResp{
@@ -102,7 +143,7 @@ func TestReportAppreciation(t *testing.T) {
 				ReportingComment: "reporting comment",
 				AppreciationId:   4,
 			},
-			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer) {
+			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer, outboxMock *mocks.OutboxStorer) {
 				reportAppreciationMock.On("CheckAppreciation", mock.Anything, mock.Anything).Return(true, nil).Once()
 				reportAppreciationMock.On("CheckDuplicateReport", mock.Anything, mock.Anything).Return(true, nil).Once()
 			},
@@ -115,7 +156,7 @@ func TestReportAppreciation(t *testing.T) {
 				ReportingComment: "reporting comment",
 				AppreciationId:   4,
 			},
-			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer) {
+			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer, outboxMock *mocks.OutboxStorer) {
 				reportAppreciationMock.On("CheckAppreciation", mock.Anything, mock.Anything).Return(false, nil).Once()
 			},
 			isErrorExpected: true,
@@ -126,7 +167,7 @@ func TestReportAppreciation(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			ctx := context.Background()
 			ctx = context.WithValue(ctx, constants.UserId, int64(test.userId))
-			test.setup(reportAppreciationRepo)
+			test.setup(reportAppreciationRepo, outboxRepo)
 
 			// test service
 			_, err := service.ReportAppreciation(ctx, test.reqData)
@@ -142,7 +183,7 @@ func TestListReportedAppreciations(t *testing.T) {
 	reportAppreciationRepo := mocks.NewReportAppreciationStorer(t)
 	userRepo := mocks.NewUserStorer(t)
 	appreciationRepo := mocks.NewAppreciationStorer(t)
-	service := NewService(reportAppreciationRepo, userRepo, appreciationRepo)
+	service := NewService(reportAppreciationRepo, userRepo, appreciationRepo, mocks.NewOutboxStorer(t), mocks.NewNotificationStorer(t), feed.NewBroker())
 
 	tests := []struct {
 		name            string
@@ -153,7 +194,7 @@ func TestListReportedAppreciations(t *testing.T) {
 		{
 			name: "Success for report appreciation",
 			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer) {
-				reportAppreciationMock.On("ListReportedAppreciations", mock.Anything, mock.Anything, mock.Anything).Return([]repository.ListReportedAppreciations{}, nil).Once()
+				reportAppreciationMock.On("ListReportedAppreciations", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]repository.ListReportedAppreciations{}, nil).Once()
 			},
 			isErrorExpected: false,
 		},
@@ -164,7 +205,76 @@ func TestListReportedAppreciations(t *testing.T) {
 			test.setup(reportAppreciationRepo)
 
 			// test service
-			_, err := service.ListReportedAppreciations(test.ctx, 0, 0)
+			_, err := service.ListReportedAppreciations(test.ctx, 0, 0, dto.OrgFilter{})
+
+			if (err != nil) != test.isErrorExpected {
+				t.Errorf("Test Failed, expected error to be %v, but got err %v", test.isErrorExpected, err != nil)
+			}
+		})
+	}
+}
+
+func TestResolveAppreciation(t *testing.T) {
+	// the resolution email links to assets served by peerly
+	viper.Set(constants.PeerlyBaseUrl, "http://localhost:33001")
+
+	tests := []struct {
+		name            string
+		reqData         dto.ModerationReq
+		setup           func(reportAppreciationMock *mocks.ReportAppreciationStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer, notificationMock *mocks.NotificationStorer)
+		isErrorExpected bool
+	}{
+		{
+			name:    "Resolution email is queued with the moderation",
+			reqData: dto.ModerationReq{ResolutionId: 1, ModeratorComment: "looks fine"},
+			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer, notificationMock *mocks.NotificationStorer) {
+				reportAppreciationMock.On("GetResolution", mock.Anything, int64(1)).Return(repository.ListReportedAppreciations{Id: 1, Appreciation_id: 4, Sender: 1004, Receiver: 1100, ReportedBy: 1334}, nil).Once()
+				userMock.On("GetUserById", mock.Anything, mock.Anything).Return(dto.GetUserByIdResp{Email: "reporter@example.com"}, nil).Times(3)
+				reportAppreciationMock.On("BeginTx", mock.Anything).Return(nil, nil).Once()
+				reportAppreciationMock.On("ResolveAppreciation", mock.Anything, nil, dto.ModerationReq{ResolutionId: 1, AppreciationId: 4, ModeratorComment: "looks fine", ModeratedBy: 7}).Return(nil).Once()
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, nil, constants.OutboxEmail, mock.MatchedBy(func(mail dto.OutboxEmail) bool {
+					return mail.To[0] == "reporter@example.com" && mail.Template == "./internal/app/email/templates/resolveAppreciation.html"
+				})).Return(nil).Once()
+				notificationMock.On("CreateNotifications", mock.Anything, nil, mock.MatchedBy(func(notifications []dto.Notification) bool {
+					return len(notifications) == 1 && notifications[0].UserID == 1334 && notifications[0].Type == constants.ReportOutcomeNotification
+				})).Return(nil).Once()
+				outboxMock.On("EnqueueWebhookEvent", mock.Anything, nil, constants.ReportResolvedEvent, dto.ReportResolvedEventData{
+					ResolutionID:     1,
+					AppreciationID:   4,
+					Outcome:          constants.ReportOutcomeKept,
+					ModeratorComment: "looks fine",
+					ModeratedBy:      7,
+				}).Return(nil).Once()
+				reportAppreciationMock.On("HandleTransaction", mock.Anything, nil, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name:    "Moderation is rolled back when the email cannot be queued",
+			reqData: dto.ModerationReq{ResolutionId: 1, ModeratorComment: "looks fine"},
+			setup: func(reportAppreciationMock *mocks.ReportAppreciationStorer, userMock *mocks.UserStorer, outboxMock *mocks.OutboxStorer, notificationMock *mocks.NotificationStorer) {
+				reportAppreciationMock.On("GetResolution", mock.Anything, int64(1)).Return(repository.ListReportedAppreciations{Id: 1, Appreciation_id: 4, Sender: 1004, Receiver: 1100, ReportedBy: 1334}, nil).Once()
+				userMock.On("GetUserById", mock.Anything, mock.Anything).Return(dto.GetUserByIdResp{Email: "reporter@example.com"}, nil).Times(3)
+				reportAppreciationMock.On("BeginTx", mock.Anything).Return(nil, nil).Once()
+				reportAppreciationMock.On("ResolveAppreciation", mock.Anything, nil, mock.Anything).Return(nil).Once()
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, nil, constants.OutboxEmail, mock.Anything).Return(apperrors.InternalServer).Once()
+				reportAppreciationMock.On("HandleTransaction", mock.Anything, nil, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reportAppreciationRepo := mocks.NewReportAppreciationStorer(t)
+			userRepo := mocks.NewUserStorer(t)
+			outboxRepo := mocks.NewOutboxStorer(t)
+			notificationRepo := mocks.NewNotificationStorer(t)
+			service := NewService(reportAppreciationRepo, userRepo, mocks.NewAppreciationStorer(t), outboxRepo, notificationRepo, feed.NewBroker())
+			test.setup(reportAppreciationRepo, userRepo, outboxRepo, notificationRepo)
+
+			ctx := context.WithValue(context.Background(), constants.UserId, int64(7))
+			err := service.ResolveAppreciation(ctx, test.reqData)
 
 			if (err != nil) != test.isErrorExpected {
 				t.Errorf("Test Failed, expected error to be %v, but got err %v", test.isErrorExpected, err != nil)
@@ -0,0 +1,22 @@
+package reward
+
+import (
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// Function to map RewardLevel DB rows to RewardLevel DTOs
+func mapRewardLevelsDbToSvc(dbLevels []repository.RewardLevel) []dto.RewardLevel {
+	levels := make([]dto.RewardLevel, 0, len(dbLevels))
+	for _, dbLevel := range dbLevels {
+		levels = append(levels, dto.RewardLevel{
+			Id:        dbLevel.Id,
+			Version:   dbLevel.Version,
+			Point:     dbLevel.Point,
+			Value:     dbLevel.Value,
+			CreatedBy: dbLevel.CreatedBy.Int64,
+			CreatedAt: dbLevel.CreatedAt,
+		})
+	}
+	return levels
+}
@@ -42,6 +42,66 @@ func (_m *Service) GiveReward(ctx context.Context, rewardReq dto.Reward) (dto.Re
 	return r0, r1
 }
 
+// ListRewardLevels provides a mock function with given fields: ctx
+func (_m *Service) ListRewardLevels(ctx context.Context) ([]dto.RewardLevel, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRewardLevels")
+	}
+
+	var r0 []dto.RewardLevel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dto.RewardLevel, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dto.RewardLevel); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.RewardLevel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRewardLevels provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateRewardLevels(ctx context.Context, req dto.UpdateRewardLevelsReq) ([]dto.RewardLevel, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRewardLevels")
+	}
+
+	var r0 []dto.RewardLevel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.UpdateRewardLevelsReq) ([]dto.RewardLevel, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.UpdateRewardLevelsReq) []dto.RewardLevel); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.RewardLevel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.UpdateRewardLevelsReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
@@ -2,9 +2,9 @@ package reward
 
 import (
 	"context"
+	"slices"
   "time"
-	"github.com/joshsoftware/peerly-backend/internal/app/notification"
-	user "github.com/joshsoftware/peerly-backend/internal/app/users"
+	"github.com/joshsoftware/peerly-backend/internal/app/feed"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
@@ -17,18 +17,28 @@ type service struct {
 	appreciationRepo        repository.AppreciationStorer
 	reportedAppreciatonRepo repository.ReportAppreciationStorer
 	userRepo                repository.UserStorer
+	rewardLevelRepo         repository.RewardLevelStorer
+	outboxRepo              repository.OutboxStorer
+	notificationRepo        repository.NotificationStorer
+	feedBroker              feed.Broker
 }
 
 type Service interface {
 	GiveReward(ctx context.Context, rewardReq dto.Reward) (dto.Reward, error)
+	ListRewardLevels(ctx context.Context) ([]dto.RewardLevel, error)
+	UpdateRewardLevels(ctx context.Context, req dto.UpdateRewardLevelsReq) ([]dto.RewardLevel, error)
 }
 
-func NewService(rewardRepo repository.RewardStorer, appreciationRepo repository.AppreciationStorer, userRepo repository.UserStorer, reportedAppreciatonRepo repository.ReportAppreciationStorer) Service {
+func NewService(rewardRepo repository.RewardStorer, appreciationRepo repository.AppreciationStorer, userRepo repository.UserStorer, reportedAppreciatonRepo repository.ReportAppreciationStorer, rewardLevelRepo repository.RewardLevelStorer, outboxRepo repository.OutboxStorer, notificationRepo repository.NotificationStorer, feedBroker feed.Broker) Service {
 	return &service{
 		rewardRepo:              rewardRepo,
 		appreciationRepo:        appreciationRepo,
 		userRepo:                userRepo,
 		reportedAppreciatonRepo: reportedAppreciatonRepo,
+		rewardLevelRepo:         rewardLevelRepo,
+		outboxRepo:              outboxRepo,
+		notificationRepo:        notificationRepo,
+		feedBroker:              feedBroker,
 	}
 }
 
@@ -117,7 +127,7 @@ func (rwrdSvc *service) GiveReward(ctx context.Context, rewardReq dto.Reward) (d
 		return dto.Reward{}, apperrors.SelfAppreciationRewardError
 	}
 
-	if appr.ReceiverID == sender {
+	if slices.Contains(appr.ReceiverIDs(), sender) {
 		logger.Error(ctx, "rewardService: SelfRewardError")
 		return dto.Reward{}, apperrors.SelfRewardError
 	}
@@ -137,6 +147,15 @@ func (rwrdSvc *service) GiveReward(ctx context.Context, rewardReq dto.Reward) (d
 		return dto.Reward{}, apperrors.NotAllowedForReportedAppreciation
 	}
 
+	// resolve the value of the reward from the current mapping so later changes don't affect it
+	rewardLevel, err := rwrdSvc.rewardLevelRepo.GetRewardLevelByPoint(ctx, nil, rewardReq.Point)
+	if err != nil {
+		logger.Errorf(ctx, "rewardService: GetRewardLevelByPoint: err: %v", err)
+		return dto.Reward{}, err
+	}
+	rewardReq.RewardLevelId = rewardLevel.Id
+	rewardReq.Value = rewardLevel.Value
+
 	userChk, err := rwrdSvc.rewardRepo.UserHasRewardQuota(ctx, nil, rewardReq.SenderId, rewardReq.Point)
 	if err != nil {
 		logger.Errorf(ctx, "rewardService: UserHasRewardQuota: err: %v", err)
@@ -167,6 +186,8 @@ func (rwrdSvc *service) GiveReward(ctx context.Context, rewardReq dto.Reward) (d
 		return dto.Reward{}, err
 	}
 
+	// set once the reward is written, published to the live feed after the commit
+	var feedEvent *dto.FeedEvent
 	defer func() {
 		rvr := recover()
 		defer func() {
@@ -182,6 +203,9 @@ func (rwrdSvc *service) GiveReward(ctx context.Context, rewardReq dto.Reward) (d
 			logger.Infof(ctx, "error in creating transaction, err: %s", txErr.Error())
 			return
 		}
+		if feedEvent != nil {
+			rwrdSvc.feedBroker.Publish(ctx, *feedEvent)
+		}
 	}()
 	repoRewardRes, err := rwrdSvc.rewardRepo.GiveReward(ctx, tx, rewardReq)
 	if err != nil {
@@ -207,60 +231,177 @@ func (rwrdSvc *service) GiveReward(ctx context.Context, rewardReq dto.Reward) (d
 	reward.AppreciationId = repoRewardRes.AppreciationId
 	reward.SenderId = repoRewardRes.SenderId
 	reward.Point = repoRewardRes.Point
-	quaterTimeStamp := user.GetQuarterStartUnixTime()
+	reward.Value = repoRewardRes.Value
+	reward.RewardLevelId = repoRewardRes.RewardLevelId
 
-	req := dto.GetUserByIdReq{
-		UserId:          sender,
-		QuaterTimeStamp: quaterTimeStamp,
+	// notifications are delivered by the outbox worker once the reward is committed
+	err = rwrdSvc.enqueueRewardNotificationToSender(ctx, tx, sender)
+	if err != nil {
+		return dto.Reward{}, err
+	}
+	for _, receiverId := range appr.ReceiverIDs() {
+		err = rwrdSvc.enqueueRewardNotificationToReceiver(ctx, tx, receiverId)
+		if err != nil {
+			return dto.Reward{}, err
+		}
+	}
+	err = rwrdSvc.addRewardToInbox(ctx, tx, appr.ReceiverIDs(), appr.ID)
+	if err != nil {
+		return dto.Reward{}, err
 	}
-	userInfo, err := rwrdSvc.userRepo.GetUserById(ctx, req)
+	err = rwrdSvc.outboxRepo.EnqueueWebhookEvent(ctx, tx, constants.RewardGivenEvent, dto.RewardEventData{
+		ID:             reward.Id,
+		AppreciationID: reward.AppreciationId,
+		SenderID:       reward.SenderId,
+		Point:          reward.Point,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "rewardService: EnqueueWebhookEvent: err: %v", err)
+		return dto.Reward{}, err
+	}
+
+	// read within the transaction so the count includes this reward
+	rewardedAppr, err := rwrdSvc.appreciationRepo.GetAppreciationById(ctx, tx, int32(reward.AppreciationId))
 	if err != nil {
-		logger.Errorf(ctx, "rewardService: err in getting user data: %v", err)
+		logger.Errorf(ctx, "rewardService: GetAppreciationById: err: %v", err)
+		return dto.Reward{}, err
+	}
+	feedEvent = &dto.FeedEvent{
+		Event: constants.RewardGivenEvent,
+		Data: dto.FeedRewardEventData{
+			AppreciationID: reward.AppreciationId,
+			TotalRewards:   rewardedAppr.TotalRewards,
+		},
 	}
-	rwrdSvc.sendRewardNotificationToSender(ctx, userInfo)
-	rwrdSvc.sendRewardNotificationToReceiver(ctx, appr.ReceiverID)
 	return reward, nil
 }
 
-func (rwrdSvc *service) sendRewardNotificationToSender(ctx context.Context, user dto.GetUserByIdResp) {
+func (rwrdSvc *service) ListRewardLevels(ctx context.Context) ([]dto.RewardLevel, error) {
+
+	version, err := rwrdSvc.rewardLevelRepo.GetLatestRewardLevelVersion(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "rewardService: GetLatestRewardLevelVersion: err: %v", err)
+		return nil, err
+	}
 
-	logger.Debug(ctx, " rewardService: sendRewardNotificationToSender: user: ", user)
-	notificationTokens, err := rwrdSvc.userRepo.ListDeviceTokensByUserID(ctx, user.UserId)
+	levels, err := rwrdSvc.rewardLevelRepo.ListRewardLevels(ctx, nil, version)
 	if err != nil {
-		logger.Errorf(ctx, "err in getting device tokens: %v", err)
-		return
+		logger.Errorf(ctx, "rewardService: ListRewardLevels: err: %v", err)
+		return nil, err
 	}
 
-	msg := notification.Message{
-		Title: "Reward Given Successfully",
-		Body:  "You have successfully given a reward! ",
+	return mapRewardLevelsDbToSvc(levels), nil
+}
+
+// UpdateRewardLevels stores the requested levels as a new version of the mapping.
+// Rewards given earlier keep the value resolved at the time they were given.
+func (rwrdSvc *service) UpdateRewardLevels(ctx context.Context, req dto.UpdateRewardLevelsReq) (levels []dto.RewardLevel, err error) {
+
+	logger.Debug(ctx, "rewardService: UpdateRewardLevels: ", req)
+	adminId, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "rewardService: err in parsing userid from token")
+		return nil, apperrors.InternalServer
 	}
 
-	logger.Debug(ctx, "msg:", msg, " notificationTokens: ", notificationTokens)
-	for _, notificationToken := range notificationTokens {
-		msg.SendNotificationToNotificationToken(notificationToken)
+	tx, err := rwrdSvc.rewardLevelRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Error(ctx, "rewardService: error in BeginTx")
+		return nil, err
 	}
 
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := rwrdSvc.rewardLevelRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in creating transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	// two admins saving at once would both write the next version, the second waits here and writes the one after
+	err = rwrdSvc.rewardLevelRepo.LockRewardLevels(ctx, tx)
+	if err != nil {
+		logger.Errorf(ctx, "rewardService: LockRewardLevels: err: %v", err)
+		return nil, err
+	}
+
+	version, err := rwrdSvc.rewardLevelRepo.GetLatestRewardLevelVersion(ctx, tx)
+	if err != nil {
+		logger.Errorf(ctx, "rewardService: GetLatestRewardLevelVersion: err: %v", err)
+		return nil, err
+	}
+
+	dbLevels, err := rwrdSvc.rewardLevelRepo.CreateRewardLevels(ctx, tx, version+1, req.Levels, adminId)
+	if err != nil {
+		logger.Errorf(ctx, "rewardService: CreateRewardLevels: err: %v", err)
+		return nil, err
+	}
+
+	return mapRewardLevelsDbToSvc(dbLevels), nil
 }
 
-func (rwrdSvc *service) sendRewardNotificationToReceiver(ctx context.Context, userID int64) {
+func (rwrdSvc *service) enqueueRewardNotificationToSender(ctx context.Context, tx repository.Transaction, userID int64) error {
+
+	logger.Debug(ctx, " rewardService: enqueueRewardNotificationToSender: userID: ", userID)
+	msg := dto.OutboxPush{
+		UserID: userID,
+		Title:  "Reward Given Successfully",
+		Body:   "You have successfully given a reward! ",
+		Event:  constants.RewardNotification,
+	}
 
-	logger.Debug(ctx, " rewardService: sendRewardNotificationToReceiver")
-	notificationTokens, err := rwrdSvc.userRepo.ListDeviceTokensByUserID(ctx, userID)
-	logger.Debug(ctx, " notificationTokens: ", notificationTokens)
+	err := rwrdSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxPush, msg)
 	if err != nil {
-		logger.Errorf(ctx, " err in getting device tokens: %v", err)
-		return
+		logger.Errorf(ctx, "rewardService: EnqueueOutboxMessage: err: %v", err)
+		return err
 	}
+	return nil
+}
 
-	msg := notification.Message{
-		Title: "Reward's incoming!",
-		Body:  "You've been awarded a reward! Well done and keep up the JOSH!",
+func (rwrdSvc *service) enqueueRewardNotificationToReceiver(ctx context.Context, tx repository.Transaction, userID int64) error {
+
+	logger.Debug(ctx, " rewardService: enqueueRewardNotificationToReceiver: userID: ", userID)
+	msg := dto.OutboxPush{
+		UserID: userID,
+		Title:  "Reward's incoming!",
+		Body:   "You've been awarded a reward! Well done and keep up the JOSH!",
+		Event:  constants.RewardNotification,
 	}
 
-	logger.Debug(ctx, " rewardService: msg: ", msg)
-	for _, notificationToken := range notificationTokens {
-		msg.SendNotificationToNotificationToken(notificationToken)
+	err := rwrdSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxPush, msg)
+	if err != nil {
+		logger.Errorf(ctx, "rewardService: EnqueueOutboxMessage: err: %v", err)
+		return err
 	}
+	return nil
+}
 
+func (rwrdSvc *service) addRewardToInbox(ctx context.Context, tx repository.Transaction, receivers []int64, appreciationID int64) error {
+
+	notifications := make([]dto.Notification, 0, len(receivers))
+	for _, receiverId := range receivers {
+		notifications = append(notifications, dto.Notification{
+			UserID:         receiverId,
+			Type:           constants.RewardNotification,
+			Title:          "Reward's incoming!",
+			Body:           "You've been awarded a reward on your appreciation! Well done and keep up the JOSH!",
+			AppreciationID: appreciationID,
+		})
+	}
+
+	err := rwrdSvc.notificationRepo.CreateNotifications(ctx, tx, notifications)
+	if err != nil {
+		logger.Errorf(ctx, "rewardService: CreateNotifications: err: %v", err)
+		return err
+	}
+	return nil
 }
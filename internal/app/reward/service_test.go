@@ -2,70 +2,85 @@ package reward
 
 import (
 	"context"
-	// "database/sql"
-	// "errors"
 	"testing"
+	"time"
 
+	"github.com/joshsoftware/peerly-backend/internal/app/feed"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
 	"github.com/joshsoftware/peerly-backend/internal/repository"
 	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+func init() {
+	log.Logger = l.New()
+}
+
 func TestGiveReward(t *testing.T) {
+	apprCreatedAt := time.Now().UnixMilli()
 	tests := []struct {
 		name            string
 		ctx             context.Context
 		rewardReq       dto.Reward
-		setup           func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer)
+		setup           func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer)
 		isErrorExpected bool
 		expectedResult  dto.Reward
 		expectedError   error
+		// titles of the push notifications expected to be queued in the outbox
+		expectedNotifications []string
 	}{
 		{
 			name: "Success",
 			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
 			rewardReq: dto.Reward{
 				AppreciationId: 1,
-				Point:          10,
+				Point:          3,
 			},
-			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer) {
-				apprMock.On("GetAppreciationById", mock.Anything, nil, 1).Return(repository.AppreciationResponse{SenderID: 2, ReceiverID: 3}, nil)
-				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(10)).Return(true, nil)
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 2, ReceiverID: 3, CreatedAt: apprCreatedAt, TotalRewards: 3}, nil).Once()
+				reportMock.On("GetReportedAppreciationByAppreciationID", mock.Anything, int64(1)).Return(repository.ListReportedAppreciations{}, apperrors.InvalidId)
+				levelMock.On("GetRewardLevelByPoint", mock.Anything, nil, int64(3)).Return(repository.RewardLevel{Id: 2, Version: 1, Point: 3, Value: 150}, nil)
+				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(3)).Return(true, nil)
 				rwrdMock.On("IsUserRewardForAppreciationPresent", mock.Anything, nil, int64(1), int64(1)).Return(false, nil)
 				rwrdMock.On("BeginTx", mock.Anything).Return(nil, nil)
-				rwrdMock.On("GiveReward", mock.Anything, mock.Anything, mock.Anything).Return(repository.Reward{Id: 1, AppreciationId: 1, SenderId: 1, Point: 10}, nil)
-				rwrdMock.On("DeduceRewardQuotaOfUser", mock.Anything, mock.Anything, int64(1), 10).Return(true, nil)
+				rwrdMock.On("GiveReward", mock.Anything, mock.Anything, dto.Reward{AppreciationId: 1, Point: 3, Value: 150, RewardLevelId: 2, SenderId: 1}).Return(repository.Reward{Id: 1, AppreciationId: 1, SenderId: 1, Point: 3, RewardLevelId: 2, Value: 150}, nil)
+				rwrdMock.On("DeduceRewardQuotaOfUser", mock.Anything, mock.Anything, int64(1), 3).Return(true, nil)
+				// read again within the transaction once the reward is counted
+				apprMock.On("GetAppreciationById", mock.Anything, mock.Anything, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 2, ReceiverID: 3, CreatedAt: apprCreatedAt, TotalRewards: 4}, nil).Once()
 				apprMock.On("HandleTransaction", mock.Anything, mock.Anything, true).Return(nil)
 			},
-			isErrorExpected: false,
-			expectedResult:  dto.Reward{Id: 1, AppreciationId: 1, SenderId: 1, Point: 10},
-			expectedError:   nil,
+			isErrorExpected:       false,
+			expectedResult:        dto.Reward{Id: 1, AppreciationId: 1, SenderId: 1, Point: 3, RewardLevelId: 2, Value: 150},
+			expectedError:         nil,
+			expectedNotifications: []string{"Reward Given Successfully", "Reward's incoming!"},
 		},
 		{
 			name: "Error in parsing userid from token",
 			ctx:  context.Background(),
 			rewardReq: dto.Reward{
 				AppreciationId: 1,
-				Point:          10,
+				Point:          3,
+			},
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
 			},
-			setup:           func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer) {},
 			isErrorExpected: true,
 			expectedResult:  dto.Reward{},
 			expectedError:   apperrors.InternalServer,
 		},
 		{
 			name: "Self appreciation reward error",
-			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(2)),
 			rewardReq: dto.Reward{
 				AppreciationId: 1,
-				Point:          10,
+				Point:          3,
 			},
-			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer) {
-				apprMock.On("GetAppreciationById", mock.Anything, nil, 1).Return(repository.AppreciationResponse{SenderID: 2, ReceiverID: 3}, nil)
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 2, ReceiverID: 3, CreatedAt: apprCreatedAt}, nil)
 			},
 			isErrorExpected: true,
 			expectedResult:  dto.Reward{},
@@ -73,28 +88,66 @@ func TestGiveReward(t *testing.T) {
 		},
 		{
 			name: "Self reward error",
-			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(3)),
+			rewardReq: dto.Reward{
+				AppreciationId: 1,
+				Point:          3,
+			},
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 2, ReceiverID: 3, CreatedAt: apprCreatedAt}, nil)
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.Reward{},
+			expectedError:   apperrors.SelfRewardError,
+		},
+		{
+			name: "Self reward error on team appreciation",
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(4)),
 			rewardReq: dto.Reward{
 				AppreciationId: 1,
-				Point:          10,
+				Point:          3,
 			},
-			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer) {
-				apprMock.On("GetAppreciationById", mock.Anything, nil, 1).Return(repository.AppreciationResponse{SenderID: 2, ReceiverID: 3}, nil)
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{
+					ID:         1,
+					SenderID:   2,
+					ReceiverID: 3,
+					Receivers:  repository.AppreciationReceivers{{ID: 3}, {ID: 4}},
+					CreatedAt:  apprCreatedAt,
+				}, nil)
 			},
 			isErrorExpected: true,
 			expectedResult:  dto.Reward{},
 			expectedError:   apperrors.SelfRewardError,
 		},
+		{
+			name: "Reward point not present in reward levels",
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
+			rewardReq: dto.Reward{
+				AppreciationId: 1,
+				Point:          4,
+			},
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 2, ReceiverID: 3, CreatedAt: apprCreatedAt}, nil)
+				reportMock.On("GetReportedAppreciationByAppreciationID", mock.Anything, int64(1)).Return(repository.ListReportedAppreciations{}, apperrors.InvalidId)
+				levelMock.On("GetRewardLevelByPoint", mock.Anything, nil, int64(4)).Return(repository.RewardLevel{}, apperrors.InvalidRewardPoint)
+			},
+			isErrorExpected: true,
+			expectedResult:  dto.Reward{},
+			expectedError:   apperrors.InvalidRewardPoint,
+		},
 		{
 			name: "Insufficient reward quota",
 			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
 			rewardReq: dto.Reward{
 				AppreciationId: 1,
-				Point:          10,
+				Point:          3,
 			},
-			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer) {
-				apprMock.On("GetAppreciationById", mock.Anything, nil, 1).Return(repository.AppreciationResponse{SenderID: 2, ReceiverID: 3}, nil)
-				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(10)).Return(false, nil)
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 2, ReceiverID: 3, CreatedAt: apprCreatedAt}, nil)
+				reportMock.On("GetReportedAppreciationByAppreciationID", mock.Anything, int64(1)).Return(repository.ListReportedAppreciations{}, apperrors.InvalidId)
+				levelMock.On("GetRewardLevelByPoint", mock.Anything, nil, int64(3)).Return(repository.RewardLevel{Id: 2, Version: 1, Point: 3, Value: 150}, nil)
+				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(3)).Return(false, nil)
 			},
 			isErrorExpected: true,
 			expectedResult:  dto.Reward{},
@@ -105,11 +158,13 @@ func TestGiveReward(t *testing.T) {
 			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
 			rewardReq: dto.Reward{
 				AppreciationId: 1,
-				Point:          10,
+				Point:          3,
 			},
-			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer) {
-				apprMock.On("GetAppreciationById", mock.Anything, nil, 1).Return(repository.AppreciationResponse{SenderID: 2, ReceiverID: 3}, nil)
-				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(10)).Return(true, nil)
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 2, ReceiverID: 3, CreatedAt: apprCreatedAt}, nil)
+				reportMock.On("GetReportedAppreciationByAppreciationID", mock.Anything, int64(1)).Return(repository.ListReportedAppreciations{}, apperrors.InvalidId)
+				levelMock.On("GetRewardLevelByPoint", mock.Anything, nil, int64(3)).Return(repository.RewardLevel{Id: 2, Version: 1, Point: 3, Value: 150}, nil)
+				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(3)).Return(true, nil)
 				rwrdMock.On("IsUserRewardForAppreciationPresent", mock.Anything, nil, int64(1), int64(1)).Return(true, nil)
 			},
 			isErrorExpected: true,
@@ -121,11 +176,13 @@ func TestGiveReward(t *testing.T) {
 			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
 			rewardReq: dto.Reward{
 				AppreciationId: 1,
-				Point:          10,
+				Point:          3,
 			},
-			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer) {
-				apprMock.On("GetAppreciationById", mock.Anything, nil, 1).Return(repository.AppreciationResponse{SenderID: 2, ReceiverID: 3}, nil)
-				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(10)).Return(true, nil)
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 2, ReceiverID: 3, CreatedAt: apprCreatedAt}, nil)
+				reportMock.On("GetReportedAppreciationByAppreciationID", mock.Anything, int64(1)).Return(repository.ListReportedAppreciations{}, apperrors.InvalidId)
+				levelMock.On("GetRewardLevelByPoint", mock.Anything, nil, int64(3)).Return(repository.RewardLevel{Id: 2, Version: 1, Point: 3, Value: 150}, nil)
+				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(3)).Return(true, nil)
 				rwrdMock.On("IsUserRewardForAppreciationPresent", mock.Anything, nil, int64(1), int64(1)).Return(false, nil)
 				rwrdMock.On("BeginTx", mock.Anything).Return(nil, apperrors.InternalServer)
 			},
@@ -138,16 +195,18 @@ func TestGiveReward(t *testing.T) {
 			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
 			rewardReq: dto.Reward{
 				AppreciationId: 1,
-				Point:          10,
+				Point:          3,
 			},
-			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer) {
-				apprMock.On("GetAppreciationById", mock.Anything, nil, 1).Return(repository.AppreciationResponse{SenderID: 2, ReceiverID: 3}, nil)
-				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(10)).Return(true, nil)
+			setup: func(rwrdMock *mocks.RewardStorer, apprMock *mocks.AppreciationStorer, reportMock *mocks.ReportAppreciationStorer, levelMock *mocks.RewardLevelStorer, userMock *mocks.UserStorer) {
+				apprMock.On("GetAppreciationById", mock.Anything, nil, int32(1)).Return(repository.AppreciationResponse{ID: 1, SenderID: 2, ReceiverID: 3, CreatedAt: apprCreatedAt}, nil)
+				reportMock.On("GetReportedAppreciationByAppreciationID", mock.Anything, int64(1)).Return(repository.ListReportedAppreciations{}, apperrors.InvalidId)
+				levelMock.On("GetRewardLevelByPoint", mock.Anything, nil, int64(3)).Return(repository.RewardLevel{Id: 2, Version: 1, Point: 3, Value: 150}, nil)
+				rwrdMock.On("UserHasRewardQuota", mock.Anything, nil, int64(1), int64(3)).Return(true, nil)
 				rwrdMock.On("IsUserRewardForAppreciationPresent", mock.Anything, nil, int64(1), int64(1)).Return(false, nil)
 				rwrdMock.On("BeginTx", mock.Anything).Return(nil, nil)
-				rwrdMock.On("GiveReward", mock.Anything, mock.Anything, mock.Anything).Return(repository.Reward{Id: 1, AppreciationId: 1, SenderId: 1, Point: 10}, nil)
-				rwrdMock.On("DeduceRewardQuotaOfUser", mock.Anything, mock.Anything, int64(1), 10).Return(false, apperrors.RewardQuotaIsNotSufficient)
-				apprMock.On("HandleTransaction", mock.Anything, mock.Anything, false).Return(apperrors.RewardQuotaIsNotSufficient)
+				rwrdMock.On("GiveReward", mock.Anything, mock.Anything, mock.Anything).Return(repository.Reward{Id: 1, AppreciationId: 1, SenderId: 1, Point: 3, RewardLevelId: 2, Value: 150}, nil)
+				rwrdMock.On("DeduceRewardQuotaOfUser", mock.Anything, mock.Anything, int64(1), 3).Return(false, apperrors.RewardQuotaIsNotSufficient)
+				apprMock.On("HandleTransaction", mock.Anything, mock.Anything, false).Return(nil)
 			},
 			isErrorExpected: true,
 			expectedResult:  dto.Reward{},
@@ -159,28 +218,155 @@ func TestGiveReward(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			rwrdMock := &mocks.RewardStorer{}
 			apprMock := &mocks.AppreciationStorer{}
+			reportMock := &mocks.ReportAppreciationStorer{}
+			levelMock := &mocks.RewardLevelStorer{}
+			userMock := &mocks.UserStorer{}
 
 			if test.setup != nil {
-				test.setup(rwrdMock, apprMock)
+				test.setup(rwrdMock, apprMock, reportMock, levelMock, userMock)
 			}
 
+			outboxMock := &mocks.OutboxStorer{}
+			queued := make([]string, 0)
+			outboxMock.On("EnqueueOutboxMessage", mock.Anything, mock.Anything, constants.OutboxPush, mock.Anything).Run(func(args mock.Arguments) {
+				queued = append(queued, args.Get(3).(dto.OutboxPush).Title)
+			}).Return(nil).Maybe()
+			events := make([]dto.RewardEventData, 0)
+			outboxMock.On("EnqueueWebhookEvent", mock.Anything, mock.Anything, constants.RewardGivenEvent, mock.Anything).Run(func(args mock.Arguments) {
+				events = append(events, args.Get(3).(dto.RewardEventData))
+			}).Return(nil).Maybe()
+
+			notificationMock := &mocks.NotificationStorer{}
+			inbox := make([]dto.Notification, 0)
+			notificationMock.On("CreateNotifications", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				inbox = append(inbox, args.Get(2).([]dto.Notification)...)
+			}).Return(nil).Maybe()
+
 			service := &service{
-				rewardRepo:       rwrdMock,
-				appreciationRepo: apprMock,
+				rewardRepo:              rwrdMock,
+				appreciationRepo:        apprMock,
+				reportedAppreciatonRepo: reportMock,
+				rewardLevelRepo:         levelMock,
+				userRepo:                userMock,
+				outboxRepo:              outboxMock,
+				notificationRepo:        notificationMock,
+				feedBroker:              feed.NewBroker(),
 			}
+			feedEvents, unsubscribe := service.feedBroker.Subscribe()
+			defer unsubscribe()
 
 			result, err := service.GiveReward(test.ctx, test.rewardReq)
 
 			if test.isErrorExpected {
 				assert.Error(t, err)
 				assert.Equal(t, test.expectedError, err)
+				assert.Empty(t, feedEvents)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, test.expectedResult, result)
+
+				assert.Equal(t, test.expectedNotifications, queued)
+				assert.Len(t, inbox, 1)
+				assert.Equal(t, constants.RewardNotification, inbox[0].Type)
+				assert.Equal(t, int64(3), inbox[0].UserID)
+				assert.Equal(t, []dto.RewardEventData{{ID: result.Id, AppreciationID: result.AppreciationId, SenderID: result.SenderId, Point: result.Point}}, events)
+				assert.Equal(t, dto.FeedEvent{Event: constants.RewardGivenEvent, Data: dto.FeedRewardEventData{AppreciationID: 1, TotalRewards: 4}}, <-feedEvents)
 			}
 
 			rwrdMock.AssertExpectations(t)
 			apprMock.AssertExpectations(t)
+			reportMock.AssertExpectations(t)
+			levelMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateRewardLevels(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		req             dto.UpdateRewardLevelsReq
+		setup           func(levelMock *mocks.RewardLevelStorer)
+		isErrorExpected bool
+		expectedResult  []dto.RewardLevel
+		expectedError   error
+	}{
+		{
+			name: "Success",
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
+			req:  dto.UpdateRewardLevelsReq{Levels: []dto.RewardLevelReq{{Point: 1, Value: 120}, {Point: 3, Value: 180}}},
+			setup: func(levelMock *mocks.RewardLevelStorer) {
+				levelMock.On("BeginTx", mock.Anything).Return(nil, nil)
+				levelMock.On("LockRewardLevels", mock.Anything, mock.Anything).Return(nil)
+				levelMock.On("GetLatestRewardLevelVersion", mock.Anything, mock.Anything).Return(int64(1), nil)
+				levelMock.On("CreateRewardLevels", mock.Anything, mock.Anything, int64(2), []dto.RewardLevelReq{{Point: 1, Value: 120}, {Point: 3, Value: 180}}, int64(1)).Return([]repository.RewardLevel{
+					{Id: 4, Version: 2, Point: 1, Value: 120},
+					{Id: 5, Version: 2, Point: 3, Value: 180},
+				}, nil)
+				levelMock.On("HandleTransaction", mock.Anything, mock.Anything, true).Return(nil)
+			},
+			isErrorExpected: false,
+			expectedResult: []dto.RewardLevel{
+				{Id: 4, Version: 2, Point: 1, Value: 120},
+				{Id: 5, Version: 2, Point: 3, Value: 180},
+			},
+		},
+		{
+			name:            "Error in parsing userid from token",
+			ctx:             context.Background(),
+			req:             dto.UpdateRewardLevelsReq{Levels: []dto.RewardLevelReq{{Point: 1, Value: 120}}},
+			setup:           func(levelMock *mocks.RewardLevelStorer) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+		{
+			name: "Error in locking reward levels",
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
+			req:  dto.UpdateRewardLevelsReq{Levels: []dto.RewardLevelReq{{Point: 1, Value: 120}}},
+			setup: func(levelMock *mocks.RewardLevelStorer) {
+				levelMock.On("BeginTx", mock.Anything).Return(nil, nil)
+				levelMock.On("LockRewardLevels", mock.Anything, mock.Anything).Return(apperrors.InternalServer)
+				levelMock.On("HandleTransaction", mock.Anything, mock.Anything, false).Return(nil)
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+		{
+			name: "Error in creating reward levels",
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
+			req:  dto.UpdateRewardLevelsReq{Levels: []dto.RewardLevelReq{{Point: 1, Value: 120}}},
+			setup: func(levelMock *mocks.RewardLevelStorer) {
+				levelMock.On("BeginTx", mock.Anything).Return(nil, nil)
+				levelMock.On("LockRewardLevels", mock.Anything, mock.Anything).Return(nil)
+				levelMock.On("GetLatestRewardLevelVersion", mock.Anything, mock.Anything).Return(int64(1), nil)
+				levelMock.On("CreateRewardLevels", mock.Anything, mock.Anything, int64(2), mock.Anything, int64(1)).Return(nil, apperrors.InternalServer)
+				levelMock.On("HandleTransaction", mock.Anything, mock.Anything, false).Return(nil)
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			levelMock := &mocks.RewardLevelStorer{}
+			test.setup(levelMock)
+
+			service := &service{
+				rewardLevelRepo: levelMock,
+			}
+
+			result, err := service.UpdateRewardLevels(test.ctx, test.req)
+
+			if test.isErrorExpected {
+				assert.Error(t, err)
+				assert.Equal(t, test.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedResult, result)
+			}
+
+			levelMock.AssertExpectations(t)
 		})
 	}
 }
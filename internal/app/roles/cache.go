@@ -0,0 +1,73 @@
+package roles
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// how long the permissions of the roles are served from memory, they only change through migrations
+const permissionCacheTTL = 5 * time.Minute
+
+type permissionCache struct {
+	mu          sync.RWMutex
+	loadedAt    time.Time
+	loaded      bool
+	permissions map[int64]map[string]bool
+}
+
+func newPermissionCache() *permissionCache {
+	return &permissionCache{
+		permissions: make(map[int64]map[string]bool),
+	}
+}
+
+func (pc *permissionCache) has(roleID int64, permission string) bool {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	return pc.permissions[roleID][permission]
+}
+
+func (pc *permissionCache) isStale(now time.Time) bool {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	return !pc.loaded || now.Sub(pc.loadedAt) > permissionCacheTTL
+}
+
+// refresh reloads the permissions, a failed reload keeps serving the previous ones until the next attempt
+func (pc *permissionCache) refresh(ctx context.Context, roleRepo repository.RoleStorer, now time.Time) error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if pc.loaded && now.Sub(pc.loadedAt) <= permissionCacheTTL {
+		return nil
+	}
+
+	rolePermissions, err := roleRepo.ListRolePermissions(ctx, nil)
+	if err != nil {
+		if !pc.loaded {
+			return err
+		}
+		logger.Errorf(ctx, "roleService: serving the previous permissions, reload failed: %v", err)
+		pc.loadedAt = now
+		return nil
+	}
+
+	permissions := make(map[int64]map[string]bool)
+	for _, rp := range rolePermissions {
+		if permissions[rp.RoleID] == nil {
+			permissions[rp.RoleID] = make(map[string]bool)
+		}
+		permissions[rp.RoleID][rp.Permission] = true
+	}
+
+	pc.permissions = permissions
+	pc.loadedAt = now
+	pc.loaded = true
+	return nil
+}
@@ -0,0 +1,120 @@
+package roles
+
+import (
+	"context"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/sessions"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type service struct {
+	roleRepo   repository.RoleStorer
+	sessionSvc sessions.Service
+	cache      *permissionCache
+}
+
+// Service tells which permissions a role holds and moves users between the user and admin roles
+type Service interface {
+	HasPermission(ctx context.Context, roleID int64, permission string) (bool, error)
+	PromoteUser(ctx context.Context, userID int64) error
+	DemoteUser(ctx context.Context, userID int64) error
+}
+
+func NewService(roleRepo repository.RoleStorer, sessionSvc sessions.Service) Service {
+	return &service{
+		roleRepo:   roleRepo,
+		sessionSvc: sessionSvc,
+		cache:      newPermissionCache(),
+	}
+}
+
+func (rlSvc *service) HasPermission(ctx context.Context, roleID int64, permission string) (bool, error) {
+
+	now := time.Now()
+	if rlSvc.cache.isStale(now) {
+		err := rlSvc.cache.refresh(ctx, rlSvc.roleRepo, now)
+		if err != nil {
+			logger.Errorf(ctx, "roleService: error in loading the permissions: %v", err)
+			return false, err
+		}
+	}
+	return rlSvc.cache.has(roleID, permission), nil
+}
+
+// PromoteUser makes a user an admin, the new role applies from their next login or token refresh
+func (rlSvc *service) PromoteUser(ctx context.Context, userID int64) error {
+	return rlSvc.changeRole(ctx, userID, constants.UserRoleID, constants.AdminRoleID)
+}
+
+// DemoteUser makes an admin a user and revokes their sessions so the admin tokens they hold stop working right away
+func (rlSvc *service) DemoteUser(ctx context.Context, userID int64) error {
+
+	err := rlSvc.changeRole(ctx, userID, constants.AdminRoleID, constants.UserRoleID)
+	if err != nil {
+		return err
+	}
+
+	err = rlSvc.sessionSvc.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		logger.Errorf(ctx, "roleService: RevokeUserSessions: user: %d, err: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+// changeRole only moves a user holding the from role, super admins are never changed this way
+func (rlSvc *service) changeRole(ctx context.Context, userID int64, from int64, to int64) (err error) {
+
+	data := ctx.Value(constants.UserId)
+	updatedBy, ok := data.(int64)
+	if !ok {
+		logger.Error(ctx, "roleService: err in parsing userid from token")
+		return apperrors.InternalServer
+	}
+
+	tx, err := rlSvc.roleRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "roleService: BeginTx: err: %v", err)
+		return err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := rlSvc.roleRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	roleID, err := rlSvc.roleRepo.GetUserRoleID(ctx, tx, userID)
+	if err != nil {
+		logger.Errorf(ctx, "roleService: GetUserRoleID: user: %d, err: %v", userID, err)
+		return err
+	}
+	if roleID != from {
+		logger.Errorf(ctx, "roleService: user %d holds role %d, expected %d", userID, roleID, from)
+		return apperrors.InvalidRoleChange
+	}
+
+	err = rlSvc.roleRepo.UpdateUserRole(ctx, tx, userID, to, updatedBy)
+	if err != nil {
+		logger.Errorf(ctx, "roleService: UpdateUserRole: user: %d, err: %v", userID, err)
+		return err
+	}
+
+	logger.Infof(ctx, "roleService: role of user %d changed from %d to %d by user %d", userID, from, to, updatedBy)
+	return nil
+}
@@ -0,0 +1,198 @@
+package roles
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	sessionMocks "github.com/joshsoftware/peerly-backend/internal/app/sessions/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestHasPermission(t *testing.T) {
+	rolePermissions := []repository.RolePermission{
+		{RoleID: constants.SuperAdminRoleID, Permission: constants.PermissionUserRoleAssign},
+		{RoleID: constants.SuperAdminRoleID, Permission: constants.PermissionGradeEdit},
+		{RoleID: constants.AdminRoleID, Permission: constants.PermissionGradeEdit},
+	}
+
+	tests := []struct {
+		name       string
+		roleID     int64
+		permission string
+		expected   bool
+	}{
+		{
+			name:       "Super admin assigns roles",
+			roleID:     constants.SuperAdminRoleID,
+			permission: constants.PermissionUserRoleAssign,
+			expected:   true,
+		},
+		{
+			name:       "Admin edits grades",
+			roleID:     constants.AdminRoleID,
+			permission: constants.PermissionGradeEdit,
+			expected:   true,
+		},
+		{
+			name:       "Admin doesn't assign roles",
+			roleID:     constants.AdminRoleID,
+			permission: constants.PermissionUserRoleAssign,
+			expected:   false,
+		},
+		{
+			name:       "User holds no permission",
+			roleID:     constants.UserRoleID,
+			permission: constants.PermissionGradeEdit,
+			expected:   false,
+		},
+	}
+
+	roleMock := mocks.NewRoleStorer(t)
+	// loaded once and then served from memory
+	roleMock.On("ListRolePermissions", mock.Anything, nil).Return(rolePermissions, nil).Once()
+	svc := NewService(roleMock, new(sessionMocks.Service))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowed, err := svc.HasPermission(context.Background(), test.roleID, test.permission)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, allowed)
+		})
+	}
+}
+
+func TestPromoteUser(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		setup           func(roleMock *mocks.RoleStorer)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name: "User becomes an admin",
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
+			setup: func(roleMock *mocks.RoleStorer) {
+				tx := &sql.Tx{}
+				roleMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				roleMock.On("GetUserRoleID", mock.Anything, tx, int64(7)).Return(constants.UserRoleID, nil).Once()
+				roleMock.On("UpdateUserRole", mock.Anything, tx, int64(7), constants.AdminRoleID, int64(1)).Return(nil).Once()
+				roleMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Already an admin",
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
+			setup: func(roleMock *mocks.RoleStorer) {
+				tx := &sql.Tx{}
+				roleMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				roleMock.On("GetUserRoleID", mock.Anything, tx, int64(7)).Return(constants.AdminRoleID, nil).Once()
+				roleMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidRoleChange,
+		},
+		{
+			name: "Unknown user",
+			ctx:  context.WithValue(context.Background(), constants.UserId, int64(1)),
+			setup: func(roleMock *mocks.RoleStorer) {
+				tx := &sql.Tx{}
+				roleMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				roleMock.On("GetUserRoleID", mock.Anything, tx, int64(7)).Return(int64(0), apperrors.UserNotFound).Once()
+				roleMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.UserNotFound,
+		},
+		{
+			name:            "User id missing from the context",
+			ctx:             context.Background(),
+			setup:           func(roleMock *mocks.RoleStorer) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roleMock := mocks.NewRoleStorer(t)
+			test.setup(roleMock)
+			svc := NewService(roleMock, new(sessionMocks.Service))
+
+			err := svc.PromoteUser(test.ctx, 7)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDemoteUser(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+
+	tests := []struct {
+		name            string
+		setup           func(roleMock *mocks.RoleStorer, sessionSvc *sessionMocks.Service)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name: "Admin becomes a user and is logged out",
+			setup: func(roleMock *mocks.RoleStorer, sessionSvc *sessionMocks.Service) {
+				tx := &sql.Tx{}
+				roleMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				roleMock.On("GetUserRoleID", mock.Anything, tx, int64(7)).Return(constants.AdminRoleID, nil).Once()
+				roleMock.On("UpdateUserRole", mock.Anything, tx, int64(7), constants.UserRoleID, int64(1)).Return(nil).Once()
+				roleMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+				sessionSvc.On("RevokeUserSessions", mock.Anything, int64(7)).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Super admins aren't demoted",
+			setup: func(roleMock *mocks.RoleStorer, sessionSvc *sessionMocks.Service) {
+				tx := &sql.Tx{}
+				roleMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				roleMock.On("GetUserRoleID", mock.Anything, tx, int64(7)).Return(constants.SuperAdminRoleID, nil).Once()
+				roleMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidRoleChange,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roleMock := mocks.NewRoleStorer(t)
+			sessionSvc := new(sessionMocks.Service)
+			test.setup(roleMock, sessionSvc)
+			svc := NewService(roleMock, sessionSvc)
+
+			err := svc.DemoteUser(ctx, 7)
+
+			sessionSvc.AssertExpectations(t)
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package sessions
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// how long the blacklist is served from memory, revocations made by other instances take effect within it
+const revocationCacheTTL = 30 * time.Second
+
+// revocationCache keeps the unexpired part of the blacklist in memory so authenticating a request doesn't hit the database
+type revocationCache struct {
+	mu       sync.RWMutex
+	loadedAt time.Time
+	loaded   bool
+	// expiry of every revoked jti
+	tokens map[string]int64
+	// tokens a user was issued before this time in milliseconds are revoked
+	usersRevokedAt map[int64]int64
+}
+
+func newRevocationCache() *revocationCache {
+	return &revocationCache{
+		tokens:         make(map[string]int64),
+		usersRevokedAt: make(map[int64]int64),
+	}
+}
+
+func (rc *revocationCache) isRevoked(claims dto.Claims) bool {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	if _, ok := rc.tokens[claims.StandardClaims.Id]; ok {
+		return true
+	}
+	revokedAt, ok := rc.usersRevokedAt[claims.Id]
+	// iat only has second precision, a token issued within the second of the revocation is kept
+	return ok && claims.IssuedAt < revokedAt/1000
+}
+
+func (rc *revocationCache) isStale(now time.Time) bool {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	return !rc.loaded || now.Sub(rc.loadedAt) > revocationCacheTTL
+}
+
+// refresh reloads the blacklist, a failed reload keeps serving the previous one until the next attempt
+func (rc *revocationCache) refresh(ctx context.Context, sessionRepo repository.SessionStorer, now time.Time) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	// another request may have reloaded it while this one waited for the lock
+	if rc.loaded && now.Sub(rc.loadedAt) <= revocationCacheTTL {
+		return nil
+	}
+
+	blacklist, err := sessionRepo.ListBlacklistedTokens(ctx, nil)
+	if err != nil {
+		if !rc.loaded {
+			return err
+		}
+		logger.Errorf(ctx, "sessionService: serving the previous blacklist, reload failed: %v", err)
+		rc.loadedAt = now
+		return nil
+	}
+
+	tokens := make(map[string]int64, len(blacklist))
+	usersRevokedAt := make(map[int64]int64)
+	for _, entry := range blacklist {
+		if entry.Token.Valid {
+			tokens[entry.Token.String] = entry.ExpiresAt
+			continue
+		}
+		if entry.RevokedAt > usersRevokedAt[entry.UserID] {
+			usersRevokedAt[entry.UserID] = entry.RevokedAt
+		}
+	}
+
+	rc.tokens = tokens
+	rc.usersRevokedAt = usersRevokedAt
+	rc.loadedAt = now
+	rc.loaded = true
+	return nil
+}
+
+// add applies a revocation made by this instance right away instead of after the next reload
+func (rc *revocationCache) add(entry repository.BlacklistedToken) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if entry.Token.Valid {
+		rc.tokens[entry.Token.String] = entry.ExpiresAt
+		return
+	}
+	if entry.RevokedAt > rc.usersRevokedAt[entry.UserID] {
+		rc.usersRevokedAt[entry.UserID] = entry.RevokedAt
+	}
+}
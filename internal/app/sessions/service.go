@@ -0,0 +1,208 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type service struct {
+	sessionRepo repository.SessionStorer
+	userRepo    repository.UserStorer
+	roleRepo    repository.RoleStorer
+	cache       *revocationCache
+}
+
+// Service issues and refreshes the tokens of a session, ends sessions before their tokens expire and tells the
+// auth middleware which tokens were revoked
+type Service interface {
+	IssueTokens(ctx context.Context, userID int64, role int) (dto.AuthTokens, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (dto.AuthTokens, error)
+	Logout(ctx context.Context, req dto.LogoutReq) error
+	RevokeUserSessions(ctx context.Context, userID int64) error
+	IsRevoked(ctx context.Context, claims dto.Claims) (bool, error)
+	PurgeExpiredTokens(ctx context.Context) (int64, error)
+}
+
+func NewService(sessionRepo repository.SessionStorer, userRepo repository.UserStorer, roleRepo repository.RoleStorer) Service {
+	return &service{
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		cache:       newRevocationCache(),
+	}
+}
+
+// Logout revokes the token of the request along with its refresh tokens and forgets the device token so the device
+// stops receiving pushes
+func (sessSvc *service) Logout(ctx context.Context, req dto.LogoutReq) (err error) {
+
+	claims, ok := ctx.Value(constants.Claims).(dto.Claims)
+	if !ok {
+		logger.Error(ctx, "sessionService: err in parsing claims from token")
+		return apperrors.InternalServer
+	}
+
+	entry := repository.BlacklistedToken{
+		UserID:    claims.Id,
+		Token:     sql.NullString{String: claims.StandardClaims.Id, Valid: true},
+		ExpiresAt: claims.ExpiresAt * 1000,
+		RevokedAt: time.Now().UnixMilli(),
+	}
+
+	tx, err := sessSvc.sessionRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: BeginTx: err: %v", err)
+		return err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := sessSvc.sessionRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+		if err == nil && rvr == nil {
+			sessSvc.cache.add(entry)
+		}
+	}()
+
+	err = sessSvc.sessionRepo.BlacklistToken(ctx, tx, entry)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: BlacklistToken: user: %d, err: %v", claims.Id, err)
+		return err
+	}
+
+	// tokens issued before refresh tokens existed have no session
+	if claims.SessionId != "" {
+		err = sessSvc.sessionRepo.RevokeRefreshTokenFamily(ctx, tx, claims.SessionId)
+		if err != nil {
+			logger.Errorf(ctx, "sessionService: RevokeRefreshTokenFamily: user: %d, err: %v", claims.Id, err)
+			return err
+		}
+	}
+
+	if req.NotificationToken != "" {
+		err = sessSvc.sessionRepo.DeleteDeviceToken(ctx, tx, claims.Id, req.NotificationToken)
+		if err != nil {
+			logger.Errorf(ctx, "sessionService: DeleteDeviceToken: user: %d, err: %v", claims.Id, err)
+			return err
+		}
+	}
+
+	logger.Infof(ctx, "sessionService: user %d logged out", claims.Id)
+	return nil
+}
+
+// RevokeUserSessions revokes every token the user holds, the user has to log in again on every device
+func (sessSvc *service) RevokeUserSessions(ctx context.Context, userID int64) (err error) {
+
+	_, err = sessSvc.userRepo.GetUserById(ctx, dto.GetUserByIdReq{UserId: userID})
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: GetUserById: user: %d, err: %v", userID, err)
+		if err == apperrors.InvalidId {
+			return apperrors.UserNotFound
+		}
+		return err
+	}
+
+	now := time.Now()
+	// kept until every token it covers has expired, tokens issued before refresh tokens existed live far longer than
+	// access tokens
+	ttl := config.AccessTokenExpiry()
+	if config.LegacyTokenExpiry() > ttl {
+		ttl = config.LegacyTokenExpiry()
+	}
+	entry := repository.BlacklistedToken{
+		UserID:    userID,
+		ExpiresAt: now.Add(ttl).UnixMilli(),
+		RevokedAt: now.UnixMilli(),
+	}
+
+	tx, err := sessSvc.sessionRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: BeginTx: err: %v", err)
+		return err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := sessSvc.sessionRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+		if err == nil && rvr == nil {
+			sessSvc.cache.add(entry)
+		}
+	}()
+
+	err = sessSvc.sessionRepo.BlacklistToken(ctx, tx, entry)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: BlacklistToken: user: %d, err: %v", userID, err)
+		return err
+	}
+
+	err = sessSvc.sessionRepo.RevokeUserRefreshTokens(ctx, tx, userID)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: RevokeUserRefreshTokens: user: %d, err: %v", userID, err)
+		return err
+	}
+
+	logger.Infof(ctx, "sessionService: sessions of user %d revoked by user %v", userID, ctx.Value(constants.UserId))
+	return nil
+}
+
+func (sessSvc *service) IsRevoked(ctx context.Context, claims dto.Claims) (bool, error) {
+
+	now := time.Now()
+	if sessSvc.cache.isStale(now) {
+		err := sessSvc.cache.refresh(ctx, sessSvc.sessionRepo, now)
+		if err != nil {
+			logger.Errorf(ctx, "sessionService: error in loading the blacklist: %v", err)
+			return false, err
+		}
+	}
+	return sessSvc.cache.isRevoked(claims), nil
+}
+
+// PurgeExpiredTokens deletes expired refresh tokens and the revocations of tokens that have expired anyway
+func (sessSvc *service) PurgeExpiredTokens(ctx context.Context) (int64, error) {
+
+	deleted, err := sessSvc.sessionRepo.DeleteExpiredBlacklistedTokens(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: DeleteExpiredBlacklistedTokens: err: %v", err)
+		return 0, err
+	}
+
+	deletedRefreshTokens, err := sessSvc.sessionRepo.DeleteExpiredRefreshTokens(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: DeleteExpiredRefreshTokens: err: %v", err)
+		return deleted, err
+	}
+	return deleted + deletedRefreshTokens, nil
+}
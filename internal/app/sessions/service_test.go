@@ -0,0 +1,289 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestLogout(t *testing.T) {
+	claims := dto.Claims{
+		Id:   7,
+		Role: constants.User,
+		StandardClaims: jwt.StandardClaims{
+			Id:        "jti-1",
+			IssuedAt:  1700000000,
+			ExpiresAt: 1700003600,
+		},
+	}
+	sessionClaims := claims
+	sessionClaims.SessionId = "family-1"
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		req             dto.LogoutReq
+		setup           func(sessionMock *mocks.SessionStorer)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name: "Token is blacklisted and the device token removed",
+			ctx:  context.WithValue(context.Background(), constants.Claims, claims),
+			req:  dto.LogoutReq{NotificationToken: "phone"},
+			setup: func(sessionMock *mocks.SessionStorer) {
+				tx := &sql.Tx{}
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("BlacklistToken", mock.Anything, tx, mock.MatchedBy(func(token repository.BlacklistedToken) bool {
+					return token.UserID == 7 && token.Token.String == "jti-1" && token.ExpiresAt == 1700003600000
+				})).Return(nil).Once()
+				sessionMock.On("DeleteDeviceToken", mock.Anything, tx, int64(7), "phone").Return(nil).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Refresh tokens of the session are revoked",
+			ctx:  context.WithValue(context.Background(), constants.Claims, sessionClaims),
+			setup: func(sessionMock *mocks.SessionStorer) {
+				tx := &sql.Tx{}
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("BlacklistToken", mock.Anything, tx, mock.Anything).Return(nil).Once()
+				sessionMock.On("RevokeRefreshTokenFamily", mock.Anything, tx, "family-1").Return(nil).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Device token is optional",
+			ctx:  context.WithValue(context.Background(), constants.Claims, claims),
+			setup: func(sessionMock *mocks.SessionStorer) {
+				tx := &sql.Tx{}
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("BlacklistToken", mock.Anything, tx, mock.Anything).Return(nil).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Blacklisting fails",
+			ctx:  context.WithValue(context.Background(), constants.Claims, claims),
+			setup: func(sessionMock *mocks.SessionStorer) {
+				tx := &sql.Tx{}
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("BlacklistToken", mock.Anything, tx, mock.Anything).Return(apperrors.InternalServer).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+		{
+			name:            "Claims missing from the context",
+			ctx:             context.Background(),
+			setup:           func(sessionMock *mocks.SessionStorer) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sessionMock := mocks.NewSessionStorer(t)
+			test.setup(sessionMock)
+			svc := NewService(sessionMock, mocks.NewUserStorer(t), mocks.NewRoleStorer(t)).(*service)
+
+			err := svc.Logout(test.ctx, test.req)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				assert.False(t, svc.cache.isRevoked(claims))
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, svc.cache.isRevoked(claims))
+		})
+	}
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	tests := []struct {
+		name            string
+		setup           func(sessionMock *mocks.SessionStorer, userMock *mocks.UserStorer)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name: "Every token of the user is revoked",
+			setup: func(sessionMock *mocks.SessionStorer, userMock *mocks.UserStorer) {
+				tx := &sql.Tx{}
+				userMock.On("GetUserById", mock.Anything, dto.GetUserByIdReq{UserId: 7}).Return(dto.GetUserByIdResp{UserId: 7}, nil).Once()
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("BlacklistToken", mock.Anything, tx, mock.MatchedBy(func(token repository.BlacklistedToken) bool {
+					return token.UserID == 7 && !token.Token.Valid && token.ExpiresAt > token.RevokedAt
+				})).Return(nil).Once()
+				sessionMock.On("RevokeUserRefreshTokens", mock.Anything, tx, int64(7)).Return(nil).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Revoking the refresh tokens fails",
+			setup: func(sessionMock *mocks.SessionStorer, userMock *mocks.UserStorer) {
+				tx := &sql.Tx{}
+				userMock.On("GetUserById", mock.Anything, dto.GetUserByIdReq{UserId: 7}).Return(dto.GetUserByIdResp{UserId: 7}, nil).Once()
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("BlacklistToken", mock.Anything, tx, mock.Anything).Return(nil).Once()
+				sessionMock.On("RevokeUserRefreshTokens", mock.Anything, tx, int64(7)).Return(apperrors.InternalServer).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+		{
+			name: "Unknown user",
+			setup: func(sessionMock *mocks.SessionStorer, userMock *mocks.UserStorer) {
+				userMock.On("GetUserById", mock.Anything, dto.GetUserByIdReq{UserId: 7}).Return(dto.GetUserByIdResp{}, apperrors.InvalidId).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.UserNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sessionMock := mocks.NewSessionStorer(t)
+			userMock := mocks.NewUserStorer(t)
+			test.setup(sessionMock, userMock)
+			svc := NewService(sessionMock, userMock, mocks.NewRoleStorer(t)).(*service)
+
+			err := svc.RevokeUserSessions(context.Background(), 7)
+
+			issuedBefore := dto.Claims{Id: 7, StandardClaims: jwt.StandardClaims{Id: "old", IssuedAt: time.Now().Add(-time.Hour).Unix()}}
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				assert.False(t, svc.cache.isRevoked(issuedBefore))
+				return
+			}
+			assert.NoError(t, err)
+			issuedAfter := dto.Claims{Id: 7, StandardClaims: jwt.StandardClaims{Id: "new", IssuedAt: time.Now().Add(time.Minute).Unix()}}
+			assert.True(t, svc.cache.isRevoked(issuedBefore))
+			assert.False(t, svc.cache.isRevoked(issuedAfter))
+		})
+	}
+}
+
+func TestRevokeUserSessionsLegacyToken(t *testing.T) {
+	sessionMock := mocks.NewSessionStorer(t)
+	userMock := mocks.NewUserStorer(t)
+	tx := &sql.Tx{}
+	var entry repository.BlacklistedToken
+	userMock.On("GetUserById", mock.Anything, dto.GetUserByIdReq{UserId: 7}).Return(dto.GetUserByIdResp{UserId: 7}, nil).Once()
+	sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	sessionMock.On("BlacklistToken", mock.Anything, tx, mock.Anything).Run(func(args mock.Arguments) {
+		entry = args.Get(2).(repository.BlacklistedToken)
+	}).Return(nil).Once()
+	sessionMock.On("RevokeUserRefreshTokens", mock.Anything, tx, int64(7)).Return(nil).Once()
+	sessionMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+	svc := NewService(sessionMock, userMock, mocks.NewRoleStorer(t))
+
+	err := svc.RevokeUserSessions(context.Background(), 7)
+
+	assert.NoError(t, err)
+	// a token issued before refresh tokens existed has no jti and lives for weeks
+	assert.GreaterOrEqual(t, entry.ExpiresAt-entry.RevokedAt, (time.Duration(constants.DefaultLegacyTokenExpiryHours) * time.Hour).Milliseconds())
+
+	// long after the access tokens expired, another instance still loads the revocation
+	sessionMock.On("ListBlacklistedTokens", mock.Anything, mock.Anything).Return([]repository.BlacklistedToken{entry}, nil).Once()
+	cache := newRevocationCache()
+	assert.NoError(t, cache.refresh(context.Background(), sessionMock, time.Now()))
+	legacy := dto.Claims{Id: 7, StandardClaims: jwt.StandardClaims{Id: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", IssuedAt: time.Now().Add(-20 * 24 * time.Hour).Unix()}}
+	assert.True(t, cache.isRevoked(legacy))
+}
+
+func TestIsRevoked(t *testing.T) {
+	blacklist := []repository.BlacklistedToken{
+		{UserID: 1, Token: sql.NullString{String: "revoked", Valid: true}, ExpiresAt: 1800000000000, RevokedAt: 1700000000000},
+		{UserID: 2, ExpiresAt: 1800000000000, RevokedAt: 1700000000000},
+	}
+
+	tests := []struct {
+		name     string
+		claims   dto.Claims
+		expected bool
+	}{
+		{
+			name:     "Revoked token",
+			claims:   dto.Claims{Id: 1, StandardClaims: jwt.StandardClaims{Id: "revoked", IssuedAt: 1690000000}},
+			expected: true,
+		},
+		{
+			name:     "Other token of the same user",
+			claims:   dto.Claims{Id: 1, StandardClaims: jwt.StandardClaims{Id: "valid", IssuedAt: 1690000000}},
+			expected: false,
+		},
+		{
+			name:     "Issued before the sessions of the user were revoked",
+			claims:   dto.Claims{Id: 2, StandardClaims: jwt.StandardClaims{Id: "old", IssuedAt: 1699999999}},
+			expected: true,
+		},
+		{
+			name:     "Issued after the sessions of the user were revoked",
+			claims:   dto.Claims{Id: 2, StandardClaims: jwt.StandardClaims{Id: "new", IssuedAt: 1700000000}},
+			expected: false,
+		},
+	}
+
+	sessionMock := mocks.NewSessionStorer(t)
+	// loaded once and then served from memory
+	sessionMock.On("ListBlacklistedTokens", mock.Anything, nil).Return(blacklist, nil).Once()
+	svc := NewService(sessionMock, mocks.NewUserStorer(t), mocks.NewRoleStorer(t))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			revoked, err := svc.IsRevoked(context.Background(), test.claims)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, revoked)
+		})
+	}
+}
+
+func TestIsRevokedKeepsServingAfterFailedReload(t *testing.T) {
+	sessionMock := mocks.NewSessionStorer(t)
+	sessionMock.On("ListBlacklistedTokens", mock.Anything, nil).Return(nil, apperrors.InternalServer).Once()
+	svc := NewService(sessionMock, mocks.NewUserStorer(t), mocks.NewRoleStorer(t)).(*service)
+	claims := dto.Claims{Id: 1, StandardClaims: jwt.StandardClaims{Id: "revoked"}}
+
+	_, err := svc.IsRevoked(context.Background(), claims)
+	assert.Equal(t, apperrors.InternalServer, err)
+
+	sessionMock.On("ListBlacklistedTokens", mock.Anything, nil).Return([]repository.BlacklistedToken{
+		{UserID: 1, Token: sql.NullString{String: "revoked", Valid: true}},
+	}, nil).Once()
+	revoked, err := svc.IsRevoked(context.Background(), claims)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	// the cache expires and the database is down
+	svc.cache.loadedAt = time.Now().Add(-2 * revocationCacheTTL)
+	sessionMock.On("ListBlacklistedTokens", mock.Anything, nil).Return(nil, apperrors.InternalServer).Once()
+	revoked, err = svc.IsRevoked(context.Background(), claims)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
@@ -0,0 +1,183 @@
+package sessions
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// IssueTokens starts a new session, every token the refresh token is rotated into joins its family
+func (sessSvc *service) IssueTokens(ctx context.Context, userID int64, role int) (dto.AuthTokens, error) {
+	return sessSvc.issueTokens(ctx, nil, userID, role, uuid.NewString())
+}
+
+// RefreshTokens swaps a refresh token for a new pair. Presenting a token that was already used means it leaked,
+// so the whole family is revoked and whoever holds it has to log in again.
+func (sessSvc *service) RefreshTokens(ctx context.Context, refreshToken string) (tokens dto.AuthTokens, err error) {
+
+	if refreshToken == "" {
+		return dto.AuthTokens{}, apperrors.InvalidRefreshToken
+	}
+	tokenHash := hashRefreshToken(refreshToken)
+
+	tx, err := sessSvc.sessionRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: BeginTx: err: %v", err)
+		return dto.AuthTokens{}, err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := sessSvc.sessionRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			tokens = dto.AuthTokens{}
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	current, err := sessSvc.sessionRepo.UseRefreshToken(ctx, tx, tokenHash)
+	if err != nil {
+		if err == apperrors.InvalidRefreshToken {
+			sessSvc.detectReuse(ctx, tokenHash)
+		}
+		return dto.AuthTokens{}, err
+	}
+
+	tokens, err = sessSvc.issueTokens(ctx, tx, current.UserID, current.Role, current.FamilyID)
+	if err != nil {
+		return dto.AuthTokens{}, err
+	}
+
+	logger.Infof(ctx, "sessionService: tokens of user %d refreshed", current.UserID)
+	return tokens, nil
+}
+
+// detectReuse revokes the family of a refresh token that was used before, it runs outside the refresh transaction
+// because that one is rolled back
+func (sessSvc *service) detectReuse(ctx context.Context, tokenHash string) {
+
+	token, err := sessSvc.sessionRepo.GetRefreshToken(ctx, nil, tokenHash)
+	if err != nil {
+		if err != apperrors.InvalidRefreshToken {
+			logger.Errorf(ctx, "sessionService: GetRefreshToken: err: %v", err)
+		}
+		return
+	}
+	if !token.UsedAt.Valid || token.RevokedAt.Valid {
+		return
+	}
+
+	logger.Warn(ctx, "sessionService: refresh token of user ", token.UserID, " reused, revoking session ", token.FamilyID)
+	err = sessSvc.sessionRepo.RevokeRefreshTokenFamily(ctx, nil, token.FamilyID)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: RevokeRefreshTokenFamily: family: %s, err: %v", token.FamilyID, err)
+	}
+}
+
+func (sessSvc *service) issueTokens(ctx context.Context, tx repository.Transaction, userID int64, role int, familyID string) (dto.AuthTokens, error) {
+
+	roleID, err := sessSvc.roleID(ctx, tx, userID, role)
+	if err != nil {
+		return dto.AuthTokens{}, err
+	}
+
+	now := time.Now()
+	// exp only has second precision
+	expiresAt := now.Add(config.AccessTokenExpiry()).Truncate(time.Second)
+	claims := &dto.Claims{
+		Id:        userID,
+		Role:      role,
+		RoleId:    roleID,
+		SessionId: familyID,
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.NewString(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+
+	authToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(config.JWTKey())
+	if err != nil {
+		logger.Errorf(ctx, "error generating authtoken. err: %s", err.Error())
+		return dto.AuthTokens{}, apperrors.InternalServerError
+	}
+
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		logger.Errorf(ctx, "error generating refresh token. err: %s", err.Error())
+		return dto.AuthTokens{}, apperrors.InternalServerError
+	}
+
+	err = sessSvc.sessionRepo.CreateRefreshToken(ctx, tx, repository.RefreshToken{
+		UserID:    userID,
+		Role:      role,
+		FamilyID:  familyID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ExpiresAt: now.Add(config.RefreshTokenExpiry()).UnixMilli(),
+	})
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: CreateRefreshToken: user: %d, err: %v", userID, err)
+		return dto.AuthTokens{}, err
+	}
+
+	return dto.AuthTokens{
+		AuthToken:          authToken,
+		AuthTokenExpiresAt: expiresAt.UnixMilli(),
+		RefreshToken:       refreshToken,
+	}, nil
+}
+
+// roleID reads the role of an admin every time its tokens are issued, an admin who was demoted can't refresh any more.
+// Tokens of the User tier only carry the permissions of a user whatever role the user holds.
+func (sessSvc *service) roleID(ctx context.Context, tx repository.Transaction, userID int64, role int) (int64, error) {
+
+	if role != constants.Admin {
+		return constants.UserRoleID, nil
+	}
+
+	roleID, err := sessSvc.roleRepo.GetUserRoleID(ctx, tx, userID)
+	if err != nil {
+		logger.Errorf(ctx, "sessionService: GetUserRoleID: user: %d, err: %v", userID, err)
+		return 0, err
+	}
+	if roleID != constants.SuperAdminRoleID && roleID != constants.AdminRoleID {
+		logger.Errorf(ctx, "sessionService: user %d no longer holds an admin role", userID)
+		return 0, apperrors.RoleUnathorized
+	}
+	return roleID, nil
+}
+
+func generateRefreshToken() (string, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// only the hash is stored, a leaked table can't be used to refresh
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
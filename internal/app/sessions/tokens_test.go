@@ -0,0 +1,160 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIssueTokens(t *testing.T) {
+	viper.Set(constants.JWTSecret, "secret")
+
+	sessionMock := mocks.NewSessionStorer(t)
+	var stored repository.RefreshToken
+	sessionMock.On("CreateRefreshToken", mock.Anything, nil, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(2).(repository.RefreshToken)
+	}).Return(nil).Once()
+	roleMock := mocks.NewRoleStorer(t)
+	roleMock.On("GetUserRoleID", mock.Anything, nil, int64(7)).Return(constants.SuperAdminRoleID, nil).Once()
+	svc := NewService(sessionMock, mocks.NewUserStorer(t), roleMock)
+
+	tokens, err := svc.IssueTokens(context.Background(), 7, constants.Admin)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, hashRefreshToken(tokens.RefreshToken), stored.TokenHash)
+	assert.Equal(t, int64(7), stored.UserID)
+	assert.Equal(t, constants.Admin, stored.Role)
+
+	claims := &dto.Claims{}
+	_, err = jwt.ParseWithClaims(tokens.AuthToken, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte("secret"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), claims.Id)
+	assert.Equal(t, constants.Admin, claims.Role)
+	assert.Equal(t, constants.SuperAdminRoleID, claims.RoleId)
+	assert.Equal(t, stored.FamilyID, claims.SessionId)
+	assert.Equal(t, tokens.AuthTokenExpiresAt, claims.ExpiresAt*1000)
+}
+
+func TestIssueTokensToDemotedAdmin(t *testing.T) {
+	roleMock := mocks.NewRoleStorer(t)
+	roleMock.On("GetUserRoleID", mock.Anything, nil, int64(7)).Return(constants.UserRoleID, nil).Once()
+	svc := NewService(mocks.NewSessionStorer(t), mocks.NewUserStorer(t), roleMock)
+
+	tokens, err := svc.IssueTokens(context.Background(), 7, constants.Admin)
+
+	assert.Equal(t, apperrors.RoleUnathorized, err)
+	assert.Empty(t, tokens)
+}
+
+func TestRefreshTokens(t *testing.T) {
+	viper.Set(constants.JWTSecret, "secret")
+
+	current := repository.RefreshToken{UserID: 7, Role: constants.User, FamilyID: "family-1"}
+
+	tests := []struct {
+		name            string
+		refreshToken    string
+		setup           func(sessionMock *mocks.SessionStorer)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name:         "Token is rotated within its family",
+			refreshToken: "valid",
+			setup: func(sessionMock *mocks.SessionStorer) {
+				tx := &sql.Tx{}
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("UseRefreshToken", mock.Anything, tx, hashRefreshToken("valid")).Return(current, nil).Once()
+				sessionMock.On("CreateRefreshToken", mock.Anything, tx, mock.MatchedBy(func(token repository.RefreshToken) bool {
+					return token.UserID == 7 && token.FamilyID == "family-1" && token.TokenHash != hashRefreshToken("valid")
+				})).Return(nil).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name:         "Reused token revokes the family",
+			refreshToken: "used",
+			setup: func(sessionMock *mocks.SessionStorer) {
+				tx := &sql.Tx{}
+				used := current
+				used.UsedAt = sql.NullInt64{Int64: 1700000000000, Valid: true}
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("UseRefreshToken", mock.Anything, tx, hashRefreshToken("used")).Return(repository.RefreshToken{}, apperrors.InvalidRefreshToken).Once()
+				sessionMock.On("GetRefreshToken", mock.Anything, nil, hashRefreshToken("used")).Return(used, nil).Once()
+				sessionMock.On("RevokeRefreshTokenFamily", mock.Anything, nil, "family-1").Return(nil).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidRefreshToken,
+		},
+		{
+			name:         "Revoked token",
+			refreshToken: "revoked",
+			setup: func(sessionMock *mocks.SessionStorer) {
+				tx := &sql.Tx{}
+				revoked := current
+				revoked.UsedAt = sql.NullInt64{Int64: 1700000000000, Valid: true}
+				revoked.RevokedAt = sql.NullInt64{Int64: 1700000000000, Valid: true}
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("UseRefreshToken", mock.Anything, tx, hashRefreshToken("revoked")).Return(repository.RefreshToken{}, apperrors.InvalidRefreshToken).Once()
+				sessionMock.On("GetRefreshToken", mock.Anything, nil, hashRefreshToken("revoked")).Return(revoked, nil).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidRefreshToken,
+		},
+		{
+			name:         "Unknown token",
+			refreshToken: "unknown",
+			setup: func(sessionMock *mocks.SessionStorer) {
+				tx := &sql.Tx{}
+				sessionMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				sessionMock.On("UseRefreshToken", mock.Anything, tx, hashRefreshToken("unknown")).Return(repository.RefreshToken{}, apperrors.InvalidRefreshToken).Once()
+				sessionMock.On("GetRefreshToken", mock.Anything, nil, hashRefreshToken("unknown")).Return(repository.RefreshToken{}, apperrors.InvalidRefreshToken).Once()
+				sessionMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidRefreshToken,
+		},
+		{
+			name:            "Missing token",
+			refreshToken:    "",
+			setup:           func(sessionMock *mocks.SessionStorer) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidRefreshToken,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sessionMock := mocks.NewSessionStorer(t)
+			test.setup(sessionMock)
+			svc := NewService(sessionMock, mocks.NewUserStorer(t), mocks.NewRoleStorer(t))
+
+			tokens, err := svc.RefreshTokens(context.Background(), test.refreshToken)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				assert.Empty(t, tokens)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, tokens.AuthToken)
+			assert.NotEqual(t, test.refreshToken, tokens.RefreshToken)
+		})
+	}
+}
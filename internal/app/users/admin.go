@@ -0,0 +1,265 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"math"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (us *service) AdminListUsers(ctx context.Context, req dto.AdminListUsersReq) (resp dto.AdminListUsersResp, err error) {
+
+	if req.Status != "" && req.Status != constants.ActiveUser && req.Status != constants.DeactivatedUser {
+		return resp, apperrors.InvalidUserStatus
+	}
+
+	dbUsers, count, err := us.userRepo.AdminListUsers(ctx, nil, req)
+	if err != nil {
+		logger.Errorf(ctx, "userService: AdminListUsers: err: %v", err)
+		return resp, err
+	}
+
+	resp.Users = make([]dto.AdminUser, 0, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		resp.Users = append(resp.Users, mapUserDbToAdminUser(dbUser))
+	}
+	resp.MetaData = dto.PageToken{
+		CurrentPage:  req.Page,
+		PageSize:     req.PageSize,
+		TotalRecords: count,
+		TotalPage:    int64(math.Ceil(float64(count) / float64(req.PageSize))),
+	}
+	return resp, nil
+}
+
+func (us *service) AdminGetUser(ctx context.Context, userID int64) (dto.AdminUser, error) {
+
+	dbUser, err := us.userRepo.GetUser(ctx, nil, userID)
+	if err != nil {
+		logger.Errorf(ctx, "userService: GetUser: user: %d, err: %v", userID, err)
+		return dto.AdminUser{}, err
+	}
+	return mapUserDbToAdminUser(dbUser), nil
+}
+
+// DeactivateUser blocks the user from the app, their appreciations stay
+func (us *service) DeactivateUser(ctx context.Context, userID int64) error {
+
+	updatedBy, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "userService: err in parsing userid from token")
+		return apperrors.InternalServer
+	}
+	if userID == updatedBy {
+		return apperrors.CannotDeactivateUser
+	}
+
+	dbUser, err := us.userRepo.GetUser(ctx, nil, userID)
+	if err != nil {
+		logger.Errorf(ctx, "userService: GetUser: user: %d, err: %v", userID, err)
+		return err
+	}
+	if dbUser.RoleID == constants.SuperAdminRoleID {
+		return apperrors.CannotDeactivateUser
+	}
+
+	err = us.deactivate(ctx, userID, updatedBy)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "userService: user %d deactivated by user %d", userID, updatedBy)
+	return nil
+}
+
+// deactivate freezes the quota of the user, forgets their devices and logs them out everywhere. updatedBy is 0 when
+// the intranet sync deactivates a leaver.
+func (us *service) deactivate(ctx context.Context, userID int64, updatedBy int64) error {
+
+	err := us.updateStatusAndForgetDevices(ctx, userID, updatedBy)
+	if err != nil {
+		return err
+	}
+	us.statusCache.set(userID, true)
+
+	err = us.sessionSvc.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		logger.Errorf(ctx, "userService: RevokeUserSessions: user: %d, err: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+func (us *service) updateStatusAndForgetDevices(ctx context.Context, userID int64, updatedBy int64) (err error) {
+
+	tx, err := us.userRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "userService: BeginTx: err: %v", err)
+		return err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := us.userRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	err = us.userRepo.UpdateUserStatus(ctx, tx, userID, constants.DeactivatedUserStatus, updatedBy)
+	if err != nil {
+		logger.Errorf(ctx, "userService: UpdateUserStatus: user: %d, err: %v", userID, err)
+		return err
+	}
+
+	err = us.userRepo.DeleteDeviceTokens(ctx, tx, userID)
+	if err != nil {
+		logger.Errorf(ctx, "userService: DeleteDeviceTokens: user: %d, err: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+func (us *service) ReactivateUser(ctx context.Context, userID int64) error {
+
+	updatedBy, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "userService: err in parsing userid from token")
+		return apperrors.InternalServer
+	}
+
+	_, err := us.userRepo.GetUser(ctx, nil, userID)
+	if err != nil {
+		logger.Errorf(ctx, "userService: GetUser: user: %d, err: %v", userID, err)
+		return err
+	}
+
+	err = us.userRepo.UpdateUserStatus(ctx, nil, userID, constants.ActiveUserStatus, updatedBy)
+	if err != nil {
+		logger.Errorf(ctx, "userService: UpdateUserStatus: user: %d, err: %v", userID, err)
+		return err
+	}
+	us.statusCache.set(userID, false)
+
+	logger.Infof(ctx, "userService: user %d reactivated by user %d", userID, updatedBy)
+	return nil
+}
+
+// SetAdminPassword sets the password an admin logs in with, or generates one when none is given, and logs the admin
+// out everywhere
+func (us *service) SetAdminPassword(ctx context.Context, userID int64, req dto.SetAdminPasswordReq) (resp dto.SetAdminPasswordResp, err error) {
+
+	updatedBy, ok := ctx.Value(constants.UserId).(int64)
+	if !ok {
+		logger.Error(ctx, "userService: err in parsing userid from token")
+		return resp, apperrors.InternalServer
+	}
+
+	password := req.Password
+	if password == "" {
+		password, err = generatePassword()
+		if err != nil {
+			logger.Errorf(ctx, "userService: error generating password: %v", err)
+			return resp, apperrors.InternalServer
+		}
+		resp.Password = password
+	} else if len(password) < constants.MinAdminPasswordLength {
+		return resp, apperrors.PasswordTooShort
+	}
+
+	dbUser, err := us.userRepo.GetUser(ctx, nil, userID)
+	if err != nil {
+		logger.Errorf(ctx, "userService: GetUser: user: %d, err: %v", userID, err)
+		return dto.SetAdminPasswordResp{}, err
+	}
+	if dbUser.RoleID != constants.SuperAdminRoleID && dbUser.RoleID != constants.AdminRoleID {
+		return dto.SetAdminPasswordResp{}, apperrors.PasswordOnlyForAdmins
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		logger.Errorf(ctx, "userService: error hashing password: %v", err)
+		return dto.SetAdminPasswordResp{}, apperrors.InternalServer
+	}
+
+	err = us.userRepo.UpdatePassword(ctx, nil, userID, string(hash), updatedBy)
+	if err != nil {
+		logger.Errorf(ctx, "userService: UpdatePassword: user: %d, err: %v", userID, err)
+		return dto.SetAdminPasswordResp{}, err
+	}
+
+	err = us.sessionSvc.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		logger.Errorf(ctx, "userService: RevokeUserSessions: user: %d, err: %v", userID, err)
+		return dto.SetAdminPasswordResp{}, err
+	}
+
+	logger.Infof(ctx, "userService: password of user %d set by user %d", userID, updatedBy)
+	return resp, nil
+}
+
+func (us *service) IsDeactivated(ctx context.Context, userID int64) (bool, error) {
+
+	now := time.Now()
+	if us.statusCache.isStale(now) {
+		err := us.statusCache.refresh(ctx, us.userRepo, now)
+		if err != nil {
+			logger.Errorf(ctx, "userService: error in loading the deactivated users: %v", err)
+			return false, err
+		}
+	}
+	return us.statusCache.isDeactivated(userID), nil
+}
+
+func mapUserDbToAdminUser(dbUser repository.User) dto.AdminUser {
+	adminUser := dto.AdminUser{
+		Id:                 dbUser.Id,
+		EmployeeId:         dbUser.EmployeeId,
+		FirstName:          dbUser.FirstName,
+		LastName:           dbUser.LastName,
+		Email:              dbUser.Email,
+		ProfileImgUrl:      dbUser.ProfileImageURL.String,
+		Designation:        dbUser.Designation,
+		GradeId:            dbUser.GradeId,
+		RoleId:             dbUser.RoleID,
+		RewardQuotaBalance: dbUser.RewardsQuotaBalance,
+		Status:             constants.ActiveUser,
+		DeactivatedAt:      dbUser.DeactivatedAt.Int64,
+		DeactivatedBy:      dbUser.DeactivatedBy.Int64,
+		FrozenRewardQuota:  dbUser.FrozenRewardQuota,
+		DepartmentId:       dbUser.DepartmentID.Int64,
+		TeamId:             dbUser.TeamID.Int64,
+		ManagerId:          dbUser.ManagerID.Int64,
+		CreatedAt:          dbUser.CreatedAt,
+	}
+	if dbUser.Status == constants.DeactivatedUserStatus {
+		adminUser.Status = constants.DeactivatedUser
+	}
+	return adminUser
+}
+
+func generatePassword() (string, error) {
+	password := make([]byte, 12)
+	_, err := rand.Read(password)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(password), nil
+}
@@ -0,0 +1,275 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/identity"
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
+	sessionMocks "github.com/joshsoftware/peerly-backend/internal/app/sessions/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newAdminTestService(t *testing.T, userRepo *mocks.UserStorer, sessionSvc *sessionMocks.Service) *service {
+	return NewService(userRepo, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), mocks.NewUserSyncStorer(t), mocks.NewOrgStorer(t), sessionSvc, identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{})).(*service)
+}
+
+func TestAdminListUsers(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             dto.AdminListUsersReq
+		setup           func(userMock *mocks.UserStorer)
+		isErrorExpected bool
+		expectedError   error
+		expectedResp    dto.AdminListUsersResp
+	}{
+		{
+			name: "Deactivated users are listed with their status",
+			req:  dto.AdminListUsersReq{Status: constants.DeactivatedUser, Page: 1, PageSize: 10},
+			setup: func(userMock *mocks.UserStorer) {
+				userMock.On("AdminListUsers", mock.Anything, nil, dto.AdminListUsersReq{Status: constants.DeactivatedUser, Page: 1, PageSize: 10}).Return([]repository.User{
+					{Id: 4, FirstName: "Sam", RoleID: constants.UserRoleID, Status: constants.DeactivatedUserStatus, DeactivatedAt: sql.NullInt64{Int64: 1700000000000, Valid: true}, DeactivatedBy: sql.NullInt64{Int64: 1, Valid: true}},
+				}, int64(11), nil).Once()
+			},
+			isErrorExpected: false,
+			expectedResp: dto.AdminListUsersResp{
+				Users: []dto.AdminUser{
+					{Id: 4, FirstName: "Sam", RoleId: constants.UserRoleID, Status: constants.DeactivatedUser, DeactivatedAt: 1700000000000, DeactivatedBy: 1},
+				},
+				MetaData: dto.PageToken{CurrentPage: 1, PageSize: 10, TotalRecords: 11, TotalPage: 2},
+			},
+		},
+		{
+			name:            "Unknown status",
+			req:             dto.AdminListUsersReq{Status: "archived", Page: 1, PageSize: 10},
+			setup:           func(userMock *mocks.UserStorer) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.InvalidUserStatus,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userMock := mocks.NewUserStorer(t)
+			test.setup(userMock)
+			svc := newAdminTestService(t, userMock, new(sessionMocks.Service))
+
+			resp, err := svc.AdminListUsers(context.Background(), test.req)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedResp, resp)
+		})
+	}
+}
+
+func TestDeactivateUser(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+
+	tests := []struct {
+		name            string
+		userID          int64
+		setup           func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name:   "User is deactivated and logged out",
+			userID: 4,
+			setup: func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service) {
+				userMock.On("GetUser", mock.Anything, nil, int64(4)).Return(repository.User{Id: 4, RoleID: constants.UserRoleID}, nil).Once()
+				tx := &sql.Tx{}
+				userMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				userMock.On("UpdateUserStatus", mock.Anything, tx, int64(4), constants.DeactivatedUserStatus, int64(1)).Return(nil).Once()
+				userMock.On("DeleteDeviceTokens", mock.Anything, tx, int64(4)).Return(nil).Once()
+				userMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+				sessionSvc.On("RevokeUserSessions", mock.Anything, int64(4)).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name:   "Device tokens can't be removed",
+			userID: 4,
+			setup: func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service) {
+				tx := &sql.Tx{}
+				userMock.On("GetUser", mock.Anything, nil, int64(4)).Return(repository.User{Id: 4, RoleID: constants.UserRoleID}, nil).Once()
+				userMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				userMock.On("UpdateUserStatus", mock.Anything, tx, int64(4), constants.DeactivatedUserStatus, int64(1)).Return(nil).Once()
+				userMock.On("DeleteDeviceTokens", mock.Anything, tx, int64(4)).Return(apperrors.InternalServer).Once()
+				userMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+		{
+			name:            "Admin can't deactivate themselves",
+			userID:          1,
+			setup:           func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.CannotDeactivateUser,
+		},
+		{
+			name:   "Super admin can't be deactivated",
+			userID: 2,
+			setup: func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service) {
+				userMock.On("GetUser", mock.Anything, nil, int64(2)).Return(repository.User{Id: 2, RoleID: constants.SuperAdminRoleID}, nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.CannotDeactivateUser,
+		},
+		{
+			name:   "Unknown user",
+			userID: 9,
+			setup: func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service) {
+				userMock.On("GetUser", mock.Anything, nil, int64(9)).Return(repository.User{}, apperrors.UserNotFound).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.UserNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userMock := mocks.NewUserStorer(t)
+			sessionSvc := new(sessionMocks.Service)
+			test.setup(userMock, sessionSvc)
+			svc := newAdminTestService(t, userMock, sessionSvc)
+
+			err := svc.DeactivateUser(ctx, test.userID)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				assert.False(t, svc.statusCache.isDeactivated(test.userID))
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, svc.statusCache.isDeactivated(test.userID))
+			sessionSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReactivateUser(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+	userMock := mocks.NewUserStorer(t)
+	userMock.On("GetUser", mock.Anything, nil, int64(4)).Return(repository.User{Id: 4, Status: constants.DeactivatedUserStatus}, nil).Once()
+	userMock.On("UpdateUserStatus", mock.Anything, nil, int64(4), constants.ActiveUserStatus, int64(1)).Return(nil).Once()
+	svc := newAdminTestService(t, userMock, new(sessionMocks.Service))
+	svc.statusCache.set(4, true)
+
+	err := svc.ReactivateUser(ctx, 4)
+
+	assert.NoError(t, err)
+	assert.False(t, svc.statusCache.isDeactivated(4))
+}
+
+func TestSetAdminPassword(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+
+	tests := []struct {
+		name              string
+		req               dto.SetAdminPasswordReq
+		setup             func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service)
+		isErrorExpected   bool
+		expectedError     error
+		passwordGenerated bool
+	}{
+		{
+			name: "Given password is hashed",
+			req:  dto.SetAdminPasswordReq{Password: "correct horse"},
+			setup: func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service) {
+				userMock.On("GetUser", mock.Anything, nil, int64(2)).Return(repository.User{Id: 2, RoleID: constants.AdminRoleID}, nil).Once()
+				userMock.On("UpdatePassword", mock.Anything, nil, int64(2), mock.MatchedBy(func(hash string) bool {
+					return bcrypt.CompareHashAndPassword([]byte(hash), []byte("correct horse")) == nil
+				}), int64(1)).Return(nil).Once()
+				sessionSvc.On("RevokeUserSessions", mock.Anything, int64(2)).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Password is generated when none is given",
+			req:  dto.SetAdminPasswordReq{},
+			setup: func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service) {
+				userMock.On("GetUser", mock.Anything, nil, int64(2)).Return(repository.User{Id: 2, RoleID: constants.SuperAdminRoleID}, nil).Once()
+				userMock.On("UpdatePassword", mock.Anything, nil, int64(2), mock.Anything, int64(1)).Return(nil).Once()
+				sessionSvc.On("RevokeUserSessions", mock.Anything, int64(2)).Return(nil).Once()
+			},
+			isErrorExpected:   false,
+			passwordGenerated: true,
+		},
+		{
+			name:            "Password too short",
+			req:             dto.SetAdminPasswordReq{Password: "short"},
+			setup:           func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service) {},
+			isErrorExpected: true,
+			expectedError:   apperrors.PasswordTooShort,
+		},
+		{
+			name: "User isn't an admin",
+			req:  dto.SetAdminPasswordReq{Password: "correct horse"},
+			setup: func(userMock *mocks.UserStorer, sessionSvc *sessionMocks.Service) {
+				userMock.On("GetUser", mock.Anything, nil, int64(2)).Return(repository.User{Id: 2, RoleID: constants.UserRoleID}, nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.PasswordOnlyForAdmins,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userMock := mocks.NewUserStorer(t)
+			sessionSvc := new(sessionMocks.Service)
+			test.setup(userMock, sessionSvc)
+			svc := newAdminTestService(t, userMock, sessionSvc)
+
+			resp, err := svc.SetAdminPassword(ctx, 2, test.req)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				assert.Empty(t, resp)
+				return
+			}
+			assert.NoError(t, err)
+			if test.passwordGenerated {
+				assert.GreaterOrEqual(t, len(resp.Password), constants.MinAdminPasswordLength)
+			} else {
+				assert.Empty(t, resp.Password)
+			}
+			sessionSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIsDeactivatedKeepsServingAfterFailedReload(t *testing.T) {
+	userMock := mocks.NewUserStorer(t)
+	userMock.On("ListDeactivatedUserIDs", mock.Anything, nil).Return([]int64{4}, nil).Once()
+	svc := newAdminTestService(t, userMock, new(sessionMocks.Service))
+
+	deactivated, err := svc.IsDeactivated(context.Background(), 4)
+	assert.NoError(t, err)
+	assert.True(t, deactivated)
+
+	// served from memory until the cache expires
+	deactivated, err = svc.IsDeactivated(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.False(t, deactivated)
+
+	svc.statusCache.loadedAt = time.Now().Add(-2 * statusCacheTTL)
+	userMock.On("ListDeactivatedUserIDs", mock.Anything, nil).Return(nil, apperrors.InternalServer).Once()
+	deactivated, err = svc.IsDeactivated(context.Background(), 4)
+	assert.NoError(t, err)
+	assert.True(t, deactivated)
+}
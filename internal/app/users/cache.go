@@ -0,0 +1,83 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// how long the deactivated users are served from memory, deactivations made by other instances take effect within it
+const statusCacheTTL = 30 * time.Second
+
+// statusCache keeps the ids of the deactivated users in memory so authenticating a request doesn't hit the database
+type statusCache struct {
+	mu          sync.RWMutex
+	loadedAt    time.Time
+	loaded      bool
+	deactivated map[int64]bool
+}
+
+func newStatusCache() *statusCache {
+	return &statusCache{
+		deactivated: make(map[int64]bool),
+	}
+}
+
+func (sc *statusCache) isDeactivated(userID int64) bool {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	return sc.deactivated[userID]
+}
+
+func (sc *statusCache) isStale(now time.Time) bool {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	return !sc.loaded || now.Sub(sc.loadedAt) > statusCacheTTL
+}
+
+// refresh reloads the deactivated users, a failed reload keeps serving the previous ones until the next attempt
+func (sc *statusCache) refresh(ctx context.Context, userRepo repository.UserStorer, now time.Time) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.loaded && now.Sub(sc.loadedAt) <= statusCacheTTL {
+		return nil
+	}
+
+	userIDs, err := userRepo.ListDeactivatedUserIDs(ctx, nil)
+	if err != nil {
+		if !sc.loaded {
+			return err
+		}
+		logger.Errorf(ctx, "userService: serving the previous deactivated users, reload failed: %v", err)
+		sc.loadedAt = now
+		return nil
+	}
+
+	deactivated := make(map[int64]bool, len(userIDs))
+	for _, userID := range userIDs {
+		deactivated[userID] = true
+	}
+
+	sc.deactivated = deactivated
+	sc.loadedAt = now
+	sc.loaded = true
+	return nil
+}
+
+// set applies a status change made by this instance right away instead of after the next reload
+func (sc *statusCache) set(userID int64, deactivated bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if deactivated {
+		sc.deactivated[userID] = true
+		return
+	}
+	delete(sc.deactivated, userID)
+}
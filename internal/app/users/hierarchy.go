@@ -0,0 +1,189 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// intranetHierarchy caches the departments and teams looked up or created while applying one list of intranet users
+type intranetHierarchy struct {
+	departments map[string]int64
+	teams       map[int64]map[string]int64
+}
+
+// applyIntranetHierarchy places the users in the departments and teams and under the managers the intranet lists them
+// with, the departments and teams Peerly doesn't know yet are created. What the intranet leaves out stays as admins set
+// it. It returns the emails of the users whose place changed.
+func (us *service) applyIntranetHierarchy(ctx context.Context, tx repository.Transaction, intranetUsers []dto.IntranetUserData) ([]string, error) {
+
+	placed := make([]dto.IntranetUserData, 0)
+	seen := make(map[string]bool)
+	emails := make([]string, 0)
+	lookup := make(map[string]bool)
+	for _, intranetUser := range intranetUsers {
+		detail := intranetUser.EmpolyeeDetail
+		if detail.Department == "" && detail.Team == "" && detail.ManagerEmail == "" {
+			continue
+		}
+		// the first listing of a user wins, like it does when importing them
+		email := strings.ToLower(strings.TrimSpace(intranetUser.Email))
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		placed = append(placed, intranetUser)
+
+		for _, email := range []string{email, strings.ToLower(detail.ManagerEmail)} {
+			if email != "" && !lookup[email] {
+				lookup[email] = true
+				emails = append(emails, email)
+			}
+		}
+	}
+	if len(placed) == 0 {
+		return nil, nil
+	}
+
+	dbUsers, err := us.userRepo.ListUsersByEmails(ctx, tx, emails)
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string]repository.User, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		users[strings.ToLower(dbUser.Email)] = dbUser
+	}
+
+	cache := &intranetHierarchy{
+		departments: make(map[string]int64),
+		teams:       make(map[int64]map[string]int64),
+	}
+	changed := make([]string, 0)
+	for _, intranetUser := range placed {
+		dbUser, ok := users[strings.ToLower(strings.TrimSpace(intranetUser.Email))]
+		if !ok {
+			// users the sync or import skipped aren't in Peerly
+			continue
+		}
+
+		hierarchy, err := us.intranetUserHierarchy(ctx, tx, cache, dbUser, intranetUser.EmpolyeeDetail, users)
+		if err != nil {
+			return nil, err
+		}
+		if hierarchy == userHierarchy(dbUser) {
+			continue
+		}
+
+		err = us.orgRepo.UpdateUserHierarchy(ctx, tx, dbUser.Id, hierarchy, 0)
+		if err != nil {
+			return nil, err
+		}
+		changed = append(changed, dbUser.Email)
+	}
+
+	return changed, nil
+}
+
+// intranetUserHierarchy overrides the current place of a user with what the intranet lists them with
+func (us *service) intranetUserHierarchy(ctx context.Context, tx repository.Transaction, cache *intranetHierarchy, dbUser repository.User, detail dto.EmployeeDetail, users map[string]repository.User) (repository.UserHierarchy, error) {
+
+	current := userHierarchy(dbUser)
+	hierarchy := current
+
+	department := strings.TrimSpace(detail.Department)
+	if department != "" {
+		departmentID, err := us.intranetDepartment(ctx, tx, cache, department)
+		if err != nil {
+			return repository.UserHierarchy{}, err
+		}
+		hierarchy.DepartmentID = sql.NullInt64{Int64: departmentID, Valid: true}
+		if hierarchy.DepartmentID != current.DepartmentID {
+			// the team the user was in belongs to the department they left
+			hierarchy.TeamID = sql.NullInt64{}
+		}
+	}
+
+	team := strings.TrimSpace(detail.Team)
+	if team != "" {
+		if !hierarchy.DepartmentID.Valid {
+			logger.Warn(ctx, "userService: ignoring intranet team ", team, ", the user isn't in a department")
+		} else {
+			teamID, err := us.intranetTeam(ctx, tx, cache, hierarchy.DepartmentID.Int64, team)
+			if err != nil {
+				return repository.UserHierarchy{}, err
+			}
+			hierarchy.TeamID = sql.NullInt64{Int64: teamID, Valid: true}
+		}
+	}
+
+	if detail.ManagerEmail != "" {
+		manager, ok := users[strings.ToLower(detail.ManagerEmail)]
+		if !ok {
+			logger.Warn(ctx, "userService: ignoring intranet manager ", detail.ManagerEmail, ", they aren't in Peerly")
+		} else if manager.Id == dbUser.Id {
+			logger.Warn(ctx, "userService: ignoring intranet manager of ", dbUser.Email, ", they can't report to themselves")
+		} else {
+			hierarchy.ManagerID = sql.NullInt64{Int64: manager.Id, Valid: true}
+		}
+	}
+
+	return hierarchy, nil
+}
+
+func userHierarchy(dbUser repository.User) repository.UserHierarchy {
+	return repository.UserHierarchy{
+		DepartmentID: dbUser.DepartmentID,
+		TeamID:       dbUser.TeamID,
+		ManagerID:    dbUser.ManagerID,
+	}
+}
+
+func (us *service) intranetDepartment(ctx context.Context, tx repository.Transaction, cache *intranetHierarchy, name string) (int64, error) {
+
+	key := strings.ToLower(name)
+	id, ok := cache.departments[key]
+	if ok {
+		return id, nil
+	}
+
+	department, err := us.orgRepo.GetDepartmentByName(ctx, tx, name)
+	if err == apperrors.DepartmentNotFound {
+		logger.Infof(ctx, "userService: creating department %s listed by the intranet", name)
+		department, err = us.orgRepo.CreateDepartment(ctx, tx, repository.Department{Name: name})
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	cache.departments[key] = department.ID
+	return department.ID, nil
+}
+
+func (us *service) intranetTeam(ctx context.Context, tx repository.Transaction, cache *intranetHierarchy, departmentID int64, name string) (int64, error) {
+
+	key := strings.ToLower(name)
+	id, ok := cache.teams[departmentID][key]
+	if ok {
+		return id, nil
+	}
+
+	team, err := us.orgRepo.GetTeamByName(ctx, tx, departmentID, name)
+	if err == apperrors.TeamNotFound {
+		logger.Infof(ctx, "userService: creating team %s listed by the intranet in department %d", name, departmentID)
+		team, err = us.orgRepo.CreateTeam(ctx, tx, repository.Team{DepartmentID: departmentID, Name: name})
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if cache.teams[departmentID] == nil {
+		cache.teams[departmentID] = make(map[string]int64)
+	}
+	cache.teams[departmentID][key] = team.ID
+	return team.ID, nil
+}
@@ -0,0 +1,203 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// userImport is the state shared by the batches of one import
+type userImport struct {
+	rewardMultiplier int64
+	roleID           int64
+	grades           map[string]repository.Grade
+	seen             map[string]bool
+	imported         []dto.IntranetUserData
+	upToDate         map[string]bool
+	summary          dto.UserImportSummary
+}
+
+// ImportUsers registers the users that don't exist yet and updates the profiles that changed, batch by batch within a
+// single transaction. Users without an email, with an unknown grade or already up to date are skipped.
+func (us *service) ImportUsers(ctx context.Context, users []dto.IntranetUserData, opts dto.UserImportOptions) (summary dto.UserImportSummary, err error) {
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = constants.DefaultUserImportBatchSize
+	}
+
+	rewardMultiplier, err := us.userRepo.GetRewardMultiplier(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "userService: GetRewardMultiplier: err: %v", err)
+		return summary, apperrors.InternalServerError
+	}
+
+	roleID, err := us.userRepo.GetRoleByName(ctx, constants.UserRole)
+	if err != nil {
+		logger.Errorf(ctx, "userService: GetRoleByName: err: %v", err)
+		return summary, apperrors.InternalServerError
+	}
+
+	imp := &userImport{
+		rewardMultiplier: rewardMultiplier,
+		roleID:           roleID,
+		grades:           make(map[string]repository.Grade),
+		seen:             make(map[string]bool),
+		upToDate:         make(map[string]bool),
+	}
+
+	tx, err := us.userRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "userService: BeginTx: err: %v", err)
+		return summary, err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		// a dry run is rolled back once everything was written
+		txErr := us.userRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil && !opts.DryRun)
+		if txErr != nil {
+			err = txErr
+			summary = dto.UserImportSummary{}
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	for start := 0; start < len(users); start += opts.BatchSize {
+		end := start + opts.BatchSize
+		if end > len(users) {
+			end = len(users)
+		}
+
+		err = us.importBatch(ctx, tx, imp, users[start:end])
+		if err != nil {
+			return dto.UserImportSummary{}, err
+		}
+	}
+
+	// the users skipped for a missing email, a duplicate or an unknown grade aren't placed either
+	placed, err := us.applyIntranetHierarchy(ctx, tx, imp.imported)
+	if err != nil {
+		return dto.UserImportSummary{}, err
+	}
+	for _, email := range placed {
+		email = strings.ToLower(email)
+		if imp.upToDate[email] {
+			delete(imp.upToDate, email)
+			imp.summary.Updated++
+			imp.summary.Skipped--
+		}
+	}
+
+	logger.Infof(ctx, "userService: imported users, created: %d, updated: %d, skipped: %d, dry run: %t",
+		imp.summary.Created, imp.summary.Updated, imp.summary.Skipped, opts.DryRun)
+	return imp.summary, nil
+}
+
+func (us *service) importBatch(ctx context.Context, tx repository.Transaction, imp *userImport, users []dto.IntranetUserData) error {
+
+	batch := make([]dto.IntranetUserData, 0, len(users))
+	emails := make([]string, 0, len(users))
+	for _, user := range users {
+		email := strings.ToLower(strings.TrimSpace(user.Email))
+		if email == "" || imp.seen[email] {
+			logger.Warn(ctx, "userService: skipping user without an email or listed twice: ", user.Email)
+			imp.summary.Skipped++
+			continue
+		}
+		imp.seen[email] = true
+		user.Email = strings.TrimSpace(user.Email)
+		batch = append(batch, user)
+		emails = append(emails, user.Email)
+	}
+
+	dbUsers, err := us.userRepo.ListUsersByEmails(ctx, tx, emails)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]repository.User, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		existing[strings.ToLower(dbUser.Email)] = dbUser
+	}
+
+	newUsers := make([]dto.User, 0, len(batch))
+	for _, user := range batch {
+		grade, err := us.importGrade(ctx, imp, user.EmpolyeeDetail.Grade)
+		if err == apperrors.GradeNotFound {
+			logger.Warn(ctx, "userService: skipping user ", user.Email, " with unknown grade ", user.EmpolyeeDetail.Grade)
+			imp.summary.Skipped++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		profile := mapIntranetUserDataToSvcUser(user)
+		profile.GradeId = grade.Id
+
+		dbUser, ok := existing[strings.ToLower(user.Email)]
+		if !ok {
+			profile.RewardQuotaBalance = grade.Points * imp.rewardMultiplier
+			profile.RoleId = imp.roleID
+			newUsers = append(newUsers, profile)
+			imp.imported = append(imp.imported, user)
+			continue
+		}
+
+		imp.imported = append(imp.imported, user)
+		if !profileChanged(dbUser, profile) {
+			imp.upToDate[strings.ToLower(user.Email)] = true
+			imp.summary.Skipped++
+			continue
+		}
+		profile.Id = dbUser.Id
+		err = us.userRepo.UpdateUserProfile(ctx, tx, profile)
+		if err != nil {
+			return err
+		}
+		imp.summary.Updated++
+	}
+
+	err = us.userRepo.CreateUsers(ctx, tx, newUsers)
+	if err != nil {
+		return err
+	}
+	imp.summary.Created += int64(len(newUsers))
+	return nil
+}
+
+func (us *service) importGrade(ctx context.Context, imp *userImport, name string) (repository.Grade, error) {
+
+	grade, ok := imp.grades[name]
+	if ok {
+		return grade, nil
+	}
+
+	grade, err := us.userRepo.GetGradeByName(ctx, name)
+	if err != nil {
+		return repository.Grade{}, err
+	}
+	imp.grades[name] = grade
+	return grade, nil
+}
+
+func profileChanged(dbUser repository.User, profile dto.User) bool {
+	return dbUser.EmployeeId != profile.EmployeeId ||
+		dbUser.FirstName != profile.FirstName ||
+		dbUser.LastName != profile.LastName ||
+		dbUser.ProfileImageURL.String != profile.ProfileImgUrl ||
+		dbUser.Designation != profile.Designation ||
+		dbUser.GradeId != profile.GradeId
+}
@@ -0,0 +1,169 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/identity"
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
+	sessionMocks "github.com/joshsoftware/peerly-backend/internal/app/sessions/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportUsers(t *testing.T) {
+	grade := repository.Grade{Id: 1, Name: "J1", Points: 10}
+	unchanged := repository.User{Id: 12, Email: "unchanged@example.com", FirstName: "Unchanged", Designation: "Engineer", GradeId: 1}
+	promoted := repository.User{Id: 11, Email: "promoted@example.com", FirstName: "Promoted", Designation: "Engineer", GradeId: 1}
+
+	unknownGrade := intranetUser("contractor@example.com", "Contractor", "Consultant")
+	unknownGrade.EmpolyeeDetail.Grade = "C1"
+
+	users := []dto.IntranetUserData{
+		intranetUser("joiner@example.com", "Joiner", "Engineer"),
+		intranetUser("Promoted@example.com", "Promoted", "Lead"),
+		intranetUser("unchanged@example.com", "Unchanged", "Engineer"),
+		intranetUser("", "Nobody", "Engineer"),
+		intranetUser("JOINER@example.com", "Joiner", "Engineer"),
+		unknownGrade,
+	}
+
+	tests := []struct {
+		name            string
+		opts            dto.UserImportOptions
+		setup           func(userMock *mocks.UserStorer)
+		isErrorExpected bool
+		expectedError   error
+		expectedSummary dto.UserImportSummary
+	}{
+		{
+			name: "Users are created, updated and skipped in batches",
+			opts: dto.UserImportOptions{BatchSize: 3},
+			setup: func(userMock *mocks.UserStorer) {
+				tx := &sql.Tx{}
+				userMock.On("GetRewardMultiplier", mock.Anything).Return(int64(5), nil).Once()
+				userMock.On("GetRoleByName", mock.Anything, constants.UserRole).Return(constants.UserRoleID, nil).Once()
+				userMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				userMock.On("GetGradeByName", mock.Anything, "J1").Return(grade, nil).Once()
+				userMock.On("GetGradeByName", mock.Anything, "C1").Return(repository.Grade{}, apperrors.GradeNotFound).Once()
+
+				userMock.On("ListUsersByEmails", mock.Anything, tx, []string{"joiner@example.com", "Promoted@example.com", "unchanged@example.com"}).
+					Return([]repository.User{promoted, unchanged}, nil).Once()
+				userMock.On("UpdateUserProfile", mock.Anything, tx, mock.MatchedBy(func(user dto.User) bool {
+					return user.Id == 11 && user.Designation == "Lead" && user.GradeId == 1
+				})).Return(nil).Once()
+				userMock.On("CreateUsers", mock.Anything, tx, mock.MatchedBy(func(users []dto.User) bool {
+					return len(users) == 1 && users[0].Email == "joiner@example.com" &&
+						users[0].RewardQuotaBalance == 50 && users[0].RoleId == constants.UserRoleID
+				})).Return(nil).Once()
+
+				userMock.On("ListUsersByEmails", mock.Anything, tx, []string{"contractor@example.com"}).Return([]repository.User{}, nil).Once()
+				userMock.On("CreateUsers", mock.Anything, tx, []dto.User{}).Return(nil).Once()
+				userMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+			expectedSummary: dto.UserImportSummary{Created: 1, Updated: 1, Skipped: 4},
+		},
+		{
+			name: "Dry run is rolled back",
+			opts: dto.UserImportOptions{DryRun: true},
+			setup: func(userMock *mocks.UserStorer) {
+				tx := &sql.Tx{}
+				userMock.On("GetRewardMultiplier", mock.Anything).Return(int64(5), nil).Once()
+				userMock.On("GetRoleByName", mock.Anything, constants.UserRole).Return(constants.UserRoleID, nil).Once()
+				userMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				userMock.On("GetGradeByName", mock.Anything, "J1").Return(grade, nil).Once()
+				userMock.On("GetGradeByName", mock.Anything, "C1").Return(repository.Grade{}, apperrors.GradeNotFound).Once()
+				userMock.On("ListUsersByEmails", mock.Anything, tx, mock.Anything).Return([]repository.User{promoted, unchanged}, nil).Once()
+				userMock.On("UpdateUserProfile", mock.Anything, tx, mock.Anything).Return(nil).Once()
+				userMock.On("CreateUsers", mock.Anything, tx, mock.Anything).Return(nil).Once()
+				userMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: false,
+			expectedSummary: dto.UserImportSummary{Created: 1, Updated: 1, Skipped: 4},
+		},
+		{
+			name: "Failed insert rolls back the import",
+			opts: dto.UserImportOptions{},
+			setup: func(userMock *mocks.UserStorer) {
+				tx := &sql.Tx{}
+				userMock.On("GetRewardMultiplier", mock.Anything).Return(int64(5), nil).Once()
+				userMock.On("GetRoleByName", mock.Anything, constants.UserRole).Return(constants.UserRoleID, nil).Once()
+				userMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				userMock.On("GetGradeByName", mock.Anything, "J1").Return(grade, nil).Once()
+				userMock.On("GetGradeByName", mock.Anything, "C1").Return(repository.Grade{}, apperrors.GradeNotFound).Once()
+				userMock.On("ListUsersByEmails", mock.Anything, tx, mock.Anything).Return([]repository.User{promoted, unchanged}, nil).Once()
+				userMock.On("UpdateUserProfile", mock.Anything, tx, mock.Anything).Return(nil).Once()
+				userMock.On("CreateUsers", mock.Anything, tx, mock.Anything).Return(apperrors.InternalServer).Once()
+				userMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.InternalServer,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userMock := mocks.NewUserStorer(t)
+			test.setup(userMock)
+			svc := newAdminTestService(t, userMock, new(sessionMocks.Service))
+
+			summary, err := svc.ImportUsers(context.Background(), users, test.opts)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				assert.Empty(t, summary)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSummary, summary)
+		})
+	}
+}
+
+func TestImportUsersHierarchy(t *testing.T) {
+	valid := func(id int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: id, Valid: true}
+	}
+	placed := func(email string, grade string) dto.IntranetUserData {
+		user := intranetUser(email, "Unchanged", "Engineer")
+		user.EmpolyeeDetail.Grade = grade
+		user.EmpolyeeDetail.Department = "Engineering"
+		return user
+	}
+	unchanged := repository.User{Id: 12, Email: "unchanged@example.com", FirstName: "Unchanged", Designation: "Engineer", GradeId: 1}
+
+	userMock := mocks.NewUserStorer(t)
+	orgMock := mocks.NewOrgStorer(t)
+	tx := &sql.Tx{}
+	userMock.On("GetRewardMultiplier", mock.Anything).Return(int64(5), nil).Once()
+	userMock.On("GetRoleByName", mock.Anything, constants.UserRole).Return(constants.UserRoleID, nil).Once()
+	userMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	userMock.On("GetGradeByName", mock.Anything, "J1").Return(repository.Grade{Id: 1, Name: "J1"}, nil).Once()
+	userMock.On("GetGradeByName", mock.Anything, "C1").Return(repository.Grade{}, apperrors.GradeNotFound).Once()
+	userMock.On("ListUsersByEmails", mock.Anything, tx, []string{"unchanged@example.com", "contractor@example.com"}).Return([]repository.User{unchanged}, nil).Once()
+	userMock.On("CreateUsers", mock.Anything, tx, []dto.User{}).Return(nil).Once()
+	// only the user the import kept is placed, the duplicate and the contractor with an unknown grade aren't
+	userMock.On("ListUsersByEmails", mock.Anything, tx, []string{"unchanged@example.com"}).Return([]repository.User{unchanged}, nil).Once()
+	orgMock.On("GetDepartmentByName", mock.Anything, tx, "Engineering").Return(repository.Department{ID: 3}, nil).Once()
+	orgMock.On("UpdateUserHierarchy", mock.Anything, tx, int64(12), repository.UserHierarchy{DepartmentID: valid(3)}, int64(0)).Return(nil).Once()
+	userMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+
+	svc := NewService(userMock, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), mocks.NewUserSyncStorer(t), orgMock, new(sessionMocks.Service), identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{}))
+
+	summary, err := svc.ImportUsers(context.Background(), []dto.IntranetUserData{
+		placed("unchanged@example.com", "J1"),
+		placed("UNCHANGED@example.com", "J1"),
+		placed("contractor@example.com", "C1"),
+	}, dto.UserImportOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.UserImportSummary{Created: 0, Updated: 1, Skipped: 2}, summary)
+}
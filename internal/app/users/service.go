@@ -2,23 +2,22 @@ package user
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"math"
 	"strings"
 
-	"net/http"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
 	"github.com/xuri/excelize/v2"
 
 	// "github.com/joshsoftware/peerly-backend/internal/app/email"
+	"github.com/joshsoftware/peerly-backend/internal/app/identity"
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
+	"github.com/joshsoftware/peerly-backend/internal/app/sessions"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
-	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
 	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/utils"
 	"github.com/joshsoftware/peerly-backend/internal/repository"
@@ -26,115 +25,91 @@ import (
 )
 
 type service struct {
-	userRepo repository.UserStorer
+	userRepo         repository.UserStorer
+	notificationSvc  notification.NotificationService
+	notificationRepo repository.NotificationStorer
+	preferenceRepo   repository.NotificationPreferenceStorer
+	outboxRepo       repository.OutboxStorer
+	userSyncRepo     repository.UserSyncStorer
+	orgRepo          repository.OrgStorer
+	sessionSvc       sessions.Service
+	identityProvider identity.IdentityProvider
+	intranetClient   *intranet.Client
+	statusCache      *statusCache
 }
 
 type Service interface {
 	ValidatePeerly(ctx context.Context, authToken string) (data dto.ValidateResp, err error)
 	GetIntranetUserData(ctx context.Context, req dto.GetIntranetUserDataReq) (data dto.IntranetUserData, err error)
+	AuthenticateUser(ctx context.Context, credential string) (dto.IntranetUserData, error)
 	LoginUser(ctx context.Context, u dto.IntranetUserData) (dto.LoginUserResp, error)
 	RegisterUser(ctx context.Context, u dto.IntranetUserData) (user dto.User, err error)
 	ListIntranetUsers(ctx context.Context, reqData dto.GetUserListReq) (data []dto.IntranetUserData, err error)
 	ListUsers(ctx context.Context, reqData dto.ListUsersReq) (resp dto.ListUsersResp, err error)
 	GetUserById(ctx context.Context) (user dto.GetUserByIdResp, err error)
 	UpdateRewardQuota(ctx context.Context) (err error)
-	GetActiveUserList(ctx context.Context, quarter int, year int) ([]dto.ActiveUser, error)
-	GetTop10Users(ctx context.Context) (users []dto.Top10User, err error)
+	GetActiveUserList(ctx context.Context, quarter int, year int, filter dto.OrgFilter) ([]dto.ActiveUser, error)
+	GetTop10Users(ctx context.Context, filter dto.OrgFilter) (users []dto.Top10User, err error)
 	AdminLogin(ctx context.Context, loginReq dto.AdminLoginReq) (resp dto.LoginUserResp, err error)
 	NotificationByAdmin(ctx context.Context, notificationReq dto.AdminNotificationReq) (err error)
 	AllAppreciationReport(ctx context.Context, appreciations []dto.AppreciationResponse) (tempFileName string, err error)
 	ReportedAppreciationReport(ctx context.Context, appreciations []dto.ReportedAppreciation) (tempFileName string, err error)
-	DynamicEngagersReport(ctx context.Context, quarter int, year int) (tempFileName string, err error)
+	DynamicEngagersReport(ctx context.Context, quarter int, year int, filter dto.OrgFilter) (tempFileName string, err error)
+	AdminListUsers(ctx context.Context, req dto.AdminListUsersReq) (resp dto.AdminListUsersResp, err error)
+	AdminGetUser(ctx context.Context, userID int64) (dto.AdminUser, error)
+	DeactivateUser(ctx context.Context, userID int64) error
+	ReactivateUser(ctx context.Context, userID int64) error
+	SetAdminPassword(ctx context.Context, userID int64, req dto.SetAdminPasswordReq) (resp dto.SetAdminPasswordResp, err error)
+	IsDeactivated(ctx context.Context, userID int64) (bool, error)
+	SyncIntranetUsers(ctx context.Context) (dto.UserSyncReport, error)
+	ListUserSyncReports(ctx context.Context, limit int64) ([]dto.UserSyncReport, error)
+	ListAllIntranetUsers(ctx context.Context) ([]dto.IntranetUserData, error)
+	ImportUsers(ctx context.Context, users []dto.IntranetUserData, opts dto.UserImportOptions) (dto.UserImportSummary, error)
 }
 
-func NewService(userRepo repository.UserStorer) Service {
+func NewService(userRepo repository.UserStorer, notificationSvc notification.NotificationService, notificationRepo repository.NotificationStorer, preferenceRepo repository.NotificationPreferenceStorer, outboxRepo repository.OutboxStorer, userSyncRepo repository.UserSyncStorer, orgRepo repository.OrgStorer, sessionSvc sessions.Service, identityProvider identity.IdentityProvider, intranetClient *intranet.Client) Service {
 	return &service{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		notificationSvc:  notificationSvc,
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		outboxRepo:       outboxRepo,
+		userSyncRepo:     userSyncRepo,
+		orgRepo:          orgRepo,
+		sessionSvc:       sessionSvc,
+		identityProvider: identityProvider,
+		intranetClient:   intranetClient,
+		statusCache:      newStatusCache(),
 	}
 }
 
 func (us *service) ValidatePeerly(ctx context.Context, authToken string) (data dto.ValidateResp, err error) {
-	client := &http.Client{}
-	validationReq, err := http.NewRequest(http.MethodPost, config.IntranetBaseUrl()+constants.PeerlyValidationPath, nil)
+	data, err = us.intranetClient.ValidatePeerly(ctx, authToken)
 	if err != nil {
-		logger.Errorf(ctx, "error in creating new validation request err: %s", err.Error())
-		err = apperrors.InternalServerError
-		return
-	}
-	validationReq.Header.Add(constants.AuthorizationHeader, authToken)
-	validationReq.Header.Add(constants.ClientCode, config.IntranetClientCode())
-	resp, err := client.Do(validationReq)
-	if err != nil {
-		logger.Errorf(ctx, "error in intranet validation api. status returned: %d, err: %s", resp.StatusCode, err.Error())
-		err = apperrors.InternalServerError
-		return
-	}
-	if resp.StatusCode != http.StatusOK {
-		logger.Errorf(ctx, "error returned,  status returned: %d", resp.StatusCode)
-		err = apperrors.IntranetValidationFailed
-		return
+		logger.Errorf(ctx, "userService: %v", err)
+		return dto.ValidateResp{}, intranet.AppError(err)
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Errorf(ctx, "error in readall parsing. err: %s", err.Error())
-		err = apperrors.JSONParsingErrorResp
-		return
-	}
-	err = json.Unmarshal(body, &data)
-	if err != nil {
-		logger.Errorf(ctx, "error in unmarshal parsing. err: %s", err.Error())
-		err = apperrors.JSONParsingErrorResp
-		return
-	}
-
-	return
+	return data, nil
 }
 
 func (us *service) GetIntranetUserData(ctx context.Context, req dto.GetIntranetUserDataReq) (data dto.IntranetUserData, err error) {
-
-	client := &http.Client{}
-	url := fmt.Sprintf("%s%s%d", config.IntranetBaseUrl(), constants.GetIntranetUserDataPath, req.UserId)
-	intranetReq, err := http.NewRequest(http.MethodGet, url, nil)
+	data, err = us.intranetClient.GetUser(ctx, req)
 	if err != nil {
-		logger.Errorf(ctx, "error in creating new get user request. err: %s", err.Error())
-		err = apperrors.InternalServerError
-		return
-	}
-
-	intranetReq.Header.Add(constants.AuthorizationHeader, req.Token)
-	resp, err := client.Do(intranetReq)
-	if err != nil {
-		logger.Errorf(ctx, "error response: %v", resp)
-		err = apperrors.InternalServerError
-		return
-	}
-	if resp.StatusCode != http.StatusOK {
-		logger.Errorf(ctx, "error response: %v", resp)
-		err = apperrors.InternalServerError
-		return
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Errorf(ctx, "error in io.readall. err: %s", err.Error())
-		err = apperrors.JSONParsingErrorResp
-		return
+		logger.Errorf(ctx, "userService: %v", err)
+		return dto.IntranetUserData{}, intranet.AppError(err)
 	}
+	return data, nil
+}
 
-	var respData dto.IntranetGetUserDataResp
+// AuthenticateUser validates the credential a user logs in with at the identity provider and fetches their profile
+func (us *service) AuthenticateUser(ctx context.Context, credential string) (dto.IntranetUserData, error) {
 
-	err = json.Unmarshal(body, &respData)
+	userIdentity, err := us.identityProvider.ValidateCredential(ctx, credential)
 	if err != nil {
-		logger.Errorf(ctx, "error in unmarshalling data. err: %s", err.Error())
-		err = apperrors.JSONParsingErrorResp
-		return
+		return dto.IntranetUserData{}, err
 	}
 
-	data = respData.Data
-
-	return
+	return us.identityProvider.GetProfile(ctx, userIdentity)
 }
 
 func (us *service) LoginUser(ctx context.Context, u dto.IntranetUserData) (dto.LoginUserResp, error) {
@@ -177,29 +152,24 @@ func (us *service) LoginUser(ctx context.Context, u dto.IntranetUserData) (dto.L
 
 	//login user
 
-	expirationTime := time.Now().Add(time.Hour * time.Duration(config.JWTExpiryDurationHours()))
-
-	claims := &dto.Claims{
-		Id:   user.Id,
-		Role: constants.User,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
-		},
+	deactivated, err := us.IsDeactivated(ctx, user.Id)
+	if err != nil {
+		return resp, err
+	}
+	if deactivated {
+		logger.Errorf(ctx, "deactivated user %d tried to log in", user.Id)
+		return resp, apperrors.UserDeactivated
 	}
 
-	var jwtKey = config.JWTKey()
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
-
+	tokens, err := us.sessionSvc.IssueTokens(ctx, user.Id, constants.User)
 	if err != nil {
-		logger.Errorf(ctx, "error generating authtoken. err: %s", err.Error())
-		err = apperrors.InternalServerError
 		return resp, err
 	}
 
 	resp.User = user
-	resp.AuthToken = tokenString
+	resp.AuthToken = tokens.AuthToken
+	resp.AuthTokenExpiresAt = tokens.AuthTokenExpiresAt
+	resp.RefreshToken = tokens.RefreshToken
 
 	err = us.userRepo.AddDeviceToken(ctx, user.Id, u.NotificationToken)
 	if err != nil {
@@ -261,46 +231,12 @@ func (us *service) RegisterUser(ctx context.Context, u dto.IntranetUserData) (us
 }
 
 func (us *service) ListIntranetUsers(ctx context.Context, reqData dto.GetUserListReq) (data []dto.IntranetUserData, err error) {
-	client := &http.Client{}
-	url := config.IntranetBaseUrl() + fmt.Sprintf(constants.ListIntranetUsersPath, reqData.Page, constants.DefaultPageSize)
-	intranetReq, err := http.NewRequest(http.MethodGet, url, nil)
+	data, err = us.intranetClient.ListUsers(ctx, reqData)
 	if err != nil {
-		logger.Errorf(ctx, "error in creating new intranet user list request. err: %s", err.Error())
-		err = apperrors.InternalServerError
-		return
+		logger.Errorf(ctx, "userService: %v", err)
+		return nil, intranet.AppError(err)
 	}
-
-	intranetReq.Header.Add(constants.AuthorizationHeader, reqData.AuthToken)
-	resp, err := client.Do(intranetReq)
-	if err != nil {
-		logger.Errorf(ctx, "error in intranet get user api. status returned: %d, err: %s ", resp.StatusCode, err.Error())
-		err = apperrors.InternalServerError
-		return
-	}
-	if resp.StatusCode != http.StatusOK {
-		logger.Errorf(ctx, "erro in intranet user list request. status returned: %d", resp.StatusCode)
-		err = apperrors.InternalServerError
-		return
-	}
-	defer resp.Body.Close()
-
-	var respData dto.ListIntranetUsersRespData
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Errorf(ctx, "error in io.readall, err: %s", err.Error())
-		err = apperrors.JSONParsingErrorResp
-	}
-
-	err = json.Unmarshal(body, &respData)
-	if err != nil {
-		logger.Errorf(ctx, "error in unmarshalling data, err: %s", err.Error())
-		err = apperrors.JSONParsingErrorResp
-		return
-	}
-
-	data = respData.Data
-	return
+	return data, nil
 }
 
 func (us *service) ListUsers(ctx context.Context, reqData dto.ListUsersReq) (resp dto.ListUsersResp, err error) {
@@ -356,7 +292,7 @@ func (us *service) AdminLogin(ctx context.Context, loginReq dto.AdminLoginReq) (
 		return
 	}
 
-	if dbUser.RoleID != 2 {
+	if dbUser.RoleID != constants.SuperAdminRoleID && dbUser.RoleID != constants.AdminRoleID {
 		logger.Errorf(ctx, "unathorized access")
 		err = apperrors.RoleUnathorized
 		return
@@ -371,29 +307,25 @@ func (us *service) AdminLogin(ctx context.Context, loginReq dto.AdminLoginReq) (
 
 	user := mapUserDbToService(dbUser)
 
-	expirationTime := time.Now().Add(time.Hour * time.Duration(config.JWTExpiryDurationHours()))
-
-	claims := &dto.Claims{
-		Id:   user.Id,
-		Role: constants.Admin,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
-		},
+	deactivated, err := us.IsDeactivated(ctx, user.Id)
+	if err != nil {
+		return
+	}
+	if deactivated {
+		logger.Errorf(ctx, "deactivated admin %d tried to log in", user.Id)
+		err = apperrors.UserDeactivated
+		return
 	}
 
-	var jwtKey = config.JWTKey()
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
-
+	tokens, err := us.sessionSvc.IssueTokens(ctx, user.Id, constants.Admin)
 	if err != nil {
-		logger.Errorf(ctx, "error generating authtoken. err: %s", err.Error())
-		err = apperrors.InternalServerError
-		return resp, err
+		return
 	}
 
 	resp.User = user
-	resp.AuthToken = tokenString
+	resp.AuthToken = tokens.AuthToken
+	resp.AuthTokenExpiresAt = tokens.AuthTokenExpiresAt
+	resp.RefreshToken = tokens.RefreshToken
 
 	return
 }
@@ -485,9 +417,9 @@ func (us *service) GetUserById(ctx context.Context) (user dto.GetUserByIdResp, e
 	return
 }
 
-func (us *service) GetActiveUserList(ctx context.Context, quarter int, year int) ([]dto.ActiveUser, error) {
+func (us *service) GetActiveUserList(ctx context.Context, quarter int, year int, filter dto.OrgFilter) ([]dto.ActiveUser, error) {
 	quarterStart, quarterEnd := getQuarterRangeUnixTime(quarter, year)
-	activeUserDb, err := us.userRepo.GetActiveUserList(ctx, nil, quarterStart, quarterEnd)
+	activeUserDb, err := us.userRepo.GetActiveUserList(ctx, nil, quarterStart, quarterEnd, filter)
 	if err != nil {
 		logger.Errorf(ctx, "userService: GetActiveUserList: err: %v", err)
 		return []dto.ActiveUser{}, err
@@ -527,8 +459,61 @@ func getQuarterRangeUnixTime(quarter int, year int) (start int64, end int64) {
 	return startTime.UnixMilli(), endTime.UnixMilli()
 }
 
-func (us *service) UpdateRewardQuota(ctx context.Context) error {
-	err := us.userRepo.UpdateRewardQuota(ctx, nil)
+func (us *service) UpdateRewardQuota(ctx context.Context) (err error) {
+
+	tx, err := us.userRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "userService: BeginTx: err: %v", err)
+		return err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := us.userRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	err = us.userRepo.UpdateRewardQuota(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = us.notificationRepo.CreateNotificationForAllUsers(ctx, tx, dto.Notification{
+		Type:  constants.QuotaRefillNotification,
+		Title: "Reward quota refilled",
+		Body:  "Your reward quota has been refilled. Go ahead and reward the appreciations you like!",
+	})
+	if err != nil {
+		return err
+	}
+
+	err = us.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxPush, dto.OutboxPush{
+		Topic: notification.AllUsersTopic,
+		Title: "Reward Quota is Refilled",
+		Body:  "Your reward quota is reset! You now recognize your colleagues.",
+		Event: constants.QuotaRefillNotification,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = us.outboxRepo.EnqueueWebhookEvent(ctx, tx, constants.QuotaRenewedEvent, dto.QuotaRenewedEventData{
+		RenewedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		logger.Errorf(ctx, "userService: EnqueueWebhookEvent: err: %v", err)
+	}
 	return err
 }
 func GetQuarterStartUnixTime() int64 {
@@ -565,10 +550,10 @@ func GetQuarterStartUnixTime() int64 {
 	return quarterStart.Unix() * 1000
 }
 
-func (us *service) GetTop10Users(ctx context.Context) (users []dto.Top10User, err error) {
+func (us *service) GetTop10Users(ctx context.Context, filter dto.OrgFilter) (users []dto.Top10User, err error) {
 
 	quaterTimeStamp := GetQuarterStartUnixTime()
-	dbUsers, err := us.userRepo.GetTop10Users(ctx, quaterTimeStamp)
+	dbUsers, err := us.userRepo.GetTop10Users(ctx, quaterTimeStamp, filter)
 	if err != nil {
 		logger.Error(ctx, err.Error())
 		err = apperrors.InternalServerError
@@ -613,23 +598,21 @@ func mapDbUserToUserListResp(dbStruct repository.User) (svcData dto.UserDetails)
 
 func (us *service) NotificationByAdmin(ctx context.Context, notificationReq dto.AdminNotificationReq) (err error) {
 
-	notificationTokens, err := us.userRepo.ListDeviceTokensByUserID(ctx, notificationReq.Id)
+	var notificationTokens []string
+	if notificationReq.All {
+		// sent to the devices of everyone who didn't turn off broadcasts, the topic can't leave them out
+		notificationTokens, err = us.preferenceRepo.ListDeviceTokensForEvent(ctx, nil, constants.BroadcastNotification)
+	} else {
+		notificationTokens, err = us.userRepo.ListDeviceTokensByUserID(ctx, notificationReq.Id)
+	}
 	if err != nil {
 		logger.Errorf(ctx, "err in getting device tokens: %v", err)
 		err = apperrors.InternalServerError
 		return
 	}
 
-	if notificationReq.All {
-		err = notificationReq.Message.SendNotificationToTopic("peerly")
-		if err != nil {
-			return
-		}
-		return
-	}
-
 	for _, notificationToken := range notificationTokens {
-		err = notificationReq.Message.SendNotificationToNotificationToken(notificationToken)
+		err = us.notificationSvc.SendNotificationToNotificationToken(ctx, notificationReq.Message, notificationToken)
 		if err != nil {
 			return
 		}
@@ -672,36 +655,54 @@ func (us *service) AllAppreciationReport(ctx context.Context, appreciations []dt
 	}
 
 	// Set header
-	headers := []string{"Core value", "Core value description", "Appreciation description", "Sender Employee ID", "Sender first name", "Sender last name", "Sender designation", "Receiver Employee ID", "Receiver first name", "Receiver last name", "Receiver designation", "Total rewards", "Total reward points", "Appreciated Date", "Quarter"}
+	headers := []string{"Core value", "Core value description", "Appreciation description", "Sender Employee ID", "Sender first name", "Sender last name", "Sender designation", "Receiver Employee ID", "Receiver first name", "Receiver last name", "Receiver designation", "Total rewards", "Total reward points", "Appreciated Date", "Quarter", "Group name", "Receiver reward points"}
 	for colIndex, header := range headers {
 
 		cell := fmt.Sprintf("%c1", 'A'+colIndex)
 		f.SetCellValue(sheetName, cell, header)
 	}
 
-	// Add data to the sheet
-	for rowIndex, app := range appreciations {
-		row := rowIndex + 2 // Starting from row 2
+	// Add data to the sheet, a team appreciation gets one row per receiver
+	row := 1
+	for _, app := range appreciations {
 
 		createdTime := time.UnixMilli(app.CreatedAt)
 		appreciatedAt := time.UnixMilli(app.CreatedAt).Format("02/01/2006")
 		quarter := GetQuarterName(createdTime)
 
-		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), app.CoreValueName)
-		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), app.CoreValueDesc)
-		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), app.Description)
-		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), app.SenderEmployeeID)
-		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), app.SenderFirstName)
-		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), app.SenderLastName)
-		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), app.SenderDesignation)
-		f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), app.ReceiverEmployeeID)
-		f.SetCellValue(sheetName, fmt.Sprintf("I%d", row), app.ReceiverFirstName)
-		f.SetCellValue(sheetName, fmt.Sprintf("J%d", row), app.ReceiverLastName)
-		f.SetCellValue(sheetName, fmt.Sprintf("K%d", row), app.ReceiverDesignation)
-		f.SetCellValue(sheetName, fmt.Sprintf("L%d", row), app.TotalRewards)
-		f.SetCellValue(sheetName, fmt.Sprintf("M%d", row), app.TotalRewardPoints)
-		f.SetCellValue(sheetName, fmt.Sprintf("N%d", row), appreciatedAt)
-		f.SetCellValue(sheetName, fmt.Sprintf("O%d", row), quarter)
+		receivers := app.Receivers
+		if len(receivers) == 0 {
+			receivers = []dto.AppreciationReceiver{{
+				ID:           app.ReceiverID,
+				EmployeeID:   app.ReceiverEmployeeID,
+				FirstName:    app.ReceiverFirstName,
+				LastName:     app.ReceiverLastName,
+				Designation:  app.ReceiverDesignation,
+				RewardPoints: app.TotalRewardPoints,
+			}}
+		}
+
+		for _, receiver := range receivers {
+			row++
+
+			f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), app.CoreValueName)
+			f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), app.CoreValueDesc)
+			f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), app.Description)
+			f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), app.SenderEmployeeID)
+			f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), app.SenderFirstName)
+			f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), app.SenderLastName)
+			f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), app.SenderDesignation)
+			f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), receiver.EmployeeID)
+			f.SetCellValue(sheetName, fmt.Sprintf("I%d", row), receiver.FirstName)
+			f.SetCellValue(sheetName, fmt.Sprintf("J%d", row), receiver.LastName)
+			f.SetCellValue(sheetName, fmt.Sprintf("K%d", row), receiver.Designation)
+			f.SetCellValue(sheetName, fmt.Sprintf("L%d", row), app.TotalRewards)
+			f.SetCellValue(sheetName, fmt.Sprintf("M%d", row), app.TotalRewardPoints)
+			f.SetCellValue(sheetName, fmt.Sprintf("N%d", row), appreciatedAt)
+			f.SetCellValue(sheetName, fmt.Sprintf("O%d", row), quarter)
+			f.SetCellValue(sheetName, fmt.Sprintf("P%d", row), app.GroupName)
+			f.SetCellValue(sheetName, fmt.Sprintf("Q%d", row), receiver.RewardPoints)
+		}
 
 	}
 
@@ -789,9 +790,9 @@ func getStandardQuarterRange(quarter int, year int) (start int64, end int64) {
 	return utils.GetStandardQuarterRange(quarter, year)
 }
 
-func (us *service) DynamicEngagersReport(ctx context.Context, quarter int, year int) (tempFileName string, err error) {
+func (us *service) DynamicEngagersReport(ctx context.Context, quarter int, year int, filter dto.OrgFilter) (tempFileName string, err error) {
 	start, end := getStandardQuarterRange(quarter, year)
-	engagers, err := us.userRepo.GetDynamicEngagersReport(ctx, nil, start, end)
+	engagers, err := us.userRepo.GetDynamicEngagersReport(ctx, nil, start, end, filter)
 	if err != nil {
 		logger.Errorf(ctx, "userService: DynamicEngagersReport: GetDynamicEngagersReport err: %v", err)
 		return "", err
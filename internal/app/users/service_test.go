@@ -6,9 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/joshsoftware/peerly-backend/internal/app/identity"
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
+	sessionMocks "github.com/joshsoftware/peerly-backend/internal/app/sessions/mocks"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
 	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/testConfig"
 	"github.com/joshsoftware/peerly-backend/internal/repository"
@@ -26,7 +30,10 @@ func init() {
 func TestLoginUser(t *testing.T) {
 	testConfig.Load()
 	userRepo := mocks.NewUserStorer(t)
-	service := NewService(userRepo)
+	userRepo.On("ListDeactivatedUserIDs", mock.Anything, nil).Return([]int64{}, nil).Maybe()
+	sessionSvc := new(sessionMocks.Service)
+	sessionSvc.On("IssueTokens", mock.Anything, mock.Anything, constants.User).Return(dto.AuthTokens{AuthToken: "token", RefreshToken: "refresh"}, nil)
+	service := NewService(userRepo, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), mocks.NewUserSyncStorer(t), mocks.NewOrgStorer(t), sessionSvc, identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{}))
 
 	tests := []struct {
 		name            string
@@ -355,7 +362,7 @@ func TestLoginUser(t *testing.T) {
 
 func TestListUsers(t *testing.T) {
 	userRepo := mocks.NewUserStorer(t)
-	service := NewService(userRepo)
+	service := NewService(userRepo, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), mocks.NewUserSyncStorer(t), mocks.NewOrgStorer(t), new(sessionMocks.Service), identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{}))
 
 	tests := []struct {
 		name            string
@@ -435,27 +442,51 @@ func TestListUsers(t *testing.T) {
 
 func TestUpdateRewardQuota(t *testing.T) {
 	userRepo := mocks.NewUserStorer(t)
-	service := NewService(userRepo)
+	notificationRepo := mocks.NewNotificationStorer(t)
+	outboxRepo := mocks.NewOutboxStorer(t)
+	service := NewService(userRepo, notification.NewRecordingService(), notificationRepo, mocks.NewNotificationPreferenceStorer(t), outboxRepo, mocks.NewUserSyncStorer(t), mocks.NewOrgStorer(t), new(sessionMocks.Service), identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{}))
 
 	tests := []struct {
 		name          string
 		context       context.Context
-		setup         func(userMock *mocks.UserStorer)
+		setup         func(userMock *mocks.UserStorer, notificationMock *mocks.NotificationStorer)
 		expectedError error
 	}{
 		{
 			name:    "success",
 			context: context.Background(),
-			setup: func(userMock *mocks.UserStorer) {
+			setup: func(userMock *mocks.UserStorer, notificationMock *mocks.NotificationStorer) {
+				userMock.On("BeginTx", mock.Anything).Return(nil, nil).Once()
 				userMock.On("UpdateRewardQuota", mock.Anything, nil).Return(nil).Once()
+				notificationMock.On("CreateNotificationForAllUsers", mock.Anything, nil, mock.MatchedBy(func(n dto.Notification) bool {
+					return n.Type == constants.QuotaRefillNotification
+				})).Return(nil).Once()
+				outboxRepo.On("EnqueueOutboxMessage", mock.Anything, nil, constants.OutboxPush, mock.MatchedBy(func(push dto.OutboxPush) bool {
+					return push.Event == constants.QuotaRefillNotification
+				})).Return(nil).Once()
+				outboxRepo.On("EnqueueWebhookEvent", mock.Anything, nil, constants.QuotaRenewedEvent, mock.AnythingOfType("dto.QuotaRenewedEventData")).Return(nil).Once()
+				userMock.On("HandleTransaction", mock.Anything, nil, true).Return(nil).Once()
 			},
 			expectedError: nil,
 		},
 		{
 			name:    "failure",
 			context: context.Background(),
-			setup: func(userMock *mocks.UserStorer) {
-				userMock.On("UpdateRewardQuota", mock.Anything, nil).Return(apperrors.InternalServer)
+			setup: func(userMock *mocks.UserStorer, notificationMock *mocks.NotificationStorer) {
+				userMock.On("BeginTx", mock.Anything).Return(nil, nil).Once()
+				userMock.On("UpdateRewardQuota", mock.Anything, nil).Return(apperrors.InternalServer).Once()
+				userMock.On("HandleTransaction", mock.Anything, nil, false).Return(nil).Once()
+			},
+			expectedError: apperrors.InternalServer,
+		},
+		{
+			name:    "quota refill is rolled back when the inbox cannot be updated",
+			context: context.Background(),
+			setup: func(userMock *mocks.UserStorer, notificationMock *mocks.NotificationStorer) {
+				userMock.On("BeginTx", mock.Anything).Return(nil, nil).Once()
+				userMock.On("UpdateRewardQuota", mock.Anything, nil).Return(nil).Once()
+				notificationMock.On("CreateNotificationForAllUsers", mock.Anything, nil, mock.Anything).Return(apperrors.InternalServer).Once()
+				userMock.On("HandleTransaction", mock.Anything, nil, false).Return(nil).Once()
 			},
 			expectedError: apperrors.InternalServer,
 		},
@@ -463,7 +494,7 @@ func TestUpdateRewardQuota(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			test.setup(userRepo)
+			test.setup(userRepo, notificationRepo)
 
 			// test service
 			err := service.UpdateRewardQuota(test.context)
@@ -476,7 +507,7 @@ func TestUpdateRewardQuota(t *testing.T) {
 
 func TestGetActiveUserList(t *testing.T) {
 	userRepo := mocks.NewUserStorer(t)
-	service := NewService(userRepo)
+	service := NewService(userRepo, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), mocks.NewUserSyncStorer(t), mocks.NewOrgStorer(t), new(sessionMocks.Service), identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{}))
 
 	tests := []struct {
 		name          string
@@ -489,7 +520,7 @@ func TestGetActiveUserList(t *testing.T) {
 			name:    "success",
 			context: context.Background(),
 			setup: func(userMock *mocks.UserStorer) {
-				userMock.On("GetActiveUserList", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]repository.ActiveUser{
+				userMock.On("GetActiveUserList", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]repository.ActiveUser{
 					{
 						ID:                 55,
 						FirstName:          "Deepak",
@@ -528,7 +559,7 @@ func TestGetActiveUserList(t *testing.T) {
 			name:    "failure",
 			context: context.Background(),
 			setup: func(userMock *mocks.UserStorer) {
-				userMock.On("GetActiveUserList", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]repository.ActiveUser{}, apperrors.InternalServer).Once()
+				userMock.On("GetActiveUserList", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]repository.ActiveUser{}, apperrors.InternalServer).Once()
 			},
 			expectedResp:  []dto.ActiveUser{},
 			expectedError: apperrors.InternalServer,
@@ -540,7 +571,7 @@ func TestGetActiveUserList(t *testing.T) {
 			test.setup(userRepo)
 
 			// test service
-			resp, err := service.GetActiveUserList(test.context, 1, 2026)
+			resp, err := service.GetActiveUserList(test.context, 1, 2026, dto.OrgFilter{})
 
 			if err != nil {
 				assert.Equal(t, test.expectedError, err)
@@ -554,7 +585,7 @@ func TestGetActiveUserList(t *testing.T) {
 
 func TestGetUserById(t *testing.T) {
 	userRepo := mocks.NewUserStorer(t)
-	service := NewService(userRepo)
+	service := NewService(userRepo, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), mocks.NewUserSyncStorer(t), mocks.NewOrgStorer(t), new(sessionMocks.Service), identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{}))
 
 	tests := []struct {
 		name            string
@@ -620,7 +651,7 @@ func TestGetUserById(t *testing.T) {
 
 func TestGetTop10Users(t *testing.T) {
 	userRepo := mocks.NewUserStorer(t)
-	service := NewService(userRepo)
+	service := NewService(userRepo, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), mocks.NewUserSyncStorer(t), mocks.NewOrgStorer(t), new(sessionMocks.Service), identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{}))
 
 	tests := []struct {
 		name            string
@@ -632,7 +663,7 @@ func TestGetTop10Users(t *testing.T) {
 			name:    "Success for get top 10 users",
 			context: context.Background(),
 			setup: func(userMock *mocks.UserStorer) {
-				userMock.On("GetTop10Users", mock.Anything, mock.Anything).Return([]repository.Top10Users{}, nil).Once()
+				userMock.On("GetTop10Users", mock.Anything, mock.Anything, mock.Anything).Return([]repository.Top10Users{}, nil).Once()
 
 			},
 			isErrorExpected: false,
@@ -641,7 +672,7 @@ func TestGetTop10Users(t *testing.T) {
 			name:    "Faliure for get top 10 users",
 			context: context.Background(),
 			setup: func(userMock *mocks.UserStorer) {
-				userMock.On("GetTop10Users", mock.Anything, mock.Anything).Return([]repository.Top10Users{}, apperrors.InternalServerError).Once()
+				userMock.On("GetTop10Users", mock.Anything, mock.Anything, mock.Anything).Return([]repository.Top10Users{}, apperrors.InternalServerError).Once()
 
 			},
 			isErrorExpected: true,
@@ -654,7 +685,7 @@ func TestGetTop10Users(t *testing.T) {
 			test.setup(userRepo)
 
 			// test service
-			_, err := service.GetTop10Users(test.context)
+			_, err := service.GetTop10Users(test.context, dto.OrgFilter{})
 
 			if (err != nil) != test.isErrorExpected {
 				t.Errorf("Test Failed, expected error to be %v, but got err %v", test.isErrorExpected, err != nil)
@@ -663,3 +694,16 @@ func TestGetTop10Users(t *testing.T) {
 	}
 
 }
+
+func TestAuthenticateUser(t *testing.T) {
+	jane := dto.IntranetUserData{Email: "jane@example.com", PublicProfile: dto.PublicProfile{FirstName: "Jane"}}
+	provider := identity.NewStaticProvider(map[string]dto.IntranetUserData{"jane-token": jane})
+	service := NewService(mocks.NewUserStorer(t), notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), mocks.NewUserSyncStorer(t), mocks.NewOrgStorer(t), new(sessionMocks.Service), provider, intranet.NewClient(intranet.Options{}))
+
+	user, err := service.AuthenticateUser(context.Background(), "jane-token")
+	assert.NoError(t, err)
+	assert.Equal(t, jane, user)
+
+	_, err = service.AuthenticateUser(context.Background(), "unknown")
+	assert.Equal(t, apperrors.InvalidAuthToken, err)
+}
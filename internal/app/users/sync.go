@@ -0,0 +1,214 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// SyncIntranetUsers reconciles the users with the intranet: joiners are registered, changed profiles updated and
+// leavers deactivated. The report of the run is saved whether it succeeded or not.
+func (us *service) SyncIntranetUsers(ctx context.Context) (dto.UserSyncReport, error) {
+
+	report := dto.UserSyncReport{
+		StartedAt: time.Now().UnixMilli(),
+		Status:    constants.UserSyncCompleted,
+		Changes: dto.UserSyncChanges{
+			Created:     []string{},
+			Updated:     []string{},
+			Deactivated: []string{},
+			Failed:      []dto.UserSyncFailure{},
+		},
+	}
+
+	syncErr := us.reconcileIntranetUsers(ctx, &report.Changes)
+	if syncErr != nil {
+		logger.Errorf(ctx, "userService: intranet sync failed: %v", syncErr)
+		report.Status = constants.UserSyncFailed
+		report.Error = syncErr.Error()
+	}
+	report.FinishedAt = time.Now().UnixMilli()
+
+	details, err := json.Marshal(report.Changes)
+	if err != nil {
+		logger.Errorf(ctx, "userService: error in marshalling sync changes: %v", err)
+		return report, apperrors.InternalServer
+	}
+
+	report.Id, err = us.userSyncRepo.CreateUserSyncReport(ctx, nil, repository.UserSyncReport{
+		StartedAt:        report.StartedAt,
+		FinishedAt:       report.FinishedAt,
+		Status:           report.Status,
+		CreatedCount:     int64(len(report.Changes.Created)),
+		UpdatedCount:     int64(len(report.Changes.Updated)),
+		DeactivatedCount: int64(len(report.Changes.Deactivated)),
+		FailedCount:      int64(len(report.Changes.Failed)),
+		Details:          details,
+		Error:            sql.NullString{String: report.Error, Valid: report.Error != ""},
+	})
+	if err != nil {
+		logger.Errorf(ctx, "userService: CreateUserSyncReport: err: %v", err)
+		return report, err
+	}
+
+	return report, syncErr
+}
+
+func (us *service) ListUserSyncReports(ctx context.Context, limit int64) ([]dto.UserSyncReport, error) {
+
+	if limit <= 0 || limit > constants.MaxUserSyncReportsLimit {
+		return nil, apperrors.InvalidPageSize
+	}
+
+	dbReports, err := us.userSyncRepo.ListUserSyncReports(ctx, nil, uint64(limit))
+	if err != nil {
+		logger.Errorf(ctx, "userService: ListUserSyncReports: err: %v", err)
+		return nil, err
+	}
+
+	reports := make([]dto.UserSyncReport, 0, len(dbReports))
+	for _, dbReport := range dbReports {
+		report := dto.UserSyncReport{
+			Id:         dbReport.ID,
+			StartedAt:  dbReport.StartedAt,
+			FinishedAt: dbReport.FinishedAt,
+			Status:     dbReport.Status,
+			Error:      dbReport.Error.String,
+		}
+		err = json.Unmarshal(dbReport.Details, &report.Changes)
+		if err != nil {
+			logger.Errorf(ctx, "userService: error in unmarshalling changes of sync report %d: %v", dbReport.ID, err)
+			return nil, apperrors.InternalServer
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// reconcileIntranetUsers only looks for leavers once every page was read, a partial list would deactivate everyone on
+// the pages that are missing
+func (us *service) reconcileIntranetUsers(ctx context.Context, changes *dto.UserSyncChanges) error {
+
+	intranetUsers, err := us.ListAllIntranetUsers(ctx)
+	if err != nil {
+		return err
+	}
+	if len(intranetUsers) == 0 {
+		return apperrors.EmptyIntranetUserList
+	}
+
+	employed := make(map[string]bool, len(intranetUsers))
+	changed := make(map[string]bool)
+	for _, intranetUser := range intranetUsers {
+		employed[strings.ToLower(intranetUser.Email)] = true
+
+		created, updated, err := us.syncIntranetUser(ctx, intranetUser)
+		if err != nil {
+			logger.Errorf(ctx, "userService: error in syncing user %s: %v", intranetUser.Email, err)
+			changes.Failed = append(changes.Failed, dto.UserSyncFailure{Email: intranetUser.Email, Error: err.Error()})
+			continue
+		}
+		if created {
+			changes.Created = append(changes.Created, intranetUser.Email)
+		} else if updated {
+			changes.Updated = append(changes.Updated, intranetUser.Email)
+		}
+		changed[strings.ToLower(intranetUser.Email)] = created || updated
+	}
+
+	// managers may have joined in this run, so the hierarchy is applied once everyone is registered
+	placed, err := us.applyIntranetHierarchy(ctx, nil, intranetUsers)
+	if err != nil {
+		logger.Errorf(ctx, "userService: error in applying the intranet hierarchy: %v", err)
+		return err
+	}
+	for _, email := range placed {
+		if !changed[strings.ToLower(email)] {
+			changes.Updated = append(changes.Updated, email)
+		}
+	}
+
+	activeUsers, err := us.userRepo.ListUsersByStatus(ctx, nil, constants.ActiveUserStatus)
+	if err != nil {
+		logger.Errorf(ctx, "userService: ListUsersByStatus: err: %v", err)
+		return err
+	}
+
+	for _, activeUser := range activeUsers {
+		// the super admins are local accounts that may not be on the intranet
+		if employed[strings.ToLower(activeUser.Email)] || activeUser.RoleID == constants.SuperAdminRoleID {
+			continue
+		}
+
+		err = us.deactivate(ctx, activeUser.Id, 0)
+		if err != nil {
+			logger.Errorf(ctx, "userService: error in deactivating leaver %d: %v", activeUser.Id, err)
+			changes.Failed = append(changes.Failed, dto.UserSyncFailure{Email: activeUser.Email, Error: err.Error()})
+			continue
+		}
+		changes.Deactivated = append(changes.Deactivated, activeUser.Email)
+	}
+
+	return nil
+}
+
+// ListAllIntranetUsers reads every page of the intranet users with the token Peerly is configured with
+func (us *service) ListAllIntranetUsers(ctx context.Context) ([]dto.IntranetUserData, error) {
+
+	validateResp, err := us.ValidatePeerly(ctx, config.IntranetAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	var intranetUsers []dto.IntranetUserData
+	for page := int64(1); ; page++ {
+		data, err := us.ListIntranetUsers(ctx, dto.GetUserListReq{AuthToken: validateResp.Data.JwtToken, Page: page})
+		if err != nil {
+			logger.Errorf(ctx, "userService: error in listing page %d of the intranet users: %v", page, err)
+			return nil, err
+		}
+		intranetUsers = append(intranetUsers, data...)
+		if len(data) < constants.DefaultPageSize {
+			return intranetUsers, nil
+		}
+	}
+}
+
+// syncIntranetUser registers a joiner or updates the profile of an existing user the way logging in does
+func (us *service) syncIntranetUser(ctx context.Context, intranetUser dto.IntranetUserData) (created bool, updated bool, err error) {
+
+	user, err := us.RegisterUser(ctx, intranetUser)
+	if err == nil {
+		return true, false, nil
+	}
+	if err != apperrors.RepeatedUser {
+		return false, false, err
+	}
+	if user.Id == 0 {
+		// RegisterUser also reports a failed lookup as a repeated user
+		return false, false, apperrors.InternalServerError
+	}
+
+	syncNeeded, dataToBeUpdated, err := us.syncData(ctx, intranetUser, user)
+	if err != nil {
+		return false, false, err
+	}
+	if !syncNeeded {
+		return false, false, nil
+	}
+
+	err = us.userRepo.SyncData(ctx, dataToBeUpdated)
+	if err != nil {
+		return false, false, err
+	}
+	return false, true, nil
+}
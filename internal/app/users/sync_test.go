@@ -0,0 +1,212 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/identity"
+	"github.com/joshsoftware/peerly-backend/internal/app/notification"
+	sessionMocks "github.com/joshsoftware/peerly-backend/internal/app/sessions/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/intranet"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeIntranet serves the validation and user list apis of the intranet, a nil page fails the request
+func fakeIntranet(t *testing.T, pages map[string][]dto.IntranetUserData) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == constants.PeerlyValidationPath {
+			json.NewEncoder(rw).Encode(dto.ValidateResp{Data: dto.IntranetValidateApiData{JwtToken: "intranet-jwt"}})
+			return
+		}
+		assert.Equal(t, "intranet-jwt", req.Header.Get(constants.AuthorizationHeader))
+		users, ok := pages[req.URL.Query().Get("page")]
+		if !ok || users == nil {
+			rw.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(rw).Encode(dto.ListIntranetUsersRespData{Data: users})
+	}))
+	t.Cleanup(server.Close)
+
+	viper.Set(constants.IntranetBaseUrl, server.URL)
+	viper.Set(constants.IntranetAuthToken, "intranet-token")
+	viper.Set(constants.IntranetClientCode, "peerly")
+}
+
+func intranetUser(email string, firstName string, designation string) dto.IntranetUserData {
+	return dto.IntranetUserData{
+		Email:          email,
+		PublicProfile:  dto.PublicProfile{FirstName: firstName},
+		EmpolyeeDetail: dto.EmployeeDetail{Designation: dto.Designation{Name: designation}, Grade: "J1"},
+	}
+}
+
+func TestSyncIntranetUsers(t *testing.T) {
+	fakeIntranet(t, map[string][]dto.IntranetUserData{
+		"1": {
+			intranetUser("joiner@example.com", "Joiner", "Engineer"),
+			intranetUser("Promoted@example.com", "Promoted", "Lead"),
+			intranetUser("unchanged@example.com", "Unchanged", "Engineer"),
+		},
+	})
+
+	userMock := mocks.NewUserStorer(t)
+	syncMock := mocks.NewUserSyncStorer(t)
+	sessionSvc := new(sessionMocks.Service)
+	grade := repository.Grade{Id: 1, Name: "J1", Points: 10}
+
+	userMock.On("GetGradeByName", mock.Anything, "J1").Return(grade, nil)
+	userMock.On("GetUserByEmail", mock.Anything, "joiner@example.com").Return(repository.User{}, apperrors.UserNotFound).Once()
+	userMock.On("GetRewardMultiplier", mock.Anything).Return(int64(5), nil).Once()
+	userMock.On("GetRoleByName", mock.Anything, constants.UserRole).Return(constants.UserRoleID, nil).Once()
+	userMock.On("CreateNewUser", mock.Anything, mock.MatchedBy(func(user dto.User) bool {
+		return user.Email == "joiner@example.com" && user.RewardQuotaBalance == 50
+	})).Return(repository.User{Id: 10, Email: "joiner@example.com"}, nil).Once()
+	userMock.On("GetUserByEmail", mock.Anything, "Promoted@example.com").Return(repository.User{Id: 11, Email: "promoted@example.com", FirstName: "Promoted", Designation: "Engineer", GradeId: 1}, nil).Once()
+	userMock.On("SyncData", mock.Anything, mock.MatchedBy(func(user dto.User) bool {
+		return user.Email == "Promoted@example.com" && user.Designation == "Lead"
+	})).Return(nil).Once()
+	userMock.On("GetUserByEmail", mock.Anything, "unchanged@example.com").Return(repository.User{Id: 12, Email: "unchanged@example.com", FirstName: "Unchanged", Designation: "Engineer", GradeId: 1}, nil).Once()
+
+	userMock.On("ListUsersByStatus", mock.Anything, nil, constants.ActiveUserStatus).Return([]repository.User{
+		{Id: 1, Email: "root@example.com", RoleID: constants.SuperAdminRoleID},
+		{Id: 10, Email: "joiner@example.com", RoleID: constants.UserRoleID},
+		{Id: 11, Email: "promoted@example.com", RoleID: constants.UserRoleID},
+		{Id: 12, Email: "unchanged@example.com", RoleID: constants.AdminRoleID},
+		{Id: 13, Email: "leaver@example.com", RoleID: constants.UserRoleID},
+	}, nil).Once()
+	tx := &sql.Tx{}
+	userMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	userMock.On("UpdateUserStatus", mock.Anything, tx, int64(13), constants.DeactivatedUserStatus, int64(0)).Return(nil).Once()
+	userMock.On("DeleteDeviceTokens", mock.Anything, tx, int64(13)).Return(nil).Once()
+	userMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+	sessionSvc.On("RevokeUserSessions", mock.Anything, int64(13)).Return(nil).Once()
+
+	syncMock.On("CreateUserSyncReport", mock.Anything, nil, mock.MatchedBy(func(report repository.UserSyncReport) bool {
+		return report.Status == constants.UserSyncCompleted && report.CreatedCount == 1 && report.UpdatedCount == 1 &&
+			report.DeactivatedCount == 1 && report.FailedCount == 0 && !report.Error.Valid
+	})).Return(int64(3), nil).Once()
+
+	svc := NewService(userMock, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), syncMock, mocks.NewOrgStorer(t), sessionSvc, identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{})).(*service)
+
+	report, err := svc.SyncIntranetUsers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), report.Id)
+	assert.Equal(t, []string{"joiner@example.com"}, report.Changes.Created)
+	assert.Equal(t, []string{"Promoted@example.com"}, report.Changes.Updated)
+	assert.Equal(t, []string{"leaver@example.com"}, report.Changes.Deactivated)
+	assert.Empty(t, report.Changes.Failed)
+	assert.True(t, svc.statusCache.isDeactivated(13))
+	sessionSvc.AssertExpectations(t)
+}
+
+func TestSyncIntranetUsersWithoutTheFullList(t *testing.T) {
+	full := make([]dto.IntranetUserData, constants.DefaultPageSize)
+
+	tests := []struct {
+		name          string
+		pages         map[string][]dto.IntranetUserData
+		expectedError error
+	}{
+		{
+			name:          "A page can't be read",
+			pages:         map[string][]dto.IntranetUserData{"1": full, "2": nil},
+			expectedError: apperrors.IntranetUnavailable,
+		},
+		{
+			name:          "Intranet returns no users",
+			pages:         map[string][]dto.IntranetUserData{"1": {}},
+			expectedError: apperrors.EmptyIntranetUserList,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeIntranet(t, test.pages)
+
+			// nobody is registered, updated or deactivated
+			userMock := mocks.NewUserStorer(t)
+			syncMock := mocks.NewUserSyncStorer(t)
+			syncMock.On("CreateUserSyncReport", mock.Anything, nil, mock.MatchedBy(func(report repository.UserSyncReport) bool {
+				return report.Status == constants.UserSyncFailed && report.Error.String == test.expectedError.Error() &&
+					report.CreatedCount == 0 && report.DeactivatedCount == 0
+			})).Return(int64(4), nil).Once()
+			svc := NewService(userMock, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), syncMock, mocks.NewOrgStorer(t), new(sessionMocks.Service), identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{}))
+
+			report, err := svc.SyncIntranetUsers(context.Background())
+
+			assert.Equal(t, test.expectedError, err)
+			assert.Equal(t, constants.UserSyncFailed, report.Status)
+			assert.Equal(t, int64(4), report.Id)
+		})
+	}
+}
+
+func TestListUserSyncReports(t *testing.T) {
+	syncMock := mocks.NewUserSyncStorer(t)
+	syncMock.On("ListUserSyncReports", mock.Anything, nil, uint64(5)).Return([]repository.UserSyncReport{
+		{ID: 2, Status: constants.UserSyncFailed, Details: json.RawMessage(`{"created":[],"updated":[],"deactivated":[],"failed":[]}`), Error: sql.NullString{String: "Intranet returned no users", Valid: true}},
+		{ID: 1, Status: constants.UserSyncCompleted, Details: json.RawMessage(`{"created":["joiner@example.com"],"updated":[],"deactivated":["leaver@example.com"],"failed":[]}`)},
+	}, nil).Once()
+	svc := NewService(mocks.NewUserStorer(t), notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), syncMock, mocks.NewOrgStorer(t), new(sessionMocks.Service), identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{}))
+
+	reports, err := svc.ListUserSyncReports(context.Background(), 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, "Intranet returned no users", reports[0].Error)
+	assert.Equal(t, []string{"leaver@example.com"}, reports[1].Changes.Deactivated)
+
+	_, err = svc.ListUserSyncReports(context.Background(), constants.MaxUserSyncReportsLimit+1)
+	assert.Equal(t, apperrors.InvalidPageSize, err)
+}
+
+func TestApplyIntranetHierarchy(t *testing.T) {
+	placed := func(email string, department string, team string, managerEmail string) dto.IntranetUserData {
+		user := intranetUser(email, "", "Engineer")
+		user.EmpolyeeDetail.Department = department
+		user.EmpolyeeDetail.Team = team
+		user.EmpolyeeDetail.ManagerEmail = managerEmail
+		return user
+	}
+	valid := func(id int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	userMock := mocks.NewUserStorer(t)
+	orgMock := mocks.NewOrgStorer(t)
+	userMock.On("ListUsersByEmails", mock.Anything, nil, []string{"alice@example.com", "bob@example.com", "carol@example.com", "ghost@example.com"}).Return([]repository.User{
+		{Id: 7, Email: "alice@example.com", DepartmentID: valid(2), TeamID: valid(9)},
+		{Id: 8, Email: "bob@example.com", DepartmentID: valid(3)},
+		{Id: 9, Email: "carol@example.com"},
+	}, nil).Once()
+	orgMock.On("GetDepartmentByName", mock.Anything, nil, "Engineering").Return(repository.Department{ID: 3, Name: "Engineering"}, nil).Once()
+	orgMock.On("GetTeamByName", mock.Anything, nil, int64(3), "Platform").Return(repository.Team{}, apperrors.TeamNotFound).Once()
+	orgMock.On("CreateTeam", mock.Anything, nil, repository.Team{DepartmentID: 3, Name: "Platform"}).Return(repository.Team{ID: 5, DepartmentID: 3, Name: "Platform"}, nil).Once()
+	// only alice moved, bob already sits in engineering and carol's manager isn't in Peerly
+	orgMock.On("UpdateUserHierarchy", mock.Anything, nil, int64(7), repository.UserHierarchy{DepartmentID: valid(3), TeamID: valid(5), ManagerID: valid(8)}, int64(0)).Return(nil).Once()
+
+	svc := NewService(userMock, notification.NewRecordingService(), mocks.NewNotificationStorer(t), mocks.NewNotificationPreferenceStorer(t), mocks.NewOutboxStorer(t), mocks.NewUserSyncStorer(t), orgMock, new(sessionMocks.Service), identity.NewStaticProvider(nil), intranet.NewClient(intranet.Options{})).(*service)
+
+	changed, err := svc.applyIntranetHierarchy(context.Background(), nil, []dto.IntranetUserData{
+		placed("alice@example.com", "Engineering", "Platform", "bob@example.com"),
+		placed("bob@example.com", "engineering", "", ""),
+		placed("carol@example.com", "", "", "ghost@example.com"),
+		intranetUser("dave@example.com", "Dave", "Engineer"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice@example.com"}, changed)
+}
@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// Headers sent with every delivery, consumers verify the signature before trusting the body
+const (
+	EventHeader     = "X-Peerly-Event"
+	EventIDHeader   = "X-Peerly-Event-Id"
+	DeliveryHeader  = "X-Peerly-Delivery"
+	TimestampHeader = "X-Peerly-Timestamp"
+	SignatureHeader = "X-Peerly-Signature"
+)
+
+// how long a consumer gets to accept a delivery before the attempt fails
+const deliveryTimeout = 10 * time.Second
+
+var deliveryClient = &http.Client{Timeout: deliveryTimeout}
+
+// Sign returns the HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret.
+// The timestamp is part of the signed content so a captured delivery can't be replayed later with a new one.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver posts the signed event to the subscription and returns the response status, 0 when no response came back.
+// Any response other than a 2xx is an error so the outbox retries it.
+func Deliver(ctx context.Context, subscription repository.WebhookSubscription, delivery repository.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("error in creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Peerly-Webhooks")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(EventIDHeader, delivery.EventID)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := deliveryClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error in posting to the webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp.StatusCode, fmt.Errorf("webhook responded with %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return resp.StatusCode, nil
+}
+
+// generateSecret returns a random signing secret for subscriptions created without one
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,34 @@
+package webhooks
+
+import (
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// mapSubscriptionDbToSvc leaves the secret out, it is only shown once when the subscription is created
+func mapSubscriptionDbToSvc(dbSubscription repository.WebhookSubscription) dto.WebhookSubscription {
+	return dto.WebhookSubscription{
+		ID:        dbSubscription.ID,
+		URL:       dbSubscription.URL,
+		Events:    dbSubscription.Events,
+		CreatedBy: dbSubscription.CreatedBy,
+		CreatedAt: dbSubscription.CreatedAt,
+	}
+}
+
+func mapDeliveryDbToSvc(dbDelivery repository.WebhookDelivery) dto.WebhookDelivery {
+	return dto.WebhookDelivery{
+		ID:             dbDelivery.ID,
+		SubscriptionID: dbDelivery.SubscriptionID,
+		EventID:        dbDelivery.EventID,
+		Event:          dbDelivery.Event,
+		Payload:        dbDelivery.Payload,
+		Status:         dbDelivery.Status,
+		Attempts:       dbDelivery.Attempts,
+		ResponseStatus: int(dbDelivery.ResponseStatus.Int64),
+		LastError:      dbDelivery.LastError.String,
+		CreatedAt:      dbDelivery.CreatedAt,
+		UpdatedAt:      dbDelivery.UpdatedAt,
+		DeliveredAt:    dbDelivery.DeliveredAt.Int64,
+	}
+}
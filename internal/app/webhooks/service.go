@@ -0,0 +1,171 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/lib/pq"
+)
+
+type service struct {
+	webhookRepo repository.WebhookStorer
+	outboxRepo  repository.OutboxStorer
+}
+
+// Service manages the webhook subscriptions of third-party consumers and their delivery logs
+type Service interface {
+	CreateSubscription(ctx context.Context, req dto.CreateWebhookSubscriptionReq) (dto.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]dto.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, filter dto.WebhookDeliveryFilter) (dto.ListWebhookDeliveriesResponse, error)
+	Redeliver(ctx context.Context, deliveryID int64) (dto.WebhookDelivery, error)
+}
+
+func NewService(webhookRepo repository.WebhookStorer, outboxRepo repository.OutboxStorer) Service {
+	return &service{
+		webhookRepo: webhookRepo,
+		outboxRepo:  outboxRepo,
+	}
+}
+
+// CreateSubscription returns the signing secret, it is not shown again afterwards
+func (whSvc *service) CreateSubscription(ctx context.Context, req dto.CreateWebhookSubscriptionReq) (dto.WebhookSubscription, error) {
+
+	data := ctx.Value(constants.UserId)
+	userID, ok := data.(int64)
+	if !ok {
+		logger.Error(ctx, "webhookService: err in parsing userid from token")
+		return dto.WebhookSubscription{}, apperrors.InternalServer
+	}
+
+	secret := strings.TrimSpace(req.Secret)
+	if secret == "" {
+		var err error
+		secret, err = generateSecret()
+		if err != nil {
+			logger.Errorf(ctx, "webhookService: error in generating secret: %v", err)
+			return dto.WebhookSubscription{}, apperrors.InternalServer
+		}
+	}
+
+	subscription, err := whSvc.webhookRepo.CreateWebhookSubscription(ctx, nil, repository.WebhookSubscription{
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    pq.StringArray(req.Events),
+		CreatedBy: userID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "webhookService: CreateWebhookSubscription: err: %v", err)
+		return dto.WebhookSubscription{}, err
+	}
+
+	logger.Infof(ctx, "webhookService: webhook subscription %d created by user %d", subscription.ID, userID)
+	res := mapSubscriptionDbToSvc(subscription)
+	res.Secret = subscription.Secret
+	return res, nil
+}
+
+func (whSvc *service) ListSubscriptions(ctx context.Context) ([]dto.WebhookSubscription, error) {
+
+	subscriptions, err := whSvc.webhookRepo.ListWebhookSubscriptions(ctx, nil)
+	if err != nil {
+		logger.Errorf(ctx, "webhookService: ListWebhookSubscriptions: err: %v", err)
+		return nil, err
+	}
+
+	res := make([]dto.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		res = append(res, mapSubscriptionDbToSvc(subscription))
+	}
+	return res, nil
+}
+
+// DeleteSubscription removes the subscription along with its delivery logs, its queued deliveries are dropped
+func (whSvc *service) DeleteSubscription(ctx context.Context, id int64) error {
+
+	err := whSvc.webhookRepo.DeleteWebhookSubscription(ctx, nil, id)
+	if err != nil {
+		logger.Errorf(ctx, "webhookService: DeleteWebhookSubscription: id: %d, err: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+func (whSvc *service) ListDeliveries(ctx context.Context, filter dto.WebhookDeliveryFilter) (dto.ListWebhookDeliveriesResponse, error) {
+
+	_, err := whSvc.webhookRepo.GetWebhookSubscription(ctx, nil, filter.SubscriptionID)
+	if err != nil {
+		logger.Errorf(ctx, "webhookService: GetWebhookSubscription: id: %d, err: %v", filter.SubscriptionID, err)
+		return dto.ListWebhookDeliveriesResponse{}, err
+	}
+
+	deliveries, pagination, err := whSvc.webhookRepo.ListWebhookDeliveries(ctx, nil, filter)
+	if err != nil {
+		logger.Errorf(ctx, "webhookService: ListWebhookDeliveries: err: %v", err)
+		return dto.ListWebhookDeliveriesResponse{}, err
+	}
+
+	res := make([]dto.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		res = append(res, mapDeliveryDbToSvc(delivery))
+	}
+
+	return dto.ListWebhookDeliveriesResponse{
+		Deliveries: res,
+		MetaData: dto.Pagination{
+			CurrentPage:  pagination.CurrentPage,
+			TotalPage:    pagination.TotalPage,
+			PageSize:     pagination.RecordPerPage,
+			TotalRecords: pagination.TotalRecords,
+		},
+	}, nil
+}
+
+// Redeliver sends the event of a delivery again as a new delivery, the event id stays the same
+func (whSvc *service) Redeliver(ctx context.Context, deliveryID int64) (res dto.WebhookDelivery, err error) {
+
+	tx, err := whSvc.webhookRepo.BeginTx(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "webhookService: BeginTx: err: %v", err)
+		return dto.WebhookDelivery{}, err
+	}
+
+	defer func() {
+		rvr := recover()
+		defer func() {
+			if rvr != nil {
+				logger.Infof(ctx, "Transaction aborted because of panic: %v, Propagating panic further", rvr)
+				panic(rvr)
+			}
+		}()
+
+		txErr := whSvc.webhookRepo.HandleTransaction(ctx, tx, err == nil && rvr == nil)
+		if txErr != nil {
+			err = txErr
+			logger.Infof(ctx, "error in handle transaction, err: %s", txErr.Error())
+			return
+		}
+	}()
+
+	delivery, err := whSvc.webhookRepo.RedeliverWebhookDelivery(ctx, tx, deliveryID)
+	if err != nil {
+		logger.Errorf(ctx, "webhookService: RedeliverWebhookDelivery: id: %d, err: %v", deliveryID, err)
+		return dto.WebhookDelivery{}, err
+	}
+
+	err = whSvc.outboxRepo.EnqueueOutboxMessage(ctx, tx, constants.OutboxWebhookEvent, dto.OutboxWebhookEvent{
+		DeliveryID: delivery.ID,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "webhookService: EnqueueOutboxMessage: delivery: %d, err: %v", delivery.ID, err)
+		return dto.WebhookDelivery{}, err
+	}
+
+	logger.Infof(ctx, "webhookService: delivery %d queued again as delivery %d", deliveryID, delivery.ID)
+	return mapDeliveryDbToSvc(delivery), nil
+}
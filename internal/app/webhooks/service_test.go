@@ -0,0 +1,146 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/joshsoftware/peerly-backend/internal/repository/mocks"
+	l "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+func TestCreateSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            dto.CreateWebhookSubscriptionReq
+		expectedSecret func(secret string) bool
+	}{
+		{
+			name: "Given secret is kept",
+			req:  dto.CreateWebhookSubscriptionReq{URL: "https://example.com/peerly", Secret: "shared", Events: []string{constants.RewardGivenEvent}},
+			expectedSecret: func(secret string) bool {
+				return secret == "shared"
+			},
+		},
+		{
+			name: "Secret is generated when none is given",
+			req:  dto.CreateWebhookSubscriptionReq{URL: "https://example.com/peerly", Events: []string{constants.RewardGivenEvent}},
+			expectedSecret: func(secret string) bool {
+				return strings.HasPrefix(secret, "whsec_") && len(secret) == len("whsec_")+64
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			webhookMock := mocks.NewWebhookStorer(t)
+			webhookMock.On("CreateWebhookSubscription", mock.Anything, nil, mock.MatchedBy(func(subscription repository.WebhookSubscription) bool {
+				return subscription.URL == test.req.URL && subscription.CreatedBy == 1 && test.expectedSecret(subscription.Secret)
+			})).Return(func(ctx context.Context, tx repository.Transaction, subscription repository.WebhookSubscription) (repository.WebhookSubscription, error) {
+				subscription.ID = 1
+				return subscription, nil
+			}).Once()
+			service := NewService(webhookMock, mocks.NewOutboxStorer(t))
+
+			ctx := context.WithValue(context.Background(), constants.UserId, int64(1))
+			result, err := service.CreateSubscription(ctx, test.req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), result.ID)
+			assert.True(t, test.expectedSecret(result.Secret))
+		})
+	}
+}
+
+func TestListSubscriptionsLeavesSecretOut(t *testing.T) {
+	webhookMock := mocks.NewWebhookStorer(t)
+	webhookMock.On("ListWebhookSubscriptions", mock.Anything, nil).Return([]repository.WebhookSubscription{
+		{ID: 1, URL: "https://example.com/peerly", Secret: "shared", Events: []string{constants.ReportCreatedEvent}},
+	}, nil).Once()
+	service := NewService(webhookMock, mocks.NewOutboxStorer(t))
+
+	result, err := service.ListSubscriptions(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Empty(t, result[0].Secret)
+	assert.Equal(t, []string{constants.ReportCreatedEvent}, result[0].Events)
+}
+
+func TestListDeliveries(t *testing.T) {
+	webhookMock := mocks.NewWebhookStorer(t)
+	webhookMock.On("GetWebhookSubscription", mock.Anything, nil, int64(9)).Return(repository.WebhookSubscription{}, apperrors.WebhookSubscriptionNotFound).Once()
+	service := NewService(webhookMock, mocks.NewOutboxStorer(t))
+
+	_, err := service.ListDeliveries(context.Background(), dto.WebhookDeliveryFilter{SubscriptionID: 9, Page: 1, Limit: 10})
+
+	assert.Equal(t, apperrors.WebhookSubscriptionNotFound, err)
+}
+
+func TestRedeliver(t *testing.T) {
+	tests := []struct {
+		name            string
+		setup           func(webhookMock *mocks.WebhookStorer, outboxMock *mocks.OutboxStorer)
+		isErrorExpected bool
+		expectedError   error
+	}{
+		{
+			name: "Redelivery is logged and queued together",
+			setup: func(webhookMock *mocks.WebhookStorer, outboxMock *mocks.OutboxStorer) {
+				tx := &sql.Tx{}
+				webhookMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				webhookMock.On("RedeliverWebhookDelivery", mock.Anything, tx, int64(3)).Return(repository.WebhookDelivery{ID: 4, EventID: "evt", Status: constants.WebhookDeliveryPending}, nil).Once()
+				outboxMock.On("EnqueueOutboxMessage", mock.Anything, tx, constants.OutboxWebhookEvent, dto.OutboxWebhookEvent{DeliveryID: 4}).Return(nil).Once()
+				webhookMock.On("HandleTransaction", mock.Anything, tx, true).Return(nil).Once()
+			},
+			isErrorExpected: false,
+		},
+		{
+			name: "Unknown delivery",
+			setup: func(webhookMock *mocks.WebhookStorer, outboxMock *mocks.OutboxStorer) {
+				tx := &sql.Tx{}
+				webhookMock.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+				webhookMock.On("RedeliverWebhookDelivery", mock.Anything, tx, int64(3)).Return(repository.WebhookDelivery{}, apperrors.WebhookDeliveryNotFound).Once()
+				webhookMock.On("HandleTransaction", mock.Anything, tx, false).Return(nil).Once()
+			},
+			isErrorExpected: true,
+			expectedError:   apperrors.WebhookDeliveryNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			webhookMock := mocks.NewWebhookStorer(t)
+			outboxMock := mocks.NewOutboxStorer(t)
+			test.setup(webhookMock, outboxMock)
+			service := NewService(webhookMock, outboxMock)
+
+			result, err := service.Redeliver(context.Background(), 3)
+
+			if test.isErrorExpected {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(4), result.ID)
+			assert.Equal(t, "evt", result.EventID)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"id":"evt"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=7c757099788fba43a4fe1e0c3b767303fdd971ab6183bc900d3de418c62b08b0", Sign("secret", 1700000000, []byte(`{"id":"evt"}`)))
+}
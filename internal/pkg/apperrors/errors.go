@@ -77,6 +77,59 @@ const (
 	InvalidLoggerLevel                 = CustomError("Invalid Logger Level")
 	PreviousQuarterRatingNotAllowed    = CustomError("Reward can be given for current quarter appreciations")
 	NotAllowedForReportedAppreciation  = CustomError(`Currently, the appreciation is under review, so we’re unable to proceed with a reward at this time.`)
+	EmptyRewardLevels                  = CustomError("At least one reward level is required")
+	DuplicateRewardLevelPoint          = CustomError("Reward level points should be unique")
+	NegativeRewardLevelValue           = CustomError("Reward level value cannot be negative")
+	CommentNotFound                    = CustomError("Comment not found")
+	CommentFieldBlank                  = CustomError("Comment cannot be blank")
+	CommentLengthExceeded              = CustomError("Comment should be at most 500 characters long")
+	CommentActionNotAllowed            = CustomError("You can only edit or delete your own comments")
+	CannotReportOwnComment             = CustomError("You cannot report your own comments")
+	InvalidReaction                    = CustomError("Invalid reaction")
+	ReactionNotFound                   = CustomError("Reaction not found")
+	TooManyReceivers                   = CustomError("Too many receivers for an appreciation")
+	GroupNameLengthExceeded            = CustomError("Group name should be at most 100 characters long")
+	AppreciationEditNotAllowed         = CustomError("You can only edit your own appreciations")
+	AppreciationEditWindowExpired      = CustomError("The appreciation can no longer be edited, the edit window has passed")
+	AppreciationNotEditable            = CustomError("The appreciation cannot be edited once it has been rewarded or reported")
+	InvalidCursor                      = CustomError("Invalid cursor")
+	SearchWithCursor                   = CustomError("Search results are ranked by relevance and paginated by page, not by cursor")
+	OutboxMessageNotFound              = CustomError("Failed delivery not found")
+	InvalidOutboxStatus                = CustomError("Invalid delivery status")
+	NotificationNotFound               = CustomError("Notification not found")
+	InvalidNotificationEvent           = CustomError("Invalid notification event")
+	InvalidNotificationChannel         = CustomError("Invalid notification channel")
+	UnregisteredNotificationToken      = CustomError("Notification token is no longer registered")
+	IntegrationNotFound                = CustomError("Integration not found")
+	InvalidIntegrationKind             = CustomError("Invalid integration kind, expected slack or teams")
+	InvalidWebhookURL                  = CustomError("Invalid webhook url")
+	IntegrationNameBlank               = CustomError("Integration name cannot be blank")
+	WebhookDeliveryFailed              = CustomError("The webhook did not accept the message")
+	WebhookSubscriptionNotFound        = CustomError("Webhook subscription not found")
+	WebhookDeliveryNotFound            = CustomError("Webhook delivery not found")
+	InvalidWebhookEvent                = CustomError("Invalid webhook event")
+	WebhookEventsEmpty                 = CustomError("Subscribe to at least one webhook event")
+	RevokedAuthToken                   = CustomError("Auth token has been revoked")
+	InvalidRefreshToken                = CustomError("Invalid refresh token")
+	InvalidRoleChange                  = CustomError("User doesn't hold the role this change applies to")
+	UserDeactivated                    = CustomError("User account is deactivated")
+	ReceiverDeactivated                = CustomError("Appreciation can't be given to a deactivated user")
+	CannotDeactivateUser               = CustomError("Super admins and your own account can't be deactivated")
+	InvalidUserStatus                  = CustomError("Invalid user status")
+	PasswordTooShort                   = CustomError("Password must be at least 8 characters long")
+	PasswordOnlyForAdmins              = CustomError("Password can only be set for admins")
+	EmptyIntranetUserList              = CustomError("Intranet returned no users")
+	IntranetUnavailable                = CustomError("Intranet is unavailable, please try again later")
+	DepartmentNotFound                 = CustomError("Department not found")
+	TeamNotFound                       = CustomError("Team not found")
+	DepartmentNameBlank                = CustomError("Department name cannot be blank")
+	TeamNameBlank                      = CustomError("Team name cannot be blank")
+	DepartmentAlreadyPresent           = CustomError("Department already exists")
+	TeamAlreadyPresent                 = CustomError("Team already exists in the department")
+	DepartmentInUse                    = CustomError("Department still has teams or users")
+	TeamInUse                          = CustomError("Team still has users")
+	TeamNotInDepartment                = CustomError("Team doesn't belong to the department")
+	InvalidManager                     = CustomError("Manager can't be the user or someone reporting to them")
 )
 
 // ErrKeyNotSet - Returns error object specific to the key value passed in
@@ -89,17 +142,21 @@ func GetHTTPStatusCode(err error) int {
 	switch err {
 	case InternalServerError, JSONParsingErrorResp:
 		return http.StatusInternalServerError
-	case OrganizationConfigNotFound, OrganizationNotFound, InvalidOrgId, GradeNotFound, AppreciationNotFound, PageParamNotFound, InvalidCoreValueData, InvalidIntranetData:
+	case OrganizationConfigNotFound, OrganizationNotFound, InvalidOrgId, GradeNotFound, AppreciationNotFound, PageParamNotFound, InvalidCoreValueData, InvalidIntranetData, CommentNotFound, ReactionNotFound, OutboxMessageNotFound, NotificationNotFound, IntegrationNotFound, WebhookSubscriptionNotFound, WebhookDeliveryNotFound, UserNotFound, DepartmentNotFound, TeamNotFound:
 		return http.StatusNotFound
-	case InvalidLoggerLevel, BadRequest, InvalidId, JSONParsingErrorReq, TextFieldBlank, InvalidParentValue, DescFieldBlank, UniqueCoreValue, SelfAppreciationError, CannotReportOwnAppreciation, RepeatedReport, InvalidCoreValueID, InvalidReceiverID, InvalidRewardMultiplier, InvalidRewardQuotaRenewalFrequency, InvalidTimezone, InvalidRewardPoint, InvalidEmail, InvalidPassword, DescriptionLengthBelowLimit, InvalidPageSize, InvalidPage, NegativeGradePoints, NegativeBadgePoints, PreviousQuarterRatingNotAllowed:
+	case InvalidLoggerLevel, BadRequest, InvalidId, JSONParsingErrorReq, TextFieldBlank, InvalidParentValue, DescFieldBlank, UniqueCoreValue, SelfAppreciationError, CannotReportOwnAppreciation, RepeatedReport, InvalidCoreValueID, InvalidReceiverID, InvalidRewardMultiplier, InvalidRewardQuotaRenewalFrequency, InvalidTimezone, InvalidRewardPoint, InvalidEmail, InvalidPassword, DescriptionLengthBelowLimit, InvalidPageSize, InvalidPage, NegativeGradePoints, NegativeBadgePoints, PreviousQuarterRatingNotAllowed, EmptyRewardLevels, DuplicateRewardLevelPoint, NegativeRewardLevelValue, CommentFieldBlank, CommentLengthExceeded, CannotReportOwnComment, InvalidReaction, TooManyReceivers, GroupNameLengthExceeded, InvalidCursor, SearchWithCursor, InvalidOutboxStatus, InvalidNotificationEvent, InvalidNotificationChannel, InvalidIntegrationKind, InvalidWebhookURL, IntegrationNameBlank, InvalidWebhookEvent, WebhookEventsEmpty, ReceiverDeactivated, InvalidUserStatus, PasswordTooShort, PasswordOnlyForAdmins, DepartmentNameBlank, TeamNameBlank, TeamNotInDepartment, InvalidManager:
 		return http.StatusBadRequest
-	case InvalidContactEmail, InvalidDomainName, UserAlreadyPresent, RewardAlreadyPresent, RepeatedUser:
+	case InvalidContactEmail, InvalidDomainName, UserAlreadyPresent, RewardAlreadyPresent, RepeatedUser, InvalidRoleChange, DepartmentAlreadyPresent, TeamAlreadyPresent, DepartmentInUse, TeamInUse:
 		return http.StatusConflict
-	case InvalidAuthToken, RoleUnathorized, IntranetValidationFailed, UnauthorizedDeveloper:
+	case InvalidAuthToken, RoleUnathorized, IntranetValidationFailed, UnauthorizedDeveloper, RevokedAuthToken, InvalidRefreshToken:
 		return http.StatusUnauthorized
 	case RewardQuotaIsNotSufficient:
 		return http.StatusUnprocessableEntity
-	case OrganizationConfigAlreadyPresent, NotAllowedForReportedAppreciation:
+	case WebhookDeliveryFailed, EmptyIntranetUserList:
+		return http.StatusBadGateway
+	case IntranetUnavailable:
+		return http.StatusServiceUnavailable
+	case OrganizationConfigAlreadyPresent, NotAllowedForReportedAppreciation, CommentActionNotAllowed, AppreciationEditNotAllowed, AppreciationEditWindowExpired, AppreciationNotEditable, UserDeactivated, CannotDeactivateUser:
 		return http.StatusForbidden
 	default:
 		return http.StatusInternalServerError
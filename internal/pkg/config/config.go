@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
@@ -28,10 +29,27 @@ func Load() {
 
 	viper.SetDefault(constants.AppName, "app")
 	viper.SetDefault(constants.AppPort, "8002")
+	viper.SetDefault(constants.AppreciationEditWindow, constants.DefaultAppreciationEditWindow)
+	viper.SetDefault(constants.NotificationProvider, "fcm")
+	viper.SetDefault(constants.FirebaseAccountKey, "serviceAccountKey.json")
+	viper.SetDefault(constants.EmailTransport, "sendgrid")
+	viper.SetDefault(constants.SMTPHost, "")
+	viper.SetDefault(constants.SMTPPort, 587)
+	viper.SetDefault(constants.SMTPUsername, "")
+	viper.SetDefault(constants.SMTPPassword, "")
+	viper.SetDefault(constants.SMTPStartTLS, true)
+	viper.SetDefault(constants.EmailOutboxDir, "./tmp/emails")
+	viper.SetDefault(constants.OutboxMaxAttempts, constants.DefaultOutboxMaxAttempts)
+	viper.SetDefault(constants.DigestWeekday, constants.DefaultDigestWeekday)
+	viper.SetDefault(constants.DigestTime, constants.DefaultDigestTime)
+	viper.SetDefault(constants.AccessTokenExpiry, constants.DefaultAccessTokenExpiryMinutes)
+	viper.SetDefault(constants.RefreshTokenExpiry, constants.DefaultRefreshTokenExpiryDays)
+	viper.SetDefault(constants.LegacyTokenExpiry, constants.DefaultLegacyTokenExpiryHours)
+	viper.SetDefault(constants.IdentityProvider, "intranet")
+	viper.SetDefault(constants.OIDCClaimMapping, "")
 
-	// Check for the presence of JWT_KEY and JWT_EXPIRY_DURATION_HOURS
+	// Check for the presence of JWT_KEY
 	JWTKey()
-	JWTExpiryDurationHours()
 }
 
 // AppName - returns the app name
@@ -55,9 +73,29 @@ func JWTKey() []byte {
 	return []byte(ReadEnvString(constants.JWTSecret))
 }
 
-// JWTExpiryDurationHours - returns duration for jwt expiry in int
-func JWTExpiryDurationHours() int {
-	return int(ReadEnvInt(constants.JWTExpiryDurationHours))
+// AccessTokenExpiry - returns how long an access token is valid for
+func AccessTokenExpiry() time.Duration {
+	if !viper.IsSet(constants.AccessTokenExpiry) {
+		return time.Duration(constants.DefaultAccessTokenExpiryMinutes) * time.Minute
+	}
+	return time.Duration(ReadEnvInt(constants.AccessTokenExpiry)) * time.Minute
+}
+
+// LegacyTokenExpiry - returns how long the tokens issued before refresh tokens existed were valid for, they may still be
+// in use until that long after the upgrade
+func LegacyTokenExpiry() time.Duration {
+	if !viper.IsSet(constants.LegacyTokenExpiry) {
+		return time.Duration(constants.DefaultLegacyTokenExpiryHours) * time.Hour
+	}
+	return time.Duration(ReadEnvInt(constants.LegacyTokenExpiry)) * time.Hour
+}
+
+// RefreshTokenExpiry - returns how long a refresh token is valid for, every refresh starts the period again
+func RefreshTokenExpiry() time.Duration {
+	if !viper.IsSet(constants.RefreshTokenExpiry) {
+		return time.Duration(constants.DefaultRefreshTokenExpiryDays) * 24 * time.Hour
+	}
+	return time.Duration(ReadEnvInt(constants.RefreshTokenExpiry)) * 24 * time.Hour
 }
 
 // ReadEnvInt - reads an environment variable as an integer
@@ -104,6 +142,141 @@ func IntranetBaseUrl() string {
 	return (ReadEnvString(constants.IntranetBaseUrl))
 }
 
+// IntranetTimeout - returns how long a single call to the intranet may take
+func IntranetTimeout() time.Duration {
+	if !viper.IsSet(constants.IntranetTimeout) {
+		return time.Duration(constants.DefaultIntranetTimeoutSeconds) * time.Second
+	}
+	return time.Duration(ReadEnvInt(constants.IntranetTimeout)) * time.Second
+}
+
+// IntranetMaxRetries - returns how often a call the intranet failed with a network error or a 5xx is tried again
+func IntranetMaxRetries() int {
+	if !viper.IsSet(constants.IntranetMaxRetries) {
+		return constants.DefaultIntranetMaxRetries
+	}
+	return ReadEnvInt(constants.IntranetMaxRetries)
+}
+
+// IntranetBreakerThreshold - returns how many failed intranet calls in a row stop calling it for a while
+func IntranetBreakerThreshold() int {
+	if !viper.IsSet(constants.IntranetBreakerThreshold) {
+		return constants.DefaultIntranetBreakerThreshold
+	}
+	return ReadEnvInt(constants.IntranetBreakerThreshold)
+}
+
+// IntranetBreakerCooldown - returns how long the intranet isn't called once it kept failing
+func IntranetBreakerCooldown() time.Duration {
+	if !viper.IsSet(constants.IntranetBreakerCooldown) {
+		return time.Duration(constants.DefaultIntranetBreakerCooldownSeconds) * time.Second
+	}
+	return time.Duration(ReadEnvInt(constants.IntranetBreakerCooldown)) * time.Second
+}
+
 func DeveloperKey() string {
 	return (ReadEnvString(constants.DeveloperKey))
 }
+
+// AppreciationEditWindow - returns the minutes after posting during which a sender can edit their appreciation
+func AppreciationEditWindow() int {
+	if !viper.IsSet(constants.AppreciationEditWindow) {
+		return constants.DefaultAppreciationEditWindow
+	}
+	return ReadEnvInt(constants.AppreciationEditWindow)
+}
+
+// NotificationProvider - returns which push notification provider to use: fcm, memory or log
+func NotificationProvider() string {
+	return ReadEnvString(constants.NotificationProvider)
+}
+
+// FirebaseAccountKey - returns the path of the firebase service account key file
+func FirebaseAccountKey() string {
+	return ReadEnvString(constants.FirebaseAccountKey)
+}
+
+// EmailTransport - returns how emails are delivered: sendgrid, smtp or file
+func EmailTransport() string {
+	return ReadEnvString(constants.EmailTransport)
+}
+
+// SenderEmail - returns the address emails are sent from
+func SenderEmail() string {
+	return ReadEnvString(constants.SenderEmail)
+}
+
+func SMTPHost() string {
+	return ReadEnvString(constants.SMTPHost)
+}
+
+func SMTPPort() int {
+	return ReadEnvInt(constants.SMTPPort)
+}
+
+func SMTPUsername() string {
+	return ReadEnvString(constants.SMTPUsername)
+}
+
+func SMTPPassword() string {
+	return ReadEnvString(constants.SMTPPassword)
+}
+
+// SMTPStartTLS - returns whether the smtp connection is upgraded with STARTTLS before authenticating
+func SMTPStartTLS() bool {
+	return ReadEnvBool(constants.SMTPStartTLS)
+}
+
+// EmailOutboxDir - returns the directory the file transport writes .eml files to
+func EmailOutboxDir() string {
+	return ReadEnvString(constants.EmailOutboxDir)
+}
+
+// OutboxMaxAttempts - returns how many times an email or push notification is tried before it is dead lettered
+func OutboxMaxAttempts() int {
+	if !viper.IsSet(constants.OutboxMaxAttempts) {
+		return constants.DefaultOutboxMaxAttempts
+	}
+	return ReadEnvInt(constants.OutboxMaxAttempts)
+}
+
+// DigestWeekday - returns the day of the week the weekly digest is sent on
+func DigestWeekday() string {
+	if !viper.IsSet(constants.DigestWeekday) {
+		return constants.DefaultDigestWeekday
+	}
+	return ReadEnvString(constants.DigestWeekday)
+}
+
+// DigestTime - returns the HH:MM time, in the organization timezone, the weekly digest is sent at
+func DigestTime() string {
+	if !viper.IsSet(constants.DigestTime) {
+		return constants.DefaultDigestTime
+	}
+	return ReadEnvString(constants.DigestTime)
+}
+
+// IdentityProvider - returns who authenticates the users logging in: intranet, oidc or static
+func IdentityProvider() string {
+	return ReadEnvString(constants.IdentityProvider)
+}
+
+// OIDCIssuerURL - returns the issuer the ID tokens of the oidc identity provider are verified against
+func OIDCIssuerURL() string {
+	return ReadEnvString(constants.OIDCIssuerURL)
+}
+
+// OIDCClientID - returns the client id Peerly is registered with at the oidc issuer, ID tokens must be issued to it
+func OIDCClientID() string {
+	return ReadEnvString(constants.OIDCClientID)
+}
+
+// OIDCClaimMapping - returns the field=claim pairs overriding which ID token claims a profile is read from
+func OIDCClaimMapping() string {
+	return ReadEnvString(constants.OIDCClaimMapping)
+}
+
+// StaticIdentityUsersFile - returns the JSON file of credentials and profiles the static identity provider logs in
+func StaticIdentityUsersFile() string {
+	return ReadEnvString(constants.StaticIdentityUsersFile)
+}
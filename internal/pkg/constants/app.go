@@ -7,7 +7,7 @@ var CreateAppreciationColumns = []string{
 	"core_value_id", "description", "quarter", "sender", "receiver",
 }
 
-var CreateRewardColumns = []string{"appreciation_id", "point", "sender"}
+var CreateRewardColumns = []string{"appreciation_id", "point", "sender", "reward_level_id", "value"}
 var OrgConfigColumns = []string{
 	"id",
 	"reward_multiplier",
@@ -18,3 +18,177 @@ var OrgConfigColumns = []string{
 }
 
 const DefaultAppreciationPoint = 200
+
+// Limits of a team appreciation
+const (
+	MaxAppreciationReceivers = 25
+	MaxGroupNameLength       = 100
+)
+
+// Minutes after posting during which a sender can edit their appreciation, unless configured otherwise
+const DefaultAppreciationEditWindow = 15
+
+// Moderation statuses of a report, values of the status enum type
+const (
+	ReportedStatus = "reported"
+	ResolvedStatus = "resolved"
+	DeletedStatus  = "deleted"
+)
+
+// Emoji reactions a user can leave on an appreciation, independent of reward points
+var AllowedReactions = []string{"clap", "heart", "celebrate", "thumbs_up", "laugh"}
+
+// Kinds of messages written to the outbox
+const (
+	OutboxEmail   = "email"
+	OutboxPush    = "push"
+	OutboxWebhook = "webhook"
+	// an event sent to a webhook subscription, unlike OutboxWebhook which is a chat post
+	OutboxWebhookEvent = "webhook_event"
+)
+
+// Delivery statuses of an outbox message, a message that ran out of attempts is dead
+const (
+	OutboxPending = "pending"
+	OutboxSent    = "sent"
+	OutboxDead    = "dead"
+)
+
+// Permissions granted to roles in role_permissions
+const (
+	PermissionAppreciationDelete    = "appreciation.delete"
+	PermissionReportModerate        = "report.moderate"
+	PermissionConfigUpdate          = "config.update"
+	PermissionGradeEdit             = "grade.edit"
+	PermissionUserRoleAssign        = "user.role.assign"
+	PermissionUserManage            = "user.manage"
+	PermissionAdminPasswordSet      = "admin.password.set"
+	PermissionOrgManage             = "org.manage"
+	PermissionAppreciationEdits     = "appreciation.edits.view"
+	PermissionSessionRevoke         = "session.revoke"
+	PermissionOutboxManage          = "outbox.manage"
+	PermissionIntegrationManage     = "integration.manage"
+	PermissionWebhookManage         = "webhook.manage"
+	PermissionNotificationBroadcast = "notification.broadcast"
+	PermissionReportExport          = "report.export"
+)
+
+// Statuses of a user as admins filter and see them
+const (
+	ActiveUser      = "active"
+	DeactivatedUser = "deactivated"
+)
+
+// Outcomes of an intranet sync run
+const (
+	UserSyncCompleted = "completed"
+	UserSyncFailed    = "failed"
+)
+
+// Sync reports listed unless the admin asks for another number, and the most they can ask for
+const (
+	DefaultUserSyncReportsLimit = 20
+	MaxUserSyncReportsLimit     = 100
+)
+
+// How far up the reporting line a manager change is checked for a cycle
+const MaxReportingLineDepth = 50
+
+// Users written per statement by the bulk import unless another batch size is given
+const DefaultUserImportBatchSize = 100
+
+// How long an intranet call may take, how often it is retried and when the circuit opens, unless configured otherwise
+const (
+	DefaultIntranetTimeoutSeconds         = 10
+	DefaultIntranetMaxRetries             = 2
+	DefaultIntranetBreakerThreshold       = 5
+	DefaultIntranetBreakerCooldownSeconds = 30
+)
+
+// Admin passwords set by a super admin are at least this long, a reset generates one
+const MinAdminPasswordLength = 8
+
+// Lifetimes of the auth tokens unless configured otherwise, the short-lived access token is renewed with the refresh token
+const (
+	DefaultAccessTokenExpiryMinutes = 15
+	DefaultRefreshTokenExpiryDays   = 30
+	// tokens issued before refresh tokens existed were valid this long
+	DefaultLegacyTokenExpiryHours = 672
+)
+
+// Attempts made to deliver an outbox message before it is dead lettered, unless configured otherwise
+const DefaultOutboxMaxAttempts = 5
+
+// Types of the notifications shown in a user's inbox
+const (
+	AppreciationNotification  = "appreciation"
+	RewardNotification        = "reward"
+	BadgeNotification         = "badge"
+	ReportOutcomeNotification = "report_outcome"
+	QuotaRefillNotification   = "quota_refill"
+	// comments are only pushed and emailed, they never land in the inbox
+	CommentNotification = "comment"
+	// broadcasts go to everyone and never land in the inbox
+	BroadcastNotification = "broadcast"
+)
+
+// Events a user can choose the channels for, the event names match the inbox types
+var NotificationEvents = []string{AppreciationNotification, RewardNotification, BadgeNotification, ReportOutcomeNotification, QuotaRefillNotification, CommentNotification, BroadcastNotification}
+
+// Channels a notification can be delivered on, every channel is on until the user turns it off
+const (
+	PushChannel  = "push"
+	EmailChannel = "email"
+	InAppChannel = "in_app"
+)
+
+var NotificationChannels = []string{PushChannel, EmailChannel, InAppChannel}
+
+// Weekday and time, in the organization timezone, the weekly digest is sent at unless configured otherwise
+const (
+	DefaultDigestWeekday = "monday"
+	DefaultDigestTime    = "09:00"
+)
+
+// Events whose emails are left out for digest subscribers, the digest summarizes them instead
+var DigestEvents = []string{AppreciationNotification, RewardNotification, BadgeNotification}
+
+// Chat tools the appreciation feed can be posted to through an incoming webhook
+const (
+	SlackIntegration = "slack"
+	TeamsIntegration = "teams"
+)
+
+var IntegrationKinds = []string{SlackIntegration, TeamsIntegration}
+
+// Events posted to the chat integrations, appreciations and badges reuse the notification event names
+const QuarterWinnersEvent = "quarter_winners"
+
+// Winners of a quarter posted to the chat integrations
+const QuarterWinnersCount = 3
+
+// Events sent to the webhook subscriptions of third-party consumers
+const (
+	AppreciationCreatedEvent = "appreciation.created"
+	AppreciationDeletedEvent = "appreciation.deleted"
+	RewardGivenEvent         = "reward.given"
+	BadgeAwardedEvent        = "badge.awarded"
+	ReportCreatedEvent       = "report.created"
+	ReportResolvedEvent      = "report.resolved"
+	QuotaRenewedEvent        = "quota.renewed"
+)
+
+var WebhookEvents = []string{AppreciationCreatedEvent, AppreciationDeletedEvent, RewardGivenEvent, BadgeAwardedEvent, ReportCreatedEvent, ReportResolvedEvent, QuotaRenewedEvent}
+
+// Statuses of a webhook delivery, a failed delivery is still retried until the outbox runs out of attempts
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Outcomes of a resolved report sent with the report.resolved event
+const (
+	ReportOutcomeKept    = "kept"
+	ReportOutcomeDeleted = "deleted"
+)
@@ -3,22 +3,48 @@ package constants
 type UserIdCtxKey string
 type RoleCtxKey string
 type RequestIDCtxKey string
+type ClaimsCtxKey string
 
 // System Constants used to setup environment and basic functionality
 const (
-	AppName                = "APP_NAME"
-	AppPort                = "APP_PORT"
-	JWTSecret              = "JWT_SECRET"
-	JWTExpiryDurationHours = "JWT_EXPIRY_DURATION_HOURS"
-	DBURI                  = "DB_URI"
-	IntranetClientCode     = "INTRANET_CLIENT_CODE"
-	MigrationFolderPath    = "MIGRATION_FOLDER_PATH"
-	IntranetAuthToken      = "INTRANET_AUTH_TOKEN"
-	PeerlyBaseUrl          = "PEERLY_BASE_URL"
-	IntranetBaseUrl        = "INTRANET_BASE_URL"
-	POST                   = "POST"
-	GET                    = "GET"
-	DeveloperKey           = "DEVELOPER_KEY"
+	AppName                  = "APP_NAME"
+	AppPort                  = "APP_PORT"
+	JWTSecret                = "JWT_SECRET"
+	AccessTokenExpiry        = "ACCESS_TOKEN_EXPIRY_MINUTES"
+	RefreshTokenExpiry       = "REFRESH_TOKEN_EXPIRY_DAYS"
+	LegacyTokenExpiry        = "JWT_EXPIRY_DURATION_HOURS"
+	DBURI                    = "DB_URI"
+	IntranetClientCode       = "INTRANET_CLIENT_CODE"
+	MigrationFolderPath      = "MIGRATION_FOLDER_PATH"
+	IntranetAuthToken        = "INTRANET_AUTH_TOKEN"
+	PeerlyBaseUrl            = "PEERLY_BASE_URL"
+	IntranetBaseUrl          = "INTRANET_BASE_URL"
+	POST                     = "POST"
+	GET                      = "GET"
+	DeveloperKey             = "DEVELOPER_KEY"
+	AppreciationEditWindow   = "APPRECIATION_EDIT_WINDOW_MINUTES"
+	NotificationProvider     = "NOTIFICATION_PROVIDER"
+	FirebaseAccountKey       = "FIREBASE_SERVICE_ACCOUNT_KEY"
+	EmailTransport           = "EMAIL_TRANSPORT"
+	SenderEmail              = "SENDER_EMAIL"
+	SMTPHost                 = "SMTP_HOST"
+	SMTPPort                 = "SMTP_PORT"
+	SMTPUsername             = "SMTP_USERNAME"
+	SMTPPassword             = "SMTP_PASSWORD"
+	SMTPStartTLS             = "SMTP_STARTTLS"
+	EmailOutboxDir           = "EMAIL_OUTBOX_DIR"
+	OutboxMaxAttempts        = "OUTBOX_MAX_ATTEMPTS"
+	DigestWeekday            = "DIGEST_WEEKDAY"
+	DigestTime               = "DIGEST_TIME"
+	IdentityProvider         = "IDENTITY_PROVIDER"
+	OIDCIssuerURL            = "OIDC_ISSUER_URL"
+	OIDCClientID             = "OIDC_CLIENT_ID"
+	OIDCClaimMapping         = "OIDC_CLAIM_MAPPING"
+	StaticIdentityUsersFile  = "STATIC_IDENTITY_USERS_FILE"
+	IntranetTimeout          = "INTRANET_TIMEOUT_SECONDS"
+	IntranetMaxRetries       = "INTRANET_MAX_RETRIES"
+	IntranetBreakerThreshold = "INTRANET_BREAKER_THRESHOLD"
+	IntranetBreakerCooldown  = "INTRANET_BREAKER_COOLDOWN_SECONDS"
 )
 
 const (
@@ -26,6 +52,19 @@ const (
 	User
 )
 
+// ids of the seeded roles, a token of the Admin tier is issued to super admins and admins
+const (
+	SuperAdminRoleID int64 = iota + 1
+	AdminRoleID
+	UserRoleID
+)
+
+// values of users.status
+const (
+	DeactivatedUserStatus int64 = iota
+	ActiveUserStatus
+)
+
 // User required constants
 const (
 	RequestID               RequestIDCtxKey = "RequestID"
@@ -35,6 +74,7 @@ const (
 	AdminRole                               = "admin"
 	UserId                  UserIdCtxKey    = "userId"
 	Role                    RoleCtxKey      = "role"
+	Claims                  ClaimsCtxKey    = "claims"
 	IntranetAuth                            = "Intranet-Auth"
 	PeerlyValidationPath                    = "/api/peerly/v1/sessions/login"
 	GetIntranetUserDataPath                 = "/api/peerly/v1/users/"
@@ -50,14 +90,37 @@ const (
 
 // Table Names
 const (
-	AppreciationsTable      = "appreciations"
-	RewardsTable            = "rewards"
-	UsersTable              = "users"
-	CoreValuesTable         = "core_values"
-	GradesTable             = "grades"
-	OrganizationConfigTable = "organization_config"
-	BadgeTable              = "badges"
-	RolesTable              = "roles"
+	AppreciationsTable         = "appreciations"
+	RewardsTable               = "rewards"
+	UsersTable                 = "users"
+	CoreValuesTable            = "core_values"
+	GradesTable                = "grades"
+	OrganizationConfigTable    = "organization_config"
+	BadgeTable                 = "badges"
+	RolesTable                 = "roles"
+	RewardLevelsTable          = "reward_levels"
+	CommentsTable              = "comments"
+	CommentResolutionsTable    = "comment_resolutions"
+	ReactionsTable             = "reactions"
+	AppreciationReceiversTable = "appreciation_receivers"
+	AppreciationEditsTable     = "appreciation_edits"
+	OutboxTable                = "outbox"
+	NotificationsTable         = "notifications"
+	NotificationPrefsTable     = "notification_preferences"
+	NotificationTokensTable    = "notification_tokens"
+	DigestSubscriptionsTable   = "digest_subscriptions"
+	IntegrationsTable          = "integrations"
+	WebhookSubscriptionsTable  = "webhook_subscriptions"
+	WebhookDeliveriesTable     = "webhook_deliveries"
+	BlacklistedTokensTable     = "user_blacklisted_tokens"
+	RefreshTokensTable         = "refresh_tokens"
+	RolePermissionsTable       = "role_permissions"
+	PermissionsTable           = "permissions"
+	UserSyncReportsTable       = "user_sync_reports"
+	DepartmentsTable           = "departments"
+	TeamsTable                 = "teams"
+	// view splitting the points of an appreciation across its receivers
+	AppreciationReceiverPointsView = "appreciation_receiver_points"
 )
 
 const DefaultOrgID = 1
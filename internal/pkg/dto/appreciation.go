@@ -1,58 +1,114 @@
 package dto
 
 import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
 )
 
 type Appreciation struct {
+	ID                int64   `json:"id"`
+	CoreValueID       int64   `json:"core_value_id" `
+	Description       string  `json:"description"`
+	TotalRewardPoints int32   `json:"total_reward_points,omitempty"`
+	Quarter           int8    `json:"quarter"`
+	Sender            int64   `json:"sender"`
+	Receiver          int64   `json:"receiver"`
+	Receivers         []int64 `json:"receivers,omitempty"`
+	GroupName         string  `json:"group_name,omitempty"`
+	CreatedAt         int64   `json:"created_at"`
+	UpdatedAt         int64   `json:"updated_at"`
+}
+
+// EditAppreciation is the change a sender makes to their appreciation within the edit window
+type EditAppreciation struct {
+	ID          int64  `json:"id"`
+	CoreValueID int64  `json:"core_value_id"`
+	Description string `json:"description"`
+	EditedBy    int64  `json:"edited_by"`
+	EditWindow  int64  `json:"-"` // minutes after posting during which the update is allowed
+}
+
+type AppreciationEdit struct {
 	ID                int64  `json:"id"`
-	CoreValueID       int64  `json:"core_value_id" `
-	Description       string `json:"description"`
-	TotalRewardPoints int32  `json:"total_reward_points,omitempty"`
-	Quarter           int8   `json:"quarter"`
-	Sender            int64  `json:"sender"`
-	Receiver          int64  `json:"receiver"`
-	CreatedAt         int64  `json:"created_at"`
-	UpdatedAt         int64  `json:"updated_at"`
+	AppreciationID    int64  `json:"appreciation_id"`
+	OldCoreValueID    int64  `json:"old_core_value_id"`
+	OldCoreValueName  string `json:"old_core_value_name"`
+	NewCoreValueID    int64  `json:"new_core_value_id"`
+	NewCoreValueName  string `json:"new_core_value_name"`
+	OldDescription    string `json:"old_description"`
+	NewDescription    string `json:"new_description"`
+	EditedBy          int64  `json:"edited_by"`
+	EditedByFirstName string `json:"edited_by_first_name"`
+	EditedByLastName  string `json:"edited_by_last_name"`
+	EditedAt          int64  `json:"edited_at"`
 }
 
 type AppreciationFilter struct {
-	Self      bool   `json:"Self"`
-	Name      string `json:"sender_name"`
-	SortOrder string `json:"sort_order"`
-	Page      int16  `json:"page"`
-	Limit     int16  `json:"page_size"`
-	Quarter   int    `json:"quarter"`
-	Year      int    `json:"year"`
+	Self        bool   `json:"Self"`
+	Name        string `json:"sender_name"`
+	Search      string `json:"search"`
+	CoreValueID int64  `json:"core_value_id"`
+	SenderID    int64  `json:"sender_id"`
+	ReceiverID  int64  `json:"receiver_id"`
+	From        int64  `json:"from"`
+	To          int64  `json:"to"`
+	SortOrder   string `json:"sort_order"`
+	UseCursor   bool   `json:"-"`
+	Cursor      string `json:"cursor"`
+	Page        int16  `json:"page"`
+	Limit       int16  `json:"page_size"`
+	Quarter     int    `json:"quarter"`
+	Year        int    `json:"year"`
+	// matches the appreciations sent or received by someone in the department or team
+	OrgFilter
 }
 
 type AppreciationResponse struct {
-	ID                  int64  `json:"id"`
-	CoreValueName       string `json:"core_value_name"`
-	CoreValueDesc       string `json:"core_value_description"`
-	Description         string `json:"description"`
-	TotalRewardPoints   int32  `json:"total_reward_points"`
-	Quarter             int8   `json:"quarter"`
-	SenderID            int64  `json:"sender_id"`
-	SenderEmployeeID    string `json:"sender_employee_id"`
-	SenderFirstName     string `json:"sender_first_name"`
-	SenderLastName      string `json:"sender_last_name"`
-	SenderImageURL      string `json:"sender_image_url"`
-	SenderDesignation   string `json:"sender_designation"`
-	ReceiverID          int64  `json:"receiver_id"`
-	ReceiverEmployeeID  string `json:"receiver_employee_id"`
-	ReceiverFirstName   string `json:"receiver_first_name"`
-	ReceiverLastName    string `json:"receiver_last_name"`
-	ReceiverImageURL    string `json:"receiver_image_url"`
-	ReceiverDesignation string `json:"receiver_designation"`
-	TotalRewards        int32  `json:"total_rewards"`
-	GivenRewardPoint    int8   `json:"given_reward_point"`
-	ReportedFlag        bool   `json:"reported_flag"`
-	ByManagement        bool   `json:"by_management"`
-	CreatedAt           int64  `json:"created_at"`
-	UpdatedAt           int64  `json:"updated_at"`
+	ID                  int64                  `json:"id"`
+	CoreValueID         int64                  `json:"core_value_id"`
+	CoreValueName       string                 `json:"core_value_name"`
+	CoreValueDesc       string                 `json:"core_value_description"`
+	Description         string                 `json:"description"`
+	TotalRewardPoints   int32                  `json:"total_reward_points"`
+	Quarter             int8                   `json:"quarter"`
+	SenderID            int64                  `json:"sender_id"`
+	SenderEmployeeID    string                 `json:"sender_employee_id"`
+	SenderFirstName     string                 `json:"sender_first_name"`
+	SenderLastName      string                 `json:"sender_last_name"`
+	SenderImageURL      string                 `json:"sender_image_url"`
+	SenderDesignation   string                 `json:"sender_designation"`
+	ReceiverID          int64                  `json:"receiver_id"`
+	ReceiverEmployeeID  string                 `json:"receiver_employee_id"`
+	ReceiverFirstName   string                 `json:"receiver_first_name"`
+	ReceiverLastName    string                 `json:"receiver_last_name"`
+	ReceiverImageURL    string                 `json:"receiver_image_url"`
+	ReceiverDesignation string                 `json:"receiver_designation"`
+	Receivers           []AppreciationReceiver `json:"receivers"`
+	GroupName           string                 `json:"group_name"`
+	TotalRewards        int32                  `json:"total_rewards"`
+	GivenRewardPoint    int8                   `json:"given_reward_point"`
+	ReportedFlag        bool                   `json:"reported_flag"`
+	ByManagement        bool                   `json:"by_management"`
+	ReactionCounts      map[string]int64       `json:"reaction_counts"`
+	MyReactions         []string               `json:"my_reactions"`
+	CreatedAt           int64                  `json:"created_at"`
+	UpdatedAt           int64                  `json:"updated_at"`
+}
+
+type AppreciationReceiver struct {
+	ID           int64  `json:"id"`
+	EmployeeID   string `json:"employee_id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	ImageURL     string `json:"image_url"`
+	Designation  string `json:"designation"`
+	RewardPoints int32  `json:"reward_points"`
 }
 
 // Pagination Object
@@ -66,6 +122,41 @@ type Pagination struct {
 type ListAppreciationsResponse struct {
 	Appreciations []AppreciationResponse `json:"appreciations"`
 	MetaData      Pagination             `json:"metadata"`
+	NextCursor    string                 `json:"next_cursor,omitempty"`
+}
+
+// AppreciationCursor is the position of the last appreciation of a feed page, handed to clients as an opaque string
+type AppreciationCursor struct {
+	CreatedAt int64
+	ID        int64
+}
+
+func (cursor AppreciationCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", cursor.CreatedAt, cursor.ID)))
+}
+
+func DecodeAppreciationCursor(encoded string) (cursor AppreciationCursor, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return AppreciationCursor{}, apperrors.InvalidCursor
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return AppreciationCursor{}, apperrors.InvalidCursor
+	}
+
+	cursor.CreatedAt, err = strconv.ParseInt(createdAt, 10, 64)
+	if err != nil {
+		return AppreciationCursor{}, apperrors.InvalidCursor
+	}
+
+	cursor.ID, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return AppreciationCursor{}, apperrors.InvalidCursor
+	}
+
+	return cursor, nil
 }
 
 func (appr *Appreciation) ValidateCreateAppreciation() (err error) {
@@ -84,8 +175,49 @@ func (appr *Appreciation) ValidateCreateAppreciation() (err error) {
 		return apperrors.DescriptionLengthBelowLimit
 	}
 
-	if appr.Receiver <= 0 {
-		return apperrors.InvalidReceiverID
+	// a single receiver can still be sent in receiver, it is treated as a team of one
+	if len(appr.Receivers) == 0 {
+		appr.Receivers = []int64{appr.Receiver}
+	}
+
+	receivers := make([]int64, 0, len(appr.Receivers))
+	for _, receiver := range appr.Receivers {
+		if receiver <= 0 {
+			return apperrors.InvalidReceiverID
+		}
+		if !slices.Contains(receivers, receiver) {
+			receivers = append(receivers, receiver)
+		}
+	}
+
+	if len(receivers) > constants.MaxAppreciationReceivers {
+		return apperrors.TooManyReceivers
+	}
+	appr.Receivers = receivers
+	appr.Receiver = receivers[0]
+
+	appr.GroupName = strings.TrimSpace(appr.GroupName)
+	if len(appr.GroupName) > constants.MaxGroupNameLength {
+		return apperrors.GroupNameLengthExceeded
+	}
+
+	return
+}
+
+func (edit *EditAppreciation) ValidateEditAppreciation() (err error) {
+
+	edit.Description = strings.TrimSpace(edit.Description)
+
+	if edit.CoreValueID <= 0 {
+		return apperrors.InvalidCoreValueID
+	}
+
+	if edit.Description == "" {
+		return apperrors.DescFieldBlank
+	}
+
+	if len(edit.Description) < 150 {
+		return apperrors.DescriptionLengthBelowLimit
 	}
 
 	return
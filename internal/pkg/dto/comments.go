@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+)
+
+const maxCommentLength = 500
+
+type Comment struct {
+	ID             int64  `json:"id"`
+	AppreciationID int64  `json:"appreciation_id"`
+	Comment        string `json:"comment"`
+	CommentedBy    int64  `json:"commented_by"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+type CommentResponse struct {
+	ID                   int64  `json:"id"`
+	AppreciationID       int64  `json:"appreciation_id"`
+	Comment              string `json:"comment"`
+	CommentedBy          int64  `json:"commented_by"`
+	CommenterEmployeeID  string `json:"commenter_employee_id"`
+	CommenterFirstName   string `json:"commenter_first_name"`
+	CommenterLastName    string `json:"commenter_last_name"`
+	CommenterImageURL    string `json:"commenter_image_url"`
+	CommenterDesignation string `json:"commenter_designation"`
+	CreatedAt            int64  `json:"created_at"`
+	UpdatedAt            int64  `json:"updated_at"`
+}
+
+type CommentFilter struct {
+	AppreciationID int64 `json:"appreciation_id"`
+	Page           int16 `json:"page"`
+	Limit          int16 `json:"page_size"`
+}
+
+type ListCommentsResponse struct {
+	Comments []CommentResponse `json:"comments"`
+	MetaData Pagination        `json:"metadata"`
+}
+
+type ReportCommentReq struct {
+	CommentID        int64  `json:"comment_id"`
+	ReportingComment string `json:"reporting_comment"`
+	ReportedBy       int64  `json:"reported_by"`
+}
+
+type ReportCommentResp struct {
+	ID               int64  `json:"id"`
+	CommentID        int64  `json:"comment_id"`
+	ReportingComment string `json:"reporting_comment"`
+	ReportedBy       int64  `json:"reported_by"`
+	ReportedAt       int64  `json:"reported_at"`
+}
+
+type ReportedComment struct {
+	ID                   int64  `json:"id"`
+	CommentID            int64  `json:"comment_id"`
+	AppreciationID       int64  `json:"appreciation_id"`
+	Comment              string `json:"comment"`
+	IsValid              bool   `json:"is_valid"`
+	CommenterFirstName   string `json:"commenter_first_name"`
+	CommenterLastName    string `json:"commenter_last_name"`
+	ReportingComment     string `json:"reporting_comment"`
+	ReportedByFirstName  string `json:"reported_by_first_name"`
+	ReportedByLastName   string `json:"reported_by_last_name"`
+	ReportedAt           int64  `json:"reported_at"`
+	ModeratorComment     string `json:"moderator_comment"`
+	ModeratedByFirstName string `json:"moderated_by_first_name"`
+	ModeratedByLastName  string `json:"moderated_by_last_name"`
+	ModeratedAt          int64  `json:"moderated_at"`
+	Status               string `json:"status"`
+}
+
+type ListReportedCommentsResponse struct {
+	Comments []ReportedComment `json:"comments"`
+}
+
+type CommentModerationReq struct {
+	ResolutionID     int64
+	CommentID        int64
+	ModeratorComment string `json:"moderator_comment"`
+	ModeratedBy      int64
+}
+
+type CommentMail struct {
+	CommenterName    string
+	AppreciationBy   string
+	AppreciationTo   string
+	AppreciationDesc string
+	Comment          string
+	Icon             string
+}
+
+type ReportedCommentMail struct {
+	Status           string
+	CommentBy        string
+	Comment          string
+	ReportingComment string
+	ModeratorComment string
+	Icon             string
+}
+
+func (cmt *Comment) ValidateComment() (err error) {
+
+	cmt.Comment = strings.TrimSpace(cmt.Comment)
+
+	if cmt.Comment == "" {
+		return apperrors.CommentFieldBlank
+	}
+
+	if len(cmt.Comment) > maxCommentLength {
+		return apperrors.CommentLengthExceeded
+	}
+
+	return
+}
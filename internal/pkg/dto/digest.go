@@ -0,0 +1,6 @@
+package dto
+
+// DigestSubscription tells whether the user gets the weekly digest instead of the per-event emails
+type DigestSubscription struct {
+	Subscribed bool `json:"subscribed"`
+}
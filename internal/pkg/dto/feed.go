@@ -0,0 +1,13 @@
+package dto
+
+// FeedEvent is streamed to the clients connected to the live feed, Event is one of the webhook event names
+type FeedEvent struct {
+	Event string      `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+// FeedRewardEventData carries the reward count of an appreciation after a reward was given
+type FeedRewardEventData struct {
+	AppreciationID int64 `json:"appreciation_id"`
+	TotalRewards   int32 `json:"total_rewards"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+)
+
+// Integration is an incoming webhook of a Slack or Teams channel the appreciation feed is posted to
+type Integration struct {
+	ID           int64   `json:"id"`
+	Kind         string  `json:"kind"`
+	Name         string  `json:"name"`
+	WebhookURL   string  `json:"webhook_url"`
+	CoreValueIDs []int64 `json:"core_value_ids"`
+	CreatedBy    int64   `json:"created_by"`
+	CreatedAt    int64   `json:"created_at"`
+}
+
+// CreateIntegrationReq registers a webhook, appreciations of every core value are posted when CoreValueIDs is empty
+type CreateIntegrationReq struct {
+	Kind         string  `json:"kind"`
+	Name         string  `json:"name"`
+	WebhookURL   string  `json:"webhook_url"`
+	CoreValueIDs []int64 `json:"core_value_ids"`
+}
+
+func (req CreateIntegrationReq) Validate() error {
+	if !slices.Contains(constants.IntegrationKinds, req.Kind) {
+		return apperrors.InvalidIntegrationKind
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return apperrors.IntegrationNameBlank
+	}
+	webhookURL, err := url.ParseRequestURI(req.WebhookURL)
+	if err != nil || (webhookURL.Scheme != "https" && webhookURL.Scheme != "http") || webhookURL.Host == "" {
+		return apperrors.InvalidWebhookURL
+	}
+	for _, coreValueID := range req.CoreValueIDs {
+		if coreValueID <= 0 {
+			return apperrors.InvalidCoreValueID
+		}
+	}
+	return nil
+}
+
+// FeedPost is a message of the appreciation feed, it is rendered as a Slack Block Kit message or a Teams Adaptive Card
+type FeedPost struct {
+	Event  string          `json:"event"`
+	Title  string          `json:"title"`
+	Text   string          `json:"text"`
+	Fields []FeedPostField `json:"fields,omitempty"`
+}
+
+type FeedPostField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
@@ -1,5 +1,12 @@
 package dto
 
+import (
+	"slices"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+)
+
 type FCMMessage struct {
 	To      string         `json:"to"`
 	Message FCMMessageData `json:"notification"`
@@ -9,3 +16,53 @@ type FCMMessageData struct {
 	Title string `json:"title"`
 	Body  string `json:"body"`
 }
+
+// Notification is an entry of a user's in-app inbox
+type Notification struct {
+	ID             int64  `json:"id"`
+	UserID         int64  `json:"user_id"`
+	Type           string `json:"type"`
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	AppreciationID int64  `json:"appreciation_id,omitempty"`
+	IsRead         bool   `json:"is_read"`
+	ReadAt         int64  `json:"read_at,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type NotificationFilter struct {
+	UserID     int64 `json:"user_id"`
+	UnreadOnly bool  `json:"unread"`
+	Page       int16 `json:"page"`
+	Limit      int16 `json:"page_size"`
+}
+
+type ListNotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+	UnreadCount   int64          `json:"unread_count"`
+	MetaData      Pagination     `json:"metadata"`
+}
+
+// NotificationPreference lists the channels a user gets an event on, no channels turns the event off
+type NotificationPreference struct {
+	Event    string   `json:"event"`
+	Channels []string `json:"channels"`
+}
+
+type UpdateNotificationPreferencesReq struct {
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+func (req UpdateNotificationPreferencesReq) Validate() error {
+	for _, preference := range req.Preferences {
+		if !slices.Contains(constants.NotificationEvents, preference.Event) {
+			return apperrors.InvalidNotificationEvent
+		}
+		for _, channel := range preference.Channels {
+			if !slices.Contains(constants.NotificationChannels, channel) {
+				return apperrors.InvalidNotificationChannel
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+)
+
+// OrgFilter narrows a list or report down to the users of a department or a team, a zero value doesn't filter
+type OrgFilter struct {
+	DepartmentID int64 `json:"department_id,omitempty"`
+	TeamID       int64 `json:"team_id,omitempty"`
+}
+
+func (f OrgFilter) IsEmpty() bool {
+	return f.DepartmentID == 0 && f.TeamID == 0
+}
+
+type Department struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type Team struct {
+	ID           int64  `json:"id"`
+	DepartmentID int64  `json:"department_id"`
+	Name         string `json:"name"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+type DepartmentReq struct {
+	Name string `json:"name"`
+}
+
+func (req DepartmentReq) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return apperrors.DepartmentNameBlank
+	}
+	return nil
+}
+
+type TeamReq struct {
+	DepartmentID int64  `json:"department_id"`
+	Name         string `json:"name"`
+}
+
+func (req TeamReq) Validate() error {
+	if req.DepartmentID <= 0 {
+		return apperrors.DepartmentNotFound
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return apperrors.TeamNameBlank
+	}
+	return nil
+}
+
+// UserHierarchyReq places a user in the organization, a zero value clears the field. The department may be left out
+// when a team is given, it is the department of the team then.
+type UserHierarchyReq struct {
+	DepartmentID int64 `json:"department_id"`
+	TeamID       int64 `json:"team_id"`
+	ManagerID    int64 `json:"manager_id"`
+}
+
+// TeamMember is a user reporting to the manager looking at their team
+type TeamMember struct {
+	ID                 int64  `json:"id"`
+	EmployeeID         string `json:"employee_id"`
+	FirstName          string `json:"first_name"`
+	LastName           string `json:"last_name"`
+	Email              string `json:"email"`
+	ProfileImgUrl      string `json:"profile_image_url"`
+	Designation        string `json:"designation"`
+	DepartmentID       int64  `json:"department_id,omitempty"`
+	TeamID             int64  `json:"team_id,omitempty"`
+	AppreciationPoints int64  `json:"appreciation_points"`
+	AppreciationsSent  int64  `json:"appreciations_sent"`
+}
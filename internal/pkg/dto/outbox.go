@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+)
+
+// OutboxEmail is the payload of an email waiting in the outbox, the template is rendered when it is delivered.
+// When UserID and Event are set the email is dropped if the user turned off emails for the event.
+type OutboxEmail struct {
+	To       []string    `json:"to"`
+	CC       []string    `json:"cc,omitempty"`
+	BCC      []string    `json:"bcc,omitempty"`
+	Subject  string      `json:"subject"`
+	Template string      `json:"template"`
+	Data     interface{} `json:"data"`
+	UserID   int64       `json:"user_id,omitempty"`
+	Event    string      `json:"event,omitempty"`
+}
+
+// OutboxPush is the payload of a push notification waiting in the outbox.
+// It is sent either to every device of a user, to a topic or to the single device set in Token.
+// With an Event set, users who turned off pushes for it are skipped, so a topic push goes to the devices of everyone else.
+type OutboxPush struct {
+	UserID   int64  `json:"user_id,omitempty"`
+	Topic    string `json:"topic,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+	ImageURL string `json:"image,omitempty"`
+	Event    string `json:"event,omitempty"`
+}
+
+// OutboxWebhook is the payload of a chat post waiting in the outbox.
+// The post is rendered for the integration's chat tool when it is delivered, a deleted integration drops it.
+type OutboxWebhook struct {
+	IntegrationID int64    `json:"integration_id"`
+	Post          FeedPost `json:"post"`
+}
+
+// OutboxWebhookEvent is the payload of a webhook delivery waiting in the outbox, the event itself is kept in the delivery log
+type OutboxWebhookEvent struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+type OutboxMessage struct {
+	ID            int64           `json:"id"`
+	Kind          string          `json:"kind"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	MaxAttempts   int             `json:"max_attempts"`
+	NextAttemptAt int64           `json:"next_attempt_at"`
+	LastError     string          `json:"last_error"`
+	CreatedAt     int64           `json:"created_at"`
+	UpdatedAt     int64           `json:"updated_at"`
+	SentAt        int64           `json:"sent_at,omitempty"`
+}
+
+type OutboxFilter struct {
+	Status string `json:"status"`
+	Page   int16  `json:"page"`
+	Limit  int16  `json:"page_size"`
+}
+
+func (filter OutboxFilter) Validate() error {
+	if !slices.Contains([]string{constants.OutboxPending, constants.OutboxSent, constants.OutboxDead}, filter.Status) {
+		return apperrors.InvalidOutboxStatus
+	}
+	return nil
+}
+
+type ListOutboxMessagesResponse struct {
+	Messages []OutboxMessage `json:"messages"`
+	MetaData Pagination      `json:"metadata"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+)
+
+type Reaction struct {
+	AppreciationID int64  `json:"appreciation_id"`
+	Reaction       string `json:"reaction"`
+	ReactedBy      int64  `json:"reacted_by"`
+}
+
+type ReactionSummary struct {
+	AppreciationID int64            `json:"appreciation_id"`
+	ReactionCounts map[string]int64 `json:"reaction_counts"`
+	MyReactions    []string         `json:"my_reactions"`
+}
+
+func (react *Reaction) ValidateReaction() (err error) {
+
+	react.Reaction = strings.ToLower(strings.TrimSpace(react.Reaction))
+
+	if !slices.Contains(constants.AllowedReactions, react.Reaction) {
+		return apperrors.InvalidReaction
+	}
+
+	return
+}
@@ -17,6 +17,8 @@ type ReportAppricaitionResp struct {
 type GetSenderAndReceiverResp struct {
 	Sender   int64 `json:"sender" db:"sender"`
 	Receiver int64 `json:"receiver" db:"receiver"`
+	// tells whether the reporter is one of the receivers of a team appreciation
+	IsReceiver bool `json:"is_receiver" db:"is_receiver"`
 }
 
 type ReportedAppreciation struct {
@@ -1,9 +1,52 @@
 package dto
 
+import "github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+
 type Reward struct {
 	Id             int64 `json:"id"`
 	AppreciationId int64 `json:"appreciation_id"`
 	Point          int64 `json:"point"`
+	Value          int64 `json:"value"`
+	RewardLevelId  int64 `json:"reward_level_id"`
 	SenderId       int64 `json:"sender"`
 	CreatedAt      int64 `json:"created_at"`
 }
+
+type RewardLevel struct {
+	Id        int64 `json:"id"`
+	Version   int64 `json:"version"`
+	Point     int64 `json:"point"`
+	Value     int64 `json:"value"`
+	CreatedBy int64 `json:"created_by"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+type RewardLevelReq struct {
+	Point int64 `json:"point"`
+	Value int64 `json:"value"`
+}
+
+type UpdateRewardLevelsReq struct {
+	Levels []RewardLevelReq `json:"levels"`
+}
+
+func (req UpdateRewardLevelsReq) ValidateRewardLevels() (err error) {
+	if len(req.Levels) == 0 {
+		return apperrors.EmptyRewardLevels
+	}
+
+	points := make(map[int64]bool)
+	for _, level := range req.Levels {
+		if level.Point < 1 {
+			return apperrors.InvalidRewardPoint
+		}
+		if level.Value < 0 {
+			return apperrors.NegativeRewardLevelValue
+		}
+		if points[level.Point] {
+			return apperrors.DuplicateRewardLevelPoint
+		}
+		points[level.Point] = true
+	}
+	return
+}
@@ -0,0 +1,17 @@
+package dto
+
+// LogoutReq names the device that logs out so it stops receiving push notifications
+type LogoutReq struct {
+	NotificationToken string `json:"notification_token"`
+}
+
+// AuthTokens are handed out on login and on every refresh, the refresh token can be used only once
+type AuthTokens struct {
+	AuthToken          string `json:"auth_token"`
+	AuthTokenExpiresAt int64  `json:"auth_token_expires_at"`
+	RefreshToken       string `json:"refresh_token"`
+}
+
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
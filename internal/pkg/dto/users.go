@@ -21,6 +21,10 @@ type EmployeeDetail struct {
 	EmployeeId  string      `json:"employee_id"`
 	Designation Designation `json:"designation"`
 	Grade       string      `json:"grade"`
+	// the intranet may leave out where the user sits, admins set it in Peerly then
+	Department   string `json:"department,omitempty"`
+	Team         string `json:"team,omitempty"`
+	ManagerEmail string `json:"manager_email,omitempty"`
 }
 type IntranetUserData struct {
 	Id                int64          `json:"id"`
@@ -65,13 +69,19 @@ type GetIntranetUserDataReq struct {
 type Claims struct {
 	Id   int64
 	Role int
+	// id of the role in the roles table, its permissions are checked by middleware.RequirePermission
+	RoleId int64 `json:",omitempty"`
+	// family of the refresh token the access token was issued with
+	SessionId string `json:",omitempty"`
 	jwt.StandardClaims
 }
 
 type LoginUserResp struct {
-	User           User
-	NewUserCreated bool
-	AuthToken      string
+	User               User
+	NewUserCreated     bool
+	AuthToken          string
+	AuthTokenExpiresAt int64
+	RefreshToken       string
 }
 type GetUserListReq struct {
 	AuthToken string
@@ -106,6 +116,7 @@ type ListUsersReq struct {
 	Page     int64
 	PageSize int64
 	Name     []string
+	OrgFilter
 }
 
 type ActiveUser struct {
@@ -170,3 +181,85 @@ type AdminNotificationReq struct {
 	All     bool                 `json:"all"`
 	Id      int64                `json:"id"`
 }
+
+// AdminListUsersReq filters the users listed to admins, a zero value doesn't filter
+type AdminListUsersReq struct {
+	Status   string
+	GradeId  int64
+	RoleId   int64
+	Name     []string
+	Page     int64
+	PageSize int64
+	OrgFilter
+}
+
+type AdminUser struct {
+	Id                 int64  `json:"id"`
+	EmployeeId         string `json:"employee_id"`
+	FirstName          string `json:"first_name"`
+	LastName           string `json:"last_name"`
+	Email              string `json:"email"`
+	ProfileImgUrl      string `json:"profile_image_url"`
+	Designation        string `json:"designation"`
+	GradeId            int64  `json:"grade_id"`
+	RoleId             int64  `json:"role_id"`
+	RewardQuotaBalance int64  `json:"reward_quota_balance"`
+	Status             string `json:"status"`
+	DeactivatedAt      int64  `json:"deactivated_at,omitempty"`
+	DeactivatedBy      int64  `json:"deactivated_by,omitempty"`
+	FrozenRewardQuota  int64  `json:"frozen_reward_quota,omitempty"`
+	DepartmentId       int64  `json:"department_id,omitempty"`
+	TeamId             int64  `json:"team_id,omitempty"`
+	ManagerId          int64  `json:"manager_id,omitempty"`
+	CreatedAt          int64  `json:"created_at"`
+}
+
+type AdminListUsersResp struct {
+	Users    []AdminUser `json:"users"`
+	MetaData PageToken   `json:"metadata"`
+}
+
+// SetAdminPasswordReq resets the password to a generated one when Password is empty
+type SetAdminPasswordReq struct {
+	Password string `json:"password"`
+}
+
+// SetAdminPasswordResp carries the generated password, it is not shown again afterwards
+type SetAdminPasswordResp struct {
+	Password string `json:"password,omitempty"`
+}
+
+// UserSyncReport summarises one reconciliation of the users with the intranet
+type UserSyncReport struct {
+	Id         int64           `json:"id"`
+	StartedAt  int64           `json:"started_at"`
+	FinishedAt int64           `json:"finished_at"`
+	Status     string          `json:"status"`
+	Changes    UserSyncChanges `json:"changes"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// UserSyncChanges lists the emails of the users a sync run changed
+type UserSyncChanges struct {
+	Created     []string          `json:"created"`
+	Updated     []string          `json:"updated"`
+	Deactivated []string          `json:"deactivated"`
+	Failed      []UserSyncFailure `json:"failed"`
+}
+
+type UserSyncFailure struct {
+	Email string `json:"email"`
+	Error string `json:"error"`
+}
+
+type UserImportOptions struct {
+	// DryRun does the whole import and rolls it back
+	DryRun    bool
+	BatchSize int
+}
+
+type UserImportSummary struct {
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+	Skipped int64 `json:"skipped"`
+}
@@ -0,0 +1,131 @@
+package dto
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+)
+
+// WebhookSubscription is a third-party endpoint the events are posted to.
+// The secret is only returned when the subscription is created.
+type WebhookSubscription struct {
+	ID        int64    `json:"id"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret,omitempty"`
+	Events    []string `json:"events"`
+	CreatedBy int64    `json:"created_by"`
+	CreatedAt int64    `json:"created_at"`
+}
+
+// CreateWebhookSubscriptionReq subscribes the url to the events, a secret is generated when none is given
+type CreateWebhookSubscriptionReq struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+func (req CreateWebhookSubscriptionReq) Validate() error {
+	webhookURL, err := url.ParseRequestURI(req.URL)
+	if err != nil || (webhookURL.Scheme != "https" && webhookURL.Scheme != "http") || webhookURL.Host == "" {
+		return apperrors.InvalidWebhookURL
+	}
+	if len(req.Events) == 0 {
+		return apperrors.WebhookEventsEmpty
+	}
+	for _, event := range req.Events {
+		if !slices.Contains(constants.WebhookEvents, event) {
+			return apperrors.InvalidWebhookEvent
+		}
+	}
+	return nil
+}
+
+// WebhookEvent is the signed JSON body posted to a subscription.
+// ID stays the same when the event is redelivered so consumers can drop duplicates.
+type WebhookEvent struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt int64       `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookDelivery is the log of an event sent to a subscription
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      int64           `json:"created_at"`
+	UpdatedAt      int64           `json:"updated_at"`
+	DeliveredAt    int64           `json:"delivered_at,omitempty"`
+}
+
+type WebhookDeliveryFilter struct {
+	SubscriptionID int64 `json:"subscription_id"`
+	Page           int16 `json:"page"`
+	Limit          int16 `json:"page_size"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	MetaData   Pagination        `json:"metadata"`
+}
+
+// Data of the webhook events
+
+type AppreciationEventData struct {
+	ID            int64   `json:"id"`
+	CoreValueID   int64   `json:"core_value_id"`
+	CoreValueName string  `json:"core_value_name"`
+	Description   string  `json:"description"`
+	SenderID      int64   `json:"sender_id"`
+	ReceiverIDs   []int64 `json:"receiver_ids"`
+	CreatedAt     int64   `json:"created_at"`
+}
+
+type AppreciationDeletedEventData struct {
+	ID int64 `json:"id"`
+}
+
+type RewardEventData struct {
+	ID             int64 `json:"id"`
+	AppreciationID int64 `json:"appreciation_id"`
+	SenderID       int64 `json:"sender_id"`
+	Point          int64 `json:"point"`
+}
+
+type BadgeEventData struct {
+	UserID      int64  `json:"user_id"`
+	BadgeID     int64  `json:"badge_id"`
+	BadgeName   string `json:"badge_name"`
+	BadgePoints int64  `json:"badge_points"`
+}
+
+type ReportEventData struct {
+	ID               int64  `json:"id"`
+	AppreciationID   int64  `json:"appreciation_id"`
+	ReportingComment string `json:"reporting_comment"`
+	ReportedBy       int64  `json:"reported_by"`
+	ReportedAt       int64  `json:"reported_at"`
+}
+
+// ReportResolvedEventData is sent when a moderator keeps or deletes a reported appreciation
+type ReportResolvedEventData struct {
+	ResolutionID     int64  `json:"resolution_id"`
+	AppreciationID   int64  `json:"appreciation_id"`
+	Outcome          string `json:"outcome"`
+	ModeratorComment string `json:"moderator_comment"`
+	ModeratedBy      int64  `json:"moderated_by"`
+}
+
+type QuotaRenewedEventData struct {
+	RenewedAt int64 `json:"renewed_at"`
+}
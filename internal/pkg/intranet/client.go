@@ -0,0 +1,211 @@
+package intranet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+const (
+	defaultTimeout          = time.Duration(constants.DefaultIntranetTimeoutSeconds) * time.Second
+	defaultRetryBackoff     = 200 * time.Millisecond
+	defaultBreakerThreshold = constants.DefaultIntranetBreakerThreshold
+	defaultBreakerCooldown  = time.Duration(constants.DefaultIntranetBreakerCooldownSeconds) * time.Second
+)
+
+// Options tune how the client copes with a slow or failing intranet, zero values fall back to the defaults except
+// for MaxRetries
+type Options struct {
+	// Timeout bounds every attempt of a call
+	Timeout time.Duration
+	// MaxRetries is how often a call that failed with a network error or a 5xx is tried again
+	MaxRetries int
+	// RetryBackoff is the upper bound of the first random wait between attempts, it doubles with every retry
+	RetryBackoff time.Duration
+	// BreakerThreshold is how many failed attempts in a row open the circuit
+	BreakerThreshold int
+	// BreakerCooldown is how long an open circuit rejects calls before it lets one through
+	BreakerCooldown time.Duration
+}
+
+// OptionsFromConfig reads the options from the intranet config
+func OptionsFromConfig() Options {
+	return Options{
+		Timeout:          config.IntranetTimeout(),
+		MaxRetries:       config.IntranetMaxRetries(),
+		RetryBackoff:     defaultRetryBackoff,
+		BreakerThreshold: config.IntranetBreakerThreshold(),
+		BreakerCooldown:  config.IntranetBreakerCooldown(),
+	}
+}
+
+// Client calls the apis of the Josh intranet. It is shared by everything calling the intranet so the circuit opens
+// for all of them at once.
+type Client struct {
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	breaker      *breaker
+}
+
+func NewClient(opts Options) *Client {
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = defaultRetryBackoff
+	}
+	if opts.BreakerThreshold <= 0 {
+		opts.BreakerThreshold = defaultBreakerThreshold
+	}
+	if opts.BreakerCooldown <= 0 {
+		opts.BreakerCooldown = defaultBreakerCooldown
+	}
+
+	return &Client{
+		httpClient:   &http.Client{Timeout: opts.Timeout},
+		maxRetries:   opts.MaxRetries,
+		retryBackoff: opts.RetryBackoff,
+		breaker:      newBreaker(opts.BreakerThreshold, opts.BreakerCooldown),
+	}
+}
+
+// ValidatePeerly exchanges an intranet token for one the user apis of the intranet accept
+func (c *Client) ValidatePeerly(ctx context.Context, authToken string) (data dto.ValidateResp, err error) {
+
+	header := http.Header{}
+	header.Set(constants.AuthorizationHeader, authToken)
+	header.Set(constants.ClientCode, config.IntranetClientCode())
+
+	err = c.call(ctx, "validate", http.MethodPost, config.IntranetBaseUrl()+constants.PeerlyValidationPath, header, &data)
+	return
+}
+
+// GetUser fetches the profile of an intranet user
+func (c *Client) GetUser(ctx context.Context, req dto.GetIntranetUserDataReq) (dto.IntranetUserData, error) {
+
+	header := http.Header{}
+	header.Set(constants.AuthorizationHeader, req.Token)
+
+	var respData dto.IntranetGetUserDataResp
+	url := fmt.Sprintf("%s%s%d", config.IntranetBaseUrl(), constants.GetIntranetUserDataPath, req.UserId)
+	err := c.call(ctx, "get user", http.MethodGet, url, header, &respData)
+	if err != nil {
+		return dto.IntranetUserData{}, err
+	}
+	return respData.Data, nil
+}
+
+// ListUsers reads a page of the intranet users
+func (c *Client) ListUsers(ctx context.Context, req dto.GetUserListReq) ([]dto.IntranetUserData, error) {
+
+	header := http.Header{}
+	header.Set(constants.AuthorizationHeader, req.AuthToken)
+
+	var respData dto.ListIntranetUsersRespData
+	url := config.IntranetBaseUrl() + fmt.Sprintf(constants.ListIntranetUsersPath, req.Page, constants.DefaultPageSize)
+	err := c.call(ctx, "list users", http.MethodGet, url, header, &respData)
+	if err != nil {
+		return nil, err
+	}
+	return respData.Data, nil
+}
+
+// call tries the request until it gets an answer that isn't worth retrying and decodes a successful one into v
+func (c *Client) call(ctx context.Context, op string, method string, url string, header http.Header, v interface{}) error {
+
+	var err *Error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			waitErr := c.wait(ctx, attempt)
+			if waitErr != nil {
+				return &Error{Op: op, Err: apperrors.IntranetUnavailable, cause: waitErr}
+			}
+			logger.Warn(ctx, "intranet: retrying ", op, ", attempt ", attempt+1, ", err: ", err)
+		}
+
+		if !c.breaker.allow() {
+			return &Error{Op: op, Err: apperrors.IntranetUnavailable, cause: fmt.Errorf("circuit open")}
+		}
+
+		var body []byte
+		body, err = c.do(ctx, op, method, url, header)
+		if err == nil {
+			c.breaker.success()
+			return decode(op, body, v)
+		}
+
+		if err.Err != apperrors.IntranetUnavailable {
+			// the intranet answered, it just didn't like the request
+			c.breaker.success()
+			return err
+		}
+		if ctx.Err() != nil {
+			// the caller gave up, that says nothing about the intranet
+			c.breaker.release()
+			return err
+		}
+		c.breaker.failure()
+	}
+	return err
+}
+
+func (c *Client) do(ctx context.Context, op string, method string, url string, header http.Header) ([]byte, *Error) {
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, &Error{Op: op, Err: apperrors.InternalServerError, cause: err}
+	}
+	req.Header = header.Clone()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &Error{Op: op, Err: apperrors.IntranetUnavailable, cause: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, statusError(op, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &Error{Op: op, Err: apperrors.IntranetUnavailable, cause: err}
+	}
+	return body, nil
+}
+
+// wait sleeps a random time up to the backoff of the attempt, spreading out the retries of concurrent callers
+func (c *Client) wait(ctx context.Context, attempt int) error {
+
+	backoff := c.retryBackoff << (attempt - 1)
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(backoff)) + 1))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func decode(op string, body []byte, v interface{}) error {
+	err := json.Unmarshal(body, v)
+	if err != nil {
+		return &Error{Op: op, StatusCode: http.StatusOK, Err: apperrors.JSONParsingErrorResp, cause: err}
+	}
+	return nil
+}
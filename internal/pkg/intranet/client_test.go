@@ -0,0 +1,259 @@
+package intranet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	log "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	l "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.Logger = l.New()
+}
+
+// fakeIntranet stands in for the intranet api, respond answers every call and calls counts them
+type fakeIntranet struct {
+	server  *httptest.Server
+	calls   int32
+	respond func(rw http.ResponseWriter, req *http.Request, call int32)
+}
+
+func newFakeIntranet(t *testing.T, respond func(rw http.ResponseWriter, req *http.Request, call int32)) *fakeIntranet {
+	fake := &fakeIntranet{respond: respond}
+	fake.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fake.respond(rw, req, atomic.AddInt32(&fake.calls, 1))
+	}))
+	t.Cleanup(fake.server.Close)
+
+	viper.Set(constants.IntranetBaseUrl, fake.server.URL)
+	viper.Set(constants.IntranetClientCode, "peerly")
+	return fake
+}
+
+func respondJSON(rw http.ResponseWriter, v interface{}) {
+	json.NewEncoder(rw).Encode(v)
+}
+
+func TestValidatePeerly(t *testing.T) {
+	newFakeIntranet(t, func(rw http.ResponseWriter, req *http.Request, call int32) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, constants.PeerlyValidationPath, req.URL.Path)
+		assert.Equal(t, "peerly", req.Header.Get(constants.ClientCode))
+		if req.Header.Get(constants.AuthorizationHeader) != "intranet-token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		respondJSON(rw, dto.ValidateResp{Data: dto.IntranetValidateApiData{JwtToken: "jwt", UserId: 6}})
+	})
+	client := NewClient(Options{MaxRetries: 2, RetryBackoff: time.Millisecond})
+
+	resp, err := client.ValidatePeerly(context.Background(), "intranet-token")
+	assert.NoError(t, err)
+	assert.Equal(t, dto.IntranetValidateApiData{JwtToken: "jwt", UserId: 6}, resp.Data)
+
+	_, err = client.ValidatePeerly(context.Background(), "unknown-token")
+	assert.Equal(t, apperrors.IntranetValidationFailed, AppError(err))
+}
+
+func TestClientErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          Options
+		respond       func(rw http.ResponseWriter, req *http.Request, call int32)
+		expectedError error
+		expectedCalls int32
+	}{
+		{
+			name: "Profile is fetched",
+			opts: Options{MaxRetries: 2, RetryBackoff: time.Millisecond},
+			respond: func(rw http.ResponseWriter, req *http.Request, call int32) {
+				assert.Equal(t, constants.GetIntranetUserDataPath+"6", req.URL.Path)
+				respondJSON(rw, dto.IntranetGetUserDataResp{Data: dto.IntranetUserData{Id: 6, Email: "jane@example.com"}})
+			},
+			expectedError: nil,
+			expectedCalls: 1,
+		},
+		{
+			name: "Server errors are retried",
+			opts: Options{MaxRetries: 2, RetryBackoff: time.Millisecond},
+			respond: func(rw http.ResponseWriter, req *http.Request, call int32) {
+				if call < 3 {
+					rw.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				respondJSON(rw, dto.IntranetGetUserDataResp{Data: dto.IntranetUserData{Id: 6, Email: "jane@example.com"}})
+			},
+			expectedError: nil,
+			expectedCalls: 3,
+		},
+		{
+			name: "Intranet keeps failing",
+			opts: Options{MaxRetries: 2, RetryBackoff: time.Millisecond},
+			respond: func(rw http.ResponseWriter, req *http.Request, call int32) {
+				rw.WriteHeader(http.StatusBadGateway)
+			},
+			expectedError: apperrors.IntranetUnavailable,
+			expectedCalls: 3,
+		},
+		{
+			name: "Unknown user isn't retried",
+			opts: Options{MaxRetries: 2, RetryBackoff: time.Millisecond},
+			respond: func(rw http.ResponseWriter, req *http.Request, call int32) {
+				rw.WriteHeader(http.StatusNotFound)
+			},
+			expectedError: apperrors.UserNotFound,
+			expectedCalls: 1,
+		},
+		{
+			name: "Rejected token isn't retried",
+			opts: Options{MaxRetries: 2, RetryBackoff: time.Millisecond},
+			respond: func(rw http.ResponseWriter, req *http.Request, call int32) {
+				rw.WriteHeader(http.StatusForbidden)
+			},
+			expectedError: apperrors.IntranetValidationFailed,
+			expectedCalls: 1,
+		},
+		{
+			name: "Invalid response",
+			opts: Options{MaxRetries: 2, RetryBackoff: time.Millisecond},
+			respond: func(rw http.ResponseWriter, req *http.Request, call int32) {
+				rw.Write([]byte(`{"data":`))
+			},
+			expectedError: apperrors.JSONParsingErrorResp,
+			expectedCalls: 1,
+		},
+		{
+			name: "Slow intranet times out",
+			opts: Options{Timeout: 20 * time.Millisecond, MaxRetries: 1, RetryBackoff: time.Millisecond},
+			respond: func(rw http.ResponseWriter, req *http.Request, call int32) {
+				time.Sleep(100 * time.Millisecond)
+				respondJSON(rw, dto.IntranetGetUserDataResp{})
+			},
+			expectedError: apperrors.IntranetUnavailable,
+			expectedCalls: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := newFakeIntranet(t, test.respond)
+			client := NewClient(test.opts)
+
+			user, err := client.GetUser(context.Background(), dto.GetIntranetUserDataReq{Token: "jwt", UserId: 6})
+
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&fake.calls))
+			if test.expectedError != nil {
+				assert.True(t, errors.Is(err, test.expectedError), "got %v", err)
+				assert.Equal(t, test.expectedError, AppError(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "jane@example.com", user.Email)
+		})
+	}
+}
+
+func TestClientUnreachableIntranet(t *testing.T) {
+	fake := newFakeIntranet(t, func(rw http.ResponseWriter, req *http.Request, call int32) {})
+	fake.server.Close()
+	client := NewClient(Options{MaxRetries: 1, RetryBackoff: time.Millisecond})
+
+	users, err := client.ListUsers(context.Background(), dto.GetUserListReq{AuthToken: "jwt", Page: 1})
+
+	assert.Nil(t, users)
+	assert.Equal(t, apperrors.IntranetUnavailable, AppError(err))
+	var intranetErr *Error
+	assert.True(t, errors.As(err, &intranetErr))
+	assert.Equal(t, "list users", intranetErr.Op)
+	assert.Equal(t, 0, intranetErr.StatusCode)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	healthy := int32(0)
+	fake := newFakeIntranet(t, func(rw http.ResponseWriter, req *http.Request, call int32) {
+		if atomic.LoadInt32(&healthy) == 0 {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		respondJSON(rw, dto.ListIntranetUsersRespData{Data: []dto.IntranetUserData{{Email: "jane@example.com"}}})
+	})
+	client := NewClient(Options{MaxRetries: 0, BreakerThreshold: 2, BreakerCooldown: 50 * time.Millisecond})
+	req := dto.GetUserListReq{AuthToken: "jwt", Page: 1}
+
+	for i := 0; i < 2; i++ {
+		_, err := client.ListUsers(context.Background(), req)
+		assert.Equal(t, apperrors.IntranetUnavailable, AppError(err))
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fake.calls))
+
+	// the circuit is open, the intranet isn't called
+	_, err := client.ListUsers(context.Background(), req)
+	assert.Equal(t, apperrors.IntranetUnavailable, AppError(err))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fake.calls))
+
+	// after the cooldown a failing probe opens it again
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.ListUsers(context.Background(), req)
+	assert.Equal(t, apperrors.IntranetUnavailable, AppError(err))
+	_, err = client.ListUsers(context.Background(), req)
+	assert.Equal(t, apperrors.IntranetUnavailable, AppError(err))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fake.calls))
+
+	// a successful probe closes it
+	atomic.StoreInt32(&healthy, 1)
+	time.Sleep(60 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		users, err := client.ListUsers(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+	}
+	assert.Equal(t, int32(5), atomic.LoadInt32(&fake.calls))
+}
+
+func TestCircuitBreakerCancelledProbe(t *testing.T) {
+	healthy := int32(0)
+	fake := newFakeIntranet(t, func(rw http.ResponseWriter, req *http.Request, call int32) {
+		switch atomic.LoadInt32(&healthy) {
+		case 0:
+			rw.WriteHeader(http.StatusInternalServerError)
+		case 1:
+			// the probe hangs until its caller gives up
+			<-req.Context().Done()
+		default:
+			respondJSON(rw, dto.ListIntranetUsersRespData{Data: []dto.IntranetUserData{{Email: "jane@example.com"}}})
+		}
+	})
+	client := NewClient(Options{MaxRetries: 0, BreakerThreshold: 1, BreakerCooldown: 20 * time.Millisecond})
+	req := dto.GetUserListReq{AuthToken: "jwt", Page: 1}
+
+	_, err := client.ListUsers(context.Background(), req)
+	assert.Equal(t, apperrors.IntranetUnavailable, AppError(err))
+
+	// the caller of the probe disconnects
+	atomic.StoreInt32(&healthy, 1)
+	time.Sleep(30 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.ListUsers(ctx, req)
+	assert.Equal(t, apperrors.IntranetUnavailable, AppError(err))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fake.calls))
+
+	// the next call probes again and closes the circuit
+	atomic.StoreInt32(&healthy, 2)
+	users, err := client.ListUsers(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fake.calls))
+}
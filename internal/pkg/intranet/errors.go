@@ -0,0 +1,65 @@
+package intranet
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+)
+
+// Error is a call to the intranet that failed
+type Error struct {
+	// Op is the intranet api that was called
+	Op string
+	// StatusCode is what the intranet answered with, 0 when no answer was received
+	StatusCode int
+	// Err is the apperror the failure is reported to the clients of Peerly as
+	Err   error
+	cause error
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("intranet %s: %s", e.Op, e.Err.Error())
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(", status: %d", e.StatusCode)
+	}
+	if e.cause != nil {
+		msg += fmt.Sprintf(", err: %v", e.cause)
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// AppError returns the apperror an error of the client is reported as
+func AppError(err error) error {
+	var intranetErr *Error
+	if errors.As(err, &intranetErr) {
+		return intranetErr.Err
+	}
+	return err
+}
+
+// statusError maps an unsuccessful answer of the intranet
+func statusError(op string, statusCode int) *Error {
+	err := &Error{Op: op, StatusCode: statusCode}
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		err.Err = apperrors.IntranetValidationFailed
+	case statusCode == http.StatusNotFound:
+		err.Err = apperrors.UserNotFound
+	case retryable(statusCode):
+		err.Err = apperrors.IntranetUnavailable
+	default:
+		err.Err = apperrors.InternalServerError
+	}
+	return err
+}
+
+// the intranet is down or overloaded, trying again later may succeed
+func retryable(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests
+}
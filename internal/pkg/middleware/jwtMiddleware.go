@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strings"
@@ -15,6 +17,30 @@ import (
 	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
 )
 
+// TokenRevocationChecker tells whether a token was revoked before it expired
+type TokenRevocationChecker interface {
+	IsRevoked(ctx context.Context, claims dto.Claims) (bool, error)
+}
+
+var revocationChecker TokenRevocationChecker
+
+// UseTokenRevocationChecker makes JwtAuthMiddleware reject the tokens the checker reports as revoked
+func UseTokenRevocationChecker(checker TokenRevocationChecker) {
+	revocationChecker = checker
+}
+
+// UserStatusChecker tells whether a user was deactivated by an admin
+type UserStatusChecker interface {
+	IsDeactivated(ctx context.Context, userID int64) (bool, error)
+}
+
+var statusChecker UserStatusChecker
+
+// UseUserStatusChecker makes JwtAuthMiddleware reject the tokens of deactivated users
+func UseUserStatusChecker(checker UserStatusChecker) {
+	statusChecker = checker
+}
+
 func JwtAuthMiddleware(next http.Handler, role int) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		jwtKey := config.JWTKey()
@@ -47,6 +73,38 @@ func JwtAuthMiddleware(next http.Handler, role int) http.Handler {
 			return
 		}
 
+		// tokens issued before they carried a jti are identified by their hash so they can still be revoked
+		if claims.StandardClaims.Id == "" {
+			sum := sha256.Sum256([]byte(authToken))
+			claims.StandardClaims.Id = hex.EncodeToString(sum[:])
+		}
+
+		if revocationChecker != nil {
+			revoked, err := revocationChecker.IsRevoked(req.Context(), *claims)
+			if err != nil {
+				dto.ErrorRepsonse(rw, apperrors.InternalServer)
+				return
+			}
+			if revoked {
+				logger.Errorf(req.Context(), "Revoked token of user %d", claims.Id)
+				dto.ErrorRepsonse(rw, apperrors.RevokedAuthToken)
+				return
+			}
+		}
+
+		if statusChecker != nil {
+			deactivated, err := statusChecker.IsDeactivated(req.Context(), claims.Id)
+			if err != nil {
+				dto.ErrorRepsonse(rw, apperrors.InternalServer)
+				return
+			}
+			if deactivated {
+				logger.Errorf(req.Context(), "Token of deactivated user %d", claims.Id)
+				dto.ErrorRepsonse(rw, apperrors.UserDeactivated)
+				return
+			}
+		}
+
 		Id := claims.Id
 		Role := claims.Role
 
@@ -60,6 +118,7 @@ func JwtAuthMiddleware(next http.Handler, role int) http.Handler {
 		fmt.Println("setting id: ", Id)
 		ctx := context.WithValue(req.Context(), constants.UserId, Id)
 		ctx = context.WithValue(ctx, constants.Role, Role)
+		ctx = context.WithValue(ctx, constants.Claims, *claims)
 
 		req = req.WithContext(ctx)
 
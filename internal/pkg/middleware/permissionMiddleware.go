@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+)
+
+// PermissionChecker tells whether a role was granted a permission
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, roleID int64, permission string) (bool, error)
+}
+
+var permissionChecker PermissionChecker
+
+// UsePermissionChecker makes RequirePermission look up the permissions of a role with the checker
+func UsePermissionChecker(checker PermissionChecker) {
+	permissionChecker = checker
+}
+
+// RequirePermission lets the request through when the role of its token holds the permission, it has to be wrapped
+// by JwtAuthMiddleware which puts the claims in the context
+func RequirePermission(next http.Handler, permission string) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		claims, ok := ctx.Value(constants.Claims).(dto.Claims)
+		if !ok || permissionChecker == nil {
+			logger.Error(ctx, "RequirePermission: claims or permission checker missing")
+			dto.ErrorRepsonse(rw, apperrors.InternalServer)
+			return
+		}
+
+		roleID := claims.RoleId
+		// tokens issued before they carried the role id
+		if roleID == 0 {
+			roleID = constants.UserRoleID
+			if claims.Role == constants.Admin {
+				roleID = constants.AdminRoleID
+			}
+		}
+
+		allowed, err := permissionChecker.HasPermission(ctx, roleID, permission)
+		if err != nil {
+			dto.ErrorRepsonse(rw, apperrors.InternalServer)
+			return
+		}
+		if !allowed {
+			logger.Errorf(ctx, "user %d with role %d lacks permission %s", claims.Id, roleID, permission)
+			dto.ErrorRepsonse(rw, apperrors.RoleUnathorized)
+			return
+		}
+
+		next.ServeHTTP(rw, req)
+	})
+}
@@ -30,9 +30,8 @@ func Load() {
 	viper.SetDefault(constants.AppName, "app")
 	viper.SetDefault(constants.AppPort, "8002")
 
-	// Check for the presence of JWT_KEY and JWT_EXPIRY_DURATION_HOURS
+	// Check for the presence of JWT_KEY
 	JWTKey()
-	JWTExpiryDurationHours()
 }
 
 // AppName - returns the app name
@@ -56,11 +55,6 @@ func JWTKey() []byte {
 	return []byte(ReadEnvString(constants.JWTSecret))
 }
 
-// JWTExpiryDurationHours - returns duration for jwt expiry in int
-func JWTExpiryDurationHours() int {
-	return int(ReadEnvInt(constants.JWTExpiryDurationHours))
-}
-
 // ReadEnvInt - reads an environment variable as an integer
 func ReadEnvInt(key string) int {
 	checkIfSet(key)
@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 
 	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/lib/pq"
 )
 
 type AppreciationStorer interface {
@@ -13,51 +16,129 @@ type AppreciationStorer interface {
 	CreateAppreciation(ctx context.Context, tx Transaction, appreciation dto.Appreciation) (Appreciation, error)
 	GetAppreciationById(ctx context.Context, tx Transaction, appreciationId int32) (AppreciationResponse, error)
 	ListAppreciations(ctx context.Context, tx Transaction, filter dto.AppreciationFilter) ([]AppreciationResponse, Pagination, error)
+	ListAppreciationsByCursor(ctx context.Context, tx Transaction, filter dto.AppreciationFilter, after *dto.AppreciationCursor) ([]AppreciationResponse, bool, error)
 	DeleteAppreciation(ctx context.Context, tx Transaction, apprId int32) error
 	IsUserPresent(ctx context.Context, tx Transaction, userID int64) (bool, error)
+	IsUserActive(ctx context.Context, tx Transaction, userID int64) (bool, error)
 	UpdateAppreciationTotalRewardsOfYesterday(ctx context.Context, tx Transaction, orgTimezone string) (bool, error)
 	UpdateUserBadgesBasedOnTotalRewards(ctx context.Context, tx Transaction) ([]UserBadgeDetails, error)
+	EditAppreciation(ctx context.Context, tx Transaction, edit dto.EditAppreciation) (Appreciation, error)
+	CreateAppreciationEdit(ctx context.Context, tx Transaction, edit AppreciationEdit) error
+	ListAppreciationEdits(ctx context.Context, tx Transaction, apprId int64) ([]AppreciationEditResponse, error)
 }
 
 type Appreciation struct {
-	ID                int64  `db:"id"`
-	CoreValueID       int64  `db:"core_value_id"`
-	Description       string `db:"description"`
-	IsValid           bool   `db:"is_valid"`
-	TotalRewardPoints int32  `db:"total_reward_points"`
-	Quarter           int8   `db:"quarter"`
-	Sender            int64  `db:"sender"`
-	Receiver          int64  `db:"receiver"`
-	CreatedAt         int64  `db:"created_at"`
-	UpdatedAt         int64  `db:"updated_at"`
+	ID                int64          `db:"id"`
+	CoreValueID       int64          `db:"core_value_id"`
+	Description       string         `db:"description"`
+	IsValid           bool           `db:"is_valid"`
+	TotalRewardPoints int32          `db:"total_reward_points"`
+	Quarter           int8           `db:"quarter"`
+	Sender            int64          `db:"sender"`
+	Receiver          int64          `db:"receiver"`
+	GroupName         sql.NullString `db:"group_name"`
+	CreatedAt         int64          `db:"created_at"`
+	UpdatedAt         int64          `db:"updated_at"`
 }
 
 type AppreciationResponse struct {
-	ID                  int64          `db:"id"`
-	CoreValueName       string         `db:"core_value_name"`
-	CoreValueDesc       string         `db:"core_value_description"`
-	Description         string         `db:"description"`
-	IsValid             bool           `db:"is_valid"`
-	TotalRewardPoints   int32          `db:"total_reward_points"`
-	Quarter             int8           `db:"quarter"`
-	SenderID            int64          `db:"sender_id"`
-	SenderFirstName     string         `db:"sender_first_name"`
-	SenderLastName      string         `db:"sender_last_name"`
-	SenderImageURL      sql.NullString `db:"sender_image_url"`
-	SenderDesignation   string         `db:"sender_designation"`
-	SenderEmployeeID    string         `db:"sender_employee_id"`
-	SenderGradeID       int64          `db:"sender_grade_id"` // New field for grade ID
-	ReceiverID          int64          `db:"receiver_id"`
-	ReceiverFirstName   string         `db:"receiver_first_name"`
-	ReceiverLastName    string         `db:"receiver_last_name"`
-	ReceiverImageURL    sql.NullString `db:"receiver_image_url"`
-	ReceiverDesignation string         `db:"receiver_designation"`
-	ReceiverEmployeeID  string         `db:"receiver_employee_id"`
-	TotalRewards        int32          `db:"total_rewards"`
-	ReportedFlag        bool           `db:"reported_flag"`
-	GivenRewardPoint    int8           `db:"given_reward_point"`
-	CreatedAt           int64          `db:"created_at"`
-	UpdatedAt           int64          `db:"updated_at"`
+	ID                  int64                 `db:"id"`
+	CoreValueID         int64                 `db:"core_value_id"`
+	CoreValueName       string                `db:"core_value_name"`
+	CoreValueDesc       string                `db:"core_value_description"`
+	Description         string                `db:"description"`
+	IsValid             bool                  `db:"is_valid"`
+	TotalRewardPoints   int32                 `db:"total_reward_points"`
+	Quarter             int8                  `db:"quarter"`
+	SenderID            int64                 `db:"sender_id"`
+	SenderFirstName     string                `db:"sender_first_name"`
+	SenderLastName      string                `db:"sender_last_name"`
+	SenderImageURL      sql.NullString        `db:"sender_image_url"`
+	SenderDesignation   string                `db:"sender_designation"`
+	SenderEmployeeID    string                `db:"sender_employee_id"`
+	SenderGradeID       int64                 `db:"sender_grade_id"` // New field for grade ID
+	ReceiverID          int64                 `db:"receiver_id"`
+	ReceiverFirstName   string                `db:"receiver_first_name"`
+	ReceiverLastName    string                `db:"receiver_last_name"`
+	ReceiverImageURL    sql.NullString        `db:"receiver_image_url"`
+	ReceiverDesignation string                `db:"receiver_designation"`
+	ReceiverEmployeeID  string                `db:"receiver_employee_id"`
+	Receivers           AppreciationReceivers `db:"receivers"`
+	GroupName           string                `db:"group_name"`
+	TotalRewards        int32                 `db:"total_rewards"`
+	ReportedFlag        bool                  `db:"reported_flag"`
+	GivenRewardPoint    int8                  `db:"given_reward_point"`
+	ReactionCounts      ReactionCounts        `db:"reaction_counts"`
+	MyReactions         pq.StringArray        `db:"my_reactions"`
+	CreatedAt           int64                 `db:"created_at"`
+	UpdatedAt           int64                 `db:"updated_at"`
+}
+
+// ReceiverIDs returns the ids of every receiver, falling back to the primary receiver
+func (appr AppreciationResponse) ReceiverIDs() []int64 {
+	if len(appr.Receivers) == 0 {
+		return []int64{appr.ReceiverID}
+	}
+	ids := make([]int64, 0, len(appr.Receivers))
+	for _, receiver := range appr.Receivers {
+		ids = append(ids, receiver.ID)
+	}
+	return ids
+}
+
+type AppreciationReceiver struct {
+	ID           int64  `json:"id"`
+	EmployeeID   string `json:"employee_id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	ImageURL     string `json:"image_url"`
+	Designation  string `json:"designation"`
+	RewardPoints int32  `json:"reward_points"`
+}
+
+// AppreciationReceivers holds every receiver of an appreciation, scanned from a json array
+type AppreciationReceivers []AppreciationReceiver
+
+func (ar *AppreciationReceivers) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*ar = AppreciationReceivers{}
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %T for appreciation receivers", src)
+	}
+	return json.Unmarshal(data, (*[]AppreciationReceiver)(ar))
+}
+
+type AppreciationEdit struct {
+	ID             int64  `db:"id"`
+	AppreciationID int64  `db:"appreciation_id"`
+	OldCoreValueID int64  `db:"old_core_value_id"`
+	NewCoreValueID int64  `db:"new_core_value_id"`
+	OldDescription string `db:"old_description"`
+	NewDescription string `db:"new_description"`
+	EditedBy       int64  `db:"edited_by"`
+	EditedAt       int64  `db:"edited_at"`
+}
+
+type AppreciationEditResponse struct {
+	ID                int64  `db:"id"`
+	AppreciationID    int64  `db:"appreciation_id"`
+	OldCoreValueID    int64  `db:"old_core_value_id"`
+	OldCoreValueName  string `db:"old_core_value_name"`
+	NewCoreValueID    int64  `db:"new_core_value_id"`
+	NewCoreValueName  string `db:"new_core_value_name"`
+	OldDescription    string `db:"old_description"`
+	NewDescription    string `db:"new_description"`
+	EditedBy          int64  `db:"edited_by"`
+	EditedByFirstName string `db:"edited_by_first_name"`
+	EditedByLastName  string `db:"edited_by_last_name"`
+	EditedAt          int64  `db:"edited_at"`
 }
 
 // Pagination Object
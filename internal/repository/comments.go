@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+)
+
+type CommentStorer interface {
+	RepositoryTransaction
+
+	CreateComment(ctx context.Context, tx Transaction, comment dto.Comment) (Comment, error)
+	GetCommentById(ctx context.Context, tx Transaction, commentId int64) (CommentResponse, error)
+	ListComments(ctx context.Context, tx Transaction, filter dto.CommentFilter) ([]CommentResponse, Pagination, error)
+	UpdateComment(ctx context.Context, tx Transaction, commentId int64, comment string) (Comment, error)
+	DeleteComment(ctx context.Context, tx Transaction, commentId int64) error
+	CheckDuplicateCommentReport(ctx context.Context, tx Transaction, commentId int64, reportedBy int64) (bool, error)
+	ReportComment(ctx context.Context, tx Transaction, reportReq dto.ReportCommentReq) (CommentResolution, error)
+	ListReportedComments(ctx context.Context, tx Transaction) ([]ReportedComment, error)
+	GetCommentResolution(ctx context.Context, tx Transaction, resolutionId int64) (ReportedComment, error)
+	UpdateCommentResolution(ctx context.Context, tx Transaction, moderationReq dto.CommentModerationReq, status string) error
+}
+
+type Comment struct {
+	ID             int64  `db:"id"`
+	AppreciationID int64  `db:"appreciation_id"`
+	Comment        string `db:"comment"`
+	CommentedBy    int64  `db:"commented_by"`
+	IsValid        bool   `db:"is_valid"`
+	CreatedAt      int64  `db:"created_at"`
+	UpdatedAt      int64  `db:"updated_at"`
+}
+
+type CommentResponse struct {
+	ID                   int64          `db:"id"`
+	AppreciationID       int64          `db:"appreciation_id"`
+	Comment              string         `db:"comment"`
+	CommentedBy          int64          `db:"commented_by"`
+	CommenterEmployeeID  string         `db:"commenter_employee_id"`
+	CommenterFirstName   string         `db:"commenter_first_name"`
+	CommenterLastName    string         `db:"commenter_last_name"`
+	CommenterImageURL    sql.NullString `db:"commenter_image_url"`
+	CommenterDesignation string         `db:"commenter_designation"`
+	CreatedAt            int64          `db:"created_at"`
+	UpdatedAt            int64          `db:"updated_at"`
+}
+
+type CommentResolution struct {
+	ID               int64  `db:"id"`
+	CommentID        int64  `db:"comment_id"`
+	ReportingComment string `db:"reporting_comment"`
+	ReportedBy       int64  `db:"reported_by"`
+	ReportedAt       int64  `db:"reported_at"`
+}
+
+type ReportedComment struct {
+	ID                   int64          `db:"id"`
+	CommentID            int64          `db:"comment_id"`
+	AppreciationID       int64          `db:"appreciation_id"`
+	Comment              string         `db:"comment"`
+	IsValid              bool           `db:"is_valid"`
+	CommentedBy          int64          `db:"commented_by"`
+	CommenterFirstName   string         `db:"commenter_first_name"`
+	CommenterLastName    string         `db:"commenter_last_name"`
+	ReportingComment     string         `db:"reporting_comment"`
+	ReportedBy           int64          `db:"reported_by"`
+	ReportedByFirstName  string         `db:"reported_by_first_name"`
+	ReportedByLastName   string         `db:"reported_by_last_name"`
+	ReportedAt           int64          `db:"reported_at"`
+	ModeratorComment     sql.NullString `db:"moderator_comment"`
+	ModeratedByFirstName sql.NullString `db:"moderated_by_first_name"`
+	ModeratedByLastName  sql.NullString `db:"moderated_by_last_name"`
+	ModeratedAt          sql.NullInt64  `db:"moderated_at"`
+	Status               string         `db:"status"`
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DigestStorer interface {
+	RepositoryTransaction
+
+	SubscribeToDigest(ctx context.Context, tx Transaction, userID int64) error
+	UnsubscribeFromDigest(ctx context.Context, tx Transaction, userID int64) error
+	IsSubscribedToDigest(ctx context.Context, tx Transaction, userID int64) (bool, error)
+	ListDigestSubscribers(ctx context.Context, tx Transaction) ([]DigestSubscriber, error)
+	// GetDigestActivity summarizes what happened to the user since the given time,
+	// quarter points are counted from quarterStart as the badges are
+	GetDigestActivity(ctx context.Context, tx Transaction, userID int64, since int64, quarterStart int64) (DigestActivity, error)
+}
+
+type DigestSubscriber struct {
+	ID        int64  `db:"id"`
+	FirstName string `db:"first_name"`
+	LastName  string `db:"last_name"`
+	Email     string `db:"email"`
+}
+
+type DigestActivity struct {
+	AppreciationsReceived int64                `db:"appreciations_received"`
+	AppreciationsGiven    int64                `db:"appreciations_given"`
+	RewardsReceived       int64                `db:"rewards_received"`
+	QuarterPoints         int64                `db:"quarter_points"`
+	NextBadgeName         sql.NullString       `db:"next_badge_name"`
+	NextBadgePoints       sql.NullInt64        `db:"next_badge_points"`
+	RecentAppreciations   []DigestAppreciation `db:"-"`
+}
+
+type DigestAppreciation struct {
+	SenderFirstName string `db:"first_name"`
+	SenderLastName  string `db:"last_name"`
+	CoreValueName   string `db:"core_value_name"`
+	Description     string `db:"description"`
+	CreatedAt       int64  `db:"created_at"`
+}
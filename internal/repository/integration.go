@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+type IntegrationStorer interface {
+	RepositoryTransaction
+
+	CreateIntegration(ctx context.Context, tx Transaction, integration Integration) (Integration, error)
+	ListIntegrations(ctx context.Context, tx Transaction) ([]Integration, error)
+	GetIntegration(ctx context.Context, tx Transaction, id int64) (Integration, error)
+	DeleteIntegration(ctx context.Context, tx Transaction, id int64) error
+	// ListIntegrationsForCoreValue returns the integrations that post appreciations of the core value
+	ListIntegrationsForCoreValue(ctx context.Context, tx Transaction, coreValueID int64) ([]Integration, error)
+	// ListQuarterWinners returns the users with the most appreciation points received between from and to
+	ListQuarterWinners(ctx context.Context, tx Transaction, from int64, to int64, limit int) ([]QuarterWinner, error)
+}
+
+type Integration struct {
+	ID           int64         `db:"id"`
+	Kind         string        `db:"kind"`
+	Name         string        `db:"name"`
+	WebhookURL   string        `db:"webhook_url"`
+	CoreValueIDs pq.Int64Array `db:"core_value_ids"`
+	CreatedBy    int64         `db:"created_by"`
+	CreatedAt    int64         `db:"created_at"`
+}
+
+type QuarterWinner struct {
+	ID                 int64  `db:"id"`
+	FirstName          string `db:"first_name"`
+	LastName           string `db:"last_name"`
+	AppreciationPoints int64  `db:"points"`
+}
@@ -0,0 +1,3 @@
+ALTER TABLE rewards DROP COLUMN value;
+ALTER TABLE rewards DROP COLUMN reward_level_id;
+DROP TABLE reward_levels;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS reward_levels (
+    id SERIAL PRIMARY KEY,
+    version INT NOT NULL,
+    point INT NOT NULL,
+    value INT NOT NULL,
+    created_by BIGINT REFERENCES users(id),
+    created_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT,
+    UNIQUE (version, point)
+);
+
+-- version 1 is the mapping that used to be hardcoded in the daily rewards job
+INSERT INTO reward_levels (version, point, value) VALUES (1, 1, 100), (1, 3, 150), (1, 5, 200);
+
+ALTER TABLE rewards
+ADD reward_level_id INT REFERENCES reward_levels(id);
+
+ALTER TABLE rewards
+ADD value INT NOT NULL DEFAULT 0;
+
+UPDATE rewards r
+SET reward_level_id = rl.id, value = rl.value
+FROM reward_levels rl
+WHERE rl.version = 1 AND rl.point = r.point;
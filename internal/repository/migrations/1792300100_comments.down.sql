@@ -0,0 +1,2 @@
+DROP TABLE comment_resolutions;
+DROP TABLE comments;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS comments (
+    id SERIAL PRIMARY KEY,
+    appreciation_id INT NOT NULL REFERENCES appreciations(id),
+    comment VARCHAR(500) NOT NULL,
+    commented_by BIGINT NOT NULL REFERENCES users(id),
+    is_valid BOOLEAN NOT NULL DEFAULT true,
+    created_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT,
+    updated_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS comments_appreciation_id_idx ON comments (appreciation_id);
+
+CREATE TABLE IF NOT EXISTS comment_resolutions (
+    id SERIAL PRIMARY KEY,
+    comment_id INT NOT NULL REFERENCES comments(id),
+    reporting_comment VARCHAR NOT NULL,
+    reported_by BIGINT NOT NULL REFERENCES users(id),
+    reported_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT,
+    moderator_comment VARCHAR,
+    moderated_by BIGINT REFERENCES users(id),
+    moderated_at BIGINT,
+    status status NOT NULL DEFAULT 'reported',
+    UNIQUE (comment_id, reported_by)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS reactions (
+    id SERIAL PRIMARY KEY,
+    appreciation_id INT NOT NULL REFERENCES appreciations(id),
+    reaction VARCHAR(20) NOT NULL,
+    reacted_by BIGINT NOT NULL REFERENCES users(id),
+    created_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT,
+    UNIQUE (appreciation_id, reacted_by, reaction)
+);
+
+CREATE INDEX IF NOT EXISTS reactions_appreciation_id_idx ON reactions (appreciation_id);
@@ -0,0 +1,3 @@
+DROP VIEW appreciation_receiver_points;
+DROP TABLE appreciation_receivers;
+ALTER TABLE appreciations DROP COLUMN group_name;
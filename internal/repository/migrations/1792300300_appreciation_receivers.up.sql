@@ -0,0 +1,35 @@
+ALTER TABLE appreciations ADD COLUMN IF NOT EXISTS group_name VARCHAR(100);
+
+CREATE TABLE IF NOT EXISTS appreciation_receivers (
+    appreciation_id INT NOT NULL REFERENCES appreciations(id),
+    receiver BIGINT NOT NULL REFERENCES users(id),
+    PRIMARY KEY (appreciation_id, receiver)
+);
+
+CREATE INDEX IF NOT EXISTS appreciation_receivers_receiver_idx ON appreciation_receivers (receiver);
+
+-- every existing appreciation has exactly one receiver
+INSERT INTO appreciation_receivers (appreciation_id, receiver)
+SELECT id, receiver FROM appreciations
+ON CONFLICT DO NOTHING;
+
+-- Share of the appreciation points credited to each receiver.
+-- total_reward_points is split evenly across the receivers, the remainder
+-- goes one point each to the receivers with the lowest user ids. A single
+-- receiver appreciation keeps all of its points.
+CREATE OR REPLACE VIEW appreciation_receiver_points AS
+SELECT
+    ar.appreciation_id,
+    ar.receiver,
+    a.total_reward_points / rc.receiver_count
+        + CASE WHEN ROW_NUMBER() OVER (PARTITION BY ar.appreciation_id ORDER BY ar.receiver) <= a.total_reward_points % rc.receiver_count
+            THEN 1 ELSE 0 END AS reward_points,
+    a.is_valid,
+    a.created_at
+FROM appreciation_receivers ar
+JOIN appreciations a ON a.id = ar.appreciation_id
+JOIN (
+    SELECT appreciation_id, COUNT(*) AS receiver_count
+    FROM appreciation_receivers
+    GROUP BY appreciation_id
+) rc ON rc.appreciation_id = ar.appreciation_id;
@@ -0,0 +1 @@
+DROP TABLE appreciation_edits;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS appreciation_edits (
+    id SERIAL PRIMARY KEY,
+    appreciation_id INT NOT NULL REFERENCES appreciations(id),
+    old_core_value_id INT NOT NULL REFERENCES core_values(id),
+    new_core_value_id INT NOT NULL REFERENCES core_values(id),
+    old_description TEXT NOT NULL,
+    new_description TEXT NOT NULL,
+    edited_by BIGINT NOT NULL REFERENCES users(id),
+    edited_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS appreciation_edits_appreciation_id_idx ON appreciation_edits (appreciation_id);
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS core_values_search_vector_trigger ON core_values;
+DROP TRIGGER IF EXISTS users_search_vector_trigger ON users;
+DROP TRIGGER IF EXISTS appreciation_receivers_search_vector_trigger ON appreciation_receivers;
+DROP TRIGGER IF EXISTS appreciations_search_vector_trigger ON appreciations;
+DROP FUNCTION IF EXISTS refresh_appreciation_search_vector();
+DROP FUNCTION IF EXISTS appreciation_search_document(INT);
+DROP INDEX IF EXISTS appreciations_search_vector_idx;
+ALTER TABLE appreciations DROP COLUMN IF EXISTS search_vector;
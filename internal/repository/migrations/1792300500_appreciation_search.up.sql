@@ -0,0 +1,69 @@
+ALTER TABLE appreciations ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+-- Search document of an appreciation. People are indexed with the simple
+-- configuration so names are not stemmed, the core value and description
+-- with english. Weights rank people and group names above the core value,
+-- and the core value above the description.
+CREATE OR REPLACE FUNCTION appreciation_search_document(appr_id INT) RETURNS tsvector AS $$
+    SELECT
+        setweight(to_tsvector('simple', COALESCE(u_sender.first_name, '') || ' ' || COALESCE(u_sender.last_name, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE((
+            SELECT string_agg(u.first_name || ' ' || u.last_name, ' ')
+            FROM appreciation_receivers ar
+            JOIN users u ON u.id = ar.receiver
+            WHERE ar.appreciation_id = a.id
+        ), '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(a.group_name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(cv.name, '')), 'B') ||
+        setweight(to_tsvector('english', a.description), 'C')
+    FROM appreciations a
+    LEFT JOIN users u_sender ON u_sender.id = a.sender
+    LEFT JOIN core_values cv ON cv.id = a.core_value_id
+    WHERE a.id = appr_id;
+$$ LANGUAGE SQL STABLE;
+
+CREATE OR REPLACE FUNCTION refresh_appreciation_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'appreciations' THEN
+        UPDATE appreciations SET search_vector = appreciation_search_document(NEW.id) WHERE id = NEW.id;
+    ELSIF TG_TABLE_NAME = 'appreciation_receivers' THEN
+        IF TG_OP = 'DELETE' THEN
+            UPDATE appreciations SET search_vector = appreciation_search_document(OLD.appreciation_id) WHERE id = OLD.appreciation_id;
+        ELSE
+            UPDATE appreciations SET search_vector = appreciation_search_document(NEW.appreciation_id) WHERE id = NEW.appreciation_id;
+        END IF;
+    ELSIF TG_TABLE_NAME = 'users' THEN
+        UPDATE appreciations SET search_vector = appreciation_search_document(id)
+        WHERE sender = NEW.id OR id IN (SELECT appreciation_id FROM appreciation_receivers WHERE receiver = NEW.id);
+    ELSIF TG_TABLE_NAME = 'core_values' THEN
+        UPDATE appreciations SET search_vector = appreciation_search_document(id) WHERE core_value_id = NEW.id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- the column lists keep the triggers from firing on their own search_vector updates
+CREATE TRIGGER appreciations_search_vector_trigger
+AFTER INSERT OR UPDATE OF description, core_value_id, group_name, sender ON appreciations
+FOR EACH ROW EXECUTE FUNCTION refresh_appreciation_search_vector();
+
+CREATE TRIGGER appreciation_receivers_search_vector_trigger
+AFTER INSERT OR DELETE ON appreciation_receivers
+FOR EACH ROW EXECUTE FUNCTION refresh_appreciation_search_vector();
+
+-- the sync rewrites names on every login, only an actual change reindexes
+CREATE TRIGGER users_search_vector_trigger
+AFTER UPDATE OF first_name, last_name ON users
+FOR EACH ROW
+WHEN (OLD.first_name IS DISTINCT FROM NEW.first_name OR OLD.last_name IS DISTINCT FROM NEW.last_name)
+EXECUTE FUNCTION refresh_appreciation_search_vector();
+
+CREATE TRIGGER core_values_search_vector_trigger
+AFTER UPDATE OF name ON core_values
+FOR EACH ROW
+WHEN (OLD.name IS DISTINCT FROM NEW.name)
+EXECUTE FUNCTION refresh_appreciation_search_vector();
+
+UPDATE appreciations SET search_vector = appreciation_search_document(id);
+
+CREATE INDEX IF NOT EXISTS appreciations_search_vector_idx ON appreciations USING GIN (search_vector);
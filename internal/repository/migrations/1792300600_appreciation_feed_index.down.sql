@@ -0,0 +1 @@
+DROP INDEX IF EXISTS appreciations_created_at_id_idx;
@@ -0,0 +1,2 @@
+-- backs the keyset paginated appreciation feed
+CREATE INDEX IF NOT EXISTS appreciations_created_at_id_idx ON appreciations (created_at DESC, id DESC);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    type VARCHAR(30) NOT NULL CHECK (type IN ('appreciation', 'reward', 'badge', 'report_outcome', 'quota_refill')),
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    appreciation_id INT REFERENCES appreciations(id),
+    read_at BIGINT,
+    created_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_id_idx ON notifications (user_id, id DESC);
+-- keeps the unread count cheap for the bell icon
+CREATE INDEX IF NOT EXISTS notifications_unread_idx ON notifications (user_id) WHERE read_at IS NULL;
@@ -0,0 +1,10 @@
+-- a missing row means the user gets the event on every channel
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    event VARCHAR(30) NOT NULL CHECK (event IN ('appreciation', 'reward', 'badge', 'report_outcome', 'quota_refill', 'comment', 'broadcast')),
+    push BOOLEAN NOT NULL DEFAULT TRUE,
+    email BOOLEAN NOT NULL DEFAULT TRUE,
+    in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT,
+    PRIMARY KEY (user_id, event)
+);
@@ -0,0 +1,5 @@
+-- users with a row get the weekly digest instead of the per-event appreciation, reward and badge emails
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id),
+    created_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT
+);
@@ -0,0 +1,5 @@
+DELETE FROM outbox WHERE kind = 'webhook';
+ALTER TABLE outbox DROP CONSTRAINT IF EXISTS outbox_kind_check;
+ALTER TABLE outbox ADD CONSTRAINT outbox_kind_check CHECK (kind IN ('email', 'push'));
+
+DROP TABLE integrations;
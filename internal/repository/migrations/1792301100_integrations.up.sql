@@ -0,0 +1,13 @@
+-- incoming webhooks the appreciation feed is posted to, an empty core_value_ids posts appreciations of every core value
+CREATE TABLE IF NOT EXISTS integrations (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('slack', 'teams')),
+    name VARCHAR(100) NOT NULL,
+    webhook_url TEXT NOT NULL,
+    core_value_ids INT[] NOT NULL DEFAULT '{}',
+    created_by BIGINT NOT NULL REFERENCES users(id),
+    created_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT
+);
+
+ALTER TABLE outbox DROP CONSTRAINT IF EXISTS outbox_kind_check;
+ALTER TABLE outbox ADD CONSTRAINT outbox_kind_check CHECK (kind IN ('email', 'push', 'webhook'));
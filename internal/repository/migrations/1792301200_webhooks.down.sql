@@ -0,0 +1,6 @@
+DELETE FROM outbox WHERE kind = 'webhook_event';
+ALTER TABLE outbox DROP CONSTRAINT IF EXISTS outbox_kind_check;
+ALTER TABLE outbox ADD CONSTRAINT outbox_kind_check CHECK (kind IN ('email', 'push', 'webhook'));
+
+DROP TABLE webhook_deliveries;
+DROP TABLE webhook_subscriptions;
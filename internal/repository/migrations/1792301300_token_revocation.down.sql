@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS user_blacklisted_tokens_expires_at_idx;
+
+DELETE FROM user_blacklisted_tokens WHERE token IS NULL;
+ALTER TABLE user_blacklisted_tokens DROP COLUMN revoked_at;
+ALTER TABLE user_blacklisted_tokens ALTER COLUMN token SET NOT NULL;
@@ -0,0 +1,6 @@
+-- the token column holds the jti of a revoked token, a row without one revokes
+-- every token issued to the user before revoked_at
+ALTER TABLE user_blacklisted_tokens ALTER COLUMN token DROP NOT NULL;
+ALTER TABLE user_blacklisted_tokens ADD COLUMN revoked_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT;
+
+CREATE INDEX IF NOT EXISTS user_blacklisted_tokens_expires_at_idx ON user_blacklisted_tokens (expires_at);
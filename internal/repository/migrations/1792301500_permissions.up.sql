@@ -0,0 +1,38 @@
+-- the roles are seeded with fixed ids, they're inserted here as well so permissions can be granted to them
+INSERT INTO roles (id, name) VALUES (1, 'super admin'), (2, 'admin'), (3, 'user') ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id INT NOT NULL REFERENCES roles(id),
+    permission_id INT NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('appreciation.delete', 'Delete any appreciation'),
+    ('report.moderate', 'Review, resolve and act on reported appreciations and comments'),
+    ('config.update', 'Change the organization config, core values, badges and reward levels'),
+    ('grade.edit', 'Change the points of a grade'),
+    ('user.role.assign', 'Promote users to admins and demote admins'),
+    ('appreciation.edits.view', 'See the edit history of an appreciation'),
+    ('session.revoke', 'Sign a user out of every session'),
+    ('outbox.manage', 'See the outbox deliveries and retry failed ones'),
+    ('integration.manage', 'Add, test and remove chat integrations'),
+    ('webhook.manage', 'Add and remove webhook subscriptions and redeliver webhooks'),
+    ('notification.broadcast', 'Send a push notification to every user'),
+    ('report.export', 'Download the appreciation, reported appreciation and dynamic engagers reports')
+ON CONFLICT (name) DO NOTHING;
+
+-- super admins hold every permission, admins every one except assigning roles
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 1, id FROM permissions
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 2, id FROM permissions WHERE name <> 'user.role.assign'
+ON CONFLICT DO NOTHING;
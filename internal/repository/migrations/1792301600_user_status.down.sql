@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE name IN ('user.manage', 'admin.password.set');
+DROP INDEX IF EXISTS users_status_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS deactivated_by;
+ALTER TABLE users DROP COLUMN IF EXISTS deactivated_at;
+ALTER TABLE users ALTER COLUMN status DROP NOT NULL;
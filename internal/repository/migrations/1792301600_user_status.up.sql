@@ -0,0 +1,19 @@
+-- status 1 is active and 0 deactivated, a deactivated user can't log in or receive appreciations
+UPDATE users SET status = 1 WHERE status IS NULL;
+ALTER TABLE users ALTER COLUMN status SET NOT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at BIGINT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_by BIGINT REFERENCES users(id);
+CREATE INDEX IF NOT EXISTS users_status_idx ON users (status);
+
+INSERT INTO permissions (name, description) VALUES
+    ('user.manage', 'List, deactivate and reactivate users'),
+    ('admin.password.set', 'Set and reset the passwords of admins')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 1, id FROM permissions WHERE name IN ('user.manage', 'admin.password.set')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 2, id FROM permissions WHERE name = 'user.manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_sync_reports;
+ALTER TABLE users DROP COLUMN IF EXISTS frozen_reward_quota;
@@ -0,0 +1,17 @@
+-- the balance a user held when deactivated, given back when they're reactivated
+ALTER TABLE users ADD COLUMN IF NOT EXISTS frozen_reward_quota BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS user_sync_reports (
+    id SERIAL PRIMARY KEY,
+    started_at BIGINT NOT NULL,
+    finished_at BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_count INT NOT NULL DEFAULT 0,
+    updated_count INT NOT NULL DEFAULT 0,
+    deactivated_count INT NOT NULL DEFAULT 0,
+    failed_count INT NOT NULL DEFAULT 0,
+    details JSONB NOT NULL DEFAULT '{}',
+    error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS user_sync_reports_started_at_idx ON user_sync_reports (started_at);
@@ -0,0 +1,6 @@
+DELETE FROM permissions WHERE name = 'org.manage';
+ALTER TABLE users DROP COLUMN IF EXISTS manager_id;
+ALTER TABLE users DROP COLUMN IF EXISTS team_id;
+ALTER TABLE users DROP COLUMN IF EXISTS department_id;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS departments;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS departments (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_by BIGINT REFERENCES users(id),
+    created_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS departments_name_idx ON departments (LOWER(name));
+
+-- a team belongs to a single department, its name is unique within it
+CREATE TABLE IF NOT EXISTS teams (
+    id SERIAL PRIMARY KEY,
+    department_id INT NOT NULL REFERENCES departments(id),
+    name TEXT NOT NULL,
+    created_by BIGINT REFERENCES users(id),
+    created_at BIGINT NOT NULL DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS teams_department_name_idx ON teams (department_id, LOWER(name));
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS department_id INT REFERENCES departments(id);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS team_id INT REFERENCES teams(id);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS manager_id BIGINT REFERENCES users(id);
+CREATE INDEX IF NOT EXISTS users_department_id_idx ON users (department_id);
+CREATE INDEX IF NOT EXISTS users_team_id_idx ON users (team_id);
+CREATE INDEX IF NOT EXISTS users_manager_id_idx ON users (manager_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('org.manage', 'Manage departments and teams and set the department, team and manager of users')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 1, id FROM permissions WHERE name = 'org.manage'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 2, id FROM permissions WHERE name = 'org.manage'
+ON CONFLICT DO NOTHING;
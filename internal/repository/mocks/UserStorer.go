@@ -27,6 +27,36 @@ func (_m *UserStorer) AddDeviceToken(ctx context.Context, userID int64, deviceTo
 	return r0
 }
 
+// AdminListUsers provides a mock function with given fields: ctx, tx, filter
+func (_m *UserStorer) AdminListUsers(ctx context.Context, tx repository.Transaction, filter dto.AdminListUsersReq) ([]repository.User, int64, error) {
+	ret := _m.Called(ctx, tx, filter)
+
+	var r0 []repository.User
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, dto.AdminListUsersReq) []repository.User); ok {
+		r0 = rf(ctx, tx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.User)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, dto.AdminListUsersReq) int64); ok {
+		r1 = rf(ctx, tx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, repository.Transaction, dto.AdminListUsersReq) error); ok {
+		r2 = rf(ctx, tx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // BeginTx provides a mock function with given fields: ctx
 func (_m *UserStorer) BeginTx(ctx context.Context) (repository.Transaction, error) {
 	ret := _m.Called(ctx)
@@ -71,13 +101,55 @@ func (_m *UserStorer) CreateNewUser(ctx context.Context, user dto.User) (reposit
 	return r0, r1
 }
 
-// GetActiveUserList provides a mock function with given fields: ctx, tx, quarterStart, quarterEnd
-func (_m *UserStorer) GetActiveUserList(ctx context.Context, tx repository.Transaction, quarterStart int64, quarterEnd int64) ([]repository.ActiveUser, error) {
-	ret := _m.Called(ctx, tx, quarterStart, quarterEnd)
+// CreateUsers provides a mock function with given fields: ctx, tx, users
+func (_m *UserStorer) CreateUsers(ctx context.Context, tx repository.Transaction, users []dto.User) error {
+	ret := _m.Called(ctx, tx, users)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, []dto.User) error); ok {
+		r0 = rf(ctx, tx, users)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDeviceTokens provides a mock function with given fields: ctx, tx, userID
+func (_m *UserStorer) DeleteDeviceTokens(ctx context.Context, tx repository.Transaction, userID int64) error {
+	ret := _m.Called(ctx, tx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64) error); ok {
+		r0 = rf(ctx, tx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUnregisteredDeviceToken provides a mock function with given fields: ctx, tx, deviceToken
+func (_m *UserStorer) DeleteUnregisteredDeviceToken(ctx context.Context, tx repository.Transaction, deviceToken string) error {
+	ret := _m.Called(ctx, tx, deviceToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, string) error); ok {
+		r0 = rf(ctx, tx, deviceToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveUserList provides a mock function with given fields: ctx, tx, quarterStart, quarterEnd, filter
+func (_m *UserStorer) GetActiveUserList(ctx context.Context, tx repository.Transaction, quarterStart int64, quarterEnd int64, filter dto.OrgFilter) ([]repository.ActiveUser, error) {
+	ret := _m.Called(ctx, tx, quarterStart, quarterEnd, filter)
 
 	var r0 []repository.ActiveUser
-	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, int64) []repository.ActiveUser); ok {
-		r0 = rf(ctx, tx, quarterStart, quarterEnd)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, int64, dto.OrgFilter) []repository.ActiveUser); ok {
+		r0 = rf(ctx, tx, quarterStart, quarterEnd, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]repository.ActiveUser)
@@ -85,8 +157,8 @@ func (_m *UserStorer) GetActiveUserList(ctx context.Context, tx repository.Trans
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, int64, int64) error); ok {
-		r1 = rf(ctx, tx, quarterStart, quarterEnd)
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, int64, int64, dto.OrgFilter) error); ok {
+		r1 = rf(ctx, tx, quarterStart, quarterEnd, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -94,13 +166,13 @@ func (_m *UserStorer) GetActiveUserList(ctx context.Context, tx repository.Trans
 	return r0, r1
 }
 
-// GetDynamicEngagersReport provides a mock function with given fields: ctx, tx, quarterStart, quarterEnd
-func (_m *UserStorer) GetDynamicEngagersReport(ctx context.Context, tx repository.Transaction, quarterStart int64, quarterEnd int64) ([]repository.DynamicEngager, error) {
-	ret := _m.Called(ctx, tx, quarterStart, quarterEnd)
+// GetDynamicEngagersReport provides a mock function with given fields: ctx, tx, quarterStart, quarterEnd, filter
+func (_m *UserStorer) GetDynamicEngagersReport(ctx context.Context, tx repository.Transaction, quarterStart int64, quarterEnd int64, filter dto.OrgFilter) ([]repository.DynamicEngager, error) {
+	ret := _m.Called(ctx, tx, quarterStart, quarterEnd, filter)
 
 	var r0 []repository.DynamicEngager
-	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, int64) []repository.DynamicEngager); ok {
-		r0 = rf(ctx, tx, quarterStart, quarterEnd)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, int64, dto.OrgFilter) []repository.DynamicEngager); ok {
+		r0 = rf(ctx, tx, quarterStart, quarterEnd, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]repository.DynamicEngager)
@@ -108,8 +180,8 @@ func (_m *UserStorer) GetDynamicEngagersReport(ctx context.Context, tx repositor
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, int64, int64) error); ok {
-		r1 = rf(ctx, tx, quarterStart, quarterEnd)
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, int64, int64, dto.OrgFilter) error); ok {
+		r1 = rf(ctx, tx, quarterStart, quarterEnd, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -223,13 +295,13 @@ func (_m *UserStorer) GetRoleByName(ctx context.Context, name string) (int64, er
 	return r0, r1
 }
 
-// GetTop10Users provides a mock function with given fields: ctx, quarterTimestamp
-func (_m *UserStorer) GetTop10Users(ctx context.Context, quarterTimestamp int64) ([]repository.Top10Users, error) {
-	ret := _m.Called(ctx, quarterTimestamp)
+// GetTop10Users provides a mock function with given fields: ctx, quarterTimestamp, filter
+func (_m *UserStorer) GetTop10Users(ctx context.Context, quarterTimestamp int64, filter dto.OrgFilter) ([]repository.Top10Users, error) {
+	ret := _m.Called(ctx, quarterTimestamp, filter)
 
 	var r0 []repository.Top10Users
-	if rf, ok := ret.Get(0).(func(context.Context, int64) []repository.Top10Users); ok {
-		r0 = rf(ctx, quarterTimestamp)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, dto.OrgFilter) []repository.Top10Users); ok {
+		r0 = rf(ctx, quarterTimestamp, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]repository.Top10Users)
@@ -237,8 +309,29 @@ func (_m *UserStorer) GetTop10Users(ctx context.Context, quarterTimestamp int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, quarterTimestamp)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, dto.OrgFilter) error); ok {
+		r1 = rf(ctx, quarterTimestamp, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, tx, userID
+func (_m *UserStorer) GetUser(ctx context.Context, tx repository.Transaction, userID int64) (repository.User, error) {
+	ret := _m.Called(ctx, tx, userID)
+
+	var r0 repository.User
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64) repository.User); ok {
+		r0 = rf(ctx, tx, userID)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, int64) error); ok {
+		r1 = rf(ctx, tx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -318,6 +411,29 @@ func (_m *UserStorer) InitiateQueryExecutor(tx repository.Transaction) sqlx.Ext
 	return r0
 }
 
+// ListDeactivatedUserIDs provides a mock function with given fields: ctx, tx
+func (_m *UserStorer) ListDeactivatedUserIDs(ctx context.Context, tx repository.Transaction) ([]int64, error) {
+	ret := _m.Called(ctx, tx)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction) []int64); ok {
+		r0 = rf(ctx, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction) error); ok {
+		r1 = rf(ctx, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDeviceTokensByUserID provides a mock function with given fields: ctx, userID
 func (_m *UserStorer) ListDeviceTokensByUserID(ctx context.Context, userID int64) ([]string, error) {
 	ret := _m.Called(ctx, userID)
@@ -371,6 +487,52 @@ func (_m *UserStorer) ListUsers(ctx context.Context, reqData dto.ListUsersReq) (
 	return r0, r1, r2
 }
 
+// ListUsersByEmails provides a mock function with given fields: ctx, tx, emails
+func (_m *UserStorer) ListUsersByEmails(ctx context.Context, tx repository.Transaction, emails []string) ([]repository.User, error) {
+	ret := _m.Called(ctx, tx, emails)
+
+	var r0 []repository.User
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, []string) []repository.User); ok {
+		r0 = rf(ctx, tx, emails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, []string) error); ok {
+		r1 = rf(ctx, tx, emails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsersByStatus provides a mock function with given fields: ctx, tx, status
+func (_m *UserStorer) ListUsersByStatus(ctx context.Context, tx repository.Transaction, status int64) ([]repository.User, error) {
+	ret := _m.Called(ctx, tx, status)
+
+	var r0 []repository.User
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64) []repository.User); ok {
+		r0 = rf(ctx, tx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Transaction, int64) error); ok {
+		r1 = rf(ctx, tx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SyncData provides a mock function with given fields: ctx, updateData
 func (_m *UserStorer) SyncData(ctx context.Context, updateData dto.User) error {
 	ret := _m.Called(ctx, updateData)
@@ -385,6 +547,20 @@ func (_m *UserStorer) SyncData(ctx context.Context, updateData dto.User) error {
 	return r0
 }
 
+// UpdatePassword provides a mock function with given fields: ctx, tx, userID, passwordHash, updatedBy
+func (_m *UserStorer) UpdatePassword(ctx context.Context, tx repository.Transaction, userID int64, passwordHash string, updatedBy int64) error {
+	ret := _m.Called(ctx, tx, userID, passwordHash, updatedBy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, string, int64) error); ok {
+		r0 = rf(ctx, tx, userID, passwordHash, updatedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRewardQuota provides a mock function with given fields: ctx, tx
 func (_m *UserStorer) UpdateRewardQuota(ctx context.Context, tx repository.Transaction) error {
 	ret := _m.Called(ctx, tx)
@@ -399,6 +575,34 @@ func (_m *UserStorer) UpdateRewardQuota(ctx context.Context, tx repository.Trans
 	return r0
 }
 
+// UpdateUserProfile provides a mock function with given fields: ctx, tx, user
+func (_m *UserStorer) UpdateUserProfile(ctx context.Context, tx repository.Transaction, user dto.User) error {
+	ret := _m.Called(ctx, tx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, dto.User) error); ok {
+		r0 = rf(ctx, tx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserStatus provides a mock function with given fields: ctx, tx, userID, status, updatedBy
+func (_m *UserStorer) UpdateUserStatus(ctx context.Context, tx repository.Transaction, userID int64, status int64, updatedBy int64) error {
+	ret := _m.Called(ctx, tx, userID, status, updatedBy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Transaction, int64, int64, int64) error); ok {
+		r0 = rf(ctx, tx, userID, status, updatedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 func NewUserStorer(t interface {
 	mock.TestingT
 	Cleanup(func())
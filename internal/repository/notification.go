@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+)
+
+type NotificationStorer interface {
+	RepositoryTransaction
+
+	CreateNotifications(ctx context.Context, tx Transaction, notifications []dto.Notification) error
+	// CreateNotificationForAllUsers puts a copy of the notification in the inbox of every active user
+	CreateNotificationForAllUsers(ctx context.Context, tx Transaction, notification dto.Notification) error
+	ListNotifications(ctx context.Context, tx Transaction, filter dto.NotificationFilter) ([]Notification, Pagination, error)
+	CountUnreadNotifications(ctx context.Context, tx Transaction, userID int64) (int64, error)
+	MarkNotificationRead(ctx context.Context, tx Transaction, userID int64, notificationID int64) error
+	MarkAllNotificationsRead(ctx context.Context, tx Transaction, userID int64) error
+	DeleteNotification(ctx context.Context, tx Transaction, userID int64, notificationID int64) error
+}
+
+type Notification struct {
+	ID             int64         `db:"id"`
+	UserID         int64         `db:"user_id"`
+	Type           string        `db:"type"`
+	Title          string        `db:"title"`
+	Body           string        `db:"body"`
+	AppreciationID sql.NullInt64 `db:"appreciation_id"`
+	ReadAt         sql.NullInt64 `db:"read_at"`
+	CreatedAt      int64         `db:"created_at"`
+}
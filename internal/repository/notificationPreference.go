@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+)
+
+type NotificationPreferenceStorer interface {
+	RepositoryTransaction
+
+	// ListNotificationPreferences returns the events the user changed the channels of
+	ListNotificationPreferences(ctx context.Context, tx Transaction, userID int64) ([]NotificationPreference, error)
+	UpsertNotificationPreferences(ctx context.Context, tx Transaction, preferences []NotificationPreference) error
+	IsChannelEnabled(ctx context.Context, tx Transaction, userID int64, event string, channel string) (bool, error)
+	// ListDeviceTokensForEvent returns the device tokens of every user who didn't turn off pushes for the event
+	ListDeviceTokensForEvent(ctx context.Context, tx Transaction, event string) ([]string, error)
+}
+
+type NotificationPreference struct {
+	UserID    int64  `db:"user_id"`
+	Event     string `db:"event"`
+	Push      bool   `db:"push"`
+	Email     bool   `db:"email"`
+	InApp     bool   `db:"in_app"`
+	UpdatedAt int64  `db:"updated_at"`
+}
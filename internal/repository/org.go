@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type OrgStorer interface {
+	RepositoryTransaction
+
+	CreateDepartment(ctx context.Context, tx Transaction, department Department) (Department, error)
+	ListDepartments(ctx context.Context, tx Transaction) ([]Department, error)
+	// GetDepartment returns apperrors.DepartmentNotFound for an unknown department
+	GetDepartment(ctx context.Context, tx Transaction, id int64) (Department, error)
+	// GetDepartmentByName matches the name case insensitively and returns apperrors.DepartmentNotFound if none does
+	GetDepartmentByName(ctx context.Context, tx Transaction, name string) (Department, error)
+	UpdateDepartment(ctx context.Context, tx Transaction, id int64, name string) (Department, error)
+	// DeleteDepartment returns apperrors.DepartmentInUse while the department has teams or users
+	DeleteDepartment(ctx context.Context, tx Transaction, id int64) error
+
+	CreateTeam(ctx context.Context, tx Transaction, team Team) (Team, error)
+	// ListTeams lists the teams of the department, or every team when departmentID is 0
+	ListTeams(ctx context.Context, tx Transaction, departmentID int64) ([]Team, error)
+	// GetTeam returns apperrors.TeamNotFound for an unknown team
+	GetTeam(ctx context.Context, tx Transaction, id int64) (Team, error)
+	// GetTeamByName matches the name within the department case insensitively and returns apperrors.TeamNotFound if
+	// none does
+	GetTeamByName(ctx context.Context, tx Transaction, departmentID int64, name string) (Team, error)
+	UpdateTeam(ctx context.Context, tx Transaction, id int64, name string) (Team, error)
+	// DeleteTeam returns apperrors.TeamInUse while the team has users
+	DeleteTeam(ctx context.Context, tx Transaction, id int64) error
+
+	UpdateUserHierarchy(ctx context.Context, tx Transaction, userID int64, hierarchy UserHierarchy, updatedBy int64) error
+	// ListTeamMembers returns the users reporting to the manager with what they received and sent since from
+	ListTeamMembers(ctx context.Context, tx Transaction, managerID int64, from int64) ([]TeamMember, error)
+}
+
+type Department struct {
+	ID        int64         `db:"id"`
+	Name      string        `db:"name"`
+	CreatedBy sql.NullInt64 `db:"created_by"`
+	CreatedAt int64         `db:"created_at"`
+}
+
+type Team struct {
+	ID           int64         `db:"id"`
+	DepartmentID int64         `db:"department_id"`
+	Name         string        `db:"name"`
+	CreatedBy    sql.NullInt64 `db:"created_by"`
+	CreatedAt    int64         `db:"created_at"`
+}
+
+// UserHierarchy is where a user sits in the organization, a null field isn't known
+type UserHierarchy struct {
+	DepartmentID sql.NullInt64 `db:"department_id"`
+	TeamID       sql.NullInt64 `db:"team_id"`
+	ManagerID    sql.NullInt64 `db:"manager_id"`
+}
+
+type TeamMember struct {
+	ID                 int64          `db:"id"`
+	EmployeeID         string         `db:"employee_id"`
+	FirstName          string         `db:"first_name"`
+	LastName           string         `db:"last_name"`
+	Email              string         `db:"email"`
+	ProfileImageURL    sql.NullString `db:"profile_image_url"`
+	Designation        string         `db:"designation"`
+	DepartmentID       sql.NullInt64  `db:"department_id"`
+	TeamID             sql.NullInt64  `db:"team_id"`
+	AppreciationPoints int64          `db:"appreciation_points"`
+	AppreciationsSent  int64          `db:"appreciations_sent"`
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+)
+
+type OutboxStorer interface {
+	RepositoryTransaction
+
+	// EnqueueOutboxMessage writes the message as part of tx, so it is only delivered once tx commits
+	EnqueueOutboxMessage(ctx context.Context, tx Transaction, kind string, payload interface{}) error
+	// EnqueueWebhookEvent logs a delivery of the event for every webhook subscription of the event
+	// and queues the deliveries as part of tx
+	EnqueueWebhookEvent(ctx context.Context, tx Transaction, event string, data interface{}) error
+	// ClaimDueOutboxMessages picks pending messages that are due and holds them until lockedUntil,
+	// so a message is not picked by two workers at the same time
+	ClaimDueOutboxMessages(ctx context.Context, tx Transaction, limit int, lockedUntil int64) ([]OutboxMessage, error)
+	MarkOutboxMessageSent(ctx context.Context, tx Transaction, id int64) error
+	MarkOutboxMessageFailed(ctx context.Context, tx Transaction, id int64, lastError string, nextAttemptAt int64, dead bool) error
+	ListOutboxMessages(ctx context.Context, tx Transaction, filter dto.OutboxFilter) ([]OutboxMessage, Pagination, error)
+	RetryOutboxMessage(ctx context.Context, tx Transaction, id int64) (OutboxMessage, error)
+}
+
+type OutboxMessage struct {
+	ID            int64           `db:"id"`
+	Kind          string          `db:"kind"`
+	Payload       json.RawMessage `db:"payload"`
+	Status        string          `db:"status"`
+	Attempts      int             `db:"attempts"`
+	MaxAttempts   int             `db:"max_attempts"`
+	NextAttemptAt int64           `db:"next_attempt_at"`
+	LastError     sql.NullString  `db:"last_error"`
+	CreatedAt     int64           `db:"created_at"`
+	UpdatedAt     int64           `db:"updated_at"`
+	SentAt        sql.NullInt64   `db:"sent_at"`
+}
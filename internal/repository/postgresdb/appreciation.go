@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"slices"
 	"strings"
 	"time"
 
@@ -18,23 +17,49 @@ import (
 	"github.com/joshsoftware/peerly-backend/internal/repository"
 )
 
-var AppreciationColumns = []string{"id", "core_value_id", "description", "total_reward_points", "quarter", "sender", "receiver"}
+var AppreciationColumns = []string{"id", "core_value_id", "description", "total_reward_points", "quarter", "sender", "receiver", "group_name"}
+
+// searchQuery matches the search text against both the english and the simple parts of the search vector, it takes the text twice
+const searchQuery = "(websearch_to_tsquery('english', ?) || websearch_to_tsquery('simple', ?))"
+
+// receiversColumn selects every receiver of the appreciation aliased as "a" along with their share of the points
+func receiversColumn() string {
+	return fmt.Sprintf(
+		`COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', u.id,
+				'employee_id', u.employee_id,
+				'first_name', u.first_name,
+				'last_name', u.last_name,
+				'image_url', COALESCE(u.profile_image_url, ''),
+				'designation', u.designation,
+				'reward_points', arp.reward_points
+			) ORDER BY arp.receiver)
+			FROM %s arp
+			JOIN %s u ON u.id = arp.receiver
+			WHERE arp.appreciation_id = a.id
+		), '[]') AS receivers`, constants.AppreciationReceiverPointsView, constants.UsersTable)
+}
 
 type appreciationsStore struct {
 	BaseRepository
-	AppreciationsTable string
-	RewardsTable       string
-	UsersTable         string
-	CoreValuesTable    string
+	AppreciationsTable         string
+	AppreciationReceiversTable string
+	AppreciationEditsTable     string
+	RewardsTable               string
+	UsersTable                 string
+	CoreValuesTable            string
 }
 
 func NewAppreciationRepo(db *sqlx.DB) repository.AppreciationStorer {
 	return &appreciationsStore{
-		BaseRepository:     BaseRepository{db},
-		AppreciationsTable: constants.AppreciationsTable,
-		RewardsTable:       constants.RewardsTable,
-		UsersTable:         constants.UsersTable,
-		CoreValuesTable:    constants.CoreValuesTable,
+		BaseRepository:             BaseRepository{db},
+		AppreciationsTable:         constants.AppreciationsTable,
+		AppreciationReceiversTable: constants.AppreciationReceiversTable,
+		AppreciationEditsTable:     constants.AppreciationEditsTable,
+		RewardsTable:               constants.RewardsTable,
+		UsersTable:                 constants.UsersTable,
+		CoreValuesTable:            constants.CoreValuesTable,
 	}
 }
 
@@ -45,8 +70,8 @@ func (appr *appreciationsStore) CreateAppreciation(ctx context.Context, tx repos
 
 	insertQuery, args, err := repository.Sq.
 		Insert(appr.AppreciationsTable).Columns(AppreciationColumns[1:]...).
-		Values(appreciation.CoreValueID, appreciation.Description, constants.DefaultAppreciationPoint, appreciation.Quarter, appreciation.Sender, appreciation.Receiver).
-		Suffix("RETURNING id,core_value_id, description,total_reward_points,quarter,sender,receiver,created_at,updated_at").
+		Values(appreciation.CoreValueID, appreciation.Description, constants.DefaultAppreciationPoint, appreciation.Quarter, appreciation.Sender, appreciation.Receiver, sql.NullString{String: appreciation.GroupName, Valid: appreciation.GroupName != ""}).
+		Suffix("RETURNING id,core_value_id, description,total_reward_points,quarter,sender,receiver,group_name,created_at,updated_at").
 		ToSql()
 	if err != nil {
 		logger.Errorf(ctx, "appreciationRepo: error in generating squirrel query, err: %v", err)
@@ -62,6 +87,27 @@ func (appr *appreciationsStore) CreateAppreciation(ctx context.Context, tx repos
 		return repository.Appreciation{}, apperrors.InternalServer
 	}
 
+	receivers := appreciation.Receivers
+	if len(receivers) == 0 {
+		receivers = []int64{appreciation.Receiver}
+	}
+
+	receiversQuery := repository.Sq.Insert(appr.AppreciationReceiversTable).Columns("appreciation_id", "receiver")
+	for _, receiver := range receivers {
+		receiversQuery = receiversQuery.Values(resAppr.ID, receiver)
+	}
+	insertReceiversQuery, args, err := receiversQuery.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "appreciationRepo: error in generating squirrel query, err: %v", err)
+		return repository.Appreciation{}, apperrors.InternalServerError
+	}
+
+	_, err = queryExecutor.Exec(insertReceiversQuery, args...)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationRepo: Error executing appreciation receivers insert query: %v", err)
+		return repository.Appreciation{}, apperrors.InternalServer
+	}
+
 	logger.Debug(ctx, "appreciationRepo: createappreciation response: ", resAppr)
 	return resAppr, nil
 }
@@ -81,6 +127,7 @@ func (appr *appreciationsStore) GetAppreciationById(ctx context.Context, tx repo
 
 	query, args, err := repository.Sq.Select(
 		"a.id",
+		"a.core_value_id",
 		"cv.name AS core_value_name",
 		"cv.description AS core_value_description",
 		"a.description",
@@ -98,6 +145,8 @@ func (appr *appreciationsStore) GetAppreciationById(ctx context.Context, tx repo
 		"u_receiver.last_name AS receiver_last_name",
 		"u_receiver.profile_image_url AS receiver_image_url",
 		"u_receiver.designation AS receiver_designation",
+		receiversColumn(),
+		"COALESCE(a.group_name, '') AS group_name",
 		"a.created_at",
 		"a.updated_at",
 		"COUNT(r.id) AS total_rewards",
@@ -107,6 +156,9 @@ func (appr *appreciationsStore) GetAppreciationById(ctx context.Context, tx repo
 					FROM rewards r2 
 					WHERE r2.appreciation_id = a.id AND r2.sender = %d
 				), 0) AS given_reward_point`, userID),
+		reportedFlagColumn(userID),
+		reactionCountsColumn(),
+		myReactionsColumn(userID),
 	).From(appr.AppreciationsTable+" a").
 		LeftJoin(appr.UsersTable+" u_sender ON a.sender = u_sender.id").
 		LeftJoin(appr.UsersTable+" u_receiver ON a.receiver = u_receiver.id").
@@ -141,40 +193,66 @@ func (appr *appreciationsStore) GetAppreciationById(ctx context.Context, tx repo
 	logger.Debug(ctx, "appreciationRepo:  appreciationById: ", resAppr)
 	return resAppr, nil
 }
-func (appr *appreciationsStore) ListAppreciations(ctx context.Context, tx repository.Transaction, filter dto.AppreciationFilter) ([]repository.AppreciationResponse, repository.Pagination, error) {
 
-	logger.Debug(ctx, "appreciationRepo:  ListAppreciations: filter: ", filter)
-	queryExecutor := appr.InitiateQueryExecutor(tx)
-
-	// Get logged-in user ID
-	data := ctx.Value(constants.UserId)
-	userID, ok := data.(int64)
-	if !ok {
-		logger.Error(ctx, "err in parsing userID from token")
-		return []repository.AppreciationResponse{}, repository.Pagination{}, apperrors.InternalServerError
-	}
-
-	// query builder for counting total records
-	queryBuilder := repository.Sq.Select("COUNT(*)").
-		From("appreciations a").
-		LeftJoin("users u_sender ON a.sender = u_sender.id").
-		LeftJoin("users u_receiver ON a.receiver = u_receiver.id").
-		LeftJoin("core_values cv ON a.core_value_id = cv.id").
-		Where(squirrel.Eq{"a.is_valid": true})
+// appreciationListFilters applies the filters shared by the paginated and the cursor based listing
+func appreciationListFilters(queryBuilder squirrel.SelectBuilder, filter dto.AppreciationFilter, userID int64) squirrel.SelectBuilder {
 
 	if filter.Name != "" {
 		lowerNameFilter := fmt.Sprintf("%%%s%%", strings.ToLower(filter.Name))
 		queryBuilder = queryBuilder.Where(
 			"(LOWER(CONCAT(u_sender.first_name, ' ', u_sender.last_name)) LIKE ? OR "+
-				"LOWER(CONCAT(u_receiver.first_name, ' ', u_receiver.last_name)) LIKE ?)",
-			lowerNameFilter, lowerNameFilter,
+				"LOWER(a.group_name) LIKE ? OR "+
+				"EXISTS (SELECT 1 FROM appreciation_receivers ar JOIN users u_ar ON u_ar.id = ar.receiver "+
+				"WHERE ar.appreciation_id = a.id AND LOWER(CONCAT(u_ar.first_name, ' ', u_ar.last_name)) LIKE ?))",
+			lowerNameFilter, lowerNameFilter, lowerNameFilter,
 		)
 	}
 
 	if filter.Self {
 		queryBuilder = queryBuilder.Where(squirrel.Or{
 			squirrel.Eq{"a.sender": userID},
-			squirrel.Eq{"a.receiver": userID},
+			squirrel.Expr("EXISTS (SELECT 1 FROM appreciation_receivers ar WHERE ar.appreciation_id = a.id AND ar.receiver = ?)", userID),
+		})
+	}
+
+	if filter.Search != "" {
+		queryBuilder = queryBuilder.Where("a.search_vector @@ "+searchQuery, filter.Search, filter.Search)
+	}
+
+	if filter.CoreValueID > 0 {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"a.core_value_id": filter.CoreValueID})
+	}
+
+	if filter.SenderID > 0 {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"a.sender": filter.SenderID})
+	}
+
+	if filter.ReceiverID > 0 {
+		queryBuilder = queryBuilder.Where("EXISTS (SELECT 1 FROM appreciation_receivers ar WHERE ar.appreciation_id = a.id AND ar.receiver = ?)", filter.ReceiverID)
+	}
+
+	if filter.From > 0 {
+		queryBuilder = queryBuilder.Where(squirrel.GtOrEq{"a.created_at": filter.From})
+	}
+
+	if filter.To > 0 {
+		queryBuilder = queryBuilder.Where(squirrel.LtOrEq{"a.created_at": filter.To})
+	}
+
+	if !filter.OrgFilter.IsEmpty() {
+		// the subqueries keep the question placeholders, they're numbered along with the outer query
+		sender := squirrel.Select("1").
+			From("users u_org").
+			Where("u_org.id = a.sender").
+			Where(orgFilterCondition("u_org.", filter.OrgFilter))
+		receivers := squirrel.Select("1").
+			From("appreciation_receivers ar").
+			Join("users u_org ON u_org.id = ar.receiver").
+			Where("ar.appreciation_id = a.id").
+			Where(orgFilterCondition("u_org.", filter.OrgFilter))
+		queryBuilder = queryBuilder.Where(squirrel.Or{
+			squirrel.Expr("EXISTS (?)", sender),
+			squirrel.Expr("EXISTS (?)", receivers),
 		})
 	}
 
@@ -194,32 +272,15 @@ func (appr *appreciationsStore) ListAppreciations(ctx context.Context, tx reposi
 		})
 	}
 
-	countSql, countArgs, err := queryBuilder.ToSql()
-	if err != nil {
-		logger.Error(ctx, "appreciationRepo: failed to build count query: ", err.Error())
-		return []repository.AppreciationResponse{}, repository.Pagination{}, apperrors.InternalServerError
-	}
-
-	logger.Debug(ctx, "appreciationRepo: listAppreciation: countSql: ", countSql, ", countArgs:", countArgs)
-
-	var totalRecords int32
-	err = queryExecutor.QueryRowx(countSql, countArgs...).Scan(&totalRecords)
-	if err != nil {
-		logger.Error(ctx, "failed to execute count query: ", err.Error())
-		return []repository.AppreciationResponse{}, repository.Pagination{}, apperrors.InternalServerError
-	}
+	return queryBuilder
+}
 
-	pagination := getPaginationMetaData(filter.Page, filter.Limit, totalRecords)
-	logger.Debug(ctx, " pagination: ", pagination)
-	queryBuilder = queryBuilder.RemoveColumns()
-	queryBuilder = queryBuilder.Columns(
+// appreciationListColumns selects an appreciation of the listing as seen by the given user
+func appreciationListColumns(userID int64) []string {
+	return []string{
 		"a.id",
-		"cv.name AS core_value_name", fmt.Sprintf(
-			`COALESCE((
-				SELECT SUM(r2.point) 
-				FROM rewards r2 
-				WHERE r2.appreciation_id = a.id AND r2.sender = %d
-			), 0) AS given_reward_point`, userID),
+		"a.core_value_id",
+		"cv.name AS core_value_name",
 		"cv.description AS core_value_description",
 		"a.description",
 		"a.is_valid",
@@ -238,6 +299,8 @@ func (appr *appreciationsStore) ListAppreciations(ctx context.Context, tx reposi
 		"u_receiver.profile_image_url AS receiver_image_url",
 		"u_receiver.designation AS receiver_designation",
 		"u_receiver.employee_id AS receiver_employee_id",
+		receiversColumn(),
+		"COALESCE(a.group_name, '') AS group_name",
 		"a.created_at",
 		"a.updated_at",
 		"COUNT(r.id) AS total_rewards",
@@ -247,10 +310,69 @@ func (appr *appreciationsStore) ListAppreciations(ctx context.Context, tx reposi
 				FROM rewards r2 
 				WHERE r2.appreciation_id = a.id AND r2.sender = %d
 			), 0) AS given_reward_point`, userID),
-	).
+		reportedFlagColumn(userID),
+		reactionCountsColumn(),
+		myReactionsColumn(userID),
+	}
+}
+
+// reportedFlagColumn selects whether the given user has reported the appreciation aliased as "a"
+func reportedFlagColumn(userID int64) string {
+	return fmt.Sprintf(
+		`EXISTS (
+			SELECT 1 FROM resolutions res WHERE res.appreciation_id = a.id AND res.reported_by = %d
+		) AS reported_flag`, userID)
+}
+
+func (appr *appreciationsStore) ListAppreciations(ctx context.Context, tx repository.Transaction, filter dto.AppreciationFilter) ([]repository.AppreciationResponse, repository.Pagination, error) {
+
+	logger.Debug(ctx, "appreciationRepo:  ListAppreciations: filter: ", filter)
+	queryExecutor := appr.InitiateQueryExecutor(tx)
+
+	// Get logged-in user ID
+	data := ctx.Value(constants.UserId)
+	userID, ok := data.(int64)
+	if !ok {
+		logger.Error(ctx, "err in parsing userID from token")
+		return []repository.AppreciationResponse{}, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	// query builder for counting total records
+	queryBuilder := repository.Sq.Select("COUNT(*)").
+		From("appreciations a").
+		LeftJoin("users u_sender ON a.sender = u_sender.id").
+		LeftJoin("users u_receiver ON a.receiver = u_receiver.id").
+		LeftJoin("core_values cv ON a.core_value_id = cv.id").
+		Where(squirrel.Eq{"a.is_valid": true})
+	queryBuilder = appreciationListFilters(queryBuilder, filter, userID)
+
+	countSql, countArgs, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Error(ctx, "appreciationRepo: failed to build count query: ", err.Error())
+		return []repository.AppreciationResponse{}, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	logger.Debug(ctx, "appreciationRepo: listAppreciation: countSql: ", countSql, ", countArgs:", countArgs)
+
+	var totalRecords int32
+	err = queryExecutor.QueryRowx(countSql, countArgs...).Scan(&totalRecords)
+	if err != nil {
+		logger.Error(ctx, "failed to execute count query: ", err.Error())
+		return []repository.AppreciationResponse{}, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	pagination := getPaginationMetaData(filter.Page, filter.Limit, totalRecords)
+	logger.Debug(ctx, " pagination: ", pagination)
+	queryBuilder = queryBuilder.RemoveColumns()
+	queryBuilder = queryBuilder.Columns(appreciationListColumns(userID)...).
 		LeftJoin("rewards r ON a.id = r.appreciation_id").
 		GroupBy("a.id", "cv.name", "cv.description", "u_sender.id", "u_receiver.id")
 
+	// search results are ranked by relevance, the sort order only breaks ties
+	if filter.Search != "" {
+		queryBuilder = queryBuilder.OrderByClause("ts_rank(a.search_vector, "+searchQuery+") DESC", filter.Search, filter.Search)
+	}
+
 	if filter.SortOrder != "" {
 		queryBuilder = queryBuilder.OrderBy(fmt.Sprintf("a.created_at %s", filter.SortOrder))
 	}
@@ -265,41 +387,74 @@ func (appr *appreciationsStore) ListAppreciations(ctx context.Context, tx reposi
 	}
 
 	logger.Debug(ctx, "appreciationRepo: listAprreciation: sqlQuery: ", sql, ",args: ", args)
-	queryExecutor = appr.InitiateQueryExecutor(tx)
 	res := make([]repository.AppreciationResponse, 0)
 	err = sqlx.Select(queryExecutor, &res, sql, args...)
 	if err != nil {
 		logger.Error(ctx, "appreciationRepo:failed to execute query appreciation: ", err.Error())
 		return nil, repository.Pagination{}, apperrors.InternalServerError
 	}
-	logger.Error(ctx, "appreciationRepo: res data: ", res)
-	userId, ok := ctx.Value(constants.UserId).(int64)
+
+	logger.Debug(ctx, fmt.Sprintf("appreciationRepo: res: %v, pagination : %v", res, pagination))
+	return res, pagination, nil
+}
+
+func (appr *appreciationsStore) ListAppreciationsByCursor(ctx context.Context, tx repository.Transaction, filter dto.AppreciationFilter, after *dto.AppreciationCursor) ([]repository.AppreciationResponse, bool, error) {
+
+	logger.Debug(ctx, "appreciationRepo: ListAppreciationsByCursor: filter: ", filter, " after: ", after)
+	queryExecutor := appr.InitiateQueryExecutor(tx)
+
+	userID, ok := ctx.Value(constants.UserId).(int64)
 	if !ok {
-		logger.Error(ctx, "unable to convert context user id to int64")
-		return nil, repository.Pagination{}, apperrors.InternalServerError
+		logger.Error(ctx, "err in parsing userID from token")
+		return nil, false, apperrors.InternalServerError
 	}
 
-	logger.Debug(ctx, " userId: ", userId)
-	for idx, appreciation := range res {
-		var userIds []int64
-		queryBuilder = repository.Sq.Select("reported_by").From("resolutions").Where(squirrel.Eq{"appreciation_id": appreciation.ID})
-		query, args, err := queryBuilder.ToSql()
-		if err != nil {
-			logger.Errorf(ctx, "error in generating squirrel query, err: %s", err.Error())
-			return nil, repository.Pagination{}, apperrors.InternalServerError
-		}
+	queryBuilder := repository.Sq.Select(appreciationListColumns(userID)...).
+		From("appreciations a").
+		LeftJoin("users u_sender ON a.sender = u_sender.id").
+		LeftJoin("users u_receiver ON a.receiver = u_receiver.id").
+		LeftJoin("core_values cv ON a.core_value_id = cv.id").
+		LeftJoin("rewards r ON a.id = r.appreciation_id").
+		Where(squirrel.Eq{"a.is_valid": true}).
+		GroupBy("a.id", "cv.name", "cv.description", "u_sender.id", "u_receiver.id")
+	queryBuilder = appreciationListFilters(queryBuilder, filter, userID)
 
-		logger.Debug(ctx, fmt.Sprintf("appreciationRepo: resolutions query: %s,args: %v", query, args))
-		err = appr.DB.SelectContext(ctx, &userIds, query, args...)
-		if err != nil {
-			logger.Errorf(ctx, "error in reported flag query, err: %s", err.Error())
-			return nil, repository.Pagination{}, apperrors.InternalServerError
+	// the feed walks (created_at, id) so rows posted meanwhile never shift the next page
+	ascending := strings.EqualFold(filter.SortOrder, "asc")
+	if after != nil {
+		if ascending {
+			queryBuilder = queryBuilder.Where("(a.created_at, a.id) > (?, ?)", after.CreatedAt, after.ID)
+		} else {
+			queryBuilder = queryBuilder.Where("(a.created_at, a.id) < (?, ?)", after.CreatedAt, after.ID)
 		}
-		res[idx].ReportedFlag = slices.Contains(userIds, userId)
+	}
+	if ascending {
+		queryBuilder = queryBuilder.OrderBy("a.created_at ASC", "a.id ASC")
+	} else {
+		queryBuilder = queryBuilder.OrderBy("a.created_at DESC", "a.id DESC")
 	}
 
-	logger.Debug(ctx, fmt.Sprintf("appreciationRepo: res: %v, pagination : %v", res, pagination))
-	return res, pagination, nil
+	// one extra row tells whether there is a next page
+	queryBuilder = queryBuilder.Limit(uint64(filter.Limit) + 1)
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Error(ctx, "failed to build query: ", err.Error())
+		return nil, false, apperrors.InternalServerError
+	}
+
+	logger.Debug(ctx, "appreciationRepo: ListAppreciationsByCursor: sqlQuery: ", query, ",args: ", args)
+	res := make([]repository.AppreciationResponse, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Error(ctx, "appreciationRepo: failed to execute cursor query: ", err.Error())
+		return nil, false, apperrors.InternalServerError
+	}
+
+	hasMore := len(res) > int(filter.Limit)
+	if hasMore {
+		res = res[:filter.Limit]
+	}
+	return res, hasMore, nil
 }
 
 func (appr *appreciationsStore) DeleteAppreciation(ctx context.Context, tx repository.Transaction, apprId int32) error {
@@ -371,6 +526,29 @@ func (appr *appreciationsStore) IsUserPresent(ctx context.Context, tx repository
 	return count > 0, nil
 }
 
+func (appr *appreciationsStore) IsUserActive(ctx context.Context, tx repository.Transaction, userID int64) (bool, error) {
+
+	query, args, err := repository.Sq.Select("COUNT(*)").
+		From(appr.UsersTable).
+		Where(squirrel.Eq{"id": userID, "status": constants.ActiveUserStatus}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "appreciationRepo: error in generating IsUserActive query: %v", err)
+		return false, apperrors.InternalServer
+	}
+
+	queryExecutor := appr.InitiateQueryExecutor(tx)
+
+	var count int
+	err = queryExecutor.QueryRowx(query, args...).Scan(&count)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationRepo: failed to execute IsUserActive query: %v", err)
+		return false, apperrors.InternalServer
+	}
+
+	return count > 0, nil
+}
+
 func (appr *appreciationsStore) UpdateAppreciationTotalRewardsOfYesterday(ctx context.Context, tx repository.Transaction, orgTimezone string) (bool, error) {
 	logger.Info(ctx, "appr: UpdateAppreciationTotalRewardsOfYesterday")
 
@@ -401,14 +579,7 @@ func (appr *appreciationsStore) UpdateAppreciationTotalRewardsOfYesterday(ctx co
 	SET total_reward_points = total_reward_points + agg.total_points
 	FROM (
     SELECT appreciation_id, 
-		SUM(
-			CASE 
-					WHEN r.point = 1 THEN 100
-					WHEN r.point = 3 THEN 150
-					WHEN r.point = 5 THEN 200
-					ELSE 0
-			END
-	) AS total_points
+		SUM(r.value) AS total_points
     FROM rewards r
     JOIN appreciations a ON r.appreciation_id = a.id
     JOIN users u ON r.sender = u.id
@@ -446,14 +617,15 @@ func (appr *appreciationsStore) UpdateUserBadgesBasedOnTotalRewards(ctx context.
 	logger.Info(ctx, " aftertime: ", afterTime)
 	query := `
 		-- Calculate total reward points for each receiver
+-- a team appreciation credits every receiver with their share of the points
 WITH receiver_points AS (
     SELECT
         receiver,
-        SUM(total_reward_points) AS total_points
+        SUM(reward_points) AS total_points
     FROM
-        appreciations
+        appreciation_receiver_points
     WHERE
-        Appreciations.is_valid = true AND appreciations.created_at >= $1
+        is_valid = true AND created_at >= $1
     GROUP BY
         receiver
 ),
@@ -549,3 +721,106 @@ JOIN
 
 	return userBadgeDetails, nil
 }
+
+func (appr *appreciationsStore) EditAppreciation(ctx context.Context, tx repository.Transaction, edit dto.EditAppreciation) (repository.Appreciation, error) {
+
+	logger.Debug(ctx, "appreciationRepo: EditAppreciation: edit: ", edit)
+	queryExecutor := appr.InitiateQueryExecutor(tx)
+
+	// the edit window, rewards and reports are checked in the same statement so that none of them can change between the
+	// check and the update
+	query, args, err := repository.Sq.Update(appr.AppreciationsTable+" a").
+		Set("core_value_id", edit.CoreValueID).
+		Set("description", edit.Description).
+		Set("updated_at", squirrel.Expr("(EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT")).
+		Where(squirrel.And{
+			squirrel.Eq{"a.id": edit.ID},
+			squirrel.Eq{"a.sender": edit.EditedBy},
+			squirrel.Eq{"a.is_valid": true},
+			squirrel.Expr("a.created_at >= (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT - ?", edit.EditWindow*time.Minute.Milliseconds()),
+			squirrel.Expr(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s r WHERE r.appreciation_id = a.id)", appr.RewardsTable)),
+			squirrel.Expr("NOT EXISTS (SELECT 1 FROM resolutions res WHERE res.appreciation_id = a.id)"),
+		}).
+		Suffix("RETURNING a.id, a.core_value_id, a.description, a.total_reward_points, a.quarter, a.sender, a.receiver, a.group_name, a.created_at, a.updated_at").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "appreciationRepo: error in generating squirrel query, err: %v", err)
+		return repository.Appreciation{}, apperrors.InternalServer
+	}
+
+	var resAppr repository.Appreciation
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&resAppr)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "appreciationRepo: appreciation %d is not editable", edit.ID)
+			return repository.Appreciation{}, apperrors.AppreciationNotEditable
+		}
+		logger.Errorf(ctx, "appreciationRepo: error in editing appreciation: %v", err)
+		return repository.Appreciation{}, apperrors.InternalServer
+	}
+
+	return resAppr, nil
+}
+
+func (appr *appreciationsStore) CreateAppreciationEdit(ctx context.Context, tx repository.Transaction, edit repository.AppreciationEdit) error {
+
+	logger.Debug(ctx, "appreciationRepo: CreateAppreciationEdit: edit: ", edit)
+	queryExecutor := appr.InitiateQueryExecutor(tx)
+
+	query, args, err := repository.Sq.Insert(appr.AppreciationEditsTable).
+		Columns("appreciation_id", "old_core_value_id", "new_core_value_id", "old_description", "new_description", "edited_by").
+		Values(edit.AppreciationID, edit.OldCoreValueID, edit.NewCoreValueID, edit.OldDescription, edit.NewDescription, edit.EditedBy).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "appreciationRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationRepo: error in inserting appreciation edit: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (appr *appreciationsStore) ListAppreciationEdits(ctx context.Context, tx repository.Transaction, apprId int64) ([]repository.AppreciationEditResponse, error) {
+
+	logger.Debug(ctx, "appreciationRepo: ListAppreciationEdits: apprId: ", apprId)
+	queryExecutor := appr.InitiateQueryExecutor(tx)
+
+	query, args, err := repository.Sq.Select(
+		"ae.id",
+		"ae.appreciation_id",
+		"ae.old_core_value_id",
+		"cv_old.name AS old_core_value_name",
+		"ae.new_core_value_id",
+		"cv_new.name AS new_core_value_name",
+		"ae.old_description",
+		"ae.new_description",
+		"ae.edited_by",
+		"u.first_name AS edited_by_first_name",
+		"u.last_name AS edited_by_last_name",
+		"ae.edited_at",
+	).From(appr.AppreciationEditsTable + " ae").
+		LeftJoin(appr.CoreValuesTable + " cv_old ON ae.old_core_value_id = cv_old.id").
+		LeftJoin(appr.CoreValuesTable + " cv_new ON ae.new_core_value_id = cv_new.id").
+		LeftJoin(appr.UsersTable + " u ON ae.edited_by = u.id").
+		Where(squirrel.Eq{"ae.appreciation_id": apprId}).
+		OrderBy("ae.edited_at ASC").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "appreciationRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	edits := make([]repository.AppreciationEditResponse, 0)
+	err = sqlx.Select(queryExecutor, &edits, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "appreciationRepo: error in listing appreciation edits: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return edits, nil
+}
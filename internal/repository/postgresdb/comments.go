@@ -0,0 +1,361 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+var commentResponseColumns = []string{
+	"c.id",
+	"c.appreciation_id",
+	"c.comment",
+	"c.commented_by",
+	"u.employee_id AS commenter_employee_id",
+	"u.first_name AS commenter_first_name",
+	"u.last_name AS commenter_last_name",
+	"u.profile_image_url AS commenter_image_url",
+	"u.designation AS commenter_designation",
+	"c.created_at",
+	"c.updated_at",
+}
+
+var reportedCommentColumns = []string{
+	"cr.id",
+	"c.id AS comment_id",
+	"c.appreciation_id",
+	"c.comment",
+	"c.is_valid",
+	"c.commented_by",
+	"u_commenter.first_name AS commenter_first_name",
+	"u_commenter.last_name AS commenter_last_name",
+	"cr.reporting_comment",
+	"cr.reported_by",
+	"u_reporter.first_name AS reported_by_first_name",
+	"u_reporter.last_name AS reported_by_last_name",
+	"cr.reported_at",
+	"cr.moderator_comment",
+	"u_moderator.first_name AS moderated_by_first_name",
+	"u_moderator.last_name AS moderated_by_last_name",
+	"cr.moderated_at",
+	"cr.status",
+}
+
+type commentStore struct {
+	BaseRepository
+	CommentsTable           string
+	CommentResolutionsTable string
+	UsersTable              string
+}
+
+func NewCommentRepo(db *sqlx.DB) repository.CommentStorer {
+	return &commentStore{
+		BaseRepository:          BaseRepository{db},
+		CommentsTable:           constants.CommentsTable,
+		CommentResolutionsTable: constants.CommentResolutionsTable,
+		UsersTable:              constants.UsersTable,
+	}
+}
+
+func (cs *commentStore) CreateComment(ctx context.Context, tx repository.Transaction, comment dto.Comment) (repository.Comment, error) {
+
+	logger.Debug(ctx, "commentRepo: CreateComment: ", comment)
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+
+	insertQuery, args, err := repository.Sq.
+		Insert(cs.CommentsTable).
+		Columns("appreciation_id", "comment", "commented_by").
+		Values(comment.AppreciationID, comment.Comment, comment.CommentedBy).
+		Suffix("RETURNING id, appreciation_id, comment, commented_by, is_valid, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in generating squirrel query, err: %v", err)
+		return repository.Comment{}, apperrors.InternalServer
+	}
+
+	var resComment repository.Comment
+	err = queryExecutor.QueryRowx(insertQuery, args...).StructScan(&resComment)
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error executing create comment insert query: %v", err)
+		return repository.Comment{}, apperrors.InternalServer
+	}
+
+	return resComment, nil
+}
+
+func (cs *commentStore) GetCommentById(ctx context.Context, tx repository.Transaction, commentId int64) (repository.CommentResponse, error) {
+
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(commentResponseColumns...).
+		From(cs.CommentsTable + " c").
+		LeftJoin(cs.UsersTable + " u ON c.commented_by = u.id").
+		Where(squirrel.And{
+			squirrel.Eq{"c.id": commentId},
+			squirrel.Eq{"c.is_valid": true},
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in generating squirrel query, err: %v", err)
+		return repository.CommentResponse{}, apperrors.InternalServer
+	}
+
+	var resComment repository.CommentResponse
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&resComment)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "commentRepo: no comment found with id: %d", commentId)
+			return repository.CommentResponse{}, apperrors.CommentNotFound
+		}
+		logger.Errorf(ctx, "commentRepo: failed to execute query: %v", err)
+		return repository.CommentResponse{}, apperrors.InternalServer
+	}
+
+	return resComment, nil
+}
+
+func (cs *commentStore) ListComments(ctx context.Context, tx repository.Transaction, filter dto.CommentFilter) ([]repository.CommentResponse, repository.Pagination, error) {
+
+	logger.Debug(ctx, "commentRepo: ListComments: filter: ", filter)
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+
+	queryBuilder := repository.Sq.Select("COUNT(*)").
+		From(cs.CommentsTable + " c").
+		LeftJoin(cs.UsersTable + " u ON c.commented_by = u.id").
+		Where(squirrel.And{
+			squirrel.Eq{"c.appreciation_id": filter.AppreciationID},
+			squirrel.Eq{"c.is_valid": true},
+		})
+
+	countSql, countArgs, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: failed to build count query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	var totalRecords int32
+	err = queryExecutor.QueryRowx(countSql, countArgs...).Scan(&totalRecords)
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: failed to execute count query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	pagination := getPaginationMetaData(filter.Page, filter.Limit, totalRecords)
+
+	offset := (filter.Page - 1) * filter.Limit
+	queryBuilder = queryBuilder.RemoveColumns().
+		Columns(commentResponseColumns...).
+		OrderBy("c.created_at ASC", "c.id ASC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(offset))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: failed to build query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	logger.Debug(ctx, "commentRepo: ListComments: query: ", query, ",args: ", args)
+	res := make([]repository.CommentResponse, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: failed to execute query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	return res, pagination, nil
+}
+
+func (cs *commentStore) UpdateComment(ctx context.Context, tx repository.Transaction, commentId int64, comment string) (repository.Comment, error) {
+
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(cs.CommentsTable).
+		Set("comment", comment).
+		Set("updated_at", squirrel.Expr("(EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT")).
+		Where(squirrel.And{
+			squirrel.Eq{"id": commentId},
+			squirrel.Eq{"is_valid": true},
+		}).
+		Suffix("RETURNING id, appreciation_id, comment, commented_by, is_valid, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in generating squirrel query, err: %v", err)
+		return repository.Comment{}, apperrors.InternalServer
+	}
+
+	var resComment repository.Comment
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&resComment)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.Comment{}, apperrors.CommentNotFound
+		}
+		logger.Errorf(ctx, "commentRepo: error in updating comment: %v", err)
+		return repository.Comment{}, apperrors.InternalServer
+	}
+
+	return resComment, nil
+}
+
+func (cs *commentStore) DeleteComment(ctx context.Context, tx repository.Transaction, commentId int64) error {
+
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(cs.CommentsTable).
+		Set("is_valid", false).
+		Where(squirrel.And{
+			squirrel.Eq{"id": commentId},
+			squirrel.Eq{"is_valid": true},
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	result, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error executing SQL: %v", err)
+		return apperrors.InternalServer
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn(ctx, "commentRepo: no rows affected")
+		return apperrors.CommentNotFound
+	}
+
+	return nil
+}
+
+func (cs *commentStore) CheckDuplicateCommentReport(ctx context.Context, tx repository.Transaction, commentId int64, reportedBy int64) (bool, error) {
+
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select("COUNT(*)").
+		From(cs.CommentResolutionsTable).
+		Where(squirrel.And{
+			squirrel.Eq{"comment_id": commentId},
+			squirrel.Eq{"reported_by": reportedBy},
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in generating squirrel query, err: %v", err)
+		return false, apperrors.InternalServer
+	}
+
+	var count int64
+	err = queryExecutor.QueryRowx(query, args...).Scan(&count)
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in looking for duplicate report, err: %v", err)
+		return false, apperrors.InternalServer
+	}
+
+	return count > 0, nil
+}
+
+func (cs *commentStore) ReportComment(ctx context.Context, tx repository.Transaction, reportReq dto.ReportCommentReq) (repository.CommentResolution, error) {
+
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Insert(cs.CommentResolutionsTable).
+		Columns("comment_id", "reporting_comment", "reported_by").
+		Values(reportReq.CommentID, reportReq.ReportingComment, reportReq.ReportedBy).
+		Suffix("RETURNING id, comment_id, reporting_comment, reported_by, reported_at").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in generating squirrel query, err: %v", err)
+		return repository.CommentResolution{}, apperrors.InternalServer
+	}
+
+	var resolution repository.CommentResolution
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&resolution)
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in creating report, err: %v", err)
+		return repository.CommentResolution{}, apperrors.InternalServer
+	}
+
+	return resolution, nil
+}
+
+func (cs *commentStore) reportedCommentsQuery() squirrel.SelectBuilder {
+	return repository.Sq.Select(reportedCommentColumns...).
+		From(cs.CommentResolutionsTable + " cr").
+		Join(cs.CommentsTable + " c ON cr.comment_id = c.id").
+		LeftJoin(cs.UsersTable + " u_commenter ON c.commented_by = u_commenter.id").
+		LeftJoin(cs.UsersTable + " u_reporter ON cr.reported_by = u_reporter.id").
+		LeftJoin(cs.UsersTable + " u_moderator ON cr.moderated_by = u_moderator.id")
+}
+
+func (cs *commentStore) ListReportedComments(ctx context.Context, tx repository.Transaction) ([]repository.ReportedComment, error) {
+
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+	query, args, err := cs.reportedCommentsQuery().OrderBy("cr.reported_at DESC").ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.ReportedComment, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in retriving reported comments, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (cs *commentStore) GetCommentResolution(ctx context.Context, tx repository.Transaction, resolutionId int64) (repository.ReportedComment, error) {
+
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+	query, args, err := cs.reportedCommentsQuery().Where(squirrel.Eq{"cr.id": resolutionId}).ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in generating squirrel query, err: %v", err)
+		return repository.ReportedComment{}, apperrors.InternalServer
+	}
+
+	var resolution repository.ReportedComment
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&resolution)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "commentRepo: no such comment resolution exists, id: %d", resolutionId)
+			return repository.ReportedComment{}, apperrors.InvalidId
+		}
+		logger.Errorf(ctx, "commentRepo: error in retriving reported comment, err: %v", err)
+		return repository.ReportedComment{}, apperrors.InternalServer
+	}
+
+	return resolution, nil
+}
+
+func (cs *commentStore) UpdateCommentResolution(ctx context.Context, tx repository.Transaction, moderationReq dto.CommentModerationReq, status string) error {
+
+	queryExecutor := cs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(cs.CommentResolutionsTable).
+		Set("moderator_comment", moderationReq.ModeratorComment).
+		Set("moderated_by", moderationReq.ModeratedBy).
+		Set("moderated_at", squirrel.Expr("(EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT")).
+		Set("status", status).
+		Where(squirrel.Eq{"id": moderationReq.ResolutionID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "commentRepo: error in updating moderation values, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+// appreciations received in the digest period that are listed in the email
+const digestRecentAppreciations = 3
+
+type digestStore struct {
+	BaseRepository
+	DigestSubscriptionsTable string
+	UsersTable               string
+}
+
+func NewDigestRepo(db *sqlx.DB) repository.DigestStorer {
+	return &digestStore{
+		BaseRepository:           BaseRepository{db},
+		DigestSubscriptionsTable: constants.DigestSubscriptionsTable,
+		UsersTable:               constants.UsersTable,
+	}
+}
+
+func (ds *digestStore) SubscribeToDigest(ctx context.Context, tx repository.Transaction, userID int64) error {
+
+	queryExecutor := ds.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Insert(ds.DigestSubscriptionsTable).
+		Columns("user_id").
+		Values(userID).
+		Suffix("ON CONFLICT (user_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: failed to subscribe user %d to the digest: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ds *digestStore) UnsubscribeFromDigest(ctx context.Context, tx repository.Transaction, userID int64) error {
+
+	queryExecutor := ds.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(ds.DigestSubscriptionsTable).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: failed to unsubscribe user %d from the digest: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ds *digestStore) IsSubscribedToDigest(ctx context.Context, tx repository.Transaction, userID int64) (bool, error) {
+
+	queryExecutor := ds.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select("COUNT(*)").
+		From(ds.DigestSubscriptionsTable).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: error in generating squirrel query, err: %v", err)
+		return false, apperrors.InternalServer
+	}
+
+	var count int64
+	err = queryExecutor.QueryRowx(query, args...).Scan(&count)
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: failed to get digest subscription of user %d: %v", userID, err)
+		return false, apperrors.InternalServer
+	}
+
+	return count > 0, nil
+}
+
+func (ds *digestStore) ListDigestSubscribers(ctx context.Context, tx repository.Transaction) ([]repository.DigestSubscriber, error) {
+
+	queryExecutor := ds.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select("u.id", "u.first_name", "u.last_name", "u.email").
+		From(ds.DigestSubscriptionsTable + " ds").
+		Join(ds.UsersTable + " u ON u.id = ds.user_id").
+		OrderBy("u.id").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.DigestSubscriber, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: failed to list digest subscribers: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ds *digestStore) GetDigestActivity(ctx context.Context, tx repository.Transaction, userID int64, since int64, quarterStart int64) (repository.DigestActivity, error) {
+
+	logger.Debug(ctx, "digestRepo: GetDigestActivity: userID: ", userID, " since: ", since)
+	queryExecutor := ds.InitiateQueryExecutor(tx)
+
+	// a team appreciation counts once for every receiver, reward points are the receiver's share as for the badges
+	activityQuery := `
+	SELECT
+	    (
+	        SELECT COUNT(*)
+	        FROM appreciation_receivers ar
+	        JOIN appreciations a ON a.id = ar.appreciation_id
+	        WHERE ar.receiver = $1 AND a.is_valid = true AND a.created_at >= $2
+	    ) AS appreciations_received,
+	    (
+	        SELECT COUNT(*)
+	        FROM appreciations
+	        WHERE sender = $1 AND is_valid = true AND created_at >= $2
+	    ) AS appreciations_given,
+	    (
+	        SELECT COUNT(*)
+	        FROM rewards r
+	        JOIN appreciation_receivers ar ON ar.appreciation_id = r.appreciation_id
+	        WHERE ar.receiver = $1 AND r.created_at >= $2
+	    ) AS rewards_received,
+	    (
+	        SELECT COALESCE(SUM(reward_points), 0)
+	        FROM appreciation_receiver_points
+	        WHERE receiver = $1 AND is_valid = true AND created_at >= $3
+	    ) AS quarter_points`
+
+	var activity repository.DigestActivity
+	err := queryExecutor.QueryRowx(activityQuery, userID, since, quarterStart).StructScan(&activity)
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: failed to get digest activity of user %d: %v", userID, err)
+		return repository.DigestActivity{}, apperrors.InternalServer
+	}
+
+	nextBadgeQuery := `
+	SELECT name AS next_badge_name, reward_points AS next_badge_points
+	FROM badges
+	WHERE reward_points > $1
+	ORDER BY reward_points ASC
+	LIMIT 1`
+
+	err = queryExecutor.QueryRowx(nextBadgeQuery, activity.QuarterPoints).StructScan(&activity)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Errorf(ctx, "digestRepo: failed to get next badge of user %d: %v", userID, err)
+		return repository.DigestActivity{}, apperrors.InternalServer
+	}
+
+	recentQuery := `
+	SELECT u.first_name, u.last_name, cv.name AS core_value_name, a.description, a.created_at
+	FROM appreciation_receivers ar
+	JOIN appreciations a ON a.id = ar.appreciation_id
+	JOIN users u ON u.id = a.sender
+	JOIN core_values cv ON cv.id = a.core_value_id
+	WHERE ar.receiver = $1 AND a.is_valid = true AND a.created_at >= $2
+	ORDER BY a.created_at DESC
+	LIMIT $3`
+
+	activity.RecentAppreciations = make([]repository.DigestAppreciation, 0)
+	err = sqlx.Select(queryExecutor, &activity.RecentAppreciations, recentQuery, userID, since, digestRecentAppreciations)
+	if err != nil {
+		logger.Errorf(ctx, "digestRepo: failed to get recent appreciations of user %d: %v", userID, err)
+		return repository.DigestActivity{}, apperrors.InternalServer
+	}
+
+	return activity, nil
+}
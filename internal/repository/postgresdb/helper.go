@@ -3,6 +3,8 @@ package repository
 import (
 	"time"
 
+	"github.com/Masterminds/squirrel"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
 	"github.com/joshsoftware/peerly-backend/internal/repository"
 )
 
@@ -31,3 +33,15 @@ func GetQuarterStartUnixTime() int64 {
 	quarterStart := time.Date(now.Year(), (now.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
 	return quarterStart.Unix() * 1000 // convert to milliseconds
 }
+
+// orgFilterCondition matches the users, whose columns are prefixed with prefix, to the department and team of the filter
+func orgFilterCondition(prefix string, filter dto.OrgFilter) squirrel.Eq {
+	condition := squirrel.Eq{}
+	if filter.DepartmentID != 0 {
+		condition[prefix+"department_id"] = filter.DepartmentID
+	}
+	if filter.TeamID != 0 {
+		condition[prefix+"team_id"] = filter.TeamID
+	}
+	return condition
+}
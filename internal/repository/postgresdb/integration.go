@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+	"github.com/lib/pq"
+)
+
+var integrationColumns = []string{
+	"id",
+	"kind",
+	"name",
+	"webhook_url",
+	"core_value_ids",
+	"created_by",
+	"created_at",
+}
+
+type integrationStore struct {
+	BaseRepository
+	IntegrationsTable              string
+	UsersTable                     string
+	AppreciationReceiverPointsView string
+}
+
+func NewIntegrationRepo(db *sqlx.DB) repository.IntegrationStorer {
+	return &integrationStore{
+		BaseRepository:                 BaseRepository{db},
+		IntegrationsTable:              constants.IntegrationsTable,
+		UsersTable:                     constants.UsersTable,
+		AppreciationReceiverPointsView: constants.AppreciationReceiverPointsView,
+	}
+}
+
+func (is *integrationStore) CreateIntegration(ctx context.Context, tx repository.Transaction, integration repository.Integration) (repository.Integration, error) {
+
+	logger.Debug(ctx, "integrationRepo: CreateIntegration: kind: ", integration.Kind, " name: ", integration.Name)
+	queryExecutor := is.InitiateQueryExecutor(tx)
+
+	coreValueIDs := integration.CoreValueIDs
+	if coreValueIDs == nil {
+		coreValueIDs = pq.Int64Array{}
+	}
+
+	query, args, err := repository.Sq.Insert(is.IntegrationsTable).
+		Columns("kind", "name", "webhook_url", "core_value_ids", "created_by").
+		Values(integration.Kind, integration.Name, integration.WebhookURL, coreValueIDs, integration.CreatedBy).
+		Suffix("RETURNING " + strings.Join(integrationColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: error in generating squirrel query, err: %v", err)
+		return repository.Integration{}, apperrors.InternalServer
+	}
+
+	var res repository.Integration
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&res)
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: error executing create integration query: %v", err)
+		return repository.Integration{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (is *integrationStore) ListIntegrations(ctx context.Context, tx repository.Transaction) ([]repository.Integration, error) {
+	return is.listIntegrations(ctx, tx, squirrel.And{})
+}
+
+func (is *integrationStore) ListIntegrationsForCoreValue(ctx context.Context, tx repository.Transaction, coreValueID int64) ([]repository.Integration, error) {
+	return is.listIntegrations(ctx, tx, squirrel.Or{
+		squirrel.Expr("cardinality(core_value_ids) = 0"),
+		squirrel.Expr("? = ANY(core_value_ids)", coreValueID),
+	})
+}
+
+func (is *integrationStore) listIntegrations(ctx context.Context, tx repository.Transaction, conditions squirrel.Sqlizer) ([]repository.Integration, error) {
+
+	queryExecutor := is.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(integrationColumns...).
+		From(is.IntegrationsTable).
+		Where(conditions).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.Integration, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: failed to list integrations: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (is *integrationStore) GetIntegration(ctx context.Context, tx repository.Transaction, id int64) (repository.Integration, error) {
+
+	queryExecutor := is.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(integrationColumns...).
+		From(is.IntegrationsTable).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: error in generating squirrel query, err: %v", err)
+		return repository.Integration{}, apperrors.InternalServer
+	}
+
+	var res repository.Integration
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&res)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "integrationRepo: no integration found with id: %d", id)
+			return repository.Integration{}, apperrors.IntegrationNotFound
+		}
+		logger.Errorf(ctx, "integrationRepo: failed to get integration %d: %v", id, err)
+		return repository.Integration{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (is *integrationStore) DeleteIntegration(ctx context.Context, tx repository.Transaction, id int64) error {
+
+	queryExecutor := is.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(is.IntegrationsTable).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: failed to delete integration %d: %v", id, err)
+		return apperrors.InternalServer
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+	if rowsAffected == 0 {
+		return apperrors.IntegrationNotFound
+	}
+
+	return nil
+}
+
+func (is *integrationStore) ListQuarterWinners(ctx context.Context, tx repository.Transaction, from int64, to int64, limit int) ([]repository.QuarterWinner, error) {
+
+	queryExecutor := is.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select("u.id", "u.first_name", "u.last_name", "SUM(arp.reward_points) AS points").
+		From(is.AppreciationReceiverPointsView+" arp").
+		Join(is.UsersTable+" u ON u.id = arp.receiver").
+		Where(squirrel.And{
+			squirrel.Eq{"arp.is_valid": true},
+			squirrel.GtOrEq{"arp.created_at": from},
+			squirrel.Lt{"arp.created_at": to},
+		}).
+		GroupBy("u.id", "u.first_name", "u.last_name").
+		OrderBy("points DESC", "u.id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.QuarterWinner, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "integrationRepo: failed to list quarter winners: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+var notificationColumns = []string{
+	"id",
+	"user_id",
+	"type",
+	"title",
+	"body",
+	"appreciation_id",
+	"read_at",
+	"created_at",
+}
+
+type notificationStore struct {
+	BaseRepository
+	NotificationsTable     string
+	UsersTable             string
+	NotificationPrefsTable string
+}
+
+func NewNotificationRepo(db *sqlx.DB) repository.NotificationStorer {
+	return &notificationStore{
+		BaseRepository:         BaseRepository{db},
+		NotificationsTable:     constants.NotificationsTable,
+		UsersTable:             constants.UsersTable,
+		NotificationPrefsTable: constants.NotificationPrefsTable,
+	}
+}
+
+func (ns *notificationStore) CreateNotifications(ctx context.Context, tx repository.Transaction, notifications []dto.Notification) error {
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	logger.Debug(ctx, "notificationRepo: CreateNotifications: ", notifications)
+	queryExecutor := ns.InitiateQueryExecutor(tx)
+
+	userIDs := make([]int64, 0, len(notifications))
+	for _, notification := range notifications {
+		userIDs = append(userIDs, notification.UserID)
+	}
+
+	// users who turned the inbox off for an event don't get its notifications
+	optedOutQuery, optedOutArgs, err := repository.Sq.Select("user_id", "event").
+		From(ns.NotificationPrefsTable).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userIDs},
+			squirrel.Eq{"in_app": false},
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	optedOut := make([]repository.NotificationPreference, 0)
+	err = sqlx.Select(queryExecutor, &optedOut, optedOutQuery, optedOutArgs...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error executing inbox preferences query: %v", err)
+		return apperrors.InternalServer
+	}
+
+	insertQuery := repository.Sq.
+		Insert(ns.NotificationsTable).
+		Columns("user_id", "type", "title", "body", "appreciation_id")
+	inserted := 0
+	for _, notification := range notifications {
+		if slices.ContainsFunc(optedOut, func(preference repository.NotificationPreference) bool {
+			return preference.UserID == notification.UserID && preference.Event == notification.Type
+		}) {
+			continue
+		}
+		inserted++
+
+		var appreciationID interface{}
+		if notification.AppreciationID != 0 {
+			appreciationID = notification.AppreciationID
+		}
+		insertQuery = insertQuery.Values(notification.UserID, notification.Type, notification.Title, notification.Body, appreciationID)
+	}
+	if inserted == 0 {
+		return nil
+	}
+
+	query, args, err := insertQuery.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error executing create notifications query: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ns *notificationStore) CreateNotificationForAllUsers(ctx context.Context, tx repository.Transaction, notification dto.Notification) error {
+
+	logger.Debug(ctx, "notificationRepo: CreateNotificationForAllUsers: ", notification)
+	queryExecutor := ns.InitiateQueryExecutor(tx)
+
+	// the subqueries use plain squirrel so the insert numbers all of their args in order
+	optedOut := squirrel.Select("1").
+		From(ns.NotificationPrefsTable + " p").
+		Where(squirrel.And{
+			squirrel.Expr(fmt.Sprintf("p.user_id = %s.id", ns.UsersTable)),
+			squirrel.Eq{"p.event": notification.Type},
+			squirrel.Eq{"p.in_app": false},
+		})
+
+	usersQuery := squirrel.
+		Select("id").
+		Column(squirrel.Expr("?", notification.Type)).
+		Column(squirrel.Expr("?", notification.Title)).
+		Column(squirrel.Expr("?", notification.Body)).
+		From(ns.UsersTable).
+		Where(squirrel.Eq{"status": constants.ActiveUserStatus}).
+		Where(squirrel.Expr("NOT EXISTS (?)", optedOut))
+
+	query, args, err := repository.Sq.
+		Insert(ns.NotificationsTable).
+		Columns("user_id", "type", "title", "body").
+		Select(usersQuery).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error executing create notification for all users query: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ns *notificationStore) ListNotifications(ctx context.Context, tx repository.Transaction, filter dto.NotificationFilter) ([]repository.Notification, repository.Pagination, error) {
+
+	logger.Debug(ctx, "notificationRepo: ListNotifications: filter: ", filter)
+	queryExecutor := ns.InitiateQueryExecutor(tx)
+
+	conditions := squirrel.And{squirrel.Eq{"user_id": filter.UserID}}
+	if filter.UnreadOnly {
+		conditions = append(conditions, squirrel.Eq{"read_at": nil})
+	}
+
+	queryBuilder := repository.Sq.Select("COUNT(*)").
+		From(ns.NotificationsTable).
+		Where(conditions)
+
+	countSql, countArgs, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: failed to build count query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	var totalRecords int32
+	err = queryExecutor.QueryRowx(countSql, countArgs...).Scan(&totalRecords)
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: failed to execute count query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	pagination := getPaginationMetaData(filter.Page, filter.Limit, totalRecords)
+
+	offset := (filter.Page - 1) * filter.Limit
+	queryBuilder = queryBuilder.RemoveColumns().
+		Columns(notificationColumns...).
+		OrderBy("id DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(offset))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: failed to build query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	res := make([]repository.Notification, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: failed to execute query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	return res, pagination, nil
+}
+
+func (ns *notificationStore) CountUnreadNotifications(ctx context.Context, tx repository.Transaction, userID int64) (int64, error) {
+
+	queryExecutor := ns.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select("COUNT(*)").
+		From(ns.NotificationsTable).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.Eq{"read_at": nil},
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: failed to build count query: %v", err)
+		return 0, apperrors.InternalServerError
+	}
+
+	var unread int64
+	err = queryExecutor.QueryRowx(query, args...).Scan(&unread)
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: failed to count unread notifications: %v", err)
+		return 0, apperrors.InternalServerError
+	}
+
+	return unread, nil
+}
+
+func (ns *notificationStore) MarkNotificationRead(ctx context.Context, tx repository.Transaction, userID int64, notificationID int64) error {
+
+	queryExecutor := ns.InitiateQueryExecutor(tx)
+	// reading an already read notification keeps the time it was first read
+	query, args, err := repository.Sq.Update(ns.NotificationsTable).
+		Set("read_at", squirrel.Expr("COALESCE(read_at, "+nowMillis+")")).
+		Where(squirrel.And{
+			squirrel.Eq{"id": notificationID},
+			squirrel.Eq{"user_id": userID},
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: failed to mark notification %d read: %v", notificationID, err)
+		return apperrors.InternalServer
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+	if rowsAffected == 0 {
+		return apperrors.NotificationNotFound
+	}
+
+	return nil
+}
+
+func (ns *notificationStore) MarkAllNotificationsRead(ctx context.Context, tx repository.Transaction, userID int64) error {
+
+	queryExecutor := ns.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(ns.NotificationsTable).
+		Set("read_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.Eq{"read_at": nil},
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: failed to mark notifications of user %d read: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ns *notificationStore) DeleteNotification(ctx context.Context, tx repository.Transaction, userID int64, notificationID int64) error {
+
+	queryExecutor := ns.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(ns.NotificationsTable).
+		Where(squirrel.And{
+			squirrel.Eq{"id": notificationID},
+			squirrel.Eq{"user_id": userID},
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: failed to delete notification %d: %v", notificationID, err)
+		return apperrors.InternalServer
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "notificationRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+	if rowsAffected == 0 {
+		return apperrors.NotificationNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+var notificationPreferenceColumns = []string{
+	"user_id",
+	"event",
+	"push",
+	"email",
+	"in_app",
+	"updated_at",
+}
+
+type notificationPreferenceStore struct {
+	BaseRepository
+	NotificationPrefsTable  string
+	NotificationTokensTable string
+}
+
+func NewNotificationPreferenceRepo(db *sqlx.DB) repository.NotificationPreferenceStorer {
+	return &notificationPreferenceStore{
+		BaseRepository:          BaseRepository{db},
+		NotificationPrefsTable:  constants.NotificationPrefsTable,
+		NotificationTokensTable: constants.NotificationTokensTable,
+	}
+}
+
+func (nps *notificationPreferenceStore) ListNotificationPreferences(ctx context.Context, tx repository.Transaction, userID int64) ([]repository.NotificationPreference, error) {
+
+	queryExecutor := nps.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(notificationPreferenceColumns...).
+		From(nps.NotificationPrefsTable).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationPreferenceRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.NotificationPreference, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationPreferenceRepo: failed to list preferences of user %d: %v", userID, err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (nps *notificationPreferenceStore) UpsertNotificationPreferences(ctx context.Context, tx repository.Transaction, preferences []repository.NotificationPreference) error {
+
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	logger.Debug(ctx, "notificationPreferenceRepo: UpsertNotificationPreferences: ", preferences)
+	queryExecutor := nps.InitiateQueryExecutor(tx)
+
+	insertQuery := repository.Sq.Insert(nps.NotificationPrefsTable).
+		Columns("user_id", "event", "push", "email", "in_app")
+	for _, preference := range preferences {
+		insertQuery = insertQuery.Values(preference.UserID, preference.Event, preference.Push, preference.Email, preference.InApp)
+	}
+
+	query, args, err := insertQuery.
+		Suffix("ON CONFLICT (user_id, event) DO UPDATE SET push = EXCLUDED.push, email = EXCLUDED.email, in_app = EXCLUDED.in_app, updated_at = " + nowMillis).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationPreferenceRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationPreferenceRepo: error executing upsert preferences query: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (nps *notificationPreferenceStore) IsChannelEnabled(ctx context.Context, tx repository.Transaction, userID int64, event string, channel string) (bool, error) {
+
+	// the channel is used as the column name, so it must be one we know of
+	if !slices.Contains(constants.NotificationChannels, channel) {
+		logger.Errorf(ctx, "notificationPreferenceRepo: unknown channel: %s", channel)
+		return false, apperrors.InvalidNotificationChannel
+	}
+
+	queryExecutor := nps.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(channel).
+		From(nps.NotificationPrefsTable).
+		Where(squirrel.Eq{"user_id": userID, "event": event}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationPreferenceRepo: error in generating squirrel query, err: %v", err)
+		return false, apperrors.InternalServer
+	}
+
+	var enabled bool
+	err = queryExecutor.QueryRowx(query, args...).Scan(&enabled)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return true, nil
+		}
+		logger.Errorf(ctx, "notificationPreferenceRepo: failed to get %s preference of user %d for %s: %v", channel, userID, event, err)
+		return false, apperrors.InternalServer
+	}
+
+	return enabled, nil
+}
+
+func (nps *notificationPreferenceStore) ListDeviceTokensForEvent(ctx context.Context, tx repository.Transaction, event string) ([]string, error) {
+
+	queryExecutor := nps.InitiateQueryExecutor(tx)
+	optedOut := squirrel.Select("1").
+		From(nps.NotificationPrefsTable + " p").
+		Where(squirrel.And{
+			squirrel.Expr(fmt.Sprintf("p.user_id = %s.user_id", nps.NotificationTokensTable)),
+			squirrel.Eq{"p.event": event},
+			squirrel.Eq{"p.push": false},
+		})
+
+	query, args, err := repository.Sq.Select("notification_token").
+		From(nps.NotificationTokensTable).
+		Where(squirrel.Expr("NOT EXISTS (?)", optedOut)).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "notificationPreferenceRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	tokens := make([]string, 0)
+	err = sqlx.Select(queryExecutor, &tokens, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "notificationPreferenceRepo: failed to list device tokens for %s: %v", event, err)
+		return nil, apperrors.InternalServer
+	}
+
+	return tokens, nil
+}
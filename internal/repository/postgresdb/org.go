@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+var (
+	departmentColumns = []string{"id", "name", "created_by", "created_at"}
+	teamColumns       = []string{"id", "department_id", "name", "created_by", "created_at"}
+)
+
+type orgStore struct {
+	BaseRepository
+	DepartmentsTable               string
+	TeamsTable                     string
+	UsersTable                     string
+	AppreciationsTable             string
+	AppreciationReceiverPointsView string
+}
+
+func NewOrgRepo(db *sqlx.DB) repository.OrgStorer {
+	return &orgStore{
+		BaseRepository:                 BaseRepository{db},
+		DepartmentsTable:               constants.DepartmentsTable,
+		TeamsTable:                     constants.TeamsTable,
+		UsersTable:                     constants.UsersTable,
+		AppreciationsTable:             constants.AppreciationsTable,
+		AppreciationReceiverPointsView: constants.AppreciationReceiverPointsView,
+	}
+}
+
+func (ors *orgStore) CreateDepartment(ctx context.Context, tx repository.Transaction, department repository.Department) (repository.Department, error) {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Insert(ors.DepartmentsTable).
+		Columns("name", "created_by").
+		Values(department.Name, department.CreatedBy).
+		Suffix("RETURNING " + strings.Join(departmentColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return repository.Department{}, apperrors.InternalServer
+	}
+
+	var res repository.Department
+	err = sqlx.Get(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: failed to create department %s: %v", department.Name, err)
+		return repository.Department{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ors *orgStore) ListDepartments(ctx context.Context, tx repository.Transaction) ([]repository.Department, error) {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(departmentColumns...).
+		From(ors.DepartmentsTable).
+		OrderBy("name", "id").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.Department, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: failed to list departments: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ors *orgStore) GetDepartment(ctx context.Context, tx repository.Transaction, id int64) (repository.Department, error) {
+	return ors.getDepartment(ctx, tx, squirrel.Eq{"id": id})
+}
+
+func (ors *orgStore) GetDepartmentByName(ctx context.Context, tx repository.Transaction, name string) (repository.Department, error) {
+	return ors.getDepartment(ctx, tx, squirrel.Eq{"LOWER(name)": strings.ToLower(name)})
+}
+
+func (ors *orgStore) getDepartment(ctx context.Context, tx repository.Transaction, where squirrel.Eq) (repository.Department, error) {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(departmentColumns...).
+		From(ors.DepartmentsTable).
+		Where(where).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return repository.Department{}, apperrors.InternalServer
+	}
+
+	var res repository.Department
+	err = sqlx.Get(queryExecutor, &res, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.Department{}, apperrors.DepartmentNotFound
+		}
+		logger.Errorf(ctx, "orgRepo: failed to get department %v: %v", where, err)
+		return repository.Department{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ors *orgStore) UpdateDepartment(ctx context.Context, tx repository.Transaction, id int64, name string) (repository.Department, error) {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(ors.DepartmentsTable).
+		Set("name", name).
+		Where(squirrel.Eq{"id": id}).
+		Suffix("RETURNING " + strings.Join(departmentColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return repository.Department{}, apperrors.InternalServer
+	}
+
+	var res repository.Department
+	err = sqlx.Get(queryExecutor, &res, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.Department{}, apperrors.DepartmentNotFound
+		}
+		logger.Errorf(ctx, "orgRepo: failed to update department %d: %v", id, err)
+		return repository.Department{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ors *orgStore) DeleteDepartment(ctx context.Context, tx repository.Transaction, id int64) error {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(ors.DepartmentsTable).
+		Where(squirrel.Eq{"id": id}).
+		Where("NOT EXISTS (SELECT 1 FROM "+ors.TeamsTable+" WHERE department_id = ?)", id).
+		Where("NOT EXISTS (SELECT 1 FROM "+ors.UsersTable+" WHERE department_id = ?)", id).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: failed to delete department %d: %v", id, err)
+		return apperrors.InternalServer
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+	if rowsAffected == 0 {
+		_, err = ors.GetDepartment(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return apperrors.DepartmentInUse
+	}
+
+	return nil
+}
+
+func (ors *orgStore) CreateTeam(ctx context.Context, tx repository.Transaction, team repository.Team) (repository.Team, error) {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Insert(ors.TeamsTable).
+		Columns("department_id", "name", "created_by").
+		Values(team.DepartmentID, team.Name, team.CreatedBy).
+		Suffix("RETURNING " + strings.Join(teamColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return repository.Team{}, apperrors.InternalServer
+	}
+
+	var res repository.Team
+	err = sqlx.Get(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: failed to create team %s in department %d: %v", team.Name, team.DepartmentID, err)
+		return repository.Team{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ors *orgStore) ListTeams(ctx context.Context, tx repository.Transaction, departmentID int64) ([]repository.Team, error) {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	queryBuilder := repository.Sq.Select(teamColumns...).
+		From(ors.TeamsTable).
+		OrderBy("name", "id")
+	if departmentID != 0 {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"department_id": departmentID})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.Team, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: failed to list teams of department %d: %v", departmentID, err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ors *orgStore) GetTeam(ctx context.Context, tx repository.Transaction, id int64) (repository.Team, error) {
+	return ors.getTeam(ctx, tx, squirrel.Eq{"id": id})
+}
+
+func (ors *orgStore) GetTeamByName(ctx context.Context, tx repository.Transaction, departmentID int64, name string) (repository.Team, error) {
+	return ors.getTeam(ctx, tx, squirrel.Eq{"department_id": departmentID, "LOWER(name)": strings.ToLower(name)})
+}
+
+func (ors *orgStore) getTeam(ctx context.Context, tx repository.Transaction, where squirrel.Eq) (repository.Team, error) {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(teamColumns...).
+		From(ors.TeamsTable).
+		Where(where).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return repository.Team{}, apperrors.InternalServer
+	}
+
+	var res repository.Team
+	err = sqlx.Get(queryExecutor, &res, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.Team{}, apperrors.TeamNotFound
+		}
+		logger.Errorf(ctx, "orgRepo: failed to get team %v: %v", where, err)
+		return repository.Team{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ors *orgStore) UpdateTeam(ctx context.Context, tx repository.Transaction, id int64, name string) (repository.Team, error) {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(ors.TeamsTable).
+		Set("name", name).
+		Where(squirrel.Eq{"id": id}).
+		Suffix("RETURNING " + strings.Join(teamColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return repository.Team{}, apperrors.InternalServer
+	}
+
+	var res repository.Team
+	err = sqlx.Get(queryExecutor, &res, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.Team{}, apperrors.TeamNotFound
+		}
+		logger.Errorf(ctx, "orgRepo: failed to update team %d: %v", id, err)
+		return repository.Team{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ors *orgStore) DeleteTeam(ctx context.Context, tx repository.Transaction, id int64) error {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(ors.TeamsTable).
+		Where(squirrel.Eq{"id": id}).
+		Where("NOT EXISTS (SELECT 1 FROM "+ors.UsersTable+" WHERE team_id = ?)", id).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: failed to delete team %d: %v", id, err)
+		return apperrors.InternalServer
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+	if rowsAffected == 0 {
+		_, err = ors.GetTeam(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return apperrors.TeamInUse
+	}
+
+	return nil
+}
+
+func (ors *orgStore) UpdateUserHierarchy(ctx context.Context, tx repository.Transaction, userID int64, hierarchy repository.UserHierarchy, updatedBy int64) error {
+
+	// updated by the intranet sync when no user is given
+	updater := sql.NullInt64{Int64: updatedBy, Valid: updatedBy != 0}
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(ors.UsersTable).
+		Set("department_id", hierarchy.DepartmentID).
+		Set("team_id", hierarchy.TeamID).
+		Set("manager_id", hierarchy.ManagerID).
+		Set("updated_by", updater).
+		Set("updated_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"id": userID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: failed to update hierarchy of user %d: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+	if rowsAffected == 0 {
+		return apperrors.UserNotFound
+	}
+
+	return nil
+}
+
+func (ors *orgStore) ListTeamMembers(ctx context.Context, tx repository.Transaction, managerID int64, from int64) ([]repository.TeamMember, error) {
+
+	queryExecutor := ors.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(
+		"u.id",
+		"u.employee_id",
+		"u.first_name",
+		"u.last_name",
+		"u.email",
+		"u.profile_image_url",
+		"u.designation",
+		"u.department_id",
+		"u.team_id",
+	).
+		Column("COALESCE((SELECT SUM(arp.reward_points) FROM "+ors.AppreciationReceiverPointsView+" arp WHERE arp.receiver = u.id AND arp.is_valid = true AND arp.created_at >= ?), 0) AS appreciation_points", from).
+		Column("(SELECT COUNT(*) FROM "+ors.AppreciationsTable+" a WHERE a.sender = u.id AND a.is_valid = true AND a.created_at >= ?) AS appreciations_sent", from).
+		From(ors.UsersTable+" u").
+		Where(squirrel.Eq{"u.manager_id": managerID, "u.status": constants.ActiveUserStatus}).
+		OrderBy("u.first_name", "u.last_name", "u.id").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.TeamMember, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "orgRepo: failed to list team of manager %d: %v", managerID, err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
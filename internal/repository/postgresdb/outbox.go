@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/config"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+var outboxColumns = []string{
+	"id",
+	"kind",
+	"payload",
+	"status",
+	"attempts",
+	"max_attempts",
+	"next_attempt_at",
+	"last_error",
+	"created_at",
+	"updated_at",
+	"sent_at",
+}
+
+const nowMillis = "(EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT"
+
+type outboxStore struct {
+	BaseRepository
+	OutboxTable               string
+	WebhookSubscriptionsTable string
+	WebhookDeliveriesTable    string
+}
+
+func NewOutboxRepo(db *sqlx.DB) repository.OutboxStorer {
+	return &outboxStore{
+		BaseRepository:            BaseRepository{db},
+		OutboxTable:               constants.OutboxTable,
+		WebhookSubscriptionsTable: constants.WebhookSubscriptionsTable,
+		WebhookDeliveriesTable:    constants.WebhookDeliveriesTable,
+	}
+}
+
+func (obs *outboxStore) EnqueueOutboxMessage(ctx context.Context, tx repository.Transaction, kind string, payload interface{}) error {
+
+	logger.Debug(ctx, "outboxRepo: EnqueueOutboxMessage: kind: ", kind, " payload: ", payload)
+	queryExecutor := obs.InitiateQueryExecutor(tx)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error in marshalling outbox payload, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	insertQuery, args, err := repository.Sq.
+		Insert(obs.OutboxTable).
+		Columns("kind", "payload", "max_attempts").
+		Values(kind, data, config.OutboxMaxAttempts()).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(insertQuery, args...)
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error executing enqueue outbox message query: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (obs *outboxStore) EnqueueWebhookEvent(ctx context.Context, tx repository.Transaction, event string, data interface{}) error {
+
+	logger.Debug(ctx, "outboxRepo: EnqueueWebhookEvent: event: ", event, " data: ", data)
+	queryExecutor := obs.InitiateQueryExecutor(tx)
+
+	eventID := uuid.NewString()
+	payload, err := json.Marshal(dto.WebhookEvent{
+		ID:        eventID,
+		Event:     event,
+		CreatedAt: time.Now().UnixMilli(),
+		Data:      data,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error in marshalling webhook event, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	// every subscription of the event gets its own delivery, which the outbox delivers and retries.
+	// The nested queries keep ? placeholders so the statement is numbered once as a whole.
+	deliveriesQuery := squirrel.Insert(obs.WebhookDeliveriesTable).
+		Columns("subscription_id", "event_id", "event", "payload").
+		Select(squirrel.Select("id").
+			Column(squirrel.Expr("?::TEXT", eventID)).
+			Column(squirrel.Expr("?::TEXT", event)).
+			Column(squirrel.Expr("?::JSONB", string(payload))).
+			From(obs.WebhookSubscriptionsTable).
+			Where(squirrel.Expr("?::TEXT = ANY(events)", event))).
+		Suffix("RETURNING id")
+
+	query, args, err := repository.Sq.Insert(obs.OutboxTable).
+		PrefixExpr(squirrel.Expr("WITH deliveries AS (?)", deliveriesQuery)).
+		Columns("kind", "payload", "max_attempts").
+		Select(squirrel.Select().
+			Column(squirrel.Expr("?::TEXT", constants.OutboxWebhookEvent)).
+			Column("JSONB_BUILD_OBJECT('delivery_id', id)").
+			Column(squirrel.Expr("?::INT", config.OutboxMaxAttempts())).
+			From("deliveries")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error executing enqueue webhook event query: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (obs *outboxStore) ClaimDueOutboxMessages(ctx context.Context, tx repository.Transaction, limit int, lockedUntil int64) ([]repository.OutboxMessage, error) {
+
+	queryExecutor := obs.InitiateQueryExecutor(tx)
+
+	// plain squirrel so the subquery args are numbered after the ones of the update
+	dueQuery := squirrel.Select("id").
+		From(obs.OutboxTable).
+		Where(squirrel.And{
+			squirrel.Eq{"status": constants.OutboxPending},
+			squirrel.Expr("next_attempt_at <= " + nowMillis),
+		}).
+		OrderBy("next_attempt_at ASC", "id ASC").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query, args, err := repository.Sq.Update(obs.OutboxTable).
+		Set("next_attempt_at", lockedUntil).
+		Set("updated_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Expr("id IN (?)", dueQuery)).
+		Suffix("RETURNING " + strings.Join(outboxColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.OutboxMessage, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: failed to claim due outbox messages: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (obs *outboxStore) MarkOutboxMessageSent(ctx context.Context, tx repository.Transaction, id int64) error {
+
+	queryExecutor := obs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(obs.OutboxTable).
+		Set("status", constants.OutboxSent).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("last_error", nil).
+		Set("sent_at", squirrel.Expr(nowMillis)).
+		Set("updated_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: failed to mark outbox message %d sent: %v", id, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (obs *outboxStore) MarkOutboxMessageFailed(ctx context.Context, tx repository.Transaction, id int64, lastError string, nextAttemptAt int64, dead bool) error {
+
+	status := constants.OutboxPending
+	if dead {
+		status = constants.OutboxDead
+	}
+
+	queryExecutor := obs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(obs.OutboxTable).
+		Set("status", status).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("last_error", lastError).
+		Set("next_attempt_at", nextAttemptAt).
+		Set("updated_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: failed to mark outbox message %d failed: %v", id, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (obs *outboxStore) ListOutboxMessages(ctx context.Context, tx repository.Transaction, filter dto.OutboxFilter) ([]repository.OutboxMessage, repository.Pagination, error) {
+
+	logger.Debug(ctx, "outboxRepo: ListOutboxMessages: filter: ", filter)
+	queryExecutor := obs.InitiateQueryExecutor(tx)
+
+	queryBuilder := repository.Sq.Select("COUNT(*)").
+		From(obs.OutboxTable).
+		Where(squirrel.Eq{"status": filter.Status})
+
+	countSql, countArgs, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: failed to build count query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	var totalRecords int32
+	err = queryExecutor.QueryRowx(countSql, countArgs...).Scan(&totalRecords)
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: failed to execute count query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	pagination := getPaginationMetaData(filter.Page, filter.Limit, totalRecords)
+
+	offset := (filter.Page - 1) * filter.Limit
+	queryBuilder = queryBuilder.RemoveColumns().
+		Columns(outboxColumns...).
+		OrderBy("updated_at DESC", "id DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(offset))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: failed to build query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	res := make([]repository.OutboxMessage, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: failed to execute query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	return res, pagination, nil
+}
+
+// RetryOutboxMessage moves a dead message back to pending with a fresh set of attempts
+func (obs *outboxStore) RetryOutboxMessage(ctx context.Context, tx repository.Transaction, id int64) (repository.OutboxMessage, error) {
+
+	queryExecutor := obs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(obs.OutboxTable).
+		Set("status", constants.OutboxPending).
+		Set("attempts", 0).
+		Set("next_attempt_at", squirrel.Expr(nowMillis)).
+		Set("updated_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.And{
+			squirrel.Eq{"id": id},
+			squirrel.Eq{"status": constants.OutboxDead},
+		}).
+		Suffix("RETURNING " + strings.Join(outboxColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "outboxRepo: error in generating squirrel query, err: %v", err)
+		return repository.OutboxMessage{}, apperrors.InternalServer
+	}
+
+	var msg repository.OutboxMessage
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&msg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "outboxRepo: no failed delivery found with id: %d", id)
+			return repository.OutboxMessage{}, apperrors.OutboxMessageNotFound
+		}
+		logger.Errorf(ctx, "outboxRepo: failed to retry outbox message %d: %v", id, err)
+		return repository.OutboxMessage{}, apperrors.InternalServer
+	}
+
+	return msg, nil
+}
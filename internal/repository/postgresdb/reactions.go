@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type reactionStore struct {
+	BaseRepository
+	ReactionsTable     string
+	AppreciationsTable string
+}
+
+func NewReactionRepo(db *sqlx.DB) repository.ReactionStorer {
+	return &reactionStore{
+		BaseRepository:     BaseRepository{db},
+		ReactionsTable:     constants.ReactionsTable,
+		AppreciationsTable: constants.AppreciationsTable,
+	}
+}
+
+// reactionCountsColumn selects the per reaction counts of the appreciation aliased as "a"
+func reactionCountsColumn() string {
+	return fmt.Sprintf(
+		`COALESCE((
+			SELECT json_object_agg(rc.reaction, rc.total)
+			FROM (SELECT reaction, COUNT(*) AS total FROM %s WHERE appreciation_id = a.id GROUP BY reaction) rc
+		), '{}') AS reaction_counts`, constants.ReactionsTable)
+}
+
+// myReactionsColumn selects the reactions left by the given user on the appreciation aliased as "a"
+func myReactionsColumn(userID int64) string {
+	return fmt.Sprintf(
+		`ARRAY(
+			SELECT reaction FROM %s WHERE appreciation_id = a.id AND reacted_by = %d ORDER BY reaction
+		) AS my_reactions`, constants.ReactionsTable, userID)
+}
+
+func (rs *reactionStore) AddReaction(ctx context.Context, tx repository.Transaction, reaction dto.Reaction) error {
+
+	logger.Debug(ctx, "reactionRepo: AddReaction: ", reaction)
+	queryExecutor := rs.InitiateQueryExecutor(tx)
+
+	// reacting twice with the same emoji is a no-op
+	query, args, err := repository.Sq.
+		Insert(rs.ReactionsTable).
+		Columns("appreciation_id", "reaction", "reacted_by").
+		Values(reaction.AppreciationID, reaction.Reaction, reaction.ReactedBy).
+		Suffix("ON CONFLICT (appreciation_id, reacted_by, reaction) DO NOTHING").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "reactionRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "reactionRepo: error executing add reaction query: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (rs *reactionStore) RemoveReaction(ctx context.Context, tx repository.Transaction, reaction dto.Reaction) error {
+
+	logger.Debug(ctx, "reactionRepo: RemoveReaction: ", reaction)
+	queryExecutor := rs.InitiateQueryExecutor(tx)
+
+	query, args, err := repository.Sq.
+		Delete(rs.ReactionsTable).
+		Where(squirrel.Eq{
+			"appreciation_id": reaction.AppreciationID,
+			"reaction":        reaction.Reaction,
+			"reacted_by":      reaction.ReactedBy,
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "reactionRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	result, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "reactionRepo: error executing remove reaction query: %v", err)
+		return apperrors.InternalServer
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "reactionRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn(ctx, "reactionRepo: no rows affected")
+		return apperrors.ReactionNotFound
+	}
+
+	return nil
+}
+
+func (rs *reactionStore) GetReactionSummary(ctx context.Context, tx repository.Transaction, appreciationId int64, userId int64) (repository.ReactionSummary, error) {
+
+	queryExecutor := rs.InitiateQueryExecutor(tx)
+
+	query, args, err := repository.Sq.Select(
+		"a.id AS appreciation_id",
+		reactionCountsColumn(),
+		myReactionsColumn(userId),
+	).From(rs.AppreciationsTable + " a").
+		Where(squirrel.And{
+			squirrel.Eq{"a.id": appreciationId},
+			squirrel.Eq{"a.is_valid": true},
+		}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "reactionRepo: error in generating squirrel query, err: %v", err)
+		return repository.ReactionSummary{}, apperrors.InternalServer
+	}
+
+	var summary repository.ReactionSummary
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&summary)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "reactionRepo: no appreciation found with id: %d", appreciationId)
+			return repository.ReactionSummary{}, apperrors.AppreciationNotFound
+		}
+		logger.Errorf(ctx, "reactionRepo: failed to execute query: %v", err)
+		return repository.ReactionSummary{}, apperrors.InternalServer
+	}
+
+	return summary, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -15,18 +16,18 @@ import (
 )
 
 type reportAppreciationStore struct {
-	DB *sqlx.DB
+	BaseRepository
 }
 
 func NewReportRepo(db *sqlx.DB) repository.ReportAppreciationStorer {
 	return &reportAppreciationStore{
-		DB: db,
+		BaseRepository: BaseRepository{db},
 	}
 }
 
 const (
 	createResolution     = `INSERT INTO resolutions (appreciation_id, reporting_comment, reported_by) VALUES ($1,$2,$3) RETURNING id, appreciation_id, reporting_comment, reported_by, reported_at`
-	getSenderAndReceiver = `SELECT sender, receiver FROM appreciations WHERE id = $1`
+	getSenderAndReceiver = `SELECT sender, receiver, EXISTS (SELECT 1 FROM appreciation_receivers WHERE appreciation_id = $1 AND receiver = $2) AS is_receiver FROM appreciations WHERE id = $1`
 	getReportsCount      = `SELECT count(*) FROM resolutions WHERE appreciation_id = $1 AND reported_by = $2;`
 	getAppreciationById  = `SELECT count(*) FROM appreciations WHERE id = $1`
 )
@@ -79,6 +80,7 @@ func (rs *reportAppreciationStore) GetSenderAndReceiver(ctx context.Context, req
 		&resp,
 		getSenderAndReceiver,
 		reqData.AppreciationId,
+		reqData.ReportedBy,
 	)
 	if err != nil {
 		logger.Errorf(ctx, "error in fetching appreciation sender and receiver, err: %v", err)
@@ -87,10 +89,11 @@ func (rs *reportAppreciationStore) GetSenderAndReceiver(ctx context.Context, req
 	return
 }
 
-func (rs *reportAppreciationStore) ReportAppreciation(ctx context.Context, reportReq dto.ReportAppreciationReq) (resp dto.ReportAppricaitionResp, err error) {
+func (rs *reportAppreciationStore) ReportAppreciation(ctx context.Context, tx repository.Transaction, reportReq dto.ReportAppreciationReq) (resp dto.ReportAppricaitionResp, err error) {
 
-	err = rs.DB.GetContext(
-		ctx,
+	queryExecutor := rs.InitiateQueryExecutor(tx)
+	err = sqlx.Get(
+		queryExecutor,
 		&resp,
 		createResolution,
 		reportReq.AppreciationId,
@@ -104,7 +107,7 @@ func (rs *reportAppreciationStore) ReportAppreciation(ctx context.Context, repor
 	return
 }
 
-func (rs *reportAppreciationStore) ListReportedAppreciations(ctx context.Context, quarter int, year int) (reportedAppreciations []repository.ListReportedAppreciations, err error) {
+func (rs *reportAppreciationStore) ListReportedAppreciations(ctx context.Context, quarter int, year int, filter dto.OrgFilter) (reportedAppreciations []repository.ListReportedAppreciations, err error) {
 	query := `
 SELECT 
   resolutions.id,
@@ -135,6 +138,7 @@ LEFT JOIN users sender_user ON sender_user.id = appreciations.sender
 LEFT JOIN users receiver_user ON receiver_user.id = appreciations.receiver
 LEFT JOIN users reporter_user ON reporter_user.id = resolutions.reported_by`
 
+	var conditions []string
 	var args []interface{}
 	if year > 0 {
 		var start, end int64
@@ -146,8 +150,31 @@ LEFT JOIN users reporter_user ON reporter_user.id = resolutions.reported_by`
 			start = startTime.UnixMilli()
 			end = endTime.UnixMilli()
 		}
-		query += ` WHERE appreciations.created_at >= $1 AND appreciations.created_at < $2`
 		args = append(args, start, end)
+		conditions = append(conditions, fmt.Sprintf("appreciations.created_at >= $%d AND appreciations.created_at < $%d", len(args)-1, len(args)))
+	}
+	if !filter.IsEmpty() {
+		member := func(alias string) string {
+			matches := make([]string, 0, 2)
+			if filter.DepartmentID != 0 {
+				args = append(args, filter.DepartmentID)
+				matches = append(matches, fmt.Sprintf("%s.department_id = $%d", alias, len(args)))
+			}
+			if filter.TeamID != 0 {
+				args = append(args, filter.TeamID)
+				matches = append(matches, fmt.Sprintf("%s.team_id = $%d", alias, len(args)))
+			}
+			return "(" + strings.Join(matches, " AND ") + ")"
+		}
+		// the appreciation is reported for the department or team when its sender or any of its receivers belongs to it,
+		// appreciations.receiver is only the first receiver of a team appreciation
+		senderMatches := member("sender_user")
+		receiverMatches := "EXISTS (SELECT 1 FROM appreciation_receivers ar JOIN users receiver_org ON receiver_org.id = ar.receiver " +
+			"WHERE ar.appreciation_id = appreciations.id AND " + member("receiver_org") + ")"
+		conditions = append(conditions, "("+senderMatches+" OR "+receiverMatches+")")
+	}
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
 	}
 
 	query += ` GROUP BY 
@@ -218,10 +245,10 @@ func (rs *reportAppreciationStore) CheckResolution(ctx context.Context, id int64
 	return
 }
 
-func (rs *reportAppreciationStore) DeleteAppreciation(ctx context.Context, moderationReq dto.ModerationReq) (err error) {
+func (rs *reportAppreciationStore) DeleteAppreciation(ctx context.Context, tx repository.Transaction, moderationReq dto.ModerationReq) (err error) {
+	queryExecutor := rs.InitiateQueryExecutor(tx)
 	moderationQuery := `update resolutions set moderator_comment = $1, moderated_by = $2, status = 'deleted' where id = $3`
-	_, err = rs.DB.ExecContext(
-		ctx,
+	_, err = queryExecutor.Exec(
 		moderationQuery,
 		moderationReq.ModeratorComment,
 		moderationReq.ModeratedBy,
@@ -232,8 +259,7 @@ func (rs *reportAppreciationStore) DeleteAppreciation(ctx context.Context, moder
 		return
 	}
 	deleteAppreciation := `update appreciations set is_valid = false where id = $1`
-	_, err = rs.DB.ExecContext(
-		ctx,
+	_, err = queryExecutor.Exec(
 		deleteAppreciation,
 		moderationReq.AppreciationId,
 	)
@@ -244,10 +270,10 @@ func (rs *reportAppreciationStore) DeleteAppreciation(ctx context.Context, moder
 	return
 }
 
-func (rs *reportAppreciationStore) ResolveAppreciation(ctx context.Context, moderationReq dto.ModerationReq) (err error) {
+func (rs *reportAppreciationStore) ResolveAppreciation(ctx context.Context, tx repository.Transaction, moderationReq dto.ModerationReq) (err error) {
+	queryExecutor := rs.InitiateQueryExecutor(tx)
 	moderationQuery := `update resolutions set moderator_comment = $1, moderated_by = $2, status = 'resolved' where id = $3`
-	_, err = rs.DB.ExecContext(
-		ctx,
+	_, err = queryExecutor.Exec(
 		moderationQuery,
 		moderationReq.ModeratorComment,
 		moderationReq.ModeratedBy,
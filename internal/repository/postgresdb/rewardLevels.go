@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+var rewardLevelColumns = []string{"id", "version", "point", "value", "created_by", "created_at"}
+
+type rewardLevelStore struct {
+	BaseRepository
+	RewardLevelsTable string
+}
+
+func NewRewardLevelRepo(db *sqlx.DB) repository.RewardLevelStorer {
+	return &rewardLevelStore{
+		BaseRepository:    BaseRepository{db},
+		RewardLevelsTable: constants.RewardLevelsTable,
+	}
+}
+
+func (rl *rewardLevelStore) LockRewardLevels(ctx context.Context, tx repository.Transaction) error {
+
+	queryExecutor := rl.InitiateQueryExecutor(tx)
+	_, err := queryExecutor.Exec("LOCK TABLE " + rl.RewardLevelsTable + " IN EXCLUSIVE MODE")
+	if err != nil {
+		logger.Errorf(ctx, "rewardLevelRepo: failed to lock reward levels: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (rl *rewardLevelStore) GetLatestRewardLevelVersion(ctx context.Context, tx repository.Transaction) (int64, error) {
+
+	queryExecutor := rl.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select("COALESCE(MAX(version), 0)").From(rl.RewardLevelsTable).ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "rewardLevelRepo: error in generating squirrel query, err: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	var version int64
+	err = queryExecutor.QueryRowx(query, args...).Scan(&version)
+	if err != nil {
+		logger.Errorf(ctx, "rewardLevelRepo: failed to execute query: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	logger.Debug(ctx, "rewardLevelRepo: latest version: ", version)
+	return version, nil
+}
+
+func (rl *rewardLevelStore) ListRewardLevels(ctx context.Context, tx repository.Transaction, version int64) ([]repository.RewardLevel, error) {
+
+	queryExecutor := rl.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(rewardLevelColumns...).
+		From(rl.RewardLevelsTable).
+		Where(squirrel.Eq{"version": version}).
+		OrderBy("point").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "rewardLevelRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	logger.Debug(ctx, "rewardLevelRepo: query: ", query, ",args: ", args)
+	levels := make([]repository.RewardLevel, 0)
+	err = sqlx.Select(queryExecutor, &levels, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "rewardLevelRepo: failed to execute query: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return levels, nil
+}
+
+func (rl *rewardLevelStore) GetRewardLevelByPoint(ctx context.Context, tx repository.Transaction, point int64) (repository.RewardLevel, error) {
+
+	queryExecutor := rl.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(rewardLevelColumns...).
+		From(rl.RewardLevelsTable).
+		Where(squirrel.Eq{"point": point}).
+		Where(squirrel.Expr("version = (SELECT MAX(version) FROM " + rl.RewardLevelsTable + ")")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "rewardLevelRepo: error in generating squirrel query, err: %v", err)
+		return repository.RewardLevel{}, apperrors.InternalServer
+	}
+
+	logger.Debug(ctx, "rewardLevelRepo: query: ", query, ",args: ", args)
+	var level repository.RewardLevel
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&level)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "rewardLevelRepo: no reward level found for point: %d", point)
+			return repository.RewardLevel{}, apperrors.InvalidRewardPoint
+		}
+		logger.Errorf(ctx, "rewardLevelRepo: failed to execute query: %v", err)
+		return repository.RewardLevel{}, apperrors.InternalServer
+	}
+
+	return level, nil
+}
+
+func (rl *rewardLevelStore) CreateRewardLevels(ctx context.Context, tx repository.Transaction, version int64, levels []dto.RewardLevelReq, createdBy int64) ([]repository.RewardLevel, error) {
+
+	queryExecutor := rl.InitiateQueryExecutor(tx)
+	queryBuilder := repository.Sq.Insert(rl.RewardLevelsTable).
+		Columns("version", "point", "value", "created_by")
+	for _, level := range levels {
+		queryBuilder = queryBuilder.Values(version, level.Point, level.Value, createdBy)
+	}
+
+	query, args, err := queryBuilder.Suffix("RETURNING id, version, point, value, created_by, created_at").ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "rewardLevelRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	logger.Debug(ctx, "rewardLevelRepo: query: ", query, ",args: ", args)
+	created := make([]repository.RewardLevel, 0, len(levels))
+	err = sqlx.Select(queryExecutor, &created, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "rewardLevelRepo: failed to insert reward levels: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return created, nil
+}
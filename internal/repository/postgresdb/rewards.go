@@ -29,8 +29,8 @@ func (rwrd *rewardStore) GiveReward(ctx context.Context, tx repository.Transacti
 	insertQuery, args, err := repository.Sq.
 		Insert("rewards").
 		Columns(constants.CreateRewardColumns...).
-		Values(reward.AppreciationId, reward.Point, reward.SenderId).
-		Suffix("RETURNING \"id\",\"appreciation_id\", \"point\",\"sender\",\"created_at\"").
+		Values(reward.AppreciationId, reward.Point, reward.SenderId, reward.RewardLevelId, reward.Value).
+		Suffix("RETURNING \"id\",\"appreciation_id\", \"point\",\"sender\",\"reward_level_id\",\"value\",\"created_at\"").
 		ToSql()
 
 	if err != nil {
@@ -40,7 +40,7 @@ func (rwrd *rewardStore) GiveReward(ctx context.Context, tx repository.Transacti
 
 	logger.Debug(ctx, "rewardRepo: insertQuery: ", insertQuery, ",args: ", args)
 	var rewardInfo repository.Reward
-	err = queryExecutor.QueryRowx(insertQuery, args...).Scan(&rewardInfo.Id, &rewardInfo.AppreciationId, &rewardInfo.Point, &rewardInfo.SenderId, &rewardInfo.CreatedAt)
+	err = queryExecutor.QueryRowx(insertQuery, args...).Scan(&rewardInfo.Id, &rewardInfo.AppreciationId, &rewardInfo.Point, &rewardInfo.SenderId, &rewardInfo.RewardLevelId, &rewardInfo.Value, &rewardInfo.CreatedAt)
 	if err != nil {
 		logger.Error(ctx, "Error executing create reward insert query: ", err)
 		return repository.Reward{}, apperrors.InternalServer
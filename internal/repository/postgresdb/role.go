@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type roleStore struct {
+	BaseRepository
+	RolePermissionsTable string
+	PermissionsTable     string
+	UsersTable           string
+}
+
+func NewRoleRepo(db *sqlx.DB) repository.RoleStorer {
+	return &roleStore{
+		BaseRepository:       BaseRepository{db},
+		RolePermissionsTable: constants.RolePermissionsTable,
+		PermissionsTable:     constants.PermissionsTable,
+		UsersTable:           constants.UsersTable,
+	}
+}
+
+func (rs *roleStore) ListRolePermissions(ctx context.Context, tx repository.Transaction) ([]repository.RolePermission, error) {
+
+	queryExecutor := rs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select("rp.role_id", "p.name AS permission").
+		From(rs.RolePermissionsTable + " rp").
+		Join(rs.PermissionsTable + " p ON p.id = rp.permission_id").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "roleRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.RolePermission, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "roleRepo: failed to list role permissions: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (rs *roleStore) GetUserRoleID(ctx context.Context, tx repository.Transaction, userID int64) (int64, error) {
+
+	queryExecutor := rs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select("role_id").
+		From(rs.UsersTable).
+		Where(squirrel.Eq{"id": userID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "roleRepo: error in generating squirrel query, err: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	var roleID int64
+	err = sqlx.Get(queryExecutor, &roleID, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, apperrors.UserNotFound
+		}
+		logger.Errorf(ctx, "roleRepo: failed to get role of user %d: %v", userID, err)
+		return 0, apperrors.InternalServer
+	}
+
+	return roleID, nil
+}
+
+func (rs *roleStore) UpdateUserRole(ctx context.Context, tx repository.Transaction, userID int64, roleID int64, updatedBy int64) error {
+
+	queryExecutor := rs.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(rs.UsersTable).
+		Set("role_id", roleID).
+		Set("updated_by", updatedBy).
+		Set("updated_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"id": userID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "roleRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "roleRepo: failed to update role of user %d: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "roleRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+	if updated == 0 {
+		return apperrors.UserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+var blacklistedTokenColumns = []string{
+	"id",
+	"user_id",
+	"token",
+	"expires_at",
+	"revoked_at",
+}
+
+var refreshTokenColumns = []string{
+	"id",
+	"user_id",
+	"role",
+	"family_id",
+	"token_hash",
+	"expires_at",
+	"created_at",
+	"used_at",
+	"revoked_at",
+}
+
+type sessionStore struct {
+	BaseRepository
+	BlacklistedTokensTable  string
+	NotificationTokensTable string
+	RefreshTokensTable      string
+}
+
+func NewSessionRepo(db *sqlx.DB) repository.SessionStorer {
+	return &sessionStore{
+		BaseRepository:          BaseRepository{db},
+		BlacklistedTokensTable:  constants.BlacklistedTokensTable,
+		NotificationTokensTable: constants.NotificationTokensTable,
+		RefreshTokensTable:      constants.RefreshTokensTable,
+	}
+}
+
+func (ss *sessionStore) BlacklistToken(ctx context.Context, tx repository.Transaction, token repository.BlacklistedToken) error {
+
+	logger.Debug(ctx, "sessionRepo: BlacklistToken: user: ", token.UserID, " token: ", token.Token.String)
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+
+	query, args, err := repository.Sq.Insert(ss.BlacklistedTokensTable).
+		Columns("user_id", "token", "expires_at", "revoked_at").
+		Values(token.UserID, token.Token, token.ExpiresAt, token.RevokedAt).
+		Suffix("ON CONFLICT (token) DO NOTHING").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: failed to blacklist token of user %d: %v", token.UserID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ss *sessionStore) ListBlacklistedTokens(ctx context.Context, tx repository.Transaction) ([]repository.BlacklistedToken, error) {
+
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(blacklistedTokenColumns...).
+		From(ss.BlacklistedTokensTable).
+		Where(squirrel.Expr("expires_at > " + nowMillis)).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.BlacklistedToken, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: failed to list blacklisted tokens: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ss *sessionStore) DeleteExpiredBlacklistedTokens(ctx context.Context, tx repository.Transaction) (int64, error) {
+
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(ss.BlacklistedTokensTable).
+		Where(squirrel.Expr("expires_at <= " + nowMillis)).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: failed to delete expired blacklisted tokens: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error getting rows affected: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	return deleted, nil
+}
+
+func (ss *sessionStore) DeleteDeviceToken(ctx context.Context, tx repository.Transaction, userID int64, deviceToken string) error {
+
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(ss.NotificationTokensTable).
+		Where(squirrel.Eq{"user_id": userID, "notification_token": deviceToken}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: failed to delete device token of user %d: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ss *sessionStore) CreateRefreshToken(ctx context.Context, tx repository.Transaction, token repository.RefreshToken) error {
+
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Insert(ss.RefreshTokensTable).
+		Columns("user_id", "role", "family_id", "token_hash", "expires_at").
+		Values(token.UserID, token.Role, token.FamilyID, token.TokenHash, token.ExpiresAt).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: failed to create refresh token of user %d: %v", token.UserID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ss *sessionStore) UseRefreshToken(ctx context.Context, tx repository.Transaction, tokenHash string) (repository.RefreshToken, error) {
+
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+	// a single statement so two requests racing with the same token can't both use it
+	query, args, err := repository.Sq.Update(ss.RefreshTokensTable).
+		Set("used_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"token_hash": tokenHash, "used_at": nil, "revoked_at": nil}).
+		Where(squirrel.Expr("expires_at > " + nowMillis)).
+		Suffix("RETURNING " + strings.Join(refreshTokenColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return repository.RefreshToken{}, apperrors.InternalServer
+	}
+
+	var token repository.RefreshToken
+	err = sqlx.Get(queryExecutor, &token, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.RefreshToken{}, apperrors.InvalidRefreshToken
+		}
+		logger.Errorf(ctx, "sessionRepo: failed to use refresh token: %v", err)
+		return repository.RefreshToken{}, apperrors.InternalServer
+	}
+
+	return token, nil
+}
+
+func (ss *sessionStore) GetRefreshToken(ctx context.Context, tx repository.Transaction, tokenHash string) (repository.RefreshToken, error) {
+
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(refreshTokenColumns...).
+		From(ss.RefreshTokensTable).
+		Where(squirrel.Eq{"token_hash": tokenHash}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return repository.RefreshToken{}, apperrors.InternalServer
+	}
+
+	var token repository.RefreshToken
+	err = sqlx.Get(queryExecutor, &token, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.RefreshToken{}, apperrors.InvalidRefreshToken
+		}
+		logger.Errorf(ctx, "sessionRepo: failed to get refresh token: %v", err)
+		return repository.RefreshToken{}, apperrors.InternalServer
+	}
+
+	return token, nil
+}
+
+func (ss *sessionStore) RevokeRefreshTokenFamily(ctx context.Context, tx repository.Transaction, familyID string) error {
+
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(ss.RefreshTokensTable).
+		Set("revoked_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"family_id": familyID, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: failed to revoke refresh token family %s: %v", familyID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ss *sessionStore) RevokeUserRefreshTokens(ctx context.Context, tx repository.Transaction, userID int64) error {
+
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(ss.RefreshTokensTable).
+		Set("revoked_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"user_id": userID, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: failed to revoke refresh tokens of user %d: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ss *sessionStore) DeleteExpiredRefreshTokens(ctx context.Context, tx repository.Transaction) (int64, error) {
+
+	queryExecutor := ss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(ss.RefreshTokensTable).
+		Where(squirrel.Expr("expires_at <= " + nowMillis)).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error in generating squirrel query, err: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: failed to delete expired refresh tokens: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "sessionRepo: error getting rows affected: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	return deleted, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -42,6 +43,7 @@ var (
 	userColumns      = []string{"id", "employee_id", "first_name", "last_name", "email", "profile_image_url", "role_id", "reward_quota_balance", "designation", "grade_id"}
 	adminColumns     = []string{"id", "employee_id", "first_name", "last_name", "email", "password", "profile_image_url", "role_id", "reward_quota_balance", "designation", "grade_id"}
 	rolesColumns     = []string{"id"}
+	adminUserColumns = []string{"id", "employee_id", "first_name", "last_name", "email", "profile_image_url", "role_id", "reward_quota_balance", "designation", "grade_id", "status", "deactivated_at", "deactivated_by", "frozen_reward_quota", "department_id", "team_id", "manager_id", "created_at"}
 	orgConfigColumns = []string{"reward_multiplier"}
 )
 
@@ -200,7 +202,7 @@ func (us *userStore) SyncData(ctx context.Context, updateData dto.User) (err err
 
 func (us *userStore) GetTotalUserCount(ctx context.Context, reqData dto.ListUsersReq) (totalCount int64, err error) {
 
-	queryBuilder := repository.Sq.Select("count(*)").From(us.UsersTable)
+	queryBuilder := repository.Sq.Select("count(*)").From(us.UsersTable).Where(squirrel.Eq{"status": constants.ActiveUserStatus})
 	conditions := []squirrel.Sqlizer{}
 	for _, name := range reqData.Name {
 		conditions = append(conditions, squirrel.Like{"lower(first_name)": "%" + name + "%"})
@@ -209,6 +211,9 @@ func (us *userStore) GetTotalUserCount(ctx context.Context, reqData dto.ListUser
 	if len(conditions) > 0 {
 		queryBuilder = queryBuilder.Where(squirrel.Or(conditions))
 	}
+	if !reqData.OrgFilter.IsEmpty() {
+		queryBuilder = queryBuilder.Where(orgFilterCondition("", reqData.OrgFilter))
+	}
 
 	getUserCountQuery, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -231,7 +236,7 @@ func (us *userStore) ListUsers(ctx context.Context, reqData dto.ListUsersReq) (r
 		return
 	}
 
-	queryBuilder := repository.Sq.Select(userColumns...).From(us.UsersTable).Where("grade_id NOT IN (?, ?)", 1, 2).OrderBy("first_name")
+	queryBuilder := repository.Sq.Select(userColumns...).From(us.UsersTable).Where("grade_id NOT IN (?, ?)", 1, 2).Where(squirrel.Eq{"status": constants.ActiveUserStatus}).OrderBy("first_name")
 	conditions := []squirrel.Sqlizer{}
 	for _, name := range reqData.Name {
 		conditions = append(conditions, squirrel.Like{"lower(first_name)": "%" + name + "%"})
@@ -240,6 +245,9 @@ func (us *userStore) ListUsers(ctx context.Context, reqData dto.ListUsersReq) (r
 	if len(conditions) > 0 {
 		queryBuilder = queryBuilder.Where(squirrel.Or(conditions))
 	}
+	if !reqData.OrgFilter.IsEmpty() {
+		queryBuilder = queryBuilder.Where(orgFilterCondition("", reqData.OrgFilter))
+	}
 	offset := reqData.PageSize * (reqData.Page - 1)
 	queryBuilder = queryBuilder.Limit(uint64(reqData.PageSize)).Offset(uint64(offset))
 
@@ -288,7 +296,7 @@ func getQuarterRangeUnixTime(quarter int, year int) (start int64, end int64) {
 	return startTime.Unix(), endTime.Unix()
 }
 
-func (us *userStore) GetActiveUserList(ctx context.Context, tx repository.Transaction, quarterStart int64, quarterEnd int64) (activeUsers []repository.ActiveUser, err error) {
+func (us *userStore) GetActiveUserList(ctx context.Context, tx repository.Transaction, quarterStart int64, quarterEnd int64, filter dto.OrgFilter) (activeUsers []repository.ActiveUser, err error) {
 	queryExecutor := us.InitiateQueryExecutor(tx)
 	query := `WITH user_points AS (
 		SELECT 
@@ -301,8 +309,8 @@ func (us *userStore) GetActiveUserList(ctx context.Context, tx repository.Transa
 			users u
 		LEFT JOIN 
 			(SELECT receiver AS user_id, COUNT(*) AS total_received_appreciations 
-			 FROM appreciations 
-			 WHERE appreciations.is_valid = true AND appreciations.created_at >= $1 AND appreciations.created_at < $2
+			 FROM appreciation_receiver_points 
+			 WHERE is_valid = true AND created_at >= $1 AND created_at < $2
 			 GROUP BY receiver
 			) AS received ON u.id = received.user_id
 		LEFT JOIN 
@@ -316,9 +324,11 @@ func (us *userStore) GetActiveUserList(ctx context.Context, tx repository.Transa
 			 WHERE rewards.created_at >= $1 AND rewards.created_at < $2
 			 GROUP BY sender) AS given ON u.id = given.user_id
 		WHERE
-			COALESCE(received.total_received_appreciations, 0) > 0 OR
+			($3 = 0 OR u.department_id = $3) AND
+			($4 = 0 OR u.team_id = $4) AND
+			(COALESCE(received.total_received_appreciations, 0) > 0 OR
 			COALESCE(sent.total_sent_appreciations, 0) > 0 OR
-			COALESCE(given.total_given_rewards, 0) > 0
+			COALESCE(given.total_given_rewards, 0) > 0)
 		ORDER BY
 			active_user_points DESC,
 			total_sent_appreciations DESC,
@@ -337,8 +347,8 @@ func (us *userStore) GetActiveUserList(ctx context.Context, tx repository.Transa
 	JOIN 
 		users u ON up.user_id = u.id
 	LEFT JOIN 
-		(SELECT receiver, SUM(total_reward_points) AS appreciation_points 
-		 FROM appreciations 
+		(SELECT receiver, SUM(reward_points) AS appreciation_points 
+		 FROM appreciation_receiver_points 
 		 GROUP BY receiver) AS ap ON u.id = ap.receiver
 	LEFT JOIN 
 		(SELECT ub.user_id, b.name
@@ -346,9 +356,9 @@ func (us *userStore) GetActiveUserList(ctx context.Context, tx repository.Transa
 		 JOIN badges b ON ub.badge_id = b.id
 		 WHERE ub.id = (SELECT MAX(id) FROM user_badges WHERE user_id = ub.user_id)) AS b ON u.id = b.user_id
 	LIMIT 10;`
-	logger.Info(ctx, "quarterStart: ", quarterStart, ", quarterEnd: ", quarterEnd)
+	logger.Info(ctx, "quarterStart: ", quarterStart, ", quarterEnd: ", quarterEnd, ", filter: ", filter)
 
-	rows, err := queryExecutor.Query(query, quarterStart, quarterEnd)
+	rows, err := queryExecutor.Query(query, quarterStart, quarterEnd, filter.DepartmentID, filter.TeamID)
 	if err != nil {
 		logger.Error(ctx, "err: userStore ", err.Error())
 		return []repository.ActiveUser{}, err
@@ -379,7 +389,7 @@ func (us *userStore) GetActiveUserList(ctx context.Context, tx repository.Transa
 	return activeUsers, nil
 }
 
-func (us *userStore) GetDynamicEngagersReport(ctx context.Context, tx repository.Transaction, quarterStart int64, quarterEnd int64) (engagers []repository.DynamicEngager, err error) {
+func (us *userStore) GetDynamicEngagersReport(ctx context.Context, tx repository.Transaction, quarterStart int64, quarterEnd int64, filter dto.OrgFilter) (engagers []repository.DynamicEngager, err error) {
 	queryExecutor := us.InitiateQueryExecutor(tx)
 	query := `WITH sent_appreciations AS (
 		SELECT sender AS user_id, COUNT(*) AS sent
@@ -389,7 +399,7 @@ func (us *userStore) GetDynamicEngagersReport(ctx context.Context, tx repository
 	),
 	received_appreciations AS (
 		SELECT receiver AS user_id, COUNT(*) AS received
-		FROM appreciations
+		FROM appreciation_receiver_points
 		WHERE is_valid = true AND created_at >= $1 AND created_at < $2
 		GROUP BY receiver
 	),
@@ -416,15 +426,17 @@ func (us *userStore) GetDynamicEngagersReport(ctx context.Context, tx repository
 	LEFT JOIN 
 		given_rewards g ON u.id = g.user_id
 	WHERE 
-		COALESCE(s.sent, 0) > 0 OR
+		($3 = 0 OR u.department_id = $3) AND
+		($4 = 0 OR u.team_id = $4) AND
+		(COALESCE(s.sent, 0) > 0 OR
 		COALESCE(r.received, 0) > 0 OR
-		COALESCE(g.reward_points, 0) > 0
+		COALESCE(g.reward_points, 0) > 0)
 	ORDER BY 
 		total_points DESC,
 		first_name ASC,
 		last_name ASC;`
 
-	rows, err := queryExecutor.Query(query, quarterStart, quarterEnd)
+	rows, err := queryExecutor.Query(query, quarterStart, quarterEnd, filter.DepartmentID, filter.TeamID)
 	if err != nil {
 		logger.Error(ctx, "err: userStore GetDynamicEngagersReport query: ", err.Error())
 		return nil, err
@@ -465,9 +477,10 @@ func (us *userStore) UpdateRewardQuota(ctx context.Context, tx repository.Transa
     SELECT oc.reward_multiplier * g.points
     FROM organization_config oc,grades g
     WHERE users.grade_id = g.id
-	)`
+	)
+	WHERE users.status = $1`
 
-	_, err = queryExecutor.Exec(query)
+	_, err = queryExecutor.Exec(query, constants.ActiveUserStatus)
 	if err != nil {
 		logger.Error(ctx, "err: userStore ", err.Error())
 		return err
@@ -490,10 +503,10 @@ func (us *userStore) GetUserById(ctx context.Context, reqData dto.GetUserByIdReq
 	    users.grade_id, 
 	    users.employee_id, 
 	    (
-	        SELECT COALESCE(SUM(total_reward_points), 0) 
-	        FROM appreciations
-	        WHERE appreciations.receiver = users.id
-	        AND appreciations.created_at >= $1
+	        SELECT COALESCE(SUM(reward_points), 0) 
+	        FROM appreciation_receiver_points
+	        WHERE appreciation_receiver_points.receiver = users.id
+	        AND appreciation_receiver_points.created_at >= $1
 	    ) AS total_points, 
 	    COALESCE(badges.name, '') AS name, -- If badge is NULL, return empty string
 	    COALESCE(latest_badge.created_at, 0) AS badge_created_at -- If badge date is NULL, return 0
@@ -554,11 +567,11 @@ func (us *userStore) GetUserById(ctx context.Context, reqData dto.GetUserByIdReq
 	return user, nil
 }
 
-func (us *userStore) GetTop10Users(ctx context.Context, quarterTimestamp int64) (users []repository.Top10Users, err error) {
+func (us *userStore) GetTop10Users(ctx context.Context, quarterTimestamp int64, filter dto.OrgFilter) (users []repository.Top10Users, err error) {
 
-	getTop10UserQuery := `select users.id, users.first_name, users.last_name, users.profile_image_url, sum(appreciations.total_reward_points) as AP from users join appreciations on users.id = appreciations.receiver where appreciations.created_at >= $1 AND appreciations.is_valid = true group by users.id, appreciations.receiver order by AP desc limit 10`
+	getTop10UserQuery := `select users.id, users.first_name, users.last_name, users.profile_image_url, sum(arp.reward_points) as AP from users join appreciation_receiver_points arp on users.id = arp.receiver where arp.created_at >= $1 AND arp.is_valid = true AND users.status = $2 AND ($3 = 0 OR users.department_id = $3) AND ($4 = 0 OR users.team_id = $4) group by users.id, arp.receiver order by AP desc limit 10`
 
-	err = us.DB.Select(&users, getTop10UserQuery, quarterTimestamp)
+	err = us.DB.Select(&users, getTop10UserQuery, quarterTimestamp, constants.ActiveUserStatus, filter.DepartmentID, filter.TeamID)
 	if err != nil {
 		err = fmt.Errorf("err in getTop10UsersQuery err: %w", err)
 		return
@@ -664,3 +677,323 @@ func (us *userStore) ListDeviceTokensByUserID(ctx context.Context, userID int64)
 	}
 	return
 }
+
+func (us *userStore) AdminListUsers(ctx context.Context, tx repository.Transaction, filter dto.AdminListUsersReq) (users []repository.User, count int64, err error) {
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	conditions := squirrel.And{}
+	switch filter.Status {
+	case constants.ActiveUser:
+		conditions = append(conditions, squirrel.Eq{"status": constants.ActiveUserStatus})
+	case constants.DeactivatedUser:
+		conditions = append(conditions, squirrel.Eq{"status": constants.DeactivatedUserStatus})
+	}
+	if filter.GradeId != 0 {
+		conditions = append(conditions, squirrel.Eq{"grade_id": filter.GradeId})
+	}
+	if filter.RoleId != 0 {
+		conditions = append(conditions, squirrel.Eq{"role_id": filter.RoleId})
+	}
+	nameConditions := squirrel.Or{}
+	for _, name := range filter.Name {
+		if name == "" {
+			continue
+		}
+		nameConditions = append(nameConditions, squirrel.ILike{"first_name": "%" + name + "%"})
+		nameConditions = append(nameConditions, squirrel.ILike{"last_name": "%" + name + "%"})
+	}
+	if len(nameConditions) > 0 {
+		conditions = append(conditions, nameConditions)
+	}
+	if !filter.OrgFilter.IsEmpty() {
+		conditions = append(conditions, orgFilterCondition("", filter.OrgFilter))
+	}
+
+	countQuery, args, err := repository.Sq.Select("COUNT(*)").From(us.UsersTable).Where(conditions).ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return nil, 0, apperrors.InternalServer
+	}
+	err = sqlx.Get(queryExecutor, &count, countQuery, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to count users: %v", err)
+		return nil, 0, apperrors.InternalServer
+	}
+
+	query, args, err := repository.Sq.Select(adminUserColumns...).
+		From(us.UsersTable).
+		Where(conditions).
+		OrderBy("first_name", "last_name", "id").
+		Limit(uint64(filter.PageSize)).
+		Offset(uint64(filter.PageSize * (filter.Page - 1))).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return nil, 0, apperrors.InternalServer
+	}
+
+	users = make([]repository.User, 0)
+	err = sqlx.Select(queryExecutor, &users, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to list users: %v", err)
+		return nil, 0, apperrors.InternalServer
+	}
+
+	return users, count, nil
+}
+
+func (us *userStore) GetUser(ctx context.Context, tx repository.Transaction, userID int64) (user repository.User, err error) {
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(adminUserColumns...).
+		From(us.UsersTable).
+		Where(squirrel.Eq{"id": userID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return repository.User{}, apperrors.InternalServer
+	}
+
+	err = sqlx.Get(queryExecutor, &user, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.User{}, apperrors.UserNotFound
+		}
+		logger.Errorf(ctx, "userRepo: failed to get user %d: %v", userID, err)
+		return repository.User{}, apperrors.InternalServer
+	}
+
+	return user, nil
+}
+
+func (us *userStore) UpdateUserStatus(ctx context.Context, tx repository.Transaction, userID int64, status int64, updatedBy int64) (err error) {
+
+	// updated by the intranet sync when no user is given
+	updater := sql.NullInt64{Int64: updatedBy, Valid: updatedBy != 0}
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	queryBuilder := repository.Sq.Update(us.UsersTable).
+		Set("status", status).
+		Set("updated_by", updater).
+		Set("updated_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"id": userID}).
+		Where(squirrel.NotEq{"status": status})
+	if status == constants.DeactivatedUserStatus {
+		// the unspent quota is frozen so it can't be spent while the user is away
+		queryBuilder = queryBuilder.Set("deactivated_at", squirrel.Expr(nowMillis)).
+			Set("deactivated_by", updater).
+			Set("frozen_reward_quota", squirrel.Expr("reward_quota_balance")).
+			Set("reward_quota_balance", 0)
+	} else {
+		queryBuilder = queryBuilder.Set("deactivated_at", nil).
+			Set("deactivated_by", nil).
+			Set("reward_quota_balance", squirrel.Expr("frozen_reward_quota")).
+			Set("frozen_reward_quota", 0)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to update status of user %d: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (us *userStore) UpdatePassword(ctx context.Context, tx repository.Transaction, userID int64, passwordHash string, updatedBy int64) (err error) {
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Update(us.UsersTable).
+		Set("password", passwordHash).
+		Set("updated_by", updatedBy).
+		Set("updated_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"id": userID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to update password of user %d: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (us *userStore) ListDeactivatedUserIDs(ctx context.Context, tx repository.Transaction) (userIDs []int64, err error) {
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select("id").
+		From(us.UsersTable).
+		Where(squirrel.Eq{"status": constants.DeactivatedUserStatus}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	userIDs = make([]int64, 0)
+	err = sqlx.Select(queryExecutor, &userIDs, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to list deactivated users: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return userIDs, nil
+}
+
+func (us *userStore) ListUsersByStatus(ctx context.Context, tx repository.Transaction, status int64) (users []repository.User, err error) {
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(adminUserColumns...).
+		From(us.UsersTable).
+		Where(squirrel.Eq{"status": status}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	users = make([]repository.User, 0)
+	err = sqlx.Select(queryExecutor, &users, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to list users with status %d: %v", status, err)
+		return nil, apperrors.InternalServer
+	}
+
+	return users, nil
+}
+
+func (us *userStore) DeleteDeviceTokens(ctx context.Context, tx repository.Transaction, userID int64) (err error) {
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(constants.NotificationTokensTable).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to delete device tokens of user %d: %v", userID, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (us *userStore) DeleteUnregisteredDeviceToken(ctx context.Context, tx repository.Transaction, deviceToken string) (err error) {
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(constants.NotificationTokensTable).
+		Where(squirrel.Eq{"notification_token": deviceToken}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to delete unregistered device token: %v", err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (us *userStore) ListUsersByEmails(ctx context.Context, tx repository.Transaction, emails []string) (users []repository.User, err error) {
+
+	users = make([]repository.User, 0)
+	if len(emails) == 0 {
+		return users, nil
+	}
+
+	lowered := make([]string, 0, len(emails))
+	for _, email := range emails {
+		lowered = append(lowered, strings.ToLower(email))
+	}
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(adminUserColumns...).
+		From(us.UsersTable).
+		Where(squirrel.Eq{"LOWER(email)": lowered}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	err = sqlx.Select(queryExecutor, &users, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to list users by emails: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return users, nil
+}
+
+func (us *userStore) CreateUsers(ctx context.Context, tx repository.Transaction, users []dto.User) (err error) {
+
+	if len(users) == 0 {
+		return nil
+	}
+
+	queryBuilder := repository.Sq.Insert(us.UsersTable).Columns(userColumns[1:]...)
+	for _, user := range users {
+		queryBuilder = queryBuilder.Values(user.EmployeeId, user.FirstName, user.LastName, user.Email, user.ProfileImgUrl, user.RoleId, user.RewardQuotaBalance, user.Designation, user.GradeId)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to create %d users: %v", len(users), err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (us *userStore) UpdateUserProfile(ctx context.Context, tx repository.Transaction, user dto.User) (err error) {
+
+	query, args, err := repository.Sq.Update(us.UsersTable).
+		Set("employee_id", user.EmployeeId).
+		Set("first_name", user.FirstName).
+		Set("last_name", user.LastName).
+		Set("profile_image_url", user.ProfileImgUrl).
+		Set("designation", user.Designation).
+		Set("grade_id", user.GradeId).
+		Where(squirrel.Eq{"id": user.Id}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	queryExecutor := us.InitiateQueryExecutor(tx)
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo: failed to update profile of user %d: %v", user.Id, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
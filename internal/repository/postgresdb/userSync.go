@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+type userSyncStore struct {
+	BaseRepository
+	UserSyncReportsTable string
+}
+
+func NewUserSyncRepo(db *sqlx.DB) repository.UserSyncStorer {
+	return &userSyncStore{
+		BaseRepository:       BaseRepository{db},
+		UserSyncReportsTable: constants.UserSyncReportsTable,
+	}
+}
+
+var userSyncReportColumns = []string{"id", "started_at", "finished_at", "status", "created_count", "updated_count", "deactivated_count", "failed_count", "details", "error"}
+
+func (uss *userSyncStore) CreateUserSyncReport(ctx context.Context, tx repository.Transaction, report repository.UserSyncReport) (int64, error) {
+
+	queryExecutor := uss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Insert(uss.UserSyncReportsTable).
+		Columns("started_at", "finished_at", "status", "created_count", "updated_count", "deactivated_count", "failed_count", "details", "error").
+		Values(report.StartedAt, report.FinishedAt, report.Status, report.CreatedCount, report.UpdatedCount, report.DeactivatedCount, report.FailedCount, []byte(report.Details), report.Error).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userSyncRepo: error in generating squirrel query, err: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	var id int64
+	err = queryExecutor.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		logger.Errorf(ctx, "userSyncRepo: failed to create user sync report: %v", err)
+		return 0, apperrors.InternalServer
+	}
+
+	return id, nil
+}
+
+func (uss *userSyncStore) ListUserSyncReports(ctx context.Context, tx repository.Transaction, limit uint64) ([]repository.UserSyncReport, error) {
+
+	queryExecutor := uss.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(userSyncReportColumns...).
+		From(uss.UserSyncReportsTable).
+		OrderBy("started_at DESC", "id DESC").
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "userSyncRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	reports := make([]repository.UserSyncReport, 0)
+	err = sqlx.Select(queryExecutor, &reports, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "userSyncRepo: failed to list user sync reports: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/constants"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	logger "github.com/joshsoftware/peerly-backend/internal/pkg/logger"
+	"github.com/joshsoftware/peerly-backend/internal/repository"
+)
+
+var webhookSubscriptionColumns = []string{
+	"id",
+	"url",
+	"secret",
+	"events",
+	"created_by",
+	"created_at",
+}
+
+var webhookDeliveryColumns = []string{
+	"id",
+	"subscription_id",
+	"event_id",
+	"event",
+	"payload",
+	"status",
+	"attempts",
+	"response_status",
+	"last_error",
+	"created_at",
+	"updated_at",
+	"delivered_at",
+}
+
+type webhookStore struct {
+	BaseRepository
+	WebhookSubscriptionsTable string
+	WebhookDeliveriesTable    string
+}
+
+func NewWebhookRepo(db *sqlx.DB) repository.WebhookStorer {
+	return &webhookStore{
+		BaseRepository:            BaseRepository{db},
+		WebhookSubscriptionsTable: constants.WebhookSubscriptionsTable,
+		WebhookDeliveriesTable:    constants.WebhookDeliveriesTable,
+	}
+}
+
+func (ws *webhookStore) CreateWebhookSubscription(ctx context.Context, tx repository.Transaction, subscription repository.WebhookSubscription) (repository.WebhookSubscription, error) {
+
+	logger.Debug(ctx, "webhookRepo: CreateWebhookSubscription: url: ", subscription.URL, " events: ", subscription.Events)
+	queryExecutor := ws.InitiateQueryExecutor(tx)
+
+	query, args, err := repository.Sq.Insert(ws.WebhookSubscriptionsTable).
+		Columns("url", "secret", "events", "created_by").
+		Values(subscription.URL, subscription.Secret, subscription.Events, subscription.CreatedBy).
+		Suffix("RETURNING " + strings.Join(webhookSubscriptionColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: error in generating squirrel query, err: %v", err)
+		return repository.WebhookSubscription{}, apperrors.InternalServer
+	}
+
+	var res repository.WebhookSubscription
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&res)
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: error executing create webhook subscription query: %v", err)
+		return repository.WebhookSubscription{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ws *webhookStore) ListWebhookSubscriptions(ctx context.Context, tx repository.Transaction) ([]repository.WebhookSubscription, error) {
+
+	queryExecutor := ws.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(webhookSubscriptionColumns...).
+		From(ws.WebhookSubscriptionsTable).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: error in generating squirrel query, err: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	res := make([]repository.WebhookSubscription, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: failed to list webhook subscriptions: %v", err)
+		return nil, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ws *webhookStore) GetWebhookSubscription(ctx context.Context, tx repository.Transaction, id int64) (repository.WebhookSubscription, error) {
+
+	queryExecutor := ws.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(webhookSubscriptionColumns...).
+		From(ws.WebhookSubscriptionsTable).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: error in generating squirrel query, err: %v", err)
+		return repository.WebhookSubscription{}, apperrors.InternalServer
+	}
+
+	var res repository.WebhookSubscription
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&res)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "webhookRepo: no webhook subscription found with id: %d", id)
+			return repository.WebhookSubscription{}, apperrors.WebhookSubscriptionNotFound
+		}
+		logger.Errorf(ctx, "webhookRepo: failed to get webhook subscription %d: %v", id, err)
+		return repository.WebhookSubscription{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ws *webhookStore) DeleteWebhookSubscription(ctx context.Context, tx repository.Transaction, id int64) error {
+
+	queryExecutor := ws.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Delete(ws.WebhookSubscriptionsTable).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	res, err := queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: failed to delete webhook subscription %d: %v", id, err)
+		return apperrors.InternalServer
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: error getting rows affected: %v", err)
+		return apperrors.InternalServer
+	}
+	if rowsAffected == 0 {
+		return apperrors.WebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (ws *webhookStore) ListWebhookDeliveries(ctx context.Context, tx repository.Transaction, filter dto.WebhookDeliveryFilter) ([]repository.WebhookDelivery, repository.Pagination, error) {
+
+	logger.Debug(ctx, "webhookRepo: ListWebhookDeliveries: filter: ", filter)
+	queryExecutor := ws.InitiateQueryExecutor(tx)
+
+	queryBuilder := repository.Sq.Select("COUNT(*)").
+		From(ws.WebhookDeliveriesTable).
+		Where(squirrel.Eq{"subscription_id": filter.SubscriptionID})
+
+	countSql, countArgs, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: failed to build count query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	var totalRecords int32
+	err = queryExecutor.QueryRowx(countSql, countArgs...).Scan(&totalRecords)
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: failed to execute count query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	pagination := getPaginationMetaData(filter.Page, filter.Limit, totalRecords)
+
+	offset := (filter.Page - 1) * filter.Limit
+	queryBuilder = queryBuilder.RemoveColumns().
+		Columns(webhookDeliveryColumns...).
+		OrderBy("id DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(offset))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: failed to build query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	res := make([]repository.WebhookDelivery, 0)
+	err = sqlx.Select(queryExecutor, &res, query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: failed to execute query: %v", err)
+		return nil, repository.Pagination{}, apperrors.InternalServerError
+	}
+
+	return res, pagination, nil
+}
+
+func (ws *webhookStore) GetWebhookDelivery(ctx context.Context, tx repository.Transaction, id int64) (repository.WebhookDelivery, error) {
+
+	queryExecutor := ws.InitiateQueryExecutor(tx)
+	query, args, err := repository.Sq.Select(webhookDeliveryColumns...).
+		From(ws.WebhookDeliveriesTable).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: error in generating squirrel query, err: %v", err)
+		return repository.WebhookDelivery{}, apperrors.InternalServer
+	}
+
+	var res repository.WebhookDelivery
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&res)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "webhookRepo: no webhook delivery found with id: %d", id)
+			return repository.WebhookDelivery{}, apperrors.WebhookDeliveryNotFound
+		}
+		logger.Errorf(ctx, "webhookRepo: failed to get webhook delivery %d: %v", id, err)
+		return repository.WebhookDelivery{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
+
+func (ws *webhookStore) RecordWebhookAttempt(ctx context.Context, tx repository.Transaction, id int64, responseStatus int, lastError string) error {
+
+	queryBuilder := repository.Sq.Update(ws.WebhookDeliveriesTable).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("updated_at", squirrel.Expr(nowMillis)).
+		Where(squirrel.Eq{"id": id})
+
+	if responseStatus != 0 {
+		queryBuilder = queryBuilder.Set("response_status", responseStatus)
+	} else {
+		queryBuilder = queryBuilder.Set("response_status", nil)
+	}
+	if lastError == "" {
+		queryBuilder = queryBuilder.
+			Set("status", constants.WebhookDeliverySucceeded).
+			Set("last_error", nil).
+			Set("delivered_at", squirrel.Expr(nowMillis))
+	} else {
+		queryBuilder = queryBuilder.
+			Set("status", constants.WebhookDeliveryFailed).
+			Set("last_error", lastError)
+	}
+
+	queryExecutor := ws.InitiateQueryExecutor(tx)
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: error in generating squirrel query, err: %v", err)
+		return apperrors.InternalServer
+	}
+
+	_, err = queryExecutor.Exec(query, args...)
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: failed to record attempt of webhook delivery %d: %v", id, err)
+		return apperrors.InternalServer
+	}
+
+	return nil
+}
+
+func (ws *webhookStore) RedeliverWebhookDelivery(ctx context.Context, tx repository.Transaction, id int64) (repository.WebhookDelivery, error) {
+
+	queryExecutor := ws.InitiateQueryExecutor(tx)
+	// the copied query keeps ? placeholders so the statement is numbered once as a whole
+	query, args, err := repository.Sq.Insert(ws.WebhookDeliveriesTable).
+		Columns("subscription_id", "event_id", "event", "payload").
+		Select(squirrel.Select("subscription_id", "event_id", "event", "payload").
+			From(ws.WebhookDeliveriesTable).
+			Where(squirrel.Eq{"id": id})).
+		Suffix("RETURNING " + strings.Join(webhookDeliveryColumns, ", ")).
+		ToSql()
+	if err != nil {
+		logger.Errorf(ctx, "webhookRepo: error in generating squirrel query, err: %v", err)
+		return repository.WebhookDelivery{}, apperrors.InternalServer
+	}
+
+	var res repository.WebhookDelivery
+	err = queryExecutor.QueryRowx(query, args...).StructScan(&res)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Errorf(ctx, "webhookRepo: no webhook delivery found with id: %d", id)
+			return repository.WebhookDelivery{}, apperrors.WebhookDeliveryNotFound
+		}
+		logger.Errorf(ctx, "webhookRepo: failed to redeliver webhook delivery %d: %v", id, err)
+		return repository.WebhookDelivery{}, apperrors.InternalServer
+	}
+
+	return res, nil
+}
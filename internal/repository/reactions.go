@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/lib/pq"
+)
+
+type ReactionStorer interface {
+	AddReaction(ctx context.Context, tx Transaction, reaction dto.Reaction) error
+	RemoveReaction(ctx context.Context, tx Transaction, reaction dto.Reaction) error
+	GetReactionSummary(ctx context.Context, tx Transaction, appreciationId int64, userId int64) (ReactionSummary, error)
+}
+
+type ReactionSummary struct {
+	AppreciationID int64          `db:"appreciation_id"`
+	ReactionCounts ReactionCounts `db:"reaction_counts"`
+	MyReactions    pq.StringArray `db:"my_reactions"`
+}
+
+// ReactionCounts holds the number of reactions per reaction type, scanned from a json object
+type ReactionCounts map[string]int64
+
+func (rc *ReactionCounts) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*rc = ReactionCounts{}
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %T for reaction counts", src)
+	}
+	return json.Unmarshal(data, (*map[string]int64)(rc))
+}
@@ -8,15 +8,17 @@ import (
 )
 
 type ReportAppreciationStorer interface {
-	ReportAppreciation(ctx context.Context, reportReq dto.ReportAppreciationReq) (resp dto.ReportAppricaitionResp, err error)
+	RepositoryTransaction
+
+	ReportAppreciation(ctx context.Context, tx Transaction, reportReq dto.ReportAppreciationReq) (resp dto.ReportAppricaitionResp, err error)
 	GetSenderAndReceiver(ctx context.Context, reqData dto.ReportAppreciationReq) (resp dto.GetSenderAndReceiverResp, err error)
 	CheckDuplicateReport(ctx context.Context, reqData dto.ReportAppreciationReq) (isDupliate bool, err error)
 	CheckAppreciation(ctx context.Context, reqData dto.ReportAppreciationReq) (doesExist bool, err error)
-	ListReportedAppreciations(ctx context.Context, quarter int, year int) (reportedAppreciations []ListReportedAppreciations, err error)
+	ListReportedAppreciations(ctx context.Context, quarter int, year int, filter dto.OrgFilter) (reportedAppreciations []ListReportedAppreciations, err error)
 	GetReportedAppreciationByAppreciationID(ctx context.Context, appreciationID int64) (reportedAppreciation ListReportedAppreciations, err error)
-	DeleteAppreciation(ctx context.Context, moderationReq dto.ModerationReq) (err error)
+	DeleteAppreciation(ctx context.Context, tx Transaction, moderationReq dto.ModerationReq) (err error)
 	CheckResolution(ctx context.Context, id int64) (doesExist bool, appreciation_id int64, err error)
-	ResolveAppreciation(ctx context.Context, moderationReq dto.ModerationReq) (err error)
+	ResolveAppreciation(ctx context.Context, tx Transaction, moderationReq dto.ModerationReq) (err error)
 	GetResolution(ctx context.Context, id int64) (reportedAppreciation ListReportedAppreciations, err error)
 }
 
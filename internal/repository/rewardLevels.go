@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+)
+
+type RewardLevelStorer interface {
+	RepositoryTransaction
+
+	// LockRewardLevels keeps other writers of the reward levels waiting until tx ends, readers aren't blocked
+	LockRewardLevels(ctx context.Context, tx Transaction) error
+	GetLatestRewardLevelVersion(ctx context.Context, tx Transaction) (int64, error)
+	ListRewardLevels(ctx context.Context, tx Transaction, version int64) ([]RewardLevel, error)
+	GetRewardLevelByPoint(ctx context.Context, tx Transaction, point int64) (RewardLevel, error)
+	CreateRewardLevels(ctx context.Context, tx Transaction, version int64, levels []dto.RewardLevelReq, createdBy int64) ([]RewardLevel, error)
+}
+
+// RewardLevel maps a reward point level to the value it adds to an appreciation.
+// Rows are never updated, every change to the mapping is stored as a new version.
+type RewardLevel struct {
+	Id        int64         `db:"id"`
+	Version   int64         `db:"version"`
+	Point     int64         `db:"point"`
+	Value     int64         `db:"value"`
+	CreatedBy sql.NullInt64 `db:"created_by"`
+	CreatedAt int64         `db:"created_at"`
+}
@@ -20,5 +20,7 @@ type Reward struct {
 	AppreciationId int64 `db:"appreciation_id"`
 	Point          int64 `db:"point"`
 	SenderId       int64 `db:"sender"`
+	RewardLevelId  int64 `db:"reward_level_id"`
+	Value          int64 `db:"value"`
 	CreatedAt      int64 `db:"created_at"`
 }
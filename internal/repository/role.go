@@ -0,0 +1,18 @@
+package repository
+
+import "context"
+
+type RoleStorer interface {
+	RepositoryTransaction
+
+	// ListRolePermissions returns every permission granted to every role
+	ListRolePermissions(ctx context.Context, tx Transaction) ([]RolePermission, error)
+	// GetUserRoleID returns apperrors.UserNotFound for an unknown user
+	GetUserRoleID(ctx context.Context, tx Transaction, userID int64) (int64, error)
+	UpdateUserRole(ctx context.Context, tx Transaction, userID int64, roleID int64, updatedBy int64) error
+}
+
+type RolePermission struct {
+	RoleID     int64  `db:"role_id"`
+	Permission string `db:"permission"`
+}
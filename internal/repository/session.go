@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type SessionStorer interface {
+	RepositoryTransaction
+
+	// BlacklistToken revokes a single token when Token is set and every token issued to the user before RevokedAt otherwise
+	BlacklistToken(ctx context.Context, tx Transaction, token BlacklistedToken) error
+	// ListBlacklistedTokens returns the revocations that still cover tokens which haven't expired
+	ListBlacklistedTokens(ctx context.Context, tx Transaction) ([]BlacklistedToken, error)
+	DeleteExpiredBlacklistedTokens(ctx context.Context, tx Transaction) (int64, error)
+	DeleteDeviceToken(ctx context.Context, tx Transaction, userID int64, deviceToken string) error
+
+	CreateRefreshToken(ctx context.Context, tx Transaction, token RefreshToken) error
+	// UseRefreshToken marks an unused, unrevoked and unexpired token as used and returns it, anything else is apperrors.InvalidRefreshToken
+	UseRefreshToken(ctx context.Context, tx Transaction, tokenHash string) (RefreshToken, error)
+	GetRefreshToken(ctx context.Context, tx Transaction, tokenHash string) (RefreshToken, error)
+	RevokeRefreshTokenFamily(ctx context.Context, tx Transaction, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, tx Transaction, userID int64) error
+	DeleteExpiredRefreshTokens(ctx context.Context, tx Transaction) (int64, error)
+}
+
+type BlacklistedToken struct {
+	ID        int64          `db:"id"`
+	UserID    int64          `db:"user_id"`
+	Token     sql.NullString `db:"token"`
+	ExpiresAt int64          `db:"expires_at"`
+	RevokedAt int64          `db:"revoked_at"`
+}
+
+type RefreshToken struct {
+	ID        int64         `db:"id"`
+	UserID    int64         `db:"user_id"`
+	Role      int           `db:"role"`
+	FamilyID  string        `db:"family_id"`
+	TokenHash string        `db:"token_hash"`
+	ExpiresAt int64         `db:"expires_at"`
+	CreatedAt int64         `db:"created_at"`
+	UsedAt    sql.NullInt64 `db:"used_at"`
+	RevokedAt sql.NullInt64 `db:"revoked_at"`
+}
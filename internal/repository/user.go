@@ -19,14 +19,30 @@ type UserStorer interface {
 	ListUsers(ctx context.Context, reqData dto.ListUsersReq) (resp []User, count int64, err error)
 
 	UpdateRewardQuota(ctx context.Context, tx Transaction) (err error)
-	GetActiveUserList(ctx context.Context, tx Transaction, quarterStart int64, quarterEnd int64) (activeUsers []ActiveUser, err error)
-	GetDynamicEngagersReport(ctx context.Context, tx Transaction, quarterStart int64, quarterEnd int64) (engagers []DynamicEngager, err error)
+	GetActiveUserList(ctx context.Context, tx Transaction, quarterStart int64, quarterEnd int64, filter dto.OrgFilter) (activeUsers []ActiveUser, err error)
+	GetDynamicEngagersReport(ctx context.Context, tx Transaction, quarterStart int64, quarterEnd int64, filter dto.OrgFilter) (engagers []DynamicEngager, err error)
 	GetUserById(ctx context.Context, reqData dto.GetUserByIdReq) (user dto.GetUserByIdResp, err error)
-	GetTop10Users(ctx context.Context, quarterTimestamp int64) (users []Top10Users, err error)
+	GetTop10Users(ctx context.Context, quarterTimestamp int64, filter dto.OrgFilter) (users []Top10Users, err error)
 	GetGradeById(ctx context.Context, id int64) (grade Grade, err error)
 	GetAdmin(ctx context.Context, email string) (user User, err error)
 	AddDeviceToken(ctx context.Context, userID int64, deviceToken string) (err error)
 	ListDeviceTokensByUserID(ctx context.Context, userID int64) (notificationTokens []string, err error)
+
+	AdminListUsers(ctx context.Context, tx Transaction, filter dto.AdminListUsersReq) (users []User, count int64, err error)
+	// GetUser returns apperrors.UserNotFound for an unknown user
+	GetUser(ctx context.Context, tx Transaction, userID int64) (user User, err error)
+	UpdateUserStatus(ctx context.Context, tx Transaction, userID int64, status int64, updatedBy int64) (err error)
+	UpdatePassword(ctx context.Context, tx Transaction, userID int64, passwordHash string, updatedBy int64) (err error)
+	ListDeactivatedUserIDs(ctx context.Context, tx Transaction) (userIDs []int64, err error)
+	ListUsersByStatus(ctx context.Context, tx Transaction, status int64) (users []User, err error)
+	DeleteDeviceTokens(ctx context.Context, tx Transaction, userID int64) (err error)
+	// DeleteUnregisteredDeviceToken removes the token from every user it was added for
+	DeleteUnregisteredDeviceToken(ctx context.Context, tx Transaction, deviceToken string) (err error)
+
+	// ListUsersByEmails matches the emails case insensitively
+	ListUsersByEmails(ctx context.Context, tx Transaction, emails []string) (users []User, err error)
+	CreateUsers(ctx context.Context, tx Transaction, users []dto.User) (err error)
+	UpdateUserProfile(ctx context.Context, tx Transaction, user dto.User) (err error)
 }
 
 // User - basic struct representing a User
@@ -46,6 +62,12 @@ type User struct {
 	SoftDelete          bool           `db:"soft_delete"`
 	SoftDeleteBy        sql.NullInt64  `db:"soft_delete_by"`
 	SoftDeleteOn        sql.NullTime   `db:"soft_delete_on"`
+	DeactivatedAt       sql.NullInt64  `db:"deactivated_at"`
+	DeactivatedBy       sql.NullInt64  `db:"deactivated_by"`
+	FrozenRewardQuota   int64          `db:"frozen_reward_quota"`
+	DepartmentID        sql.NullInt64  `db:"department_id"`
+	TeamID              sql.NullInt64  `db:"team_id"`
+	ManagerID           sql.NullInt64  `db:"manager_id"`
 	CreatedAt           int64          `db:"created_at"`
 }
 
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+type UserSyncStorer interface {
+	RepositoryTransaction
+
+	CreateUserSyncReport(ctx context.Context, tx Transaction, report UserSyncReport) (int64, error)
+	// ListUserSyncReports returns the latest reports first
+	ListUserSyncReports(ctx context.Context, tx Transaction, limit uint64) ([]UserSyncReport, error)
+}
+
+type UserSyncReport struct {
+	ID               int64           `db:"id"`
+	StartedAt        int64           `db:"started_at"`
+	FinishedAt       int64           `db:"finished_at"`
+	Status           string          `db:"status"`
+	CreatedCount     int64           `db:"created_count"`
+	UpdatedCount     int64           `db:"updated_count"`
+	DeactivatedCount int64           `db:"deactivated_count"`
+	FailedCount      int64           `db:"failed_count"`
+	Details          json.RawMessage `db:"details"`
+	Error            sql.NullString  `db:"error"`
+}
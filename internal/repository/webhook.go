@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/lib/pq"
+)
+
+type WebhookStorer interface {
+	RepositoryTransaction
+
+	CreateWebhookSubscription(ctx context.Context, tx Transaction, subscription WebhookSubscription) (WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context, tx Transaction) ([]WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, tx Transaction, id int64) (WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, tx Transaction, id int64) error
+	ListWebhookDeliveries(ctx context.Context, tx Transaction, filter dto.WebhookDeliveryFilter) ([]WebhookDelivery, Pagination, error)
+	GetWebhookDelivery(ctx context.Context, tx Transaction, id int64) (WebhookDelivery, error)
+	// RecordWebhookAttempt logs the outcome of an attempt, responseStatus is 0 when no response came back
+	RecordWebhookAttempt(ctx context.Context, tx Transaction, id int64, responseStatus int, lastError string) error
+	// RedeliverWebhookDelivery logs a new delivery of the same event to the same subscription
+	RedeliverWebhookDelivery(ctx context.Context, tx Transaction, id int64) (WebhookDelivery, error)
+}
+
+type WebhookSubscription struct {
+	ID        int64          `db:"id"`
+	URL       string         `db:"url"`
+	Secret    string         `db:"secret"`
+	Events    pq.StringArray `db:"events"`
+	CreatedBy int64          `db:"created_by"`
+	CreatedAt int64          `db:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `db:"id"`
+	SubscriptionID int64           `db:"subscription_id"`
+	EventID        string          `db:"event_id"`
+	Event          string          `db:"event"`
+	Payload        json.RawMessage `db:"payload"`
+	Status         string          `db:"status"`
+	Attempts       int             `db:"attempts"`
+	ResponseStatus sql.NullInt64   `db:"response_status"`
+	LastError      sql.NullString  `db:"last_error"`
+	CreatedAt      int64           `db:"created_at"`
+	UpdatedAt      int64           `db:"updated_at"`
+	DeliveredAt    sql.NullInt64   `db:"delivered_at"`
+}
@@ -0,0 +1,137 @@
+package script
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	user "github.com/joshsoftware/peerly-backend/internal/app/users"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+)
+
+type ImportUsersOptions struct {
+	// File is a .csv or .json file the users are read from instead of the intranet
+	File      string
+	DryRun    bool
+	BatchSize int
+}
+
+// columns of a users CSV file, its header names them in any order and only email is required
+const (
+	csvEmail         = "email"
+	csvEmployeeId    = "employee_id"
+	csvFirstName     = "first_name"
+	csvLastName      = "last_name"
+	csvProfileImgUrl = "profile_image_url"
+	csvDesignation   = "designation"
+	csvGrade         = "grade"
+)
+
+// ImportUsers reads the users from the intranet or a file, imports them and prints how many were created, updated
+// and skipped
+func ImportUsers(ctx context.Context, userSvc user.Service, opts ImportUsersOptions, out io.Writer) error {
+
+	var users []dto.IntranetUserData
+	var err error
+	if opts.File != "" {
+		users, err = ReadUsersFile(opts.File)
+	} else {
+		users, err = userSvc.ListAllIntranetUsers(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	summary, err := userSvc.ImportUsers(ctx, users, dto.UserImportOptions{
+		DryRun:    opts.DryRun,
+		BatchSize: opts.BatchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		fmt.Fprintln(out, "dry run, nothing was saved")
+	}
+	fmt.Fprintf(out, "created: %d, updated: %d, skipped: %d\n", summary.Created, summary.Updated, summary.Skipped)
+	return nil
+}
+
+// ReadUsersFile reads a CSV file with a header row or a JSON array of users in the format the intranet lists them in
+func ReadUsersFile(path string) ([]dto.IntranetUserData, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		var users []dto.IntranetUserData
+		err = json.NewDecoder(file).Decode(&users)
+		if err != nil {
+			return nil, fmt.Errorf("error in parsing %s: %w", path, err)
+		}
+		return users, nil
+	case ".csv":
+		return readUsersCSV(file)
+	default:
+		return nil, fmt.Errorf("unsupported users file %s, use a .csv or .json file", path)
+	}
+}
+
+func readUsersCSV(r io.Reader) ([]dto.IntranetUserData, error) {
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error in reading the csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns[csvEmail]; !ok {
+		return nil, fmt.Errorf("csv header has no %s column", csvEmail)
+	}
+
+	var users []dto.IntranetUserData
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return users, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error in reading the csv: %w", err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		users = append(users, dto.IntranetUserData{
+			Email: field(csvEmail),
+			PublicProfile: dto.PublicProfile{
+				ProfileImgUrl: field(csvProfileImgUrl),
+				FirstName:     field(csvFirstName),
+				LastName:      field(csvLastName),
+			},
+			EmpolyeeDetail: dto.EmployeeDetail{
+				EmployeeId:  field(csvEmployeeId),
+				Designation: dto.Designation{Name: field(csvDesignation)},
+				Grade:       field(csvGrade),
+			},
+		})
+	}
+}
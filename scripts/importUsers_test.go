@@ -0,0 +1,139 @@
+package script
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joshsoftware/peerly-backend/internal/app/users/mocks"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/apperrors"
+	"github.com/joshsoftware/peerly-backend/internal/pkg/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func writeUsersFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0o600)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestReadUsersFile(t *testing.T) {
+	expected := []dto.IntranetUserData{
+		{
+			Email:          "jane@example.com",
+			PublicProfile:  dto.PublicProfile{FirstName: "Jane", LastName: "Doe"},
+			EmpolyeeDetail: dto.EmployeeDetail{EmployeeId: "101", Designation: dto.Designation{Name: "Engineer"}, Grade: "J1"},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		file            string
+		content         string
+		isErrorExpected bool
+	}{
+		{
+			name:            "CSV file",
+			file:            "users.csv",
+			content:         "Email, first_name,last_name,employee_id,designation,grade\njane@example.com,Jane,Doe,101,Engineer,J1\n",
+			isErrorExpected: false,
+		},
+		{
+			name:            "JSON file",
+			file:            "users.json",
+			content:         `[{"email":"jane@example.com","public_profile":{"first_name":"Jane","last_name":"Doe"},"employee_detail":{"employee_id":"101","designation":{"name":"Engineer"},"grade":"J1"}}]`,
+			isErrorExpected: false,
+		},
+		{
+			name:            "CSV file without an email column",
+			file:            "users.csv",
+			content:         "first_name,last_name\nJane,Doe\n",
+			isErrorExpected: true,
+		},
+		{
+			name:            "Unsupported file",
+			file:            "users.txt",
+			content:         "jane@example.com",
+			isErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			users, err := ReadUsersFile(writeUsersFile(t, test.file, test.content))
+
+			if test.isErrorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, expected, users)
+		})
+	}
+}
+
+func TestImportUsers(t *testing.T) {
+	users := []dto.IntranetUserData{{Email: "jane@example.com"}}
+	summary := dto.UserImportSummary{Created: 1, Updated: 2, Skipped: 3}
+
+	tests := []struct {
+		name            string
+		opts            ImportUsersOptions
+		setup           func(userSvc *mocks.Service)
+		isErrorExpected bool
+		expectedOutput  string
+	}{
+		{
+			name: "Users are read from the intranet",
+			opts: ImportUsersOptions{BatchSize: 50},
+			setup: func(userSvc *mocks.Service) {
+				userSvc.On("ListAllIntranetUsers", mock.Anything).Return(users, nil).Once()
+				userSvc.On("ImportUsers", mock.Anything, users, dto.UserImportOptions{BatchSize: 50}).Return(summary, nil).Once()
+			},
+			isErrorExpected: false,
+			expectedOutput:  "created: 1, updated: 2, skipped: 3\n",
+		},
+		{
+			name: "Dry run of a file",
+			opts: ImportUsersOptions{File: "users.csv", DryRun: true},
+			setup: func(userSvc *mocks.Service) {
+				userSvc.On("ImportUsers", mock.Anything, users, dto.UserImportOptions{DryRun: true}).Return(summary, nil).Once()
+			},
+			isErrorExpected: false,
+			expectedOutput:  "dry run, nothing was saved\ncreated: 1, updated: 2, skipped: 3\n",
+		},
+		{
+			name: "Intranet is unreachable",
+			opts: ImportUsersOptions{},
+			setup: func(userSvc *mocks.Service) {
+				userSvc.On("ListAllIntranetUsers", mock.Anything).Return(nil, apperrors.IntranetValidationFailed).Once()
+			},
+			isErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userSvc := mocks.NewService(t)
+			test.setup(userSvc)
+			if test.opts.File != "" {
+				test.opts.File = writeUsersFile(t, test.opts.File, "email\njane@example.com\n")
+			}
+			var out bytes.Buffer
+
+			err := ImportUsers(context.Background(), userSvc, test.opts, &out)
+
+			if test.isErrorExpected {
+				assert.Error(t, err)
+				assert.Empty(t, out.String())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedOutput, out.String())
+		})
+	}
+}